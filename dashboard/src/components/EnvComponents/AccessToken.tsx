@@ -33,15 +33,225 @@ const AccessToken = ({ variables, setVariables }: any) => {
 						/>
 					</Flex>
 				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">Session Expiry Time:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.SESSION_EXPIRY_TIME}
+							placeholder="8760h0m0s"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">Refresh Token Expiry Time:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.REFRESH_TOKEN_EXPIRY_TIME}
+							placeholder="8760h0m0s"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">Session Idle Timeout:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.SESSION_IDLE_TIMEOUT}
+							placeholder="24h0m0s"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">Session Expiry Time By Role:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.SESSION_EXPIRY_TIME_BY_ROLE}
+							placeholder="admin:1h,editor:24h"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">OTP Expiry Time:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.OTP_EXPIRY_TIME}
+							placeholder="1m0s"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">OTP Length:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.OTP_LENGTH}
+							placeholder="6"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">OTP Charset:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.OTP_CHARSET}
+							placeholder="alphanumeric"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">OTP Max Attempts:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.OTP_MAX_ATTEMPTS}
+							placeholder="5"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">Token Exchange Policy:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.TOKEN_EXCHANGE_POLICY}
+							placeholder="client_id:audience,client_id:*"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '50%'}
+						justifyContent="start"
+						alignItems="center"
+					>
+						<Text fontSize="sm">Back-Channel Logout URI:</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							borderRadius={5}
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextInputType.BACKCHANNEL_LOGOUT_URI}
+							placeholder="https://app.example.com/backchannel-logout"
+						/>
+					</Flex>
+				</Flex>
 				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
 					<Flex
 						w={isNotSmallerScreen ? '30%' : '60%'}
 						justifyContent="start"
 						direction="column"
 					>
-						<Text fontSize="sm">Custom Scripts:</Text>
+						<Text fontSize="sm">Access Token Script:</Text>
 						<Text fontSize="xs" color="blackAlpha.500">
-							(Used to add custom fields in ID token)
+							(Used to add custom fields in access token)
 						</Text>
 					</Flex>
 					<Flex
@@ -57,6 +267,79 @@ const AccessToken = ({ variables, setVariables }: any) => {
 						/>
 					</Flex>
 				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '60%'}
+						justifyContent="start"
+						direction="column"
+					>
+						<Text fontSize="sm">ID Token Script:</Text>
+						<Text fontSize="xs" color="blackAlpha.500">
+							(Used to add custom fields in ID token, defaults to access token script)
+						</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextAreaInputType.CUSTOM_ID_TOKEN_SCRIPT}
+							placeholder="Add script here"
+							minH="25vh"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '60%'}
+						justifyContent="start"
+						direction="column"
+					>
+						<Text fontSize="sm">User Info Script:</Text>
+						<Text fontSize="xs" color="blackAlpha.500">
+							(Used to add custom fields in /userinfo response)
+						</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextAreaInputType.CUSTOM_USER_INFO_SCRIPT}
+							placeholder="Add script here"
+							minH="25vh"
+						/>
+					</Flex>
+				</Flex>
+				<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+					<Flex
+						w={isNotSmallerScreen ? '30%' : '60%'}
+						justifyContent="start"
+						direction="column"
+					>
+						<Text fontSize="sm">Custom Scopes:</Text>
+						<Text fontSize="xs" color="blackAlpha.500">
+							(JSON array of scopes with name, description, allowed roles and
+							claims mapped from user fields or app_data keys)
+						</Text>
+					</Flex>
+					<Flex
+						w={isNotSmallerScreen ? '70%' : '100%'}
+						mt={isNotSmallerScreen ? '0' : '3'}
+					>
+						<InputField
+							variables={variables}
+							setVariables={setVariables}
+							inputType={TextAreaInputType.CUSTOM_SCOPES}
+							placeholder='[{"name": "billing", "description": "Billing plan", "roles": ["user"], "claims": ["app_data.plan"]}]'
+							minH="25vh"
+						/>
+					</Flex>
+				</Flex>
 			</Stack>
 		</div>
 	);
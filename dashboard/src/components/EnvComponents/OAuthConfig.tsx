@@ -78,6 +78,24 @@ const OAuthConfig = ({
 							/>
 						</Center>
 					</Flex>
+					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
+						<Flex w="30%" justifyContent="start" alignItems="center">
+							<Text fontSize="sm">Client Registration Token:</Text>
+						</Flex>
+						<Center
+							w={isNotSmallerScreen ? '70%' : '100%'}
+							mt={isNotSmallerScreen ? '0' : '3'}
+						>
+							<InputField
+								variables={envVariables}
+								setVariables={setVariables}
+								fieldVisibility={fieldVisibility}
+								setFieldVisibility={setFieldVisibility}
+								inputType={HiddenInputType.CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN}
+								placeholder="Initial access token for /oauth/register"
+							/>
+						</Center>
+					</Flex>
 					<Flex direction={isNotSmallerScreen ? 'row' : 'column'}>
 						<Flex w="30%" justifyContent="start" alignItems="center">
 							<Text fontSize="sm">Default Response Type:</Text>
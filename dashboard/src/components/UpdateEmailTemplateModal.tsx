@@ -261,6 +261,13 @@ const UpdateEmailTemplate = ({
 					val === emailTemplateVariables.otp) ||
 				(templateData[EmailTemplateInputDataFields.EVENT_NAME] ===
 					emailTemplateEventNames['Verify Otp'] &&
+					val === emailTemplateVariables.verification_url) ||
+				(templateData[EmailTemplateInputDataFields.EVENT_NAME] !==
+					emailTemplateEventNames['Revert Email Change'] &&
+					(val === emailTemplateVariables.previous_email ||
+						val === emailTemplateVariables.revert_url)) ||
+				(templateData[EmailTemplateInputDataFields.EVENT_NAME] ===
+					emailTemplateEventNames['Revert Email Change'] &&
 					val === emailTemplateVariables.verification_url)
 			) {
 				return acc;
@@ -3,6 +3,16 @@ export const LOGO_URL =
 
 export const TextInputType = {
 	ACCESS_TOKEN_EXPIRY_TIME: 'ACCESS_TOKEN_EXPIRY_TIME',
+	SESSION_EXPIRY_TIME: 'SESSION_EXPIRY_TIME',
+	REFRESH_TOKEN_EXPIRY_TIME: 'REFRESH_TOKEN_EXPIRY_TIME',
+	SESSION_IDLE_TIMEOUT: 'SESSION_IDLE_TIMEOUT',
+	SESSION_EXPIRY_TIME_BY_ROLE: 'SESSION_EXPIRY_TIME_BY_ROLE',
+	OTP_EXPIRY_TIME: 'OTP_EXPIRY_TIME',
+	OTP_LENGTH: 'OTP_LENGTH',
+	OTP_CHARSET: 'OTP_CHARSET',
+	OTP_MAX_ATTEMPTS: 'OTP_MAX_ATTEMPTS',
+	TOKEN_EXCHANGE_POLICY: 'TOKEN_EXCHANGE_POLICY',
+	BACKCHANNEL_LOGOUT_URI: 'BACKCHANNEL_LOGOUT_URI',
 	CLIENT_ID: 'CLIENT_ID',
 	GOOGLE_CLIENT_ID: 'GOOGLE_CLIENT_ID',
 	GITHUB_CLIENT_ID: 'GITHUB_CLIENT_ID',
@@ -52,6 +62,8 @@ export const HiddenInputType = {
 	SMTP_PASSWORD: 'SMTP_PASSWORD',
 	ADMIN_SECRET: 'ADMIN_SECRET',
 	OLD_ADMIN_SECRET: 'OLD_ADMIN_SECRET',
+	CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN:
+		'CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN',
 };
 
 export const ArrayInputType = {
@@ -74,6 +86,9 @@ export const MultiSelectInputType = {
 
 export const TextAreaInputType = {
 	CUSTOM_ACCESS_TOKEN_SCRIPT: 'CUSTOM_ACCESS_TOKEN_SCRIPT',
+	CUSTOM_ID_TOKEN_SCRIPT: 'CUSTOM_ID_TOKEN_SCRIPT',
+	CUSTOM_USER_INFO_SCRIPT: 'CUSTOM_USER_INFO_SCRIPT',
+	CUSTOM_SCOPES: 'CUSTOM_SCOPES',
 	JWT_PRIVATE_KEY: 'JWT_PRIVATE_KEY',
 	JWT_PUBLIC_KEY: 'JWT_PUBLIC_KEY',
 };
@@ -94,6 +109,7 @@ export const SwitchInputType = {
 	DISABLE_PLAYGROUND: 'DISABLE_PLAYGROUND',
 	DISABLE_TOTP_LOGIN: 'DISABLE_TOTP_LOGIN',
 	DISABLE_MAIL_OTP_LOGIN: 'DISABLE_MAIL_OTP_LOGIN',
+	ENABLE_LOGIN_CODE: 'ENABLE_LOGIN_CODE',
 };
 
 export const DateInputType = {
@@ -165,6 +181,8 @@ export interface envVarTypes {
 	ORGANIZATION_NAME: string;
 	ORGANIZATION_LOGO: string;
 	CUSTOM_ACCESS_TOKEN_SCRIPT: string;
+	CUSTOM_ID_TOKEN_SCRIPT: string;
+	CUSTOM_USER_INFO_SCRIPT: string;
 	ADMIN_SECRET: string;
 	APP_COOKIE_SECURE: boolean;
 	ADMIN_COOKIE_SECURE: boolean;
@@ -180,12 +198,25 @@ export interface envVarTypes {
 	DATABASE_TYPE: string;
 	DATABASE_URL: string;
 	ACCESS_TOKEN_EXPIRY_TIME: string;
+	SESSION_EXPIRY_TIME: string;
+	REFRESH_TOKEN_EXPIRY_TIME: string;
+	SESSION_IDLE_TIMEOUT: string;
+	SESSION_EXPIRY_TIME_BY_ROLE: string;
+	OTP_EXPIRY_TIME: string;
+	OTP_LENGTH: string;
+	OTP_CHARSET: string;
+	OTP_MAX_ATTEMPTS: string;
+	TOKEN_EXCHANGE_POLICY: string;
+	BACKCHANNEL_LOGOUT_URI: string;
+	CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN: string;
+	CUSTOM_SCOPES: string;
 	DISABLE_MULTI_FACTOR_AUTHENTICATION: boolean;
 	ENFORCE_MULTI_FACTOR_AUTHENTICATION: boolean;
 	DEFAULT_AUTHORIZE_RESPONSE_TYPE: string;
 	DEFAULT_AUTHORIZE_RESPONSE_MODE: string;
 	DISABLE_PLAYGROUND: boolean;
 	DISABLE_TOTP_LOGIN: boolean;
+	ENABLE_LOGIN_CODE: boolean;
 	DISABLE_MAIL_OTP_LOGIN: boolean;
 }
 
@@ -241,6 +272,10 @@ export const webhookEventNames = {
 	'User access enabled': 'user.access_enabled',
 	'User access revoked': 'user.access_revoked',
 	'User deactivated': 'user.deactivated',
+	'User identity linked': 'user.identity_linked',
+	'User identity unlinked': 'user.identity_unlinked',
+	'User refresh token reused': 'user.refresh_token_reused',
+	'User role expired': 'user.role_expired',
 };
 
 export const emailTemplateEventNames = {
@@ -250,6 +285,7 @@ export const emailTemplateEventNames = {
 	'Forgot Password': 'forgot_password',
 	'Verify Otp': 'verify_otp',
 	'Invite member': 'invite_member',
+	'Revert Email Change': 'revert_email_change',
 };
 
 export enum webhookVerifiedStatus {
@@ -343,6 +379,14 @@ export const emailTemplateVariables = {
 		description: `OTP sent during login with Multi factor authentication`,
 		value: '{.otp}}',
 	},
+	previous_email: {
+		description: `Email address of user before it was changed`,
+		value: '{.previous_email}}',
+	},
+	revert_url: {
+		description: `URL to revert the email change, in case of revert email change event`,
+		value: '{.revert_url}}',
+	},
 };
 
 export const webhookPayloadExample: string = `{
@@ -72,16 +72,31 @@ export const EnvVariablesQuery = `
       DISABLE_STRONG_PASSWORD
       DISABLE_REDIS_FOR_ENV
       CUSTOM_ACCESS_TOKEN_SCRIPT
+      CUSTOM_ID_TOKEN_SCRIPT
+      CUSTOM_USER_INFO_SCRIPT
       DATABASE_NAME
       DATABASE_TYPE
       DATABASE_URL
       ACCESS_TOKEN_EXPIRY_TIME
+      SESSION_EXPIRY_TIME
+      REFRESH_TOKEN_EXPIRY_TIME
+      SESSION_IDLE_TIMEOUT
+      SESSION_EXPIRY_TIME_BY_ROLE
+      OTP_EXPIRY_TIME
+      OTP_LENGTH
+      OTP_CHARSET
+      OTP_MAX_ATTEMPTS
+      TOKEN_EXCHANGE_POLICY
+      BACKCHANNEL_LOGOUT_URI
+      CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN
+      CUSTOM_SCOPES
       DISABLE_MULTI_FACTOR_AUTHENTICATION
       ENFORCE_MULTI_FACTOR_AUTHENTICATION
       DEFAULT_AUTHORIZE_RESPONSE_TYPE
       DEFAULT_AUTHORIZE_RESPONSE_MODE
       DISABLE_PLAYGROUND
       DISABLE_TOTP_LOGIN
+      ENABLE_LOGIN_CODE
       DISABLE_MAIL_OTP_LOGIN
     }
   }
@@ -81,6 +81,8 @@ const Environment = () => {
 		ORGANIZATION_NAME: '',
 		ORGANIZATION_LOGO: '',
 		CUSTOM_ACCESS_TOKEN_SCRIPT: '',
+		CUSTOM_ID_TOKEN_SCRIPT: '',
+		CUSTOM_USER_INFO_SCRIPT: '',
 		ADMIN_SECRET: '',
 		APP_COOKIE_SECURE: false,
 		ADMIN_COOKIE_SECURE: false,
@@ -96,12 +98,25 @@ const Environment = () => {
 		DATABASE_TYPE: '',
 		DATABASE_URL: '',
 		ACCESS_TOKEN_EXPIRY_TIME: '',
+		SESSION_EXPIRY_TIME: '',
+		REFRESH_TOKEN_EXPIRY_TIME: '',
+		SESSION_IDLE_TIMEOUT: '',
+		SESSION_EXPIRY_TIME_BY_ROLE: '',
+		OTP_EXPIRY_TIME: '',
+		OTP_LENGTH: '',
+		OTP_CHARSET: '',
+		OTP_MAX_ATTEMPTS: '',
+		TOKEN_EXCHANGE_POLICY: '',
+		BACKCHANNEL_LOGOUT_URI: '',
+		CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN: '',
+		CUSTOM_SCOPES: '',
 		DISABLE_MULTI_FACTOR_AUTHENTICATION: false,
 		ENFORCE_MULTI_FACTOR_AUTHENTICATION: false,
 		DEFAULT_AUTHORIZE_RESPONSE_TYPE: '',
 		DEFAULT_AUTHORIZE_RESPONSE_MODE: '',
 		DISABLE_PLAYGROUND: false,
 		DISABLE_TOTP_LOGIN: false,
+		ENABLE_LOGIN_CODE: false,
 		DISABLE_MAIL_OTP_LOGIN: true,
 	});
 
@@ -120,6 +135,7 @@ const Environment = () => {
 		SMTP_PASSWORD: false,
 		ADMIN_SECRET: false,
 		OLD_ADMIN_SECRET: false,
+		CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN: false,
 	});
 
 	const { sec } = useParams();
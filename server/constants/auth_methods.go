@@ -9,6 +9,8 @@ const (
 	AuthRecipeMethodMagicLinkLogin = "magic_link_login"
 	// AuthRecipeMethodMobileOTP is the mobile_otp auth method
 	AuthRecipeMethodMobileOTP = "mobile_otp"
+	// AuthRecipeMethodLoginCode is the login_code auth method, where user logs in with one time code sent via email or sms
+	AuthRecipeMethodLoginCode = "login_code"
 	// AuthRecipeMethodGoogle is the google auth method
 	AuthRecipeMethodGoogle = "google"
 	// AuthRecipeMethodGithub is the github auth method
@@ -30,3 +32,17 @@ const (
 	// AuthRecipeMethodRoblox is the roblox auth method
 	AuthRecipeMethodRoblox = "roblox"
 )
+
+// OAuthRecipeMethods is the list of auth methods that use an external oauth provider
+var OAuthRecipeMethods = []string{
+	AuthRecipeMethodGoogle,
+	AuthRecipeMethodGithub,
+	AuthRecipeMethodFacebook,
+	AuthRecipeMethodLinkedIn,
+	AuthRecipeMethodApple,
+	AuthRecipeMethodDiscord,
+	AuthRecipeMethodTwitter,
+	AuthRecipeMethodMicrosoft,
+	AuthRecipeMethodTwitch,
+	AuthRecipeMethodRoblox,
+}
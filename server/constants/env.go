@@ -15,6 +15,28 @@ const (
 	EnvKeyPort = "PORT"
 	// EnvKeyAccessTokenExpiryTime key for env variable ACCESS_TOKEN_EXPIRY_TIME
 	EnvKeyAccessTokenExpiryTime = "ACCESS_TOKEN_EXPIRY_TIME"
+	// EnvKeySessionExpiryTime key for env variable SESSION_EXPIRY_TIME
+	// it is the absolute lifetime of browser session, irrespective of activity
+	EnvKeySessionExpiryTime = "SESSION_EXPIRY_TIME"
+	// EnvKeyRefreshTokenExpiryTime key for env variable REFRESH_TOKEN_EXPIRY_TIME
+	EnvKeyRefreshTokenExpiryTime = "REFRESH_TOKEN_EXPIRY_TIME"
+	// EnvKeySessionIdleTimeout key for env variable SESSION_IDLE_TIMEOUT
+	// session is invalidated if it is not used within this duration, empty value disables it
+	EnvKeySessionIdleTimeout = "SESSION_IDLE_TIMEOUT"
+	// EnvKeySessionExpiryTimeByRole key for env variable SESSION_EXPIRY_TIME_BY_ROLE
+	// comma separated role:duration pairs, eg: admin:1h,editor:24h
+	EnvKeySessionExpiryTimeByRole = "SESSION_EXPIRY_TIME_BY_ROLE"
+	// EnvKeyOTPLength key for env variable OTP_LENGTH
+	EnvKeyOTPLength = "OTP_LENGTH"
+	// EnvKeyOTPCharset key for env variable OTP_CHARSET
+	// Possible values are alphanumeric (default) and numeric, otps sent via sms are always numeric
+	EnvKeyOTPCharset = "OTP_CHARSET"
+	// EnvKeyOTPExpiryTime key for env variable OTP_EXPIRY_TIME
+	// If not set, otps for login expire in 1m and otps for phone verification in 10m
+	EnvKeyOTPExpiryTime = "OTP_EXPIRY_TIME"
+	// EnvKeyOTPMaxAttempts key for env variable OTP_MAX_ATTEMPTS
+	// otp is invalidated after these many failed verifications
+	EnvKeyOTPMaxAttempts = "OTP_MAX_ATTEMPTS"
 	// EnvKeyAdminSecret key for env variable ADMIN_SECRET
 	EnvKeyAdminSecret = "ADMIN_SECRET"
 	// EnvKeyDatabaseType key for env variable DATABASE_TYPE
@@ -84,6 +106,52 @@ const (
 	EnvKeyAppURL = "APP_URL"
 	// EnvKeyRedisURL key for env variable REDIS_URL
 	EnvKeyRedisURL = "REDIS_URL"
+	// EnvKeyRedisSentinelMasterName key for env variable REDIS_SENTINEL_MASTER_NAME
+	EnvKeyRedisSentinelMasterName = "REDIS_SENTINEL_MASTER_NAME"
+	// EnvKeyRedisSentinelPassword key for env variable REDIS_SENTINEL_PASSWORD
+	EnvKeyRedisSentinelPassword = "REDIS_SENTINEL_PASSWORD"
+	// EnvKeyRedisCert key for env variable REDIS_CERT
+	EnvKeyRedisCert = "REDIS_CERT"
+	// EnvKeyRedisCertKey key for env variable REDIS_CERT_KEY
+	EnvKeyRedisCertKey = "REDIS_CERT_KEY"
+	// EnvKeyRedisCACert key for env variable REDIS_CA_CERT
+	EnvKeyRedisCACert = "REDIS_CA_CERT"
+	// EnvKeyRedisKeyPrefix key for env variable REDIS_KEY_PREFIX
+	EnvKeyRedisKeyPrefix = "REDIS_KEY_PREFIX"
+	// EnvKeyRedisPoolSize key for env variable REDIS_POOL_SIZE
+	EnvKeyRedisPoolSize = "REDIS_POOL_SIZE"
+	// EnvKeyRedisMinIdleConns key for env variable REDIS_MIN_IDLE_CONNS
+	EnvKeyRedisMinIdleConns = "REDIS_MIN_IDLE_CONNS"
+	// EnvKeyRedisPoolTimeout key for env variable REDIS_POOL_TIMEOUT
+	EnvKeyRedisPoolTimeout = "REDIS_POOL_TIMEOUT"
+	// EnvKeyEnvSyncInterval key for env variable ENV_SYNC_INTERVAL
+	// It is the interval at which instances check database for config changes, 0 disables it
+	EnvKeyEnvSyncInterval = "ENV_SYNC_INTERVAL"
+	// EnvKeyRoleGrantSweepInterval key for env variable ROLE_GRANT_SWEEP_INTERVAL
+	// It is the interval at which expired role grants are removed from users, 0 disables it
+	EnvKeyRoleGrantSweepInterval = "ROLE_GRANT_SWEEP_INTERVAL"
+	// EnvKeyOtelTracesExporter key for env variable OTEL_TRACES_EXPORTER
+	// Possible values are otlp, console and none (default)
+	EnvKeyOtelTracesExporter = "OTEL_TRACES_EXPORTER"
+	// EnvKeyTLSCertFile key for env variable TLS_CERT_FILE
+	// Server is started with https if both cert and key files are set
+	EnvKeyTLSCertFile = "TLS_CERT_FILE"
+	// EnvKeyTLSKeyFile key for env variable TLS_KEY_FILE
+	EnvKeyTLSKeyFile = "TLS_KEY_FILE"
+	// EnvKeyTLSClientCAFile key for env variable TLS_CLIENT_CA_FILE
+	// If set, clients have to present a certificate signed by this CA (mTLS)
+	EnvKeyTLSClientCAFile = "TLS_CLIENT_CA_FILE"
+	// EnvKeyHTTPReadTimeout key for env variable HTTP_READ_TIMEOUT
+	EnvKeyHTTPReadTimeout = "HTTP_READ_TIMEOUT"
+	// EnvKeyHTTPReadHeaderTimeout key for env variable HTTP_READ_HEADER_TIMEOUT
+	EnvKeyHTTPReadHeaderTimeout = "HTTP_READ_HEADER_TIMEOUT"
+	// EnvKeyHTTPWriteTimeout key for env variable HTTP_WRITE_TIMEOUT
+	EnvKeyHTTPWriteTimeout = "HTTP_WRITE_TIMEOUT"
+	// EnvKeyHTTPIdleTimeout key for env variable HTTP_IDLE_TIMEOUT
+	EnvKeyHTTPIdleTimeout = "HTTP_IDLE_TIMEOUT"
+	// EnvKeyShutdownTimeout key for env variable SHUTDOWN_TIMEOUT
+	// It is the max time to drain in-flight requests and pending webhooks on shutdown
+	EnvKeyShutdownTimeout = "SHUTDOWN_TIMEOUT"
 	// EnvKeyResetPasswordURL key for env variable RESET_PASSWORD_URL
 	EnvKeyResetPasswordURL = "RESET_PASSWORD_URL"
 	// EnvKeyJwtRoleClaim key for env variable JWT_ROLE_CLAIM
@@ -136,6 +204,21 @@ const (
 	EnvKeyOrganizationLogo = "ORGANIZATION_LOGO"
 	// EnvKeyCustomAccessTokenScript key for env variable CUSTOM_ACCESS_TOKEN_SCRIPT
 	EnvKeyCustomAccessTokenScript = "CUSTOM_ACCESS_TOKEN_SCRIPT"
+	// EnvKeyCustomIDTokenScript key for env variable CUSTOM_ID_TOKEN_SCRIPT
+	EnvKeyCustomIDTokenScript = "CUSTOM_ID_TOKEN_SCRIPT"
+	// EnvKeyCustomUserInfoScript key for env variable CUSTOM_USER_INFO_SCRIPT
+	EnvKeyCustomUserInfoScript = "CUSTOM_USER_INFO_SCRIPT"
+	// EnvKeyTokenExchangePolicy key for env variable TOKEN_EXCHANGE_POLICY
+	// comma separated client_id:audience pairs, eg: gateway:orders-api,gateway:billing-api
+	EnvKeyTokenExchangePolicy = "TOKEN_EXCHANGE_POLICY"
+	// EnvKeyBackchannelLogoutURI key for env variable BACKCHANNEL_LOGOUT_URI
+	EnvKeyBackchannelLogoutURI = "BACKCHANNEL_LOGOUT_URI"
+	// EnvKeyClientRegistrationInitialAccessToken key for env variable CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN
+	// dynamic client registration is disabled when it is not set
+	EnvKeyClientRegistrationInitialAccessToken = "CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN"
+	// EnvKeyCustomScopes key for env variable CUSTOM_SCOPES
+	// json array of scopes with name, description, roles and claims
+	EnvKeyCustomScopes = "CUSTOM_SCOPES"
 
 	// Not Exposed Keys
 	// EnvKeyClientID key for env variable CLIENT_ID
@@ -164,6 +247,9 @@ const (
 	EnvKeyDisableSignUp = "DISABLE_SIGN_UP"
 	// EnvKeyDisableRedisForEnv key for env variable DISABLE_REDIS_FOR_ENV
 	EnvKeyDisableRedisForEnv = "DISABLE_REDIS_FOR_ENV"
+	// EnvKeyEnableDatabaseMemoryStore key for env variable ENABLE_DATABASE_MEMORY_STORE
+	// It is used to keep sessions in database when redis is not configured
+	EnvKeyEnableDatabaseMemoryStore = "ENABLE_DATABASE_MEMORY_STORE"
 	// EnvKeyDisableStrongPassword key for env variable DISABLE_STRONG_PASSWORD
 	EnvKeyDisableStrongPassword = "DISABLE_STRONG_PASSWORD"
 	// EnvKeyEnforceMultiFactorAuthentication is key for env variable ENFORCE_MULTI_FACTOR_AUTHENTICATION
@@ -184,6 +270,9 @@ const (
 	// EnvKeyDisablePlayGround is key for env variable DISABLE_PLAYGROUND
 	// this variable will disable or enable playground use in dashboard
 	EnvKeyDisablePlayGround = "DISABLE_PLAYGROUND"
+	// EnvKeyEnableLoginCode is key for env variable ENABLE_LOGIN_CODE
+	// this variable enables passwordless login with one time code sent via email or sms
+	EnvKeyEnableLoginCode = "ENABLE_LOGIN_CODE"
 
 	// Slice variables
 	// EnvKeyRoles key for env variable ROLES
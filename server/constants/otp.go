@@ -0,0 +1,13 @@
+package constants
+
+const (
+	// OTPCharsetAlphanumeric is the OTP_CHARSET for otps with upper case letters and digits
+	OTPCharsetAlphanumeric = "alphanumeric"
+	// OTPCharsetNumeric is the OTP_CHARSET for otps with only digits
+	OTPCharsetNumeric = "numeric"
+
+	// DefaultOTPLength is the length of otp when OTP_LENGTH is not set
+	DefaultOTPLength = 6
+	// DefaultOTPMaxAttempts is the number of failed verifications after which otp is invalidated
+	DefaultOTPMaxAttempts = 5
+)
@@ -0,0 +1,15 @@
+package constants
+
+const (
+	// RoleChangeActionGranted is the action of role change when role is granted to user
+	RoleChangeActionGranted = "granted"
+	// RoleChangeActionRevoked is the action of role change when role is removed from user
+	RoleChangeActionRevoked = "revoked"
+	// RoleChangeActionExpired is the action of role change when grant of role expired
+	RoleChangeActionExpired = "expired"
+
+	// RoleChangedBySuperAdmin is the grantor of roles changed with admin apis when grantor is not given
+	RoleChangedBySuperAdmin = "super_admin"
+	// RoleChangedBySystem is the grantor of roles changed by authorizer itself, eg. expired grants
+	RoleChangedBySystem = "system"
+)
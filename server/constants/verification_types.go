@@ -13,6 +13,15 @@ const (
 	VerificationTypeInviteMember = "invite_member"
 	// VerificationTypeOTP is the otp verification type
 	VerificationTypeOTP = "verify_otp"
+	// VerificationTypeRevertEmailChange is the revert_email_change verification type
+	// It is sent to the previous email of user after email is changed
+	VerificationTypeRevertEmailChange = "revert_email_change"
+	// VerificationTypeRevertPhoneNumberChange is the revert_phone_number_change verification type
+	// It is sent to the previous phone number of user after phone number is changed
+	VerificationTypeRevertPhoneNumberChange = "revert_phone_number_change"
+	// VerificationTypeUpdatePhoneNumber is the update_phone_number verification type
+	// It holds the phone number set with update_profile until the otp sent to it is verified
+	VerificationTypeUpdatePhoneNumber = "update_phone_number"
 )
 
 var (
@@ -17,4 +17,12 @@ const (
 	UserDeletedWebhookEvent = `user.deleted`
 	// UserDeactivatedWebhookEvent name for user deactivated event
 	UserDeactivatedWebhookEvent = `user.deactivated`
+	// UserIdentityLinkedWebhookEvent name for oauth identity linked with user event
+	UserIdentityLinkedWebhookEvent = `user.identity_linked`
+	// UserIdentityUnlinkedWebhookEvent name for oauth identity unlinked from user event
+	UserIdentityUnlinkedWebhookEvent = `user.identity_unlinked`
+	// UserRefreshTokenReusedWebhookEvent name for security event when already rotated refresh token is used
+	UserRefreshTokenReusedWebhookEvent = `user.refresh_token_reused`
+	// UserRoleExpiredWebhookEvent name for event when time bound role grant of user expired
+	UserRoleExpiredWebhookEvent = `user.role_expired`
 )
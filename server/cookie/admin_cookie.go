@@ -15,7 +15,7 @@ import (
 func SetAdminCookie(gc *gin.Context, token string) {
 	adminCookieSecure, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyAdminCookieSecure)
 	if err != nil {
-		log.Debug("Error while getting admin cookie secure from env variable: %v", err)
+		log.Debug("Error while getting admin cookie secure from env variable: ", err)
 		adminCookieSecure = true
 	}
 
@@ -46,7 +46,7 @@ func GetAdminCookie(gc *gin.Context) (string, error) {
 func DeleteAdminCookie(gc *gin.Context) {
 	adminCookieSecure, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyAdminCookieSecure)
 	if err != nil {
-		log.Debug("Error while getting admin cookie secure from env variable: %v", err)
+		log.Debug("Error while getting admin cookie secure from env variable: ", err)
 		adminCookieSecure = true
 	}
 
@@ -9,6 +9,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -16,7 +17,7 @@ import (
 func SetSession(gc *gin.Context, sessionID string) {
 	appCookieSecure, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyAppCookieSecure)
 	if err != nil {
-		log.Debug("Error while getting app cookie secure from env variable: %v", err)
+		log.Debug("Error while getting app cookie secure from env variable: ", err)
 		appCookieSecure = true
 	}
 
@@ -40,18 +41,24 @@ func SetSession(gc *gin.Context, sessionID string) {
 	} else {
 		gc.SetSameSite(http.SameSiteNoneMode)
 	}
-	// TODO allow configuring from dashboard
-	year := 60 * 60 * 24 * 365
+	// cookie lives as long as the session can, session itself is validated on server
+	maxAge := 60 * 60 * 24 * 365
+	sessionExpiryTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySessionExpiryTime)
+	if err == nil && sessionExpiryTime != "" {
+		if duration, err := utils.ParseDurationInSeconds(sessionExpiryTime); err == nil {
+			maxAge = int(duration.Seconds())
+		}
+	}
 
-	gc.SetCookie(constants.AppCookieName+"_session", sessionID, year, "/", host, secure, httpOnly)
-	gc.SetCookie(constants.AppCookieName+"_session_domain", sessionID, year, "/", domain, secure, httpOnly)
+	gc.SetCookie(constants.AppCookieName+"_session", sessionID, maxAge, "/", host, secure, httpOnly)
+	gc.SetCookie(constants.AppCookieName+"_session_domain", sessionID, maxAge, "/", domain, secure, httpOnly)
 }
 
 // DeleteSession sets session cookies to expire
 func DeleteSession(gc *gin.Context) {
 	appCookieSecure, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyAppCookieSecure)
 	if err != nil {
-		log.Debug("Error while getting app cookie secure from env variable: %v", err)
+		log.Debug("Error while getting app cookie secure from env variable: ", err)
 		appCookieSecure = true
 	}
 
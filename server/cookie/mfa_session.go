@@ -16,7 +16,7 @@ import (
 func SetMfaSession(gc *gin.Context, sessionID string) {
 	appCookieSecure, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyAppCookieSecure)
 	if err != nil {
-		log.Debug("Error while getting app cookie secure from env variable: %v", err)
+		log.Debug("Error while getting app cookie secure from env variable: ", err)
 		appCookieSecure = true
 	}
 
@@ -51,7 +51,7 @@ func SetMfaSession(gc *gin.Context, sessionID string) {
 func DeleteMfaSession(gc *gin.Context) {
 	appCookieSecure, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyAppCookieSecure)
 	if err != nil {
-		log.Debug("Error while getting app cookie secure from env variable: %v", err)
+		log.Debug("Error while getting app cookie secure from env variable: ", err)
 		appCookieSecure = true
 	}
 
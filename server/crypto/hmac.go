@@ -1,7 +1,15 @@
 package crypto
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
 	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
 )
 
 // NewHMAC key returns new key that can be used to ecnrypt data using HMAC algo
@@ -24,3 +32,18 @@ func IsHMACA(algo string) bool {
 		return false
 	}
 }
+
+// HashOTP returns the keyed hash of otp, which is stored in database instead of the otp.
+// Encryption key is used as hmac key, so that hashes can't be brute forced without it.
+func HashOTP(otp string) (string, error) {
+	key, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyEncryptionKey)
+	if err != nil {
+		return "", err
+	}
+	if key == "" {
+		return "", errors.New("encryption key is not set")
+	}
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(otp))
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
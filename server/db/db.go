@@ -82,5 +82,8 @@ func InitDB() error {
 		}
 	}
 
+	// record metrics and traces of database calls
+	Provider = newInstrumentedProvider(Provider)
+
 	return nil
 }
@@ -0,0 +1,916 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/db/providers"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/tracing"
+)
+
+// instrumentedProvider wraps the database provider to record latency
+// and create trace span of each method
+type instrumentedProvider struct {
+	provider providers.Provider
+}
+
+// newInstrumentedProvider returns database provider which records metrics and traces for given provider
+func newInstrumentedProvider(provider providers.Provider) providers.Provider {
+	return &instrumentedProvider{
+		provider: provider,
+	}
+}
+
+func (p *instrumentedProvider) AddUser(ctx context.Context, user *models.User) (*models.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddUser")
+	start := time.Now()
+	res, err := p.provider.AddUser(ctx, user)
+	metrics.RecordDBCall("AddUser", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateUser(ctx context.Context, user *models.User) (*models.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateUser")
+	start := time.Now()
+	res, err := p.provider.UpdateUser(ctx, user)
+	metrics.RecordDBCall("UpdateUser", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteUser(ctx context.Context, user *models.User) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteUser")
+	start := time.Now()
+	err := p.provider.DeleteUser(ctx, user)
+	metrics.RecordDBCall("DeleteUser", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) ListUsers(ctx context.Context, pagination *model.Pagination) (*model.Users, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListUsers")
+	start := time.Now()
+	res, err := p.provider.ListUsers(ctx, pagination)
+	metrics.RecordDBCall("ListUsers", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetUserByEmail")
+	start := time.Now()
+	res, err := p.provider.GetUserByEmail(ctx, email)
+	metrics.RecordDBCall("GetUserByEmail", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetUserByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetUserByPhoneNumber")
+	start := time.Now()
+	res, err := p.provider.GetUserByPhoneNumber(ctx, phoneNumber)
+	metrics.RecordDBCall("GetUserByPhoneNumber", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetUserByID")
+	start := time.Now()
+	res, err := p.provider.GetUserByID(ctx, id)
+	metrics.RecordDBCall("GetUserByID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateUsers(ctx context.Context, data map[string]interface{}, ids []string) error {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateUsers")
+	start := time.Now()
+	err := p.provider.UpdateUsers(ctx, data, ids)
+	metrics.RecordDBCall("UpdateUsers", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddVerificationRequest(ctx context.Context, verificationRequest *models.VerificationRequest) (*models.VerificationRequest, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddVerificationRequest")
+	start := time.Now()
+	res, err := p.provider.AddVerificationRequest(ctx, verificationRequest)
+	metrics.RecordDBCall("AddVerificationRequest", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetVerificationRequestByToken(ctx context.Context, token string) (*models.VerificationRequest, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetVerificationRequestByToken")
+	start := time.Now()
+	res, err := p.provider.GetVerificationRequestByToken(ctx, token)
+	metrics.RecordDBCall("GetVerificationRequestByToken", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetVerificationRequestByEmail(ctx context.Context, email string, identifier string) (*models.VerificationRequest, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetVerificationRequestByEmail")
+	start := time.Now()
+	res, err := p.provider.GetVerificationRequestByEmail(ctx, email, identifier)
+	metrics.RecordDBCall("GetVerificationRequestByEmail", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListVerificationRequests(ctx context.Context, pagination *model.Pagination) (*model.VerificationRequests, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListVerificationRequests")
+	start := time.Now()
+	res, err := p.provider.ListVerificationRequests(ctx, pagination)
+	metrics.RecordDBCall("ListVerificationRequests", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteVerificationRequest(ctx context.Context, verificationRequest *models.VerificationRequest) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteVerificationRequest")
+	start := time.Now()
+	err := p.provider.DeleteVerificationRequest(ctx, verificationRequest)
+	metrics.RecordDBCall("DeleteVerificationRequest", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddSession(ctx context.Context, session *models.Session) error {
+	ctx, span := tracing.StartSpan(ctx, "db.AddSession")
+	start := time.Now()
+	err := p.provider.AddSession(ctx, session)
+	metrics.RecordDBCall("AddSession", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteSession(ctx context.Context, userId string) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteSession")
+	start := time.Now()
+	err := p.provider.DeleteSession(ctx, userId)
+	metrics.RecordDBCall("DeleteSession", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListSessionsByUserID")
+	start := time.Now()
+	res, err := p.provider.ListSessionsByUserID(ctx, userID)
+	metrics.RecordDBCall("ListSessionsByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetSessionByID")
+	start := time.Now()
+	res, err := p.provider.GetSessionByID(ctx, id)
+	metrics.RecordDBCall("GetSessionByID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetSessionByNonce")
+	start := time.Now()
+	res, err := p.provider.GetSessionByNonce(ctx, nonce)
+	metrics.RecordDBCall("GetSessionByNonce", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateSession")
+	start := time.Now()
+	res, err := p.provider.UpdateSession(ctx, session)
+	metrics.RecordDBCall("UpdateSession", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteSessionByID(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteSessionByID")
+	start := time.Now()
+	err := p.provider.DeleteSessionByID(ctx, id)
+	metrics.RecordDBCall("DeleteSessionByID", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddEnv(ctx context.Context, env *models.Env) (*models.Env, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddEnv")
+	start := time.Now()
+	res, err := p.provider.AddEnv(ctx, env)
+	metrics.RecordDBCall("AddEnv", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateEnv(ctx context.Context, env *models.Env) (*models.Env, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateEnv")
+	start := time.Now()
+	res, err := p.provider.UpdateEnv(ctx, env)
+	metrics.RecordDBCall("UpdateEnv", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetEnv(ctx context.Context) (*models.Env, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetEnv")
+	start := time.Now()
+	res, err := p.provider.GetEnv(ctx)
+	metrics.RecordDBCall("GetEnv", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) AddWebhook(ctx context.Context, webhook *models.Webhook) (*model.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddWebhook")
+	start := time.Now()
+	res, err := p.provider.AddWebhook(ctx, webhook)
+	metrics.RecordDBCall("AddWebhook", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateWebhook(ctx context.Context, webhook *models.Webhook) (*model.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateWebhook")
+	start := time.Now()
+	res, err := p.provider.UpdateWebhook(ctx, webhook)
+	metrics.RecordDBCall("UpdateWebhook", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListWebhook(ctx context.Context, pagination *model.Pagination) (*model.Webhooks, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListWebhook")
+	start := time.Now()
+	res, err := p.provider.ListWebhook(ctx, pagination)
+	metrics.RecordDBCall("ListWebhook", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetWebhookByID(ctx context.Context, webhookID string) (*model.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetWebhookByID")
+	start := time.Now()
+	res, err := p.provider.GetWebhookByID(ctx, webhookID)
+	metrics.RecordDBCall("GetWebhookByID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetWebhookByEventName(ctx context.Context, eventName string) ([]*model.Webhook, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetWebhookByEventName")
+	start := time.Now()
+	res, err := p.provider.GetWebhookByEventName(ctx, eventName)
+	metrics.RecordDBCall("GetWebhookByEventName", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteWebhook(ctx context.Context, webhook *model.Webhook) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteWebhook")
+	start := time.Now()
+	err := p.provider.DeleteWebhook(ctx, webhook)
+	metrics.RecordDBCall("DeleteWebhook", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddWebhookLog(ctx context.Context, webhookLog *models.WebhookLog) (*model.WebhookLog, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddWebhookLog")
+	start := time.Now()
+	res, err := p.provider.AddWebhookLog(ctx, webhookLog)
+	metrics.RecordDBCall("AddWebhookLog", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListWebhookLogs(ctx context.Context, pagination *model.Pagination, webhookID string) (*model.WebhookLogs, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListWebhookLogs")
+	start := time.Now()
+	res, err := p.provider.ListWebhookLogs(ctx, pagination, webhookID)
+	metrics.RecordDBCall("ListWebhookLogs", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) AddEmailTemplate(ctx context.Context, emailTemplate *models.EmailTemplate) (*model.EmailTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddEmailTemplate")
+	start := time.Now()
+	res, err := p.provider.AddEmailTemplate(ctx, emailTemplate)
+	metrics.RecordDBCall("AddEmailTemplate", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateEmailTemplate(ctx context.Context, emailTemplate *models.EmailTemplate) (*model.EmailTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateEmailTemplate")
+	start := time.Now()
+	res, err := p.provider.UpdateEmailTemplate(ctx, emailTemplate)
+	metrics.RecordDBCall("UpdateEmailTemplate", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListEmailTemplate(ctx context.Context, pagination *model.Pagination) (*model.EmailTemplates, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListEmailTemplate")
+	start := time.Now()
+	res, err := p.provider.ListEmailTemplate(ctx, pagination)
+	metrics.RecordDBCall("ListEmailTemplate", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetEmailTemplateByID(ctx context.Context, emailTemplateID string) (*model.EmailTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetEmailTemplateByID")
+	start := time.Now()
+	res, err := p.provider.GetEmailTemplateByID(ctx, emailTemplateID)
+	metrics.RecordDBCall("GetEmailTemplateByID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetEmailTemplateByEventName(ctx context.Context, eventName string) (*model.EmailTemplate, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetEmailTemplateByEventName")
+	start := time.Now()
+	res, err := p.provider.GetEmailTemplateByEventName(ctx, eventName)
+	metrics.RecordDBCall("GetEmailTemplateByEventName", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteEmailTemplate(ctx context.Context, emailTemplate *model.EmailTemplate) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteEmailTemplate")
+	start := time.Now()
+	err := p.provider.DeleteEmailTemplate(ctx, emailTemplate)
+	metrics.RecordDBCall("DeleteEmailTemplate", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) UpsertOTP(ctx context.Context, otp *models.OTP) (*models.OTP, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpsertOTP")
+	start := time.Now()
+	res, err := p.provider.UpsertOTP(ctx, otp)
+	metrics.RecordDBCall("UpsertOTP", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetOTPByEmail(ctx context.Context, emailAddress string) (*models.OTP, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetOTPByEmail")
+	start := time.Now()
+	res, err := p.provider.GetOTPByEmail(ctx, emailAddress)
+	metrics.RecordDBCall("GetOTPByEmail", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetOTPByPhoneNumber(ctx context.Context, phoneNumber string) (*models.OTP, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetOTPByPhoneNumber")
+	start := time.Now()
+	res, err := p.provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+	metrics.RecordDBCall("GetOTPByPhoneNumber", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.IncrementOTPAttempts")
+	start := time.Now()
+	res, err := p.provider.IncrementOTPAttempts(ctx, otp)
+	metrics.RecordDBCall("IncrementOTPAttempts", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteOTP")
+	start := time.Now()
+	err := p.provider.DeleteOTP(ctx, otp)
+	metrics.RecordDBCall("DeleteOTP", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddAuthenticator(ctx context.Context, totp *models.Authenticator) (*models.Authenticator, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddAuthenticator")
+	start := time.Now()
+	res, err := p.provider.AddAuthenticator(ctx, totp)
+	metrics.RecordDBCall("AddAuthenticator", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateAuthenticator(ctx context.Context, totp *models.Authenticator) (*models.Authenticator, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateAuthenticator")
+	start := time.Now()
+	res, err := p.provider.UpdateAuthenticator(ctx, totp)
+	metrics.RecordDBCall("UpdateAuthenticator", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetAuthenticatorDetailsByUserId(ctx context.Context, userId string, authenticatorType string) (*models.Authenticator, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetAuthenticatorDetailsByUserId")
+	start := time.Now()
+	res, err := p.provider.GetAuthenticatorDetailsByUserId(ctx, userId, authenticatorType)
+	metrics.RecordDBCall("GetAuthenticatorDetailsByUserId", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddIdentity")
+	start := time.Now()
+	res, err := p.provider.AddIdentity(ctx, identity)
+	metrics.RecordDBCall("AddIdentity", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetIdentityByProviderUserID")
+	start := time.Now()
+	res, err := p.provider.GetIdentityByProviderUserID(ctx, provider, providerUserID)
+	metrics.RecordDBCall("GetIdentityByProviderUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListIdentitiesByUserID")
+	start := time.Now()
+	res, err := p.provider.ListIdentitiesByUserID(ctx, userID)
+	metrics.RecordDBCall("ListIdentitiesByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteIdentity")
+	start := time.Now()
+	err := p.provider.DeleteIdentity(ctx, identity)
+	metrics.RecordDBCall("DeleteIdentity", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddClient")
+	start := time.Now()
+	res, err := p.provider.AddClient(ctx, client)
+	metrics.RecordDBCall("AddClient", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateClient")
+	start := time.Now()
+	res, err := p.provider.UpdateClient(ctx, client)
+	metrics.RecordDBCall("UpdateClient", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetClientByClientID")
+	start := time.Now()
+	res, err := p.provider.GetClientByClientID(ctx, clientID)
+	metrics.RecordDBCall("GetClientByClientID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteClient(ctx context.Context, client *models.Client) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteClient")
+	start := time.Now()
+	err := p.provider.DeleteClient(ctx, client)
+	metrics.RecordDBCall("DeleteClient", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddGrant")
+	start := time.Now()
+	res, err := p.provider.AddGrant(ctx, grant)
+	metrics.RecordDBCall("AddGrant", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateGrant")
+	start := time.Now()
+	res, err := p.provider.UpdateGrant(ctx, grant)
+	metrics.RecordDBCall("UpdateGrant", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetGrantByUserIDAndClientID")
+	start := time.Now()
+	res, err := p.provider.GetGrantByUserIDAndClientID(ctx, userID, clientID)
+	metrics.RecordDBCall("GetGrantByUserIDAndClientID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListGrantsByUserID")
+	start := time.Now()
+	res, err := p.provider.ListGrantsByUserID(ctx, userID)
+	metrics.RecordDBCall("ListGrantsByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteGrant(ctx context.Context, grant *models.Grant) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteGrant")
+	start := time.Now()
+	err := p.provider.DeleteGrant(ctx, grant)
+	metrics.RecordDBCall("DeleteGrant", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddOrganization")
+	start := time.Now()
+	res, err := p.provider.AddOrganization(ctx, organization)
+	metrics.RecordDBCall("AddOrganization", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateOrganization")
+	start := time.Now()
+	res, err := p.provider.UpdateOrganization(ctx, organization)
+	metrics.RecordDBCall("UpdateOrganization", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetOrganizationByID")
+	start := time.Now()
+	res, err := p.provider.GetOrganizationByID(ctx, organizationID)
+	metrics.RecordDBCall("GetOrganizationByID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetOrganizationByName")
+	start := time.Now()
+	res, err := p.provider.GetOrganizationByName(ctx, name)
+	metrics.RecordDBCall("GetOrganizationByName", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListOrganizations")
+	start := time.Now()
+	res, err := p.provider.ListOrganizations(ctx, pagination)
+	metrics.RecordDBCall("ListOrganizations", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteOrganization")
+	start := time.Now()
+	err := p.provider.DeleteOrganization(ctx, organization)
+	metrics.RecordDBCall("DeleteOrganization", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddRole")
+	start := time.Now()
+	res, err := p.provider.AddRole(ctx, role)
+	metrics.RecordDBCall("AddRole", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateRole")
+	start := time.Now()
+	res, err := p.provider.UpdateRole(ctx, role)
+	metrics.RecordDBCall("UpdateRole", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetRoleByName")
+	start := time.Now()
+	res, err := p.provider.GetRoleByName(ctx, name)
+	metrics.RecordDBCall("GetRoleByName", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListRoles")
+	start := time.Now()
+	res, err := p.provider.ListRoles(ctx, pagination)
+	metrics.RecordDBCall("ListRoles", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteRole(ctx context.Context, role *models.Role) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteRole")
+	start := time.Now()
+	err := p.provider.DeleteRole(ctx, role)
+	metrics.RecordDBCall("DeleteRole", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddPermission")
+	start := time.Now()
+	res, err := p.provider.AddPermission(ctx, permission)
+	metrics.RecordDBCall("AddPermission", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdatePermission")
+	start := time.Now()
+	res, err := p.provider.UpdatePermission(ctx, permission)
+	metrics.RecordDBCall("UpdatePermission", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetPermissionByName")
+	start := time.Now()
+	res, err := p.provider.GetPermissionByName(ctx, name)
+	metrics.RecordDBCall("GetPermissionByName", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListPermissions")
+	start := time.Now()
+	res, err := p.provider.ListPermissions(ctx, pagination)
+	metrics.RecordDBCall("ListPermissions", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeletePermission")
+	start := time.Now()
+	err := p.provider.DeletePermission(ctx, permission)
+	metrics.RecordDBCall("DeletePermission", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddRoleGrant")
+	start := time.Now()
+	res, err := p.provider.AddRoleGrant(ctx, roleGrant)
+	metrics.RecordDBCall("AddRoleGrant", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateRoleGrant")
+	start := time.Now()
+	res, err := p.provider.UpdateRoleGrant(ctx, roleGrant)
+	metrics.RecordDBCall("UpdateRoleGrant", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetRoleGrantByUserIDAndRole")
+	start := time.Now()
+	res, err := p.provider.GetRoleGrantByUserIDAndRole(ctx, userID, role)
+	metrics.RecordDBCall("GetRoleGrantByUserIDAndRole", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListRoleGrantsByUserID")
+	start := time.Now()
+	res, err := p.provider.ListRoleGrantsByUserID(ctx, userID)
+	metrics.RecordDBCall("ListRoleGrantsByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListExpiredRoleGrants")
+	start := time.Now()
+	res, err := p.provider.ListExpiredRoleGrants(ctx, expiresAt)
+	metrics.RecordDBCall("ListExpiredRoleGrants", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteRoleGrant")
+	start := time.Now()
+	err := p.provider.DeleteRoleGrant(ctx, roleGrant)
+	metrics.RecordDBCall("DeleteRoleGrant", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddRoleChange")
+	start := time.Now()
+	res, err := p.provider.AddRoleChange(ctx, roleChange)
+	metrics.RecordDBCall("AddRoleChange", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListRoleChangesByUserID")
+	start := time.Now()
+	res, err := p.provider.ListRoleChangesByUserID(ctx, userID)
+	metrics.RecordDBCall("ListRoleChangesByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddRefreshTokenReuse")
+	start := time.Now()
+	res, err := p.provider.AddRefreshTokenReuse(ctx, refreshTokenReuse)
+	metrics.RecordDBCall("AddRefreshTokenReuse", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListRefreshTokenReusesByUserID")
+	start := time.Now()
+	res, err := p.provider.ListRefreshTokenReusesByUserID(ctx, userID)
+	metrics.RecordDBCall("ListRefreshTokenReusesByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddMembership")
+	start := time.Now()
+	res, err := p.provider.AddMembership(ctx, membership)
+	metrics.RecordDBCall("AddMembership", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpdateMembership")
+	start := time.Now()
+	res, err := p.provider.UpdateMembership(ctx, membership)
+	metrics.RecordDBCall("UpdateMembership", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetMembershipByOrganizationIDAndUserID")
+	start := time.Now()
+	res, err := p.provider.GetMembershipByOrganizationIDAndUserID(ctx, organizationID, userID)
+	metrics.RecordDBCall("GetMembershipByOrganizationIDAndUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListMembershipsByOrganizationID")
+	start := time.Now()
+	res, err := p.provider.ListMembershipsByOrganizationID(ctx, organizationID)
+	metrics.RecordDBCall("ListMembershipsByOrganizationID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.ListMembershipsByUserID")
+	start := time.Now()
+	res, err := p.provider.ListMembershipsByUserID(ctx, userID)
+	metrics.RecordDBCall("ListMembershipsByUserID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteMembership")
+	start := time.Now()
+	err := p.provider.DeleteMembership(ctx, membership)
+	metrics.RecordDBCall("DeleteMembership", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.UpsertMemoryStoreEntry")
+	start := time.Now()
+	res, err := p.provider.UpsertMemoryStoreEntry(ctx, entry)
+	metrics.RecordDBCall("UpsertMemoryStoreEntry", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.AddMemoryStoreEntryIfNotExists")
+	start := time.Now()
+	res, err := p.provider.AddMemoryStoreEntryIfNotExists(ctx, entry)
+	metrics.RecordDBCall("AddMemoryStoreEntryIfNotExists", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	ctx, span := tracing.StartSpan(ctx, "db.GetMemoryStoreEntryByID")
+	start := time.Now()
+	res, err := p.provider.GetMemoryStoreEntryByID(ctx, id)
+	metrics.RecordDBCall("GetMemoryStoreEntryByID", err, start)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteMemoryStoreEntryByID")
+	start := time.Now()
+	err := p.provider.DeleteMemoryStoreEntryByID(ctx, id)
+	metrics.RecordDBCall("DeleteMemoryStoreEntryByID", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteMemoryStoreEntriesByNamespace")
+	start := time.Now()
+	err := p.provider.DeleteMemoryStoreEntriesByNamespace(ctx, namespace)
+	metrics.RecordDBCall("DeleteMemoryStoreEntriesByNamespace", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteMemoryStoreEntriesBySubject")
+	start := time.Now()
+	err := p.provider.DeleteMemoryStoreEntriesBySubject(ctx, subject)
+	metrics.RecordDBCall("DeleteMemoryStoreEntriesBySubject", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	ctx, span := tracing.StartSpan(ctx, "db.DeleteExpiredMemoryStoreEntries")
+	start := time.Now()
+	err := p.provider.DeleteExpiredMemoryStoreEntries(ctx, expiresAt)
+	metrics.RecordDBCall("DeleteExpiredMemoryStoreEntries", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) HealthCheck(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "db.HealthCheck")
+	start := time.Now()
+	err := p.provider.HealthCheck(ctx)
+	metrics.RecordDBCall("HealthCheck", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) Close(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "db.Close")
+	start := time.Now()
+	err := p.provider.Close(ctx)
+	metrics.RecordDBCall("Close", err, start)
+	tracing.EndSpan(span, err)
+	return err
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"strings"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Client model for db
+// It is the oauth client registered dynamically using /oauth/register endpoint.
+// ClientSecret and RegistrationAccessToken are stored as sha256 hashes.
+type Client struct {
+	Key                     string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID                      string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	ClientID                string `gorm:"unique" json:"client_id" bson:"client_id" cql:"client_id" dynamo:"client_id" index:"client_id,hash"`
+	ClientSecret            string `json:"client_secret" bson:"client_secret" cql:"client_secret" dynamo:"client_secret"`
+	RegistrationAccessToken string `json:"registration_access_token" bson:"registration_access_token" cql:"registration_access_token" dynamo:"registration_access_token"`
+	ClientName              string `json:"client_name" bson:"client_name" cql:"client_name" dynamo:"client_name"`
+	RedirectURIs            string `gorm:"type:text" json:"redirect_uris" bson:"redirect_uris" cql:"redirect_uris" dynamo:"redirect_uris"`
+	GrantTypes              string `json:"grant_types" bson:"grant_types" cql:"grant_types" dynamo:"grant_types"`
+	ResponseTypes           string `json:"response_types" bson:"response_types" cql:"response_types" dynamo:"response_types"`
+	TokenEndpointAuthMethod string `json:"token_endpoint_auth_method" bson:"token_endpoint_auth_method" cql:"token_endpoint_auth_method" dynamo:"token_endpoint_auth_method"`
+	Scope                   string `json:"scope" bson:"scope" cql:"scope" dynamo:"scope"`
+	JWKS                    string `gorm:"type:text" json:"jwks" bson:"jwks" cql:"jwks" dynamo:"jwks"`
+	JWKSURI                 string `json:"jwks_uri" bson:"jwks_uri" cql:"jwks_uri" dynamo:"jwks_uri"`
+	// RequirePushedAuthorizationRequests rejects authorize requests which are not pushed with /oauth/par
+	RequirePushedAuthorizationRequests bool `json:"require_pushed_authorization_requests" bson:"require_pushed_authorization_requests" cql:"require_pushed_authorization_requests" dynamo:"require_pushed_authorization_requests"`
+	// BackchannelLogoutURI is notified with logout token when session of user ends
+	BackchannelLogoutURI string `json:"backchannel_logout_uri" bson:"backchannel_logout_uri" cql:"backchannel_logout_uri" dynamo:"backchannel_logout_uri"`
+	// BackchannelLogoutSessionRequired adds sid claim to the logout token sent to client
+	BackchannelLogoutSessionRequired bool  `json:"backchannel_logout_session_required" bson:"backchannel_logout_session_required" cql:"backchannel_logout_session_required" dynamo:"backchannel_logout_session_required"`
+	CreatedAt                        int64 `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt                        int64 `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// splitClientMetadata splits the comma separated list of client metadata
+func splitClientMetadata(value string) []string {
+	res := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// GetRedirectURIs returns the list of redirect uris registered by client
+func (c *Client) GetRedirectURIs() []string {
+	return splitClientMetadata(c.RedirectURIs)
+}
+
+// GetGrantTypes returns the list of grant types client can use
+func (c *Client) GetGrantTypes() []string {
+	return splitClientMetadata(c.GrantTypes)
+}
+
+// GetResponseTypes returns the list of response types client can use
+func (c *Client) GetResponseTypes() []string {
+	return splitClientMetadata(c.ResponseTypes)
+}
+
+// HasRedirectURI returns true if redirect uri exactly matches one of registered redirect uris
+func (c *Client) HasRedirectURI(redirectURI string) bool {
+	for _, uri := range c.GetRedirectURIs() {
+		if uri == redirectURI {
+			return true
+		}
+	}
+	return false
+}
+
+// HasGrantType returns true if client is allowed to use the grant type
+func (c *Client) HasGrantType(grantType string) bool {
+	for _, v := range c.GetGrantTypes() {
+		if v == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// HasResponseType returns true if client is allowed to use the response type
+func (c *Client) HasResponseType(responseType string) bool {
+	for _, v := range c.GetResponseTypes() {
+		if v == responseType {
+			return true
+		}
+	}
+	return false
+}
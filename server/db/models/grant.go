@@ -0,0 +1,61 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Grant model for db
+// It stores the scopes a user has consented to share with a registered client
+type Grant struct {
+	Key      string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID       string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	UserID   string `gorm:"type:char(36)" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
+	ClientID string `json:"client_id" bson:"client_id" cql:"client_id" dynamo:"client_id"`
+	// Scope is the space separated list of granted scopes
+	Scope     string `gorm:"type:text" json:"scope" bson:"scope" cql:"scope" dynamo:"scope"`
+	GrantedAt int64  `json:"granted_at" bson:"granted_at" cql:"granted_at" dynamo:"granted_at"`
+	CreatedAt int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// GetScopes returns the list of granted scopes
+func (g *Grant) GetScopes() []string {
+	return strings.Fields(g.Scope)
+}
+
+// HasScopes returns true if all the given scopes are granted
+func (g *Grant) HasScopes(scopes []string) bool {
+	granted := g.GetScopes()
+	for _, scope := range scopes {
+		found := false
+		for _, s := range granted {
+			if s == scope {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AsAPIGrant to return grant as graphql response object
+func (g *Grant) AsAPIGrant() *model.Grant {
+	id := g.ID
+	if strings.Contains(id, Collections.Grant+"/") {
+		id = strings.TrimPrefix(id, Collections.Grant+"/")
+	}
+	return &model.Grant{
+		ID:        id,
+		ClientID:  g.ClientID,
+		Scopes:    g.GetScopes(),
+		GrantedAt: refs.NewInt64Ref(g.GrantedAt),
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Identity model for db
+// It links a user with the account they hold at an external oauth provider
+type Identity struct {
+	Key            string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID             string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	UserID         string `gorm:"type:char(36)" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
+	Provider       string `gorm:"type:varchar(64);uniqueIndex:idx_identity_provider_user_id" json:"provider" bson:"provider" cql:"provider" dynamo:"provider"`
+	ProviderUserID string `gorm:"type:varchar(256);uniqueIndex:idx_identity_provider_user_id" json:"provider_user_id" bson:"provider_user_id" cql:"provider_user_id" dynamo:"provider_user_id"`
+	Email          string `json:"email" bson:"email" cql:"email" dynamo:"email"`
+	LinkedAt       int64  `json:"linked_at" bson:"linked_at" cql:"linked_at" dynamo:"linked_at"`
+	CreatedAt      int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt      int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// AsAPIIdentity to return identity as graphql response object
+func (i *Identity) AsAPIIdentity() *model.Identity {
+	id := i.ID
+	if strings.Contains(id, Collections.Identity+"/") {
+		id = strings.TrimPrefix(id, Collections.Identity+"/")
+	}
+	return &model.Identity{
+		ID:             id,
+		Provider:       i.Provider,
+		ProviderUserID: i.ProviderUserID,
+		Email:          refs.NewStringRef(i.Email),
+		LinkedAt:       refs.NewInt64Ref(i.LinkedAt),
+	}
+}
+
+// IdentityID returns the id of identity for given provider account.
+// It is derived from provider and provider user id, so that the databases
+// without unique indexes also reject an account which is already linked.
+func IdentityID(provider, providerUserID string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(provider+":"+providerUserID)).String()
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Membership model for db
+// It links a user with an organization along with the roles user has in that organization
+type Membership struct {
+	Key            string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID             string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	OrganizationID string `gorm:"type:char(36);uniqueIndex:idx_membership_organization_id_user_id" json:"organization_id" bson:"organization_id" cql:"organization_id" dynamo:"organization_id" index:"organization_id,hash"`
+	UserID         string `gorm:"type:char(36);uniqueIndex:idx_membership_organization_id_user_id" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
+	// Roles is the comma separated list of roles user has in the organization
+	Roles     string `json:"roles" bson:"roles" cql:"roles" dynamo:"roles"`
+	CreatedAt int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// GetRoles returns the list of roles user has in the organization
+func (m *Membership) GetRoles() []string {
+	return splitRoles(m.Roles)
+}
+
+// AsAPIMembership to return membership as graphql response object
+func (m *Membership) AsAPIMembership() *model.Membership {
+	id := m.ID
+	if strings.Contains(id, Collections.Membership+"/") {
+		id = strings.TrimPrefix(id, Collections.Membership+"/")
+	}
+	return &model.Membership{
+		ID:             id,
+		OrganizationID: m.OrganizationID,
+		UserID:         m.UserID,
+		Roles:          m.GetRoles(),
+		CreatedAt:      refs.NewInt64Ref(m.CreatedAt),
+		UpdatedAt:      refs.NewInt64Ref(m.UpdatedAt),
+	}
+}
@@ -0,0 +1,20 @@
+package models
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// MemoryStoreEntry model for db
+// It stores the session, state and mfa session entries when database is used as memory store
+type MemoryStoreEntry struct {
+	Key string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID  string `gorm:"primaryKey;type:char(64)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	// Namespace is the user identifier in form recipe:user_id or the store name for states
+	Namespace string `gorm:"index" json:"namespace" bson:"namespace" cql:"namespace" dynamo:"namespace" index:"namespace,hash"`
+	// Recipe is the first part of namespace, it is used to delete entries of a login method
+	Recipe string `gorm:"index" json:"recipe" bson:"recipe" cql:"recipe" dynamo:"recipe"`
+	// Subject is the last part of namespace, it is used to delete entries of a user
+	Subject   string `gorm:"index" json:"subject" bson:"subject" cql:"subject" dynamo:"subject"`
+	Value     string `gorm:"type:text" json:"value" bson:"value" cql:"value" dynamo:"value"`
+	ExpiresAt int64  `gorm:"index" json:"expires_at" bson:"expires_at" cql:"expires_at" dynamo:"expires_at"`
+	CreatedAt int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
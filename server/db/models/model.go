@@ -12,6 +12,17 @@ type CollectionList struct {
 	OTP                    string
 	SMSVerificationRequest string
 	Authenticators         string
+	Identity               string
+	Client                 string
+	Grant                  string
+	Organization           string
+	Membership             string
+	Role                   string
+	Permission             string
+	RoleGrant              string
+	RoleChange             string
+	RefreshTokenReuse      string
+	MemoryStoreEntry       string
 }
 
 var (
@@ -29,5 +40,16 @@ var (
 		OTP:                    Prefix + "otps",
 		SMSVerificationRequest: Prefix + "sms_verification_requests",
 		Authenticators:         Prefix + "authenticators",
+		Identity:               Prefix + "identities",
+		Client:                 Prefix + "clients",
+		Grant:                  Prefix + "grants",
+		Organization:           Prefix + "organizations",
+		Membership:             Prefix + "memberships",
+		Role:                   Prefix + "roles",
+		Permission:             Prefix + "permissions",
+		RoleGrant:              Prefix + "role_grants",
+		RoleChange:             Prefix + "role_changes",
+		RefreshTokenReuse:      Prefix + "refresh_token_reuses",
+		MemoryStoreEntry:       Prefix + "memory_store_entries",
 	}
 )
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Organization model for db
+// It is the tenant which has its own members and roles
+type Organization struct {
+	Key  string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID   string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	Name string `gorm:"unique" json:"name" bson:"name" cql:"name" dynamo:"name" index:"name,hash"`
+	// Roles is the comma separated list of roles that can be assigned to members of organization
+	Roles string `json:"roles" bson:"roles" cql:"roles" dynamo:"roles"`
+	// DefaultRoles is the comma separated list of roles assigned to new members of organization
+	DefaultRoles string `json:"default_roles" bson:"default_roles" cql:"default_roles" dynamo:"default_roles"`
+	CreatedAt    int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt    int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// GetRoles returns the list of roles of organization
+func (o *Organization) GetRoles() []string {
+	return splitRoles(o.Roles)
+}
+
+// GetDefaultRoles returns the list of default roles of organization
+func (o *Organization) GetDefaultRoles() []string {
+	return splitRoles(o.DefaultRoles)
+}
+
+// AsAPIOrganization to return organization as graphql response object
+func (o *Organization) AsAPIOrganization() *model.Organization {
+	id := o.ID
+	if strings.Contains(id, Collections.Organization+"/") {
+		id = strings.TrimPrefix(id, Collections.Organization+"/")
+	}
+	return &model.Organization{
+		ID:           id,
+		Name:         o.Name,
+		Roles:        o.GetRoles(),
+		DefaultRoles: o.GetDefaultRoles(),
+		CreatedAt:    refs.NewInt64Ref(o.CreatedAt),
+		UpdatedAt:    refs.NewInt64Ref(o.UpdatedAt),
+	}
+}
+
+// splitRoles splits the comma separated list of roles
+func splitRoles(roles string) []string {
+	res := []string{}
+	for _, role := range strings.Split(roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			res = append(res, role)
+		}
+	}
+	return res
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Permission model for db
+// Name is the action that can be performed, eg. invoice:read
+type Permission struct {
+	Key         string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID          string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	Name        string `gorm:"unique" json:"name" bson:"name" cql:"name" dynamo:"name" index:"name,hash"`
+	Description string `json:"description" bson:"description" cql:"description" dynamo:"description"`
+	CreatedAt   int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt   int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// AsAPIPermission to return permission as graphql response object
+func (p *Permission) AsAPIPermission() *model.Permission {
+	id := p.ID
+	if strings.Contains(id, Collections.Permission+"/") {
+		id = strings.TrimPrefix(id, Collections.Permission+"/")
+	}
+	return &model.Permission{
+		ID:          id,
+		Name:        p.Name,
+		Description: refs.NewStringRef(p.Description),
+		CreatedAt:   refs.NewInt64Ref(p.CreatedAt),
+		UpdatedAt:   refs.NewInt64Ref(p.UpdatedAt),
+	}
+}
@@ -0,0 +1,18 @@
+package models
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// RefreshTokenReuse model for db
+// It is the record of already rotated refresh token presented again, which revoked the token family
+type RefreshTokenReuse struct {
+	Key      string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID       string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	UserID   string `gorm:"type:char(36);index" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
+	FamilyID string `json:"family_id" bson:"family_id" cql:"family_id" dynamo:"family_id"`
+	// RevokedNonce is the nonce of latest session of the family that was revoked, empty if family had no active session
+	RevokedNonce string `json:"revoked_nonce" bson:"revoked_nonce" cql:"revoked_nonce" dynamo:"revoked_nonce"`
+	LoginMethod  string `json:"login_method" bson:"login_method" cql:"login_method" dynamo:"login_method"`
+	IP           string `json:"ip" bson:"ip" cql:"ip" dynamo:"ip"`
+	UserAgent    string `json:"user_agent" bson:"user_agent" cql:"user_agent" dynamo:"user_agent"`
+	CreatedAt    int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// Role model for db
+// It holds the definition of role which is assigned to users, with the permissions granted by it
+type Role struct {
+	Key         string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID          string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	Name        string `gorm:"unique" json:"name" bson:"name" cql:"name" dynamo:"name" index:"name,hash"`
+	Description string `json:"description" bson:"description" cql:"description" dynamo:"description"`
+	// Permissions is the comma separated list of permission names granted by role
+	Permissions string `json:"permissions" bson:"permissions" cql:"permissions" dynamo:"permissions"`
+	CreatedAt   int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt   int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// GetPermissions returns the list of permissions granted by role
+func (r *Role) GetPermissions() []string {
+	return splitRoles(r.Permissions)
+}
+
+// AsAPIRole to return role as graphql response object
+func (r *Role) AsAPIRole() *model.Role {
+	id := r.ID
+	if strings.Contains(id, Collections.Role+"/") {
+		id = strings.TrimPrefix(id, Collections.Role+"/")
+	}
+	return &model.Role{
+		ID:          id,
+		Name:        r.Name,
+		Description: refs.NewStringRef(r.Description),
+		Permissions: r.GetPermissions(),
+		CreatedAt:   refs.NewInt64Ref(r.CreatedAt),
+		UpdatedAt:   refs.NewInt64Ref(r.UpdatedAt),
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// RoleChange model for db
+// It is the history entry of role granted to or removed from user
+type RoleChange struct {
+	Key    string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID     string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	UserID string `gorm:"type:char(36);index" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
+	Role   string `json:"role" bson:"role" cql:"role" dynamo:"role"`
+	// Action is one of granted, revoked or expired
+	Action    string `json:"action" bson:"action" cql:"action" dynamo:"action"`
+	ChangedBy string `json:"changed_by" bson:"changed_by" cql:"changed_by" dynamo:"changed_by"`
+	// ExpiresAt is the expiry of grant at the time of change, 0 if it does not expire
+	ExpiresAt int64 `json:"expires_at" bson:"expires_at" cql:"expires_at" dynamo:"expires_at"`
+	CreatedAt int64 `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+}
+
+// AsAPIRoleChange to return role change as graphql response object
+func (r *RoleChange) AsAPIRoleChange() *model.RoleChange {
+	id := r.ID
+	if strings.Contains(id, Collections.RoleChange+"/") {
+		id = strings.TrimPrefix(id, Collections.RoleChange+"/")
+	}
+	res := &model.RoleChange{
+		ID:        id,
+		UserID:    r.UserID,
+		Role:      r.Role,
+		Action:    r.Action,
+		ChangedBy: r.ChangedBy,
+		CreatedAt: refs.NewInt64Ref(r.CreatedAt),
+	}
+	if r.ExpiresAt > 0 {
+		res.ExpiresAt = refs.NewInt64Ref(r.ExpiresAt)
+	}
+	return res
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
+
+// RoleGrant model for db
+// It records who granted the role to user and until when, role itself is stored in user roles
+type RoleGrant struct {
+	Key       string `json:"_key,omitempty" bson:"_key,omitempty" cql:"_key,omitempty" dynamo:"key,omitempty"` // for arangodb
+	ID        string `gorm:"primaryKey;type:char(36)" json:"_id" bson:"_id" cql:"id" dynamo:"id,hash"`
+	UserID    string `gorm:"type:char(36);uniqueIndex:idx_role_grant_user_id_role" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
+	Role      string `gorm:"uniqueIndex:idx_role_grant_user_id_role" json:"role" bson:"role" cql:"role" dynamo:"role"`
+	GrantedBy string `json:"granted_by" bson:"granted_by" cql:"granted_by" dynamo:"granted_by"`
+	// ExpiresAt is 0 for the grants which do not expire
+	ExpiresAt int64 `gorm:"index" json:"expires_at" bson:"expires_at" cql:"expires_at" dynamo:"expires_at"`
+	CreatedAt int64 `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt int64 `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// IsExpired returns true if grant has expiry and it is before given time
+func (r *RoleGrant) IsExpired(now int64) bool {
+	return r.ExpiresAt > 0 && r.ExpiresAt <= now
+}
+
+// AsAPIRoleGrant to return role grant as graphql response object
+func (r *RoleGrant) AsAPIRoleGrant() *model.RoleGrant {
+	id := r.ID
+	if strings.Contains(id, Collections.RoleGrant+"/") {
+		id = strings.TrimPrefix(id, Collections.RoleGrant+"/")
+	}
+	res := &model.RoleGrant{
+		ID:        id,
+		UserID:    r.UserID,
+		Role:      r.Role,
+		GrantedBy: r.GrantedBy,
+		CreatedAt: refs.NewInt64Ref(r.CreatedAt),
+		UpdatedAt: refs.NewInt64Ref(r.UpdatedAt),
+	}
+	if r.ExpiresAt > 0 {
+		res.ExpiresAt = refs.NewInt64Ref(r.ExpiresAt)
+	}
+	return res
+}
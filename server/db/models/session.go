@@ -1,5 +1,12 @@
 package models
 
+import (
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
 // Note: any change here should be reflected in providers/casandra/provider.go as it does not have model support in collection creation
 
 // Session model for db
@@ -9,6 +16,36 @@ type Session struct {
 	UserID    string `gorm:"type:char(36)" json:"user_id" bson:"user_id" cql:"user_id" dynamo:"user_id" index:"user_id,hash"`
 	UserAgent string `json:"user_agent" bson:"user_agent" cql:"user_agent" dynamo:"user_agent"`
 	IP        string `json:"ip" bson:"ip" cql:"ip" dynamo:"ip"`
-	CreatedAt int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
-	UpdatedAt int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+	// LoginMethod and Nonce are used to build the memory store keys of the session
+	LoginMethod  string `json:"login_method" bson:"login_method" cql:"login_method" dynamo:"login_method"`
+	Nonce        string `json:"nonce" bson:"nonce" cql:"nonce" dynamo:"nonce"`
+	ExpiresAt    int64  `json:"expires_at" bson:"expires_at" cql:"expires_at" dynamo:"expires_at"`
+	LastActiveAt int64  `json:"last_active_at" bson:"last_active_at" cql:"last_active_at" dynamo:"last_active_at"`
+	CreatedAt    int64  `json:"created_at" bson:"created_at" cql:"created_at" dynamo:"created_at"`
+	UpdatedAt    int64  `json:"updated_at" bson:"updated_at" cql:"updated_at" dynamo:"updated_at"`
+}
+
+// StoreKey returns the key used for saving session tokens in memory store
+func (s *Session) StoreKey() string {
+	if s.LoginMethod == "" {
+		return s.UserID
+	}
+	return s.LoginMethod + ":" + s.UserID
+}
+
+// AsAPISession to return session as graphql response object
+func (s *Session) AsAPISession() *model.Session {
+	id := s.ID
+	if strings.Contains(id, Collections.Session+"/") {
+		id = strings.TrimPrefix(id, Collections.Session+"/")
+	}
+	return &model.Session{
+		ID:           id,
+		UserAgent:    refs.NewStringRef(s.UserAgent),
+		IP:           refs.NewStringRef(s.IP),
+		LoginMethod:  refs.NewStringRef(s.LoginMethod),
+		CreatedAt:    refs.NewInt64Ref(s.CreatedAt),
+		LastActiveAt: refs.NewInt64Ref(s.LastActiveAt),
+		ExpiresAt:    refs.NewInt64Ref(s.ExpiresAt),
+	}
 }
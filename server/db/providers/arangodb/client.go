@@ -0,0 +1,75 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	clientCollection, _ := p.db.Collection(ctx, models.Collections.Client)
+	meta, err := clientCollection.CreateDocument(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	client.Key = meta.Key
+	client.ID = meta.ID.String()
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Client)
+	meta, err := collection.UpdateDocument(ctx, client.Key, client)
+	if err != nil {
+		return nil, err
+	}
+	client.Key = meta.Key
+	client.ID = meta.ID.String()
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client *models.Client
+	query := fmt.Sprintf("FOR d in %s FILTER d.client_id == @client_id LIMIT 1 RETURN d", models.Collections.Client)
+	bindVars := map[string]interface{}{
+		"client_id": clientID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if client == nil {
+				return client, fmt.Errorf("client not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &client)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return client, nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Client)
+	_, err := collection.RemoveDocument(ctx, client.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	if grant.GrantedAt == 0 {
+		grant.GrantedAt = grant.CreatedAt
+	}
+	grantCollection, _ := p.db.Collection(ctx, models.Collections.Grant)
+	meta, err := grantCollection.CreateDocument(arangoDriver.WithOverwrite(ctx), grant)
+	if err != nil {
+		return nil, err
+	}
+	grant.Key = meta.Key
+	grant.ID = meta.ID.String()
+	return grant, nil
+}
+
+func (p *provider) UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Grant)
+	meta, err := collection.UpdateDocument(ctx, grant.Key, grant)
+	if err != nil {
+		return nil, err
+	}
+	grant.Key = meta.Key
+	grant.ID = meta.ID.String()
+	return grant, nil
+}
+
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error) {
+	var grant *models.Grant
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id AND d.client_id == @client_id LIMIT 1 RETURN d", models.Collections.Grant)
+	bindVars := map[string]interface{}{
+		"user_id":   userID,
+		"client_id": clientID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if grant == nil {
+				return grant, fmt.Errorf("grant not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &grant)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return grant, nil
+}
+
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error) {
+	grants := []*models.Grant{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.granted_at ASC RETURN d", models.Collections.Grant)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var grant *models.Grant
+		meta, err := cursor.ReadDocument(ctx, &grant)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			grants = append(grants, grant)
+		}
+	}
+	return grants, nil
+}
+
+func (p *provider) DeleteGrant(ctx context.Context, grant *models.Grant) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Grant)
+	_, err := collection.RemoveDocument(ctx, grant.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+package arangodb
+
+import "context"
+
+// HealthCheck fetches the database info to check if arangodb is reachable
+func (p *provider) HealthCheck(ctx context.Context) error {
+	_, err := p.db.Info(ctx)
+	return err
+}
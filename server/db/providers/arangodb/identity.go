@@ -0,0 +1,95 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	if identity.ID == "" {
+		identity.ID = uuid.New().String()
+	}
+	identity.Key = identity.ID
+	identity.CreatedAt = time.Now().Unix()
+	identity.UpdatedAt = time.Now().Unix()
+	if identity.LinkedAt == 0 {
+		identity.LinkedAt = identity.CreatedAt
+	}
+	identityCollection, _ := p.db.Collection(ctx, models.Collections.Identity)
+	meta, err := identityCollection.CreateDocument(arangoDriver.WithOverwrite(ctx), identity)
+	if err != nil {
+		return nil, err
+	}
+	identity.Key = meta.Key
+	identity.ID = meta.ID.String()
+	return identity, nil
+}
+
+func (p *provider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	var identity *models.Identity
+	query := fmt.Sprintf("FOR d in %s FILTER d.provider == @provider AND d.provider_user_id == @provider_user_id LIMIT 1 RETURN d", models.Collections.Identity)
+	bindVars := map[string]interface{}{
+		"provider":         provider,
+		"provider_user_id": providerUserID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if identity == nil {
+				return identity, fmt.Errorf("identity not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &identity)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return identity, nil
+}
+
+func (p *provider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	identities := []*models.Identity{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.linked_at ASC RETURN d", models.Collections.Identity)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var identity *models.Identity
+		meta, err := cursor.ReadDocument(ctx, &identity)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			identities = append(identities, identity)
+		}
+	}
+	return identities, nil
+}
+
+func (p *provider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Identity)
+	_, err := collection.RemoveDocument(ctx, identity.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	if membership.ID == "" {
+		membership.ID = uuid.New().String()
+	}
+	membership.Key = membership.ID
+	membership.CreatedAt = time.Now().Unix()
+	membership.UpdatedAt = time.Now().Unix()
+	membershipCollection, _ := p.db.Collection(ctx, models.Collections.Membership)
+	meta, err := membershipCollection.CreateDocument(ctx, membership)
+	if err != nil {
+		return nil, err
+	}
+	membership.Key = meta.Key
+	membership.ID = meta.ID.String()
+	return membership, nil
+}
+
+func (p *provider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	membership.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Membership)
+	meta, err := collection.UpdateDocument(ctx, membership.Key, membership)
+	if err != nil {
+		return nil, err
+	}
+	membership.Key = meta.Key
+	membership.ID = meta.ID.String()
+	return membership, nil
+}
+
+func (p *provider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	var membership *models.Membership
+	query := fmt.Sprintf("FOR d in %s FILTER d.organization_id == @organization_id AND d.user_id == @user_id LIMIT 1 RETURN d", models.Collections.Membership)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if membership == nil {
+				return membership, fmt.Errorf("membership not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &membership)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return membership, nil
+}
+
+func (p *provider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.organization_id == @organization_id SORT d.created_at ASC RETURN d", models.Collections.Membership)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+	}
+	return p.listMemberships(ctx, query, bindVars)
+}
+
+func (p *provider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at ASC RETURN d", models.Collections.Membership)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	return p.listMemberships(ctx, query, bindVars)
+}
+
+// listMemberships returns the memberships matching query
+func (p *provider) listMemberships(ctx context.Context, query string, bindVars map[string]interface{}) ([]*models.Membership, error) {
+	memberships := []*models.Membership{}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var membership *models.Membership
+		meta, err := cursor.ReadDocument(ctx, &membership)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			memberships = append(memberships, membership)
+		}
+	}
+	return memberships, nil
+}
+
+func (p *provider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Membership)
+	_, err := collection.RemoveDocument(ctx, membership.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
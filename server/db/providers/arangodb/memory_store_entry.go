@@ -0,0 +1,113 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	entry.Key = entry.ID
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	entry.UpdatedAt = time.Now().Unix()
+	entryCollection, _ := p.db.Collection(ctx, models.Collections.MemoryStoreEntry)
+	meta, err := entryCollection.CreateDocument(arangoDriver.WithOverwrite(ctx), entry)
+	if err != nil {
+		return nil, err
+	}
+	entry.Key = meta.Key
+	entry.ID = meta.ID.String()
+	return entry, nil
+}
+
+func (p *provider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	var entry *models.MemoryStoreEntry
+	entryCollection, _ := p.db.Collection(ctx, models.Collections.MemoryStoreEntry)
+	_, err := entryCollection.ReadDocument(ctx, id, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	entryCollection, _ := p.db.Collection(ctx, models.Collections.MemoryStoreEntry)
+	_, err := entryCollection.RemoveDocument(ctx, id)
+	if err != nil && !arangoDriver.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	query := fmt.Sprintf("FOR d IN %s FILTER d.namespace == @namespace OR d.recipe == @namespace REMOVE { _key: d._key } IN %s", models.Collections.MemoryStoreEntry, models.Collections.MemoryStoreEntry)
+	bindVars := map[string]interface{}{
+		"namespace": namespace,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	query := fmt.Sprintf("FOR d IN %s FILTER d.subject == @subject REMOVE { _key: d._key } IN %s", models.Collections.MemoryStoreEntry, models.Collections.MemoryStoreEntry)
+	bindVars := map[string]interface{}{
+		"subject": subject,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	return nil
+}
+
+func (p *provider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	query := fmt.Sprintf("FOR d IN %s FILTER d.expires_at < @expires_at REMOVE { _key: d._key } IN %s", models.Collections.MemoryStoreEntry, models.Collections.MemoryStoreEntry)
+	bindVars := map[string]interface{}{
+		"expires_at": expiresAt,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	return nil
+}
+
+func (p *provider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	entry.Key = entry.ID
+	now := time.Now().Unix()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	// expired entry is not deleted by cleanup yet
+	query := fmt.Sprintf("FOR d IN %s FILTER d._key == @key AND d.expires_at <= @now REMOVE d IN %s", models.Collections.MemoryStoreEntry, models.Collections.MemoryStoreEntry)
+	cursor, err := p.db.Query(ctx, query, map[string]interface{}{
+		"key": entry.Key,
+		"now": now,
+	})
+	if err != nil {
+		return false, err
+	}
+	cursor.Close()
+	entryCollection, _ := p.db.Collection(ctx, models.Collections.MemoryStoreEntry)
+	meta, err := entryCollection.CreateDocument(ctx, entry)
+	if arangoDriver.IsConflict(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	entry.Key = meta.Key
+	entry.ID = meta.ID.String()
+	return true, nil
+}
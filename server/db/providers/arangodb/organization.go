@@ -0,0 +1,120 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection, _ := p.db.Collection(ctx, models.Collections.Organization)
+	meta, err := organizationCollection.CreateDocument(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	organization.Key = meta.Key
+	organization.ID = meta.ID.String()
+	return organization, nil
+}
+
+func (p *provider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Organization)
+	meta, err := collection.UpdateDocument(ctx, organization.Key, organization)
+	if err != nil {
+		return nil, err
+	}
+	organization.Key = meta.Key
+	organization.ID = meta.ID.String()
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d._key == @organization_id LIMIT 1 RETURN d", models.Collections.Organization)
+	bindVars := map[string]interface{}{
+		"organization_id": organizationID,
+	}
+	return p.getOrganization(ctx, query, bindVars)
+}
+
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.name == @name LIMIT 1 RETURN d", models.Collections.Organization)
+	bindVars := map[string]interface{}{
+		"name": name,
+	}
+	return p.getOrganization(ctx, query, bindVars)
+}
+
+// getOrganization returns the organization matching query
+func (p *provider) getOrganization(ctx context.Context, query string, bindVars map[string]interface{}) (*models.Organization, error) {
+	var organization *models.Organization
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if organization == nil {
+				return organization, fmt.Errorf("organization not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &organization)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return organization, nil
+}
+
+func (p *provider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.Organization, pagination.Offset, pagination.Limit)
+	sctx := arangoDriver.WithQueryFullCount(ctx)
+	cursor, err := p.db.Query(sctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	paginationClone := pagination
+	paginationClone.Total = cursor.Statistics().FullCount()
+	for {
+		var organization *models.Organization
+		meta, err := cursor.ReadDocument(ctx, &organization)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			organizations = append(organizations, organization.AsAPIOrganization())
+		}
+	}
+	return &model.Organizations{
+		Pagination:    paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+func (p *provider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Organization)
+	_, err := collection.RemoveDocument(ctx, organization.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
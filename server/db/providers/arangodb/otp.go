@@ -37,12 +37,14 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			Email:       otpParam.Email,
 			PhoneNumber: otpParam.PhoneNumber,
 			ExpiresAt:   otpParam.ExpiresAt,
+			Attempts:    otpParam.Attempts,
 			CreatedAt:   time.Now().Unix(),
 		}
 		shouldCreate = true
 	} else {
 		otp.Otp = otpParam.Otp
 		otp.ExpiresAt = otpParam.ExpiresAt
+		otp.Attempts = otpParam.Attempts
 	}
 	otp.UpdatedAt = time.Now().Unix()
 	otpCollection, _ := p.db.Collection(ctx, models.Collections.OTP)
@@ -124,3 +126,25 @@ func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 	}
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	query := fmt.Sprintf("FOR d IN %s FILTER d._key == @key UPDATE d WITH { attempts: d.attempts + 1, updated_at: @updated_at } IN %s RETURN NEW.attempts", models.Collections.OTP, models.Collections.OTP)
+	bindVars := map[string]interface{}{
+		"key":        otp.Key,
+		"updated_at": time.Now().Unix(),
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close()
+	if !cursor.HasMore() {
+		return 0, errors.New("otp not found")
+	}
+	var attempts int64
+	if _, err := cursor.ReadDocument(ctx, &attempts); err != nil {
+		return 0, err
+	}
+	return attempts, nil
+}
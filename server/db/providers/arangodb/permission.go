@@ -0,0 +1,112 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	if permission.ID == "" {
+		permission.ID = uuid.New().String()
+	}
+	permission.Key = permission.ID
+	permission.CreatedAt = time.Now().Unix()
+	permission.UpdatedAt = time.Now().Unix()
+	permissionCollection, _ := p.db.Collection(ctx, models.Collections.Permission)
+	meta, err := permissionCollection.CreateDocument(ctx, permission)
+	if err != nil {
+		return nil, err
+	}
+	permission.Key = meta.Key
+	permission.ID = meta.ID.String()
+	return permission, nil
+}
+
+func (p *provider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	permission.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Permission)
+	meta, err := collection.UpdateDocument(ctx, permission.Key, permission)
+	if err != nil {
+		return nil, err
+	}
+	permission.Key = meta.Key
+	permission.ID = meta.ID.String()
+	return permission, nil
+}
+
+func (p *provider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.name == @name LIMIT 1 RETURN d", models.Collections.Permission)
+	bindVars := map[string]interface{}{
+		"name": name,
+	}
+	return p.getPermission(ctx, query, bindVars)
+}
+
+// getPermission returns the permission matching query
+func (p *provider) getPermission(ctx context.Context, query string, bindVars map[string]interface{}) (*models.Permission, error) {
+	var permission *models.Permission
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if permission == nil {
+				return permission, fmt.Errorf("permission not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &permission)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return permission, nil
+}
+
+func (p *provider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	permissions := []*model.Permission{}
+	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.Permission, pagination.Offset, pagination.Limit)
+	sctx := arangoDriver.WithQueryFullCount(ctx)
+	cursor, err := p.db.Query(sctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	paginationClone := pagination
+	paginationClone.Total = cursor.Statistics().FullCount()
+	for {
+		var permission *models.Permission
+		meta, err := cursor.ReadDocument(ctx, &permission)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			permissions = append(permissions, permission.AsAPIPermission())
+		}
+	}
+	return &model.Permissions{
+		Pagination:  paginationClone,
+		Permissions: permissions,
+	}, nil
+}
+
+func (p *provider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Permission)
+	_, err := collection.RemoveDocument(ctx, permission.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
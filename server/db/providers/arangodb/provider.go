@@ -140,6 +140,9 @@ func NewProvider() (*provider, error) {
 	sessionCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
 		Sparse: true,
 	})
+	sessionCollection.EnsureHashIndex(ctx, []string{"nonce"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
 	envCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Env)
 	if err != nil {
 		return nil, err
@@ -244,7 +247,236 @@ func NewProvider() (*provider, error) {
 		Sparse: true,
 	})
 
+	identityCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Identity)
+	if err != nil {
+		return nil, err
+	}
+	if !identityCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Identity, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	identityCollection, err := arangodb.Collection(ctx, models.Collections.Identity)
+	if err != nil {
+		return nil, err
+	}
+	identityCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+	identityCollection.EnsureHashIndex(ctx, []string{"provider", "provider_user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	clientCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Client)
+	if err != nil {
+		return nil, err
+	}
+	if !clientCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Client, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	clientCollection, err := arangodb.Collection(ctx, models.Collections.Client)
+	if err != nil {
+		return nil, err
+	}
+	clientCollection.EnsureHashIndex(ctx, []string{"client_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	grantCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Grant)
+	if err != nil {
+		return nil, err
+	}
+	if !grantCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Grant, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	grantCollection, err := arangodb.Collection(ctx, models.Collections.Grant)
+	if err != nil {
+		return nil, err
+	}
+	grantCollection.EnsureHashIndex(ctx, []string{"user_id", "client_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	organizationCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Organization)
+	if err != nil {
+		return nil, err
+	}
+	if !organizationCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Organization, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	organizationCollection, err := arangodb.Collection(ctx, models.Collections.Organization)
+	if err != nil {
+		return nil, err
+	}
+	organizationCollection.EnsureHashIndex(ctx, []string{"name"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	membershipCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Membership)
+	if err != nil {
+		return nil, err
+	}
+	if !membershipCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Membership, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	membershipCollection, err := arangodb.Collection(ctx, models.Collections.Membership)
+	if err != nil {
+		return nil, err
+	}
+	membershipCollection.EnsureHashIndex(ctx, []string{"organization_id", "user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+	membershipCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+
+	roleCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Role)
+	if err != nil {
+		return nil, err
+	}
+	if !roleCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Role, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	roleCollection, err := arangodb.Collection(ctx, models.Collections.Role)
+	if err != nil {
+		return nil, err
+	}
+	roleCollection.EnsureHashIndex(ctx, []string{"name"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	permissionCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.Permission)
+	if err != nil {
+		return nil, err
+	}
+	if !permissionCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.Permission, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	permissionCollection, err := arangodb.Collection(ctx, models.Collections.Permission)
+	if err != nil {
+		return nil, err
+	}
+	permissionCollection.EnsureHashIndex(ctx, []string{"name"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+
+	roleGrantCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.RoleGrant)
+	if err != nil {
+		return nil, err
+	}
+	if !roleGrantCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.RoleGrant, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	roleGrantCollection, err := arangodb.Collection(ctx, models.Collections.RoleGrant)
+	if err != nil {
+		return nil, err
+	}
+	roleGrantCollection.EnsureHashIndex(ctx, []string{"user_id", "role"}, &arangoDriver.EnsureHashIndexOptions{
+		Unique: true,
+		Sparse: true,
+	})
+	roleGrantCollection.EnsureSkipListIndex(ctx, []string{"expires_at"}, &arangoDriver.EnsureSkipListIndexOptions{
+		Sparse: true,
+	})
+
+	roleChangeCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.RoleChange)
+	if err != nil {
+		return nil, err
+	}
+	if !roleChangeCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.RoleChange, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	roleChangeCollection, err := arangodb.Collection(ctx, models.Collections.RoleChange)
+	if err != nil {
+		return nil, err
+	}
+	roleChangeCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+
+	refreshTokenReuseCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.RefreshTokenReuse)
+	if err != nil {
+		return nil, err
+	}
+	if !refreshTokenReuseCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.RefreshTokenReuse, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	refreshTokenReuseCollection, err := arangodb.Collection(ctx, models.Collections.RefreshTokenReuse)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenReuseCollection.EnsureHashIndex(ctx, []string{"user_id"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+
+	memoryStoreEntryCollectionExists, err := arangodb.CollectionExists(ctx, models.Collections.MemoryStoreEntry)
+	if err != nil {
+		return nil, err
+	}
+	if !memoryStoreEntryCollectionExists {
+		_, err = arangodb.CreateCollection(ctx, models.Collections.MemoryStoreEntry, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	memoryStoreEntryCollection, err := arangodb.Collection(ctx, models.Collections.MemoryStoreEntry)
+	if err != nil {
+		return nil, err
+	}
+	memoryStoreEntryCollection.EnsureHashIndex(ctx, []string{"namespace"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+	memoryStoreEntryCollection.EnsureHashIndex(ctx, []string{"recipe"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+	memoryStoreEntryCollection.EnsureHashIndex(ctx, []string{"subject"}, &arangoDriver.EnsureHashIndexOptions{
+		Sparse: true,
+	})
+	memoryStoreEntryCollection.EnsureSkipListIndex(ctx, []string{"expires_at"}, &arangoDriver.EnsureSkipListIndexOptions{
+		Sparse: true,
+	})
+
 	return &provider{
 		db: arangodb,
 	}, err
 }
+
+// Close is no-op for arangodb, as the driver uses http connections which are not kept open
+func (p *provider) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,55 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	if refreshTokenReuse.ID == "" {
+		refreshTokenReuse.ID = uuid.New().String()
+	}
+	refreshTokenReuse.Key = refreshTokenReuse.ID
+	refreshTokenReuse.CreatedAt = time.Now().Unix()
+	refreshTokenReuseCollection, _ := p.db.Collection(ctx, models.Collections.RefreshTokenReuse)
+	meta, err := refreshTokenReuseCollection.CreateDocument(ctx, refreshTokenReuse)
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenReuse.Key = meta.Key
+	refreshTokenReuse.ID = meta.ID.String()
+	return refreshTokenReuse, nil
+}
+
+func (p *provider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	refreshTokenReuses := []*models.RefreshTokenReuse{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at ASC RETURN d", models.Collections.RefreshTokenReuse)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var refreshTokenReuse *models.RefreshTokenReuse
+		meta, err := cursor.ReadDocument(ctx, &refreshTokenReuse)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			refreshTokenReuses = append(refreshTokenReuses, refreshTokenReuse)
+		}
+	}
+	return refreshTokenReuses, nil
+}
@@ -0,0 +1,112 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.Key = role.ID
+	role.CreatedAt = time.Now().Unix()
+	role.UpdatedAt = time.Now().Unix()
+	roleCollection, _ := p.db.Collection(ctx, models.Collections.Role)
+	meta, err := roleCollection.CreateDocument(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	role.Key = meta.Key
+	role.ID = meta.ID.String()
+	return role, nil
+}
+
+func (p *provider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	role.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Role)
+	meta, err := collection.UpdateDocument(ctx, role.Key, role)
+	if err != nil {
+		return nil, err
+	}
+	role.Key = meta.Key
+	role.ID = meta.ID.String()
+	return role, nil
+}
+
+func (p *provider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.name == @name LIMIT 1 RETURN d", models.Collections.Role)
+	bindVars := map[string]interface{}{
+		"name": name,
+	}
+	return p.getRole(ctx, query, bindVars)
+}
+
+// getRole returns the role matching query
+func (p *provider) getRole(ctx context.Context, query string, bindVars map[string]interface{}) (*models.Role, error) {
+	var role *models.Role
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if role == nil {
+				return role, fmt.Errorf("role not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &role)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return role, nil
+}
+
+func (p *provider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	roles := []*model.Role{}
+	query := fmt.Sprintf("FOR d in %s SORT d.created_at DESC LIMIT %d, %d RETURN d", models.Collections.Role, pagination.Offset, pagination.Limit)
+	sctx := arangoDriver.WithQueryFullCount(ctx)
+	cursor, err := p.db.Query(sctx, query, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	paginationClone := pagination
+	paginationClone.Total = cursor.Statistics().FullCount()
+	for {
+		var role *models.Role
+		meta, err := cursor.ReadDocument(ctx, &role)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			roles = append(roles, role.AsAPIRole())
+		}
+	}
+	return &model.Roles{
+		Pagination: paginationClone,
+		Roles:      roles,
+	}, nil
+}
+
+func (p *provider) DeleteRole(ctx context.Context, role *models.Role) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Role)
+	_, err := collection.RemoveDocument(ctx, role.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
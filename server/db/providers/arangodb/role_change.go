@@ -0,0 +1,55 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	if roleChange.ID == "" {
+		roleChange.ID = uuid.New().String()
+	}
+	roleChange.Key = roleChange.ID
+	roleChange.CreatedAt = time.Now().Unix()
+	roleChangeCollection, _ := p.db.Collection(ctx, models.Collections.RoleChange)
+	meta, err := roleChangeCollection.CreateDocument(ctx, roleChange)
+	if err != nil {
+		return nil, err
+	}
+	roleChange.Key = meta.Key
+	roleChange.ID = meta.ID.String()
+	return roleChange, nil
+}
+
+func (p *provider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	roleChanges := []*models.RoleChange{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at ASC RETURN d", models.Collections.RoleChange)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var roleChange *models.RoleChange
+		meta, err := cursor.ReadDocument(ctx, &roleChange)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			roleChanges = append(roleChanges, roleChange)
+		}
+	}
+	return roleChanges, nil
+}
@@ -0,0 +1,117 @@
+package arangodb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	arangoDriver "github.com/arangodb/go-driver"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	if roleGrant.ID == "" {
+		roleGrant.ID = uuid.New().String()
+	}
+	roleGrant.Key = roleGrant.ID
+	roleGrant.CreatedAt = time.Now().Unix()
+	roleGrant.UpdatedAt = time.Now().Unix()
+	roleGrantCollection, _ := p.db.Collection(ctx, models.Collections.RoleGrant)
+	meta, err := roleGrantCollection.CreateDocument(ctx, roleGrant)
+	if err != nil {
+		return nil, err
+	}
+	roleGrant.Key = meta.Key
+	roleGrant.ID = meta.ID.String()
+	return roleGrant, nil
+}
+
+func (p *provider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	roleGrant.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.RoleGrant)
+	meta, err := collection.UpdateDocument(ctx, roleGrant.Key, roleGrant)
+	if err != nil {
+		return nil, err
+	}
+	roleGrant.Key = meta.Key
+	roleGrant.ID = meta.ID.String()
+	return roleGrant, nil
+}
+
+func (p *provider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	var roleGrant *models.RoleGrant
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id AND d.role == @role LIMIT 1 RETURN d", models.Collections.RoleGrant)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+		"role":    role,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if roleGrant == nil {
+				return roleGrant, fmt.Errorf("role grant not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &roleGrant)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.created_at ASC RETURN d", models.Collections.RoleGrant)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	return p.listRoleGrants(ctx, query, bindVars)
+}
+
+func (p *provider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	query := fmt.Sprintf("FOR d in %s FILTER d.expires_at > 0 AND d.expires_at <= @expires_at RETURN d", models.Collections.RoleGrant)
+	bindVars := map[string]interface{}{
+		"expires_at": expiresAt,
+	}
+	return p.listRoleGrants(ctx, query, bindVars)
+}
+
+// listRoleGrants returns the role grants matching query
+func (p *provider) listRoleGrants(ctx context.Context, query string, bindVars map[string]interface{}) ([]*models.RoleGrant, error) {
+	roleGrants := []*models.RoleGrant{}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var roleGrant *models.RoleGrant
+		meta, err := cursor.ReadDocument(ctx, &roleGrant)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			roleGrants = append(roleGrants, roleGrant)
+		}
+	}
+	return roleGrants, nil
+}
+
+func (p *provider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.RoleGrant)
+	_, err := collection.RemoveDocument(ctx, roleGrant.Key)
+	if err != nil {
+		return err
+	}
+	return nil
+}
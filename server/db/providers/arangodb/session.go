@@ -2,8 +2,11 @@ package arangodb
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	arangoDriver "github.com/arangodb/go-driver"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/google/uuid"
 )
@@ -16,6 +19,9 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 	}
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
+	if session.LastActiveAt == 0 {
+		session.LastActiveAt = session.CreatedAt
+	}
 	sessionCollection, _ := p.db.Collection(ctx, models.Collections.Session)
 	_, err := sessionCollection.CreateDocument(ctx, session)
 	if err != nil {
@@ -28,3 +34,107 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 func (p *provider) DeleteSession(ctx context.Context, userId string) error {
 	return nil
 }
+
+// ListSessionsByUserID to get list of sessions for given user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	sessions := []*models.Session{}
+	query := fmt.Sprintf("FOR d in %s FILTER d.user_id == @user_id SORT d.last_active_at DESC RETURN d", models.Collections.Session)
+	bindVars := map[string]interface{}{
+		"user_id": userID,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		var session *models.Session
+		meta, err := cursor.ReadDocument(ctx, &session)
+		if arangoDriver.IsNoMoreDocuments(err) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if meta.Key != "" {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+// GetSessionByID to get session information from database using session ID
+func (p *provider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	var session *models.Session
+	query := fmt.Sprintf("FOR d in %s FILTER d._key == @id LIMIT 1 RETURN d", models.Collections.Session)
+	bindVars := map[string]interface{}{
+		"id": strings.TrimPrefix(id, models.Collections.Session+"/"),
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if session == nil {
+				return nil, fmt.Errorf("session not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &session)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// GetSessionByNonce to get session information using the nonce of its memory store keys
+func (p *provider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	var session *models.Session
+	query := fmt.Sprintf("FOR d in %s FILTER d.nonce == @nonce LIMIT 1 RETURN d", models.Collections.Session)
+	bindVars := map[string]interface{}{
+		"nonce": nonce,
+	}
+	cursor, err := p.db.Query(ctx, query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	for {
+		if !cursor.HasMore() {
+			if session == nil {
+				return nil, fmt.Errorf("session not found")
+			}
+			break
+		}
+		_, err := cursor.ReadDocument(ctx, &session)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// UpdateSession to update session information in database
+func (p *provider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	session.UpdatedAt = time.Now().Unix()
+	collection, _ := p.db.Collection(ctx, models.Collections.Session)
+	meta, err := collection.UpdateDocument(ctx, session.Key, session)
+	if err != nil {
+		return nil, err
+	}
+	session.Key = meta.Key
+	session.ID = meta.ID.String()
+	return session, nil
+}
+
+// DeleteSessionByID to delete single session information from database
+func (p *provider) DeleteSessionByID(ctx context.Context, id string) error {
+	collection, _ := p.db.Collection(ctx, models.Collections.Session)
+	_, err := collection.RemoveDocument(ctx, strings.TrimPrefix(id, models.Collections.Session+"/"))
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package cassandradb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+const clientFields = "id, client_id, client_secret, registration_access_token, client_name, redirect_uris, grant_types, response_types, token_endpoint_auth_method, scope, jwks, jwks_uri, require_pushed_authorization_requests, backchannel_logout_uri, backchannel_logout_session_required, created_at, updated_at"
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+
+	bytes, err := json.Marshal(client)
+	if err != nil {
+		return nil, err
+	}
+
+	// use decoder instead of json.Unmarshall, because it converts int64 -> float64 after unmarshalling
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	clientMap := map[string]interface{}{}
+	err = decoder.Decode(&clientMap)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := "("
+	values := "("
+	for key, value := range clientMap {
+		if value != nil {
+			if key == "_id" {
+				fields += "id,"
+			} else {
+				fields += key + ","
+			}
+
+			valueType := reflect.TypeOf(value)
+			if valueType.Name() == "string" {
+				values += fmt.Sprintf("'%s',", value.(string))
+			} else {
+				values += fmt.Sprintf("%v,", value)
+			}
+		}
+	}
+
+	fields = fields[:len(fields)-1] + ")"
+	values = values[:len(values)-1] + ")"
+
+	query := fmt.Sprintf("INSERT INTO %s %s VALUES %s IF NOT EXISTS", KeySpace+"."+models.Collections.Client, fields, values)
+	err = p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+
+	bytes, err := json.Marshal(client)
+	if err != nil {
+		return nil, err
+	}
+	// use decoder instead of json.Unmarshall, because it converts int64 -> float64 after unmarshalling
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	clientMap := map[string]interface{}{}
+	err = decoder.Decode(&clientMap)
+	if err != nil {
+		return nil, err
+	}
+
+	updateFields := ""
+	for key, value := range clientMap {
+		if key == "_id" || key == "_key" {
+			continue
+		}
+
+		if value == nil {
+			updateFields += fmt.Sprintf("%s = null, ", key)
+			continue
+		}
+
+		valueType := reflect.TypeOf(value)
+		if valueType.Name() == "string" {
+			updateFields += fmt.Sprintf("%s = '%s', ", key, value.(string))
+		} else {
+			updateFields += fmt.Sprintf("%s = %v, ", key, value)
+		}
+	}
+	updateFields = strings.Trim(updateFields, " ")
+	updateFields = strings.TrimSuffix(updateFields, ",")
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = '%s'", KeySpace+"."+models.Collections.Client, updateFields, client.ID)
+	err = p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client models.Client
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE client_id = '%s' LIMIT 1 ALLOW FILTERING", clientFields, KeySpace+"."+models.Collections.Client, clientID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&client.ID, &client.ClientID, &client.ClientSecret, &client.RegistrationAccessToken, &client.ClientName, &client.RedirectURIs, &client.GrantTypes, &client.ResponseTypes, &client.TokenEndpointAuthMethod, &client.Scope, &client.JWKS, &client.JWKSURI, &client.RequirePushedAuthorizationRequests, &client.BackchannelLogoutURI, &client.BackchannelLogoutSessionRequired, &client.CreatedAt, &client.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Client, client.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package cassandradb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	if grant.GrantedAt == 0 {
+		grant.GrantedAt = grant.CreatedAt
+	}
+
+	bytes, err := json.Marshal(grant)
+	if err != nil {
+		return nil, err
+	}
+
+	// use decoder instead of json.Unmarshall, because it converts int64 -> float64 after unmarshalling
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	grantMap := map[string]interface{}{}
+	err = decoder.Decode(&grantMap)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := "("
+	values := "("
+	for key, value := range grantMap {
+		if value != nil {
+			if key == "_id" {
+				fields += "id,"
+			} else {
+				fields += key + ","
+			}
+
+			valueType := reflect.TypeOf(value)
+			if valueType.Name() == "string" {
+				values += fmt.Sprintf("'%s',", value.(string))
+			} else {
+				values += fmt.Sprintf("%v,", value)
+			}
+		}
+	}
+
+	fields = fields[:len(fields)-1] + ")"
+	values = values[:len(values)-1] + ")"
+
+	query := fmt.Sprintf("INSERT INTO %s %s VALUES %s IF NOT EXISTS", KeySpace+"."+models.Collections.Grant, fields, values)
+	err = p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+func (p *provider) UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET scope = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Grant, grant.Scope, grant.UpdatedAt, grant.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error) {
+	var grant models.Grant
+	query := fmt.Sprintf("SELECT id, user_id, client_id, scope, granted_at, created_at, updated_at FROM %s WHERE user_id = '%s' AND client_id = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Grant, userID, clientID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&grant.ID, &grant.UserID, &grant.ClientID, &grant.Scope, &grant.GrantedAt, &grant.CreatedAt, &grant.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &grant, nil
+}
+
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error) {
+	grants := []*models.Grant{}
+	query := fmt.Sprintf("SELECT id, user_id, client_id, scope, granted_at, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Grant, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var grant models.Grant
+		err := scanner.Scan(&grant.ID, &grant.UserID, &grant.ClientID, &grant.Scope, &grant.GrantedAt, &grant.CreatedAt, &grant.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, &grant)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(grants, func(i, j int) bool {
+		return grants[i].GrantedAt < grants[j].GrantedAt
+	})
+	return grants, nil
+}
+
+func (p *provider) DeleteGrant(ctx context.Context, grant *models.Grant) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Grant, grant.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,8 @@
+package cassandradb
+
+import "context"
+
+// HealthCheck runs a lightweight query against system keyspace
+func (p *provider) HealthCheck(ctx context.Context) error {
+	return p.db.Query("SELECT now() FROM system.local").WithContext(ctx).Exec()
+}
@@ -0,0 +1,113 @@
+package cassandradb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	if identity.ID == "" {
+		identity.ID = models.IdentityID(identity.Provider, identity.ProviderUserID)
+	}
+	identity.CreatedAt = time.Now().Unix()
+	identity.UpdatedAt = time.Now().Unix()
+	if identity.LinkedAt == 0 {
+		identity.LinkedAt = identity.CreatedAt
+	}
+
+	bytes, err := json.Marshal(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// use decoder instead of json.Unmarshall, because it converts int64 -> float64 after unmarshalling
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	identityMap := map[string]interface{}{}
+	err = decoder.Decode(&identityMap)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := "("
+	values := "("
+	for key, value := range identityMap {
+		if value != nil {
+			if key == "_id" {
+				fields += "id,"
+			} else {
+				fields += key + ","
+			}
+
+			valueType := reflect.TypeOf(value)
+			if valueType.Name() == "string" {
+				values += fmt.Sprintf("'%s',", value.(string))
+			} else {
+				values += fmt.Sprintf("%v,", value)
+			}
+		}
+	}
+
+	fields = fields[:len(fields)-1] + ")"
+	values = values[:len(values)-1] + ")"
+
+	query := fmt.Sprintf("INSERT INTO %s %s VALUES %s IF NOT EXISTS", KeySpace+"."+models.Collections.Identity, fields, values)
+	// id is unique for provider account
+	applied, err := p.db.Query(query).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return nil, errors.New("identity already exists")
+	}
+
+	return identity, nil
+}
+
+func (p *provider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	var identity models.Identity
+	query := fmt.Sprintf("SELECT id, user_id, provider, provider_user_id, email, linked_at, created_at, updated_at FROM %s WHERE provider = '%s' AND provider_user_id = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Identity, provider, providerUserID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.ProviderUserID, &identity.Email, &identity.LinkedAt, &identity.CreatedAt, &identity.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (p *provider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	identities := []*models.Identity{}
+	query := fmt.Sprintf("SELECT id, user_id, provider, provider_user_id, email, linked_at, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Identity, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var identity models.Identity
+		err := scanner.Scan(&identity.ID, &identity.UserID, &identity.Provider, &identity.ProviderUserID, &identity.Email, &identity.LinkedAt, &identity.CreatedAt, &identity.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, &identity)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].LinkedAt < identities[j].LinkedAt
+	})
+	return identities, nil
+}
+
+func (p *provider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Identity, identity.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	if membership.ID == "" {
+		membership.ID = uuid.New().String()
+	}
+	membership.Key = membership.ID
+	membership.CreatedAt = time.Now().Unix()
+	membership.UpdatedAt = time.Now().Unix()
+	existingMembership, _ := p.GetMembershipByOrganizationIDAndUserID(ctx, membership.OrganizationID, membership.UserID)
+	if existingMembership != nil {
+		return nil, fmt.Errorf("user is already member of organization")
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, organization_id, user_id, roles, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Membership, membership.ID, membership.OrganizationID, membership.UserID, membership.Roles, membership.CreatedAt, membership.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	membership.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET roles = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Membership, membership.Roles, membership.UpdatedAt, membership.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	var membership models.Membership
+	query := fmt.Sprintf("SELECT id, organization_id, user_id, roles, created_at, updated_at FROM %s WHERE organization_id = '%s' AND user_id = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Membership, organizationID, userID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&membership.ID, &membership.OrganizationID, &membership.UserID, &membership.Roles, &membership.CreatedAt, &membership.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+func (p *provider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	query := fmt.Sprintf("SELECT id, organization_id, user_id, roles, created_at, updated_at FROM %s WHERE organization_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Membership, organizationID)
+	return p.listMemberships(query)
+}
+
+func (p *provider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	query := fmt.Sprintf("SELECT id, organization_id, user_id, roles, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.Membership, userID)
+	return p.listMemberships(query)
+}
+
+// listMemberships returns the memberships selected by query in the order they were created
+func (p *provider) listMemberships(query string) ([]*models.Membership, error) {
+	memberships := []*models.Membership{}
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var membership models.Membership
+		err := scanner.Scan(&membership.ID, &membership.OrganizationID, &membership.UserID, &membership.Roles, &membership.CreatedAt, &membership.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &membership)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].CreatedAt < memberships[j].CreatedAt
+	})
+	return memberships, nil
+}
+
+func (p *provider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Membership, membership.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	entry.UpdatedAt = time.Now().Unix()
+	// values are passed as query parameters as memory store values can have any character
+	query := fmt.Sprintf("INSERT INTO %s (id, namespace, recipe, subject, value, expires_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)", KeySpace+"."+models.Collections.MemoryStoreEntry)
+	err := p.db.Query(query, entry.ID, entry.Namespace, entry.Recipe, entry.Subject, entry.Value, entry.ExpiresAt, entry.CreatedAt, entry.UpdatedAt).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	var entry models.MemoryStoreEntry
+	query := fmt.Sprintf("SELECT id, namespace, recipe, subject, value, expires_at, created_at, updated_at FROM %s WHERE id = ? LIMIT 1", KeySpace+"."+models.Collections.MemoryStoreEntry)
+	err := p.db.Query(query, id).Consistency(gocql.One).Scan(&entry.ID, &entry.Namespace, &entry.Recipe, &entry.Subject, &entry.Value, &entry.ExpiresAt, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (p *provider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = ?", KeySpace+"."+models.Collections.MemoryStoreEntry)
+	err := p.db.Query(query, id).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	// cassandra does not support OR conditions
+	err := p.deleteMemoryStoreEntries("namespace = ?", namespace)
+	if err != nil {
+		return err
+	}
+	return p.deleteMemoryStoreEntries("recipe = ?", namespace)
+}
+
+func (p *provider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	return p.deleteMemoryStoreEntries("subject = ?", subject)
+}
+
+func (p *provider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	return p.deleteMemoryStoreEntries("expires_at < ?", expiresAt)
+}
+
+// deleteMemoryStoreEntries deletes the entries matching the condition,
+// as cassandra can only delete rows by primary key
+func (p *provider) deleteMemoryStoreEntries(condition string, value interface{}) error {
+	query := fmt.Sprintf("SELECT id FROM %s WHERE %s ALLOW FILTERING", KeySpace+"."+models.Collections.MemoryStoreEntry, condition)
+	scanner := p.db.Query(query, value).Iter().Scanner()
+	ids := []string{}
+	for scanner.Next() {
+		var id string
+		err := scanner.Scan(&id)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE id IN ?", KeySpace+"."+models.Collections.MemoryStoreEntry)
+	return p.db.Query(deleteQuery, ids).Exec()
+}
+
+func (p *provider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	now := time.Now().Unix()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	// expired entry is not deleted by cleanup yet
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE id = ? IF expires_at <= ?", KeySpace+"."+models.Collections.MemoryStoreEntry)
+	if _, err := p.db.Query(deleteQuery, entry.ID, now).MapScanCAS(map[string]interface{}{}); err != nil {
+		return false, err
+	}
+	query := fmt.Sprintf("INSERT INTO %s (id, namespace, recipe, subject, value, expires_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?) IF NOT EXISTS", KeySpace+"."+models.Collections.MemoryStoreEntry)
+	applied, err := p.db.Query(query, entry.ID, entry.Namespace, entry.Recipe, entry.Subject, entry.Value, entry.ExpiresAt, entry.CreatedAt, entry.UpdatedAt).MapScanCAS(map[string]interface{}{})
+	if err != nil {
+		return false, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,105 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	existingOrganization, _ := p.GetOrganizationByName(ctx, organization.Name)
+	if existingOrganization != nil {
+		return nil, fmt.Errorf("organization with %s name already exists", organization.Name)
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, name, roles, default_roles, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Organization, organization.ID, organization.Name, organization.Roles, organization.DefaultRoles, organization.CreatedAt, organization.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET name = '%s', roles = '%s', default_roles = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Organization, organization.Name, organization.Roles, organization.DefaultRoles, organization.UpdatedAt, organization.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("SELECT id, name, roles, default_roles, created_at, updated_at FROM %s WHERE id = '%s' LIMIT 1", KeySpace+"."+models.Collections.Organization, organizationID)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&organization.ID, &organization.Name, &organization.Roles, &organization.DefaultRoles, &organization.CreatedAt, &organization.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	var organization models.Organization
+	query := fmt.Sprintf("SELECT id, name, roles, default_roles, created_at, updated_at FROM %s WHERE name = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Organization, name)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&organization.ID, &organization.Name, &organization.Roles, &organization.DefaultRoles, &organization.CreatedAt, &organization.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (p *provider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+	paginationClone := pagination
+
+	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.Organization)
+	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	// there is no offset in cassandra
+	// so we fetch till limit + offset
+	// and return the results from offset to limit
+	query := fmt.Sprintf("SELECT id, name, roles, default_roles, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.Organization, pagination.Limit+pagination.Offset)
+	scanner := p.db.Query(query).Iter().Scanner()
+	counter := int64(0)
+	for scanner.Next() {
+		if counter >= pagination.Offset {
+			var organization models.Organization
+			err := scanner.Scan(&organization.ID, &organization.Name, &organization.Roles, &organization.DefaultRoles, &organization.CreatedAt, &organization.UpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+			organizations = append(organizations, organization.AsAPIOrganization())
+		}
+		counter++
+	}
+
+	return &model.Organizations{
+		Pagination:    paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+func (p *provider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Organization, organization.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -36,20 +36,22 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			Email:       otpParam.Email,
 			PhoneNumber: otpParam.PhoneNumber,
 			ExpiresAt:   otpParam.ExpiresAt,
+			Attempts:    otpParam.Attempts,
 			CreatedAt:   time.Now().Unix(),
 			UpdatedAt:   time.Now().Unix(),
 		}
 	} else {
 		otp.Otp = otpParam.Otp
 		otp.ExpiresAt = otpParam.ExpiresAt
+		otp.Attempts = otpParam.Attempts
 	}
 
 	otp.UpdatedAt = time.Now().Unix()
 	query := ""
 	if shouldCreate {
-		query = fmt.Sprintf(`INSERT INTO %s (id, email, phone_number, otp, expires_at, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d, %d)`, KeySpace+"."+models.Collections.OTP, otp.ID, otp.Email, otp.PhoneNumber, otp.Otp, otp.ExpiresAt, otp.CreatedAt, otp.UpdatedAt)
+		query = fmt.Sprintf(`INSERT INTO %s (id, email, phone_number, otp, expires_at, attempts, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d, %d, %d)`, KeySpace+"."+models.Collections.OTP, otp.ID, otp.Email, otp.PhoneNumber, otp.Otp, otp.ExpiresAt, otp.Attempts, otp.CreatedAt, otp.UpdatedAt)
 	} else {
-		query = fmt.Sprintf(`UPDATE %s SET otp = '%s', expires_at = %d, updated_at = %d WHERE id = '%s'`, KeySpace+"."+models.Collections.OTP, otp.Otp, otp.ExpiresAt, otp.UpdatedAt, otp.ID)
+		query = fmt.Sprintf(`UPDATE %s SET otp = '%s', expires_at = %d, attempts = %d, updated_at = %d WHERE id = '%s'`, KeySpace+"."+models.Collections.OTP, otp.Otp, otp.ExpiresAt, otp.Attempts, otp.UpdatedAt, otp.ID)
 	}
 
 	err := p.db.Query(query).Exec()
@@ -63,8 +65,8 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 // GetOTPByEmail to get otp for a given email address
 func (p *provider) GetOTPByEmail(ctx context.Context, emailAddress string) (*models.OTP, error) {
 	var otp models.OTP
-	query := fmt.Sprintf(`SELECT id, email, phone_number, otp, expires_at, created_at, updated_at FROM %s WHERE email = '%s' LIMIT 1 ALLOW FILTERING`, KeySpace+"."+models.Collections.OTP, emailAddress)
-	err := p.db.Query(query).Consistency(gocql.One).Scan(&otp.ID, &otp.Email, &otp.PhoneNumber, &otp.Otp, &otp.ExpiresAt, &otp.CreatedAt, &otp.UpdatedAt)
+	query := fmt.Sprintf(`SELECT id, email, phone_number, otp, expires_at, attempts, created_at, updated_at FROM %s WHERE email = '%s' LIMIT 1 ALLOW FILTERING`, KeySpace+"."+models.Collections.OTP, emailAddress)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&otp.ID, &otp.Email, &otp.PhoneNumber, &otp.Otp, &otp.ExpiresAt, &otp.Attempts, &otp.CreatedAt, &otp.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +76,8 @@ func (p *provider) GetOTPByEmail(ctx context.Context, emailAddress string) (*mod
 // GetOTPByPhoneNumber to get otp for a given phone number
 func (p *provider) GetOTPByPhoneNumber(ctx context.Context, phoneNumber string) (*models.OTP, error) {
 	var otp models.OTP
-	query := fmt.Sprintf(`SELECT id, email, phone_number, otp, expires_at, created_at, updated_at FROM %s WHERE phone_number = '%s' LIMIT 1 ALLOW FILTERING`, KeySpace+"."+models.Collections.OTP, phoneNumber)
-	err := p.db.Query(query).Consistency(gocql.One).Scan(&otp.ID, &otp.Email, &otp.PhoneNumber, &otp.Otp, &otp.ExpiresAt, &otp.CreatedAt, &otp.UpdatedAt)
+	query := fmt.Sprintf(`SELECT id, email, phone_number, otp, expires_at, attempts, created_at, updated_at FROM %s WHERE phone_number = '%s' LIMIT 1 ALLOW FILTERING`, KeySpace+"."+models.Collections.OTP, phoneNumber)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&otp.ID, &otp.Email, &otp.PhoneNumber, &otp.Otp, &otp.ExpiresAt, &otp.Attempts, &otp.CreatedAt, &otp.UpdatedAt)
 	if err != nil {
 		return nil, err
 	}
@@ -91,3 +93,25 @@ func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 	}
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count.
+// Lightweight transaction is used so that concurrent verifications are all counted.
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	var attempts int64
+	query := fmt.Sprintf("SELECT attempts FROM %s WHERE id = '%s' LIMIT 1", KeySpace+"."+models.Collections.OTP, otp.ID)
+	if err := p.db.Query(query).Consistency(gocql.One).Scan(&attempts); err != nil {
+		return 0, err
+	}
+	for {
+		updateQuery := fmt.Sprintf("UPDATE %s SET attempts = %d, updated_at = %d WHERE id = '%s' IF attempts = %d", KeySpace+"."+models.Collections.OTP, attempts+1, time.Now().Unix(), otp.ID, attempts)
+		var currentAttempts int64
+		applied, err := p.db.Query(updateQuery).ScanCAS(&currentAttempts)
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return attempts + 1, nil
+		}
+		attempts = currentAttempts
+	}
+}
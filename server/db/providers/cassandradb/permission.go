@@ -0,0 +1,95 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	if permission.ID == "" {
+		permission.ID = uuid.New().String()
+	}
+	permission.Key = permission.ID
+	permission.CreatedAt = time.Now().Unix()
+	permission.UpdatedAt = time.Now().Unix()
+	existingPermission, _ := p.GetPermissionByName(ctx, permission.Name)
+	if existingPermission != nil {
+		return nil, fmt.Errorf("permission with %s name already exists", permission.Name)
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, name, description, created_at, updated_at) VALUES ('%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Permission, permission.ID, permission.Name, permission.Description, permission.CreatedAt, permission.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	permission.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET name = '%s', description = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Permission, permission.Name, permission.Description, permission.UpdatedAt, permission.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	var permission models.Permission
+	query := fmt.Sprintf("SELECT id, name, description, created_at, updated_at FROM %s WHERE name = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Permission, name)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&permission.ID, &permission.Name, &permission.Description, &permission.CreatedAt, &permission.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &permission, nil
+}
+
+func (p *provider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	permissions := []*model.Permission{}
+	paginationClone := pagination
+
+	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.Permission)
+	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	// there is no offset in cassandra
+	// so we fetch till limit + offset
+	// and return the results from offset to limit
+	query := fmt.Sprintf("SELECT id, name, description, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.Permission, pagination.Limit+pagination.Offset)
+	scanner := p.db.Query(query).Iter().Scanner()
+	counter := int64(0)
+	for scanner.Next() {
+		if counter >= pagination.Offset {
+			var permission models.Permission
+			err := scanner.Scan(&permission.ID, &permission.Name, &permission.Description, &permission.CreatedAt, &permission.UpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+			permissions = append(permissions, permission.AsAPIPermission())
+		}
+		counter++
+	}
+
+	return &model.Permissions{
+		Pagination:  paginationClone,
+		Permissions: permissions,
+	}, nil
+}
+
+func (p *provider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Permission, permission.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package cassandradb
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
@@ -150,6 +151,17 @@ func NewProvider() (*provider, error) {
 	if err != nil {
 		return nil, err
 	}
+	sessionAlterQuery := fmt.Sprintf(`ALTER TABLE %s.%s ADD (login_method text, nonce text, expires_at bigint, last_active_at bigint);`, KeySpace, models.Collections.Session)
+	err = session.Query(sessionAlterQuery).Exec()
+	if err != nil {
+		log.Debug("Failed to alter table as column exists: ", err)
+		// return nil, err
+	}
+	sessionNonceIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_session_nonce ON %s.%s (nonce)", KeySpace, models.Collections.Session)
+	err = session.Query(sessionNonceIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
 
 	userCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, email text, email_verified_at bigint, password text, signup_methods text, given_name text, family_name text, middle_name text, nickname text, gender text, birthdate text, phone_number text, phone_number_verified_at bigint, picture text, roles text, updated_at bigint, created_at bigint, revoked_timestamp bigint, PRIMARY KEY (id))", KeySpace, models.Collections.User)
 	err = session.Query(userCollectionQuery).Exec()
@@ -261,6 +273,13 @@ func NewProvider() (*provider, error) {
 		log.Debug("Failed to alter table as column exists: ", err)
 		// continue
 	}
+	// Add attempts column to otp table
+	otpAttemptsAlterQuery := fmt.Sprintf(`ALTER TABLE %s.%s ADD (attempts bigint);`, KeySpace, models.Collections.OTP)
+	err = session.Query(otpAttemptsAlterQuery).Exec()
+	if err != nil {
+		log.Debug("Failed to alter otp table as attempts column exists: ", err)
+		// continue
+	}
 	// Add app_data column to users table
 	appDataAlterQuery := fmt.Sprintf(`ALTER TABLE %s.%s ADD (app_data text);`, KeySpace, models.Collections.User)
 	err = session.Query(appDataAlterQuery).Exec()
@@ -281,7 +300,163 @@ func NewProvider() (*provider, error) {
 		return nil, err
 	}
 
+	// add identities table
+	identityCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, provider text, provider_user_id text, email text, linked_at bigint, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Identity)
+	err = session.Query(identityCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	identityIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_identity_user_id ON %s.%s (user_id)", KeySpace, models.Collections.Identity)
+	err = session.Query(identityIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add clients table
+	clientCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, client_id text, client_secret text, registration_access_token text, client_name text, redirect_uris text, grant_types text, response_types text, token_endpoint_auth_method text, scope text, jwks text, jwks_uri text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Client)
+	err = session.Query(clientCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	clientIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_client_client_id ON %s.%s (client_id)", KeySpace, models.Collections.Client)
+	err = session.Query(clientIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	clientAlterQuery := fmt.Sprintf(`ALTER TABLE %s.%s ADD (require_pushed_authorization_requests boolean);`, KeySpace, models.Collections.Client)
+	err = session.Query(clientAlterQuery).Exec()
+	if err != nil {
+		log.Debug("Failed to alter table as column exists: ", err)
+		// continue
+	}
+	clientBackchannelLogoutAlterQuery := fmt.Sprintf(`ALTER TABLE %s.%s ADD (backchannel_logout_uri text, backchannel_logout_session_required boolean);`, KeySpace, models.Collections.Client)
+	err = session.Query(clientBackchannelLogoutAlterQuery).Exec()
+	if err != nil {
+		log.Debug("Failed to alter table as column exists: ", err)
+		// continue
+	}
+
+	// add grants table
+	grantCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, client_id text, scope text, granted_at bigint, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Grant)
+	err = session.Query(grantCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	grantIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_grant_user_id ON %s.%s (user_id)", KeySpace, models.Collections.Grant)
+	err = session.Query(grantIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add organizations table
+	organizationCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, name text, roles text, default_roles text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Organization)
+	err = session.Query(organizationCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	organizationIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_organization_name ON %s.%s (name)", KeySpace, models.Collections.Organization)
+	err = session.Query(organizationIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add memberships table
+	membershipCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, organization_id text, user_id text, roles text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Membership)
+	err = session.Query(membershipCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{"organization_id", "user_id"} {
+		membershipIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_membership_%s ON %s.%s (%s)", column, KeySpace, models.Collections.Membership, column)
+		err = session.Query(membershipIndexQuery).Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// add roles table
+	roleCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, name text, description text, permissions text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Role)
+	err = session.Query(roleCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	roleIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_role_name ON %s.%s (name)", KeySpace, models.Collections.Role)
+	err = session.Query(roleIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add permissions table
+	permissionCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, name text, description text, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.Permission)
+	err = session.Query(permissionCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	permissionIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_permission_name ON %s.%s (name)", KeySpace, models.Collections.Permission)
+	err = session.Query(permissionIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add role grants table
+	roleGrantCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, role text, granted_by text, expires_at bigint, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.RoleGrant)
+	err = session.Query(roleGrantCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{"user_id", "expires_at"} {
+		roleGrantIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_role_grant_%s ON %s.%s (%s)", column, KeySpace, models.Collections.RoleGrant, column)
+		err = session.Query(roleGrantIndexQuery).Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// add role changes table
+	roleChangeCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, role text, action text, changed_by text, expires_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.RoleChange)
+	err = session.Query(roleChangeCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	roleChangeIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_role_change_user_id ON %s.%s (user_id)", KeySpace, models.Collections.RoleChange)
+	err = session.Query(roleChangeIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add refresh token reuses table
+	refreshTokenReuseCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, user_id text, family_id text, revoked_nonce text, login_method text, ip text, user_agent text, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.RefreshTokenReuse)
+	err = session.Query(refreshTokenReuseCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	refreshTokenReuseIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_refresh_token_reuse_user_id ON %s.%s (user_id)", KeySpace, models.Collections.RefreshTokenReuse)
+	err = session.Query(refreshTokenReuseIndexQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+
+	// add memory store entries table
+	memoryStoreEntryCollectionQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s.%s (id text, namespace text, recipe text, subject text, value text, expires_at bigint, updated_at bigint, created_at bigint, PRIMARY KEY (id))", KeySpace, models.Collections.MemoryStoreEntry)
+	err = session.Query(memoryStoreEntryCollectionQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{"namespace", "recipe", "subject"} {
+		memoryStoreEntryIndexQuery := fmt.Sprintf("CREATE INDEX IF NOT EXISTS authorizer_memory_store_entry_%s ON %s.%s (%s)", column, KeySpace, models.Collections.MemoryStoreEntry, column)
+		err = session.Query(memoryStoreEntryIndexQuery).Exec()
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &provider{
 		db: session,
 	}, err
 }
+
+// Close closes the cassandra session
+func (p *provider) Close(ctx context.Context) error {
+	p.db.Close()
+	return nil
+}
@@ -0,0 +1,45 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	if refreshTokenReuse.ID == "" {
+		refreshTokenReuse.ID = uuid.New().String()
+	}
+	refreshTokenReuse.Key = refreshTokenReuse.ID
+	refreshTokenReuse.CreatedAt = time.Now().Unix()
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, user_id, family_id, revoked_nonce, login_method, ip, user_agent, created_at) VALUES ('%s', '%s', '%s', '%s', '%s', '%s', '%s', %d)", KeySpace+"."+models.Collections.RefreshTokenReuse, refreshTokenReuse.ID, refreshTokenReuse.UserID, refreshTokenReuse.FamilyID, refreshTokenReuse.RevokedNonce, refreshTokenReuse.LoginMethod, refreshTokenReuse.IP, refreshTokenReuse.UserAgent, refreshTokenReuse.CreatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokenReuse, nil
+}
+
+func (p *provider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	refreshTokenReuses := []*models.RefreshTokenReuse{}
+	query := fmt.Sprintf("SELECT id, user_id, family_id, revoked_nonce, login_method, ip, user_agent, created_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.RefreshTokenReuse, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var refreshTokenReuse models.RefreshTokenReuse
+		err := scanner.Scan(&refreshTokenReuse.ID, &refreshTokenReuse.UserID, &refreshTokenReuse.FamilyID, &refreshTokenReuse.RevokedNonce, &refreshTokenReuse.LoginMethod, &refreshTokenReuse.IP, &refreshTokenReuse.UserAgent, &refreshTokenReuse.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		refreshTokenReuses = append(refreshTokenReuses, &refreshTokenReuse)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(refreshTokenReuses, func(i, j int) bool {
+		return refreshTokenReuses[i].CreatedAt < refreshTokenReuses[j].CreatedAt
+	})
+	return refreshTokenReuses, nil
+}
@@ -0,0 +1,95 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.Key = role.ID
+	role.CreatedAt = time.Now().Unix()
+	role.UpdatedAt = time.Now().Unix()
+	existingRole, _ := p.GetRoleByName(ctx, role.Name)
+	if existingRole != nil {
+		return nil, fmt.Errorf("role with %s name already exists", role.Name)
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, name, description, permissions, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Role, role.ID, role.Name, role.Description, role.Permissions, role.CreatedAt, role.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	role.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET name = '%s', description = '%s', permissions = '%s', updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Role, role.Name, role.Description, role.Permissions, role.UpdatedAt, role.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	query := fmt.Sprintf("SELECT id, name, description, permissions, created_at, updated_at FROM %s WHERE name = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.Role, name)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&role.ID, &role.Name, &role.Description, &role.Permissions, &role.CreatedAt, &role.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+func (p *provider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	roles := []*model.Role{}
+	paginationClone := pagination
+
+	totalCountQuery := fmt.Sprintf(`SELECT COUNT(*) FROM %s`, KeySpace+"."+models.Collections.Role)
+	err := p.db.Query(totalCountQuery).Consistency(gocql.One).Scan(&paginationClone.Total)
+	if err != nil {
+		return nil, err
+	}
+
+	// there is no offset in cassandra
+	// so we fetch till limit + offset
+	// and return the results from offset to limit
+	query := fmt.Sprintf("SELECT id, name, description, permissions, created_at, updated_at FROM %s LIMIT %d", KeySpace+"."+models.Collections.Role, pagination.Limit+pagination.Offset)
+	scanner := p.db.Query(query).Iter().Scanner()
+	counter := int64(0)
+	for scanner.Next() {
+		if counter >= pagination.Offset {
+			var role models.Role
+			err := scanner.Scan(&role.ID, &role.Name, &role.Description, &role.Permissions, &role.CreatedAt, &role.UpdatedAt)
+			if err != nil {
+				return nil, err
+			}
+			roles = append(roles, role.AsAPIRole())
+		}
+		counter++
+	}
+
+	return &model.Roles{
+		Pagination: paginationClone,
+		Roles:      roles,
+	}, nil
+}
+
+func (p *provider) DeleteRole(ctx context.Context, role *models.Role) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Role, role.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	if roleChange.ID == "" {
+		roleChange.ID = uuid.New().String()
+	}
+	roleChange.Key = roleChange.ID
+	roleChange.CreatedAt = time.Now().Unix()
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, user_id, role, action, changed_by, expires_at, created_at) VALUES ('%s', '%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.RoleChange, roleChange.ID, roleChange.UserID, roleChange.Role, roleChange.Action, roleChange.ChangedBy, roleChange.ExpiresAt, roleChange.CreatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return roleChange, nil
+}
+
+func (p *provider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	roleChanges := []*models.RoleChange{}
+	query := fmt.Sprintf("SELECT id, user_id, role, action, changed_by, expires_at, created_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.RoleChange, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var roleChange models.RoleChange
+		err := scanner.Scan(&roleChange.ID, &roleChange.UserID, &roleChange.Role, &roleChange.Action, &roleChange.ChangedBy, &roleChange.ExpiresAt, &roleChange.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		roleChanges = append(roleChanges, &roleChange)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(roleChanges, func(i, j int) bool {
+		return roleChanges[i].CreatedAt < roleChanges[j].CreatedAt
+	})
+	return roleChanges, nil
+}
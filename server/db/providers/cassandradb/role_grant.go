@@ -0,0 +1,90 @@
+package cassandradb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gocql/gocql"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	if roleGrant.ID == "" {
+		roleGrant.ID = uuid.New().String()
+	}
+	roleGrant.Key = roleGrant.ID
+	roleGrant.CreatedAt = time.Now().Unix()
+	roleGrant.UpdatedAt = time.Now().Unix()
+	existingRoleGrant, _ := p.GetRoleGrantByUserIDAndRole(ctx, roleGrant.UserID, roleGrant.Role)
+	if existingRoleGrant != nil {
+		return nil, fmt.Errorf("role is already granted to user")
+	}
+	insertQuery := fmt.Sprintf("INSERT INTO %s (id, user_id, role, granted_by, expires_at, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d, %d)", KeySpace+"."+models.Collections.RoleGrant, roleGrant.ID, roleGrant.UserID, roleGrant.Role, roleGrant.GrantedBy, roleGrant.ExpiresAt, roleGrant.CreatedAt, roleGrant.UpdatedAt)
+	err := p.db.Query(insertQuery).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	roleGrant.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET granted_by = '%s', expires_at = %d, updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.RoleGrant, roleGrant.GrantedBy, roleGrant.ExpiresAt, roleGrant.UpdatedAt, roleGrant.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	var roleGrant models.RoleGrant
+	query := fmt.Sprintf("SELECT id, user_id, role, granted_by, expires_at, created_at, updated_at FROM %s WHERE user_id = '%s' AND role = '%s' LIMIT 1 ALLOW FILTERING", KeySpace+"."+models.Collections.RoleGrant, userID, role)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&roleGrant.ID, &roleGrant.UserID, &roleGrant.Role, &roleGrant.GrantedBy, &roleGrant.ExpiresAt, &roleGrant.CreatedAt, &roleGrant.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &roleGrant, nil
+}
+
+func (p *provider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	query := fmt.Sprintf("SELECT id, user_id, role, granted_by, expires_at, created_at, updated_at FROM %s WHERE user_id = '%s' ALLOW FILTERING", KeySpace+"."+models.Collections.RoleGrant, userID)
+	return p.listRoleGrants(query)
+}
+
+func (p *provider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	query := fmt.Sprintf("SELECT id, user_id, role, granted_by, expires_at, created_at, updated_at FROM %s WHERE expires_at > 0 AND expires_at <= %d ALLOW FILTERING", KeySpace+"."+models.Collections.RoleGrant, expiresAt)
+	return p.listRoleGrants(query)
+}
+
+// listRoleGrants returns the role grants selected by query in the order they were created
+func (p *provider) listRoleGrants(query string) ([]*models.RoleGrant, error) {
+	roleGrants := []*models.RoleGrant{}
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var roleGrant models.RoleGrant
+		err := scanner.Scan(&roleGrant.ID, &roleGrant.UserID, &roleGrant.Role, &roleGrant.GrantedBy, &roleGrant.ExpiresAt, &roleGrant.CreatedAt, &roleGrant.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		roleGrants = append(roleGrants, &roleGrant)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(roleGrants, func(i, j int) bool {
+		return roleGrants[i].CreatedAt < roleGrants[j].CreatedAt
+	})
+	return roleGrants, nil
+}
+
+func (p *provider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.RoleGrant, roleGrant.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
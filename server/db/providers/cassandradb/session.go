@@ -3,12 +3,16 @@ package cassandradb
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/gocql/gocql"
 	"github.com/google/uuid"
 )
 
+const sessionFields = "id, user_id, user_agent, ip, login_method, nonce, expires_at, last_active_at, created_at, updated_at"
+
 // AddSession to save session information in database
 func (p *provider) AddSession(ctx context.Context, session *models.Session) error {
 	if session.ID == "" {
@@ -16,7 +20,10 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 	}
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
-	insertSessionQuery := fmt.Sprintf("INSERT INTO %s (id, user_id, user_agent, ip, created_at, updated_at) VALUES ('%s', '%s', '%s', '%s', %d, %d)", KeySpace+"."+models.Collections.Session, session.ID, session.UserID, session.UserAgent, session.IP, session.CreatedAt, session.UpdatedAt)
+	if session.LastActiveAt == 0 {
+		session.LastActiveAt = session.CreatedAt
+	}
+	insertSessionQuery := fmt.Sprintf("INSERT INTO %s (%s) VALUES ('%s', '%s', '%s', '%s', '%s', '%s', %d, %d, %d, %d)", KeySpace+"."+models.Collections.Session, sessionFields, session.ID, session.UserID, session.UserAgent, session.IP, session.LoginMethod, session.Nonce, session.ExpiresAt, session.LastActiveAt, session.CreatedAt, session.UpdatedAt)
 	err := p.db.Query(insertSessionQuery).Exec()
 	if err != nil {
 		return err
@@ -28,3 +35,66 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 func (p *provider) DeleteSession(ctx context.Context, userId string) error {
 	return nil
 }
+
+// ListSessionsByUserID to get list of sessions for given user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	sessions := []*models.Session{}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE user_id = '%s' ALLOW FILTERING", sessionFields, KeySpace+"."+models.Collections.Session, userID)
+	scanner := p.db.Query(query).Iter().Scanner()
+	for scanner.Next() {
+		var session models.Session
+		err := scanner.Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.LoginMethod, &session.Nonce, &session.ExpiresAt, &session.LastActiveAt, &session.CreatedAt, &session.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	// cassandra can only order by clustering columns
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActiveAt > sessions[j].LastActiveAt
+	})
+	return sessions, nil
+}
+
+// GetSessionByID to get session information from database using session ID
+func (p *provider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	var session models.Session
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE id = '%s' LIMIT 1", sessionFields, KeySpace+"."+models.Collections.Session, id)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.LoginMethod, &session.Nonce, &session.ExpiresAt, &session.LastActiveAt, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// GetSessionByNonce to get session information using the nonce of its memory store keys
+func (p *provider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	var session models.Session
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE nonce = '%s' LIMIT 1 ALLOW FILTERING", sessionFields, KeySpace+"."+models.Collections.Session, nonce)
+	err := p.db.Query(query).Consistency(gocql.One).Scan(&session.ID, &session.UserID, &session.UserAgent, &session.IP, &session.LoginMethod, &session.Nonce, &session.ExpiresAt, &session.LastActiveAt, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// UpdateSession to update session information in database
+func (p *provider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	session.UpdatedAt = time.Now().Unix()
+	query := fmt.Sprintf("UPDATE %s SET nonce = '%s', expires_at = %d, last_active_at = %d, updated_at = %d WHERE id = '%s'", KeySpace+"."+models.Collections.Session, session.Nonce, session.ExpiresAt, session.LastActiveAt, session.UpdatedAt, session.ID)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// DeleteSessionByID to delete single session information from database
+func (p *provider) DeleteSessionByID(ctx context.Context, id string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = '%s'", KeySpace+"."+models.Collections.Session, id)
+	err := p.db.Query(query).Exec()
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package couchbase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Client).Insert(client.ID, client, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	bytes, err := json.Marshal(client)
+	if err != nil {
+		return nil, err
+	}
+	// use decoder instead of json.Unmarshall, because it converts int64 -> float64 after unmarshalling
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	clientMap := map[string]interface{}{}
+	err = decoder.Decode(&clientMap)
+	if err != nil {
+		return nil, err
+	}
+	updateFields, params := GetSetFields(clientMap)
+	query := fmt.Sprintf("UPDATE %s.%s SET %s WHERE _id = '%s'", p.scopeName, models.Collections.Client, updateFields, client.ID)
+	_, err = p.db.Query(query, &gocb.QueryOptions{
+		Context:         ctx,
+		ScanConsistency: gocb.QueryScanConsistencyRequestPlus,
+		NamedParameters: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client *models.Client
+	query := fmt.Sprintf("SELECT _id, client_id, client_secret, registration_access_token, client_name, redirect_uris, grant_types, response_types, token_endpoint_auth_method, scope, jwks, jwks_uri, require_pushed_authorization_requests, backchannel_logout_uri, backchannel_logout_session_required, created_at, updated_at FROM %s.%s WHERE client_id = $1 LIMIT 1", p.scopeName, models.Collections.Client)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{clientID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Client).Remove(client.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	if grant.GrantedAt == 0 {
+		grant.GrantedAt = grant.CreatedAt
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Grant).Insert(grant.ID, grant, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (p *provider) UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Grant).Upsert(grant.ID, grant, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error) {
+	var grant *models.Grant
+	query := fmt.Sprintf("SELECT _id, user_id, client_id, scope, granted_at, created_at, updated_at FROM %s.%s WHERE user_id = $1 AND client_id = $2 LIMIT 1", p.scopeName, models.Collections.Grant)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID, clientID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&grant)
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error) {
+	grants := []*models.Grant{}
+	query := fmt.Sprintf("SELECT _id, user_id, client_id, scope, granted_at, created_at, updated_at FROM %s.%s WHERE user_id = $1 ORDER BY granted_at ASC", p.scopeName, models.Collections.Grant)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var grant models.Grant
+		err := queryResult.Row(&grant)
+		if err != nil {
+			return nil, err
+		}
+		grants = append(grants, &grant)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return grants, nil
+}
+
+func (p *provider) DeleteGrant(ctx context.Context, grant *models.Grant) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Grant).Remove(grant.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
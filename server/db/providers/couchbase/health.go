@@ -0,0 +1,18 @@
+package couchbase
+
+import (
+	"context"
+
+	"github.com/couchbase/gocb/v2"
+)
+
+// HealthCheck runs a simple N1QL query to check if couchbase is reachable
+func (p *provider) HealthCheck(ctx context.Context) error {
+	res, err := p.db.Query("SELECT 1", &gocb.QueryOptions{
+		Context: ctx,
+	})
+	if err != nil {
+		return err
+	}
+	return res.Close()
+}
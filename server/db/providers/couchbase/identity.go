@@ -0,0 +1,85 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	if identity.ID == "" {
+		identity.ID = models.IdentityID(identity.Provider, identity.ProviderUserID)
+	}
+	identity.Key = identity.ID
+	identity.CreatedAt = time.Now().Unix()
+	identity.UpdatedAt = time.Now().Unix()
+	if identity.LinkedAt == 0 {
+		identity.LinkedAt = identity.CreatedAt
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Identity).Insert(identity.ID, identity, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *provider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	var identity *models.Identity
+	query := fmt.Sprintf("SELECT _id, user_id, provider, provider_user_id, email, linked_at, created_at, updated_at FROM %s.%s WHERE provider = $1 AND provider_user_id = $2 LIMIT 1", p.scopeName, models.Collections.Identity)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{provider, providerUserID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&identity)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *provider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	identities := []*models.Identity{}
+	query := fmt.Sprintf("SELECT _id, user_id, provider, provider_user_id, email, linked_at, created_at, updated_at FROM %s.%s WHERE user_id = $1 ORDER BY linked_at ASC", p.scopeName, models.Collections.Identity)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var identity models.Identity
+		err := queryResult.Row(&identity)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, &identity)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return identities, nil
+}
+
+func (p *provider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Identity).Remove(identity.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	if membership.ID == "" {
+		membership.ID = uuid.New().String()
+	}
+	membership.Key = membership.ID
+	membership.CreatedAt = time.Now().Unix()
+	membership.UpdatedAt = time.Now().Unix()
+	existingMembership, _ := p.GetMembershipByOrganizationIDAndUserID(ctx, membership.OrganizationID, membership.UserID)
+	if existingMembership != nil {
+		return nil, fmt.Errorf("user is already member of organization")
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Membership).Insert(membership.ID, membership, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	membership.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Membership).Upsert(membership.ID, membership, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	var membership *models.Membership
+	query := fmt.Sprintf("SELECT _id, organization_id, user_id, roles, created_at, updated_at FROM %s.%s WHERE organization_id = $1 AND user_id = $2 LIMIT 1", p.scopeName, models.Collections.Membership)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{organizationID, userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&membership)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	query := fmt.Sprintf("SELECT _id, organization_id, user_id, roles, created_at, updated_at FROM %s.%s WHERE organization_id = $1 ORDER BY created_at ASC", p.scopeName, models.Collections.Membership)
+	return p.listMemberships(ctx, query, organizationID)
+}
+
+func (p *provider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	query := fmt.Sprintf("SELECT _id, organization_id, user_id, roles, created_at, updated_at FROM %s.%s WHERE user_id = $1 ORDER BY created_at ASC", p.scopeName, models.Collections.Membership)
+	return p.listMemberships(ctx, query, userID)
+}
+
+// listMemberships returns the memberships selected by query with given parameter
+func (p *provider) listMemberships(ctx context.Context, query string, param string) ([]*models.Membership, error) {
+	memberships := []*models.Membership{}
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{param},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var membership models.Membership
+		err := queryResult.Row(&membership)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, &membership)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return memberships, nil
+}
+
+func (p *provider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Membership).Remove(membership.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package couchbase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	entry.Key = entry.ID
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	entry.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.MemoryStoreEntry).Upsert(entry.ID, entry, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	var entry *models.MemoryStoreEntry
+	getOpt := gocb.GetOptions{
+		Context: ctx,
+	}
+	res, err := p.db.Collection(models.Collections.MemoryStoreEntry).Get(id, &getOpt)
+	if err != nil {
+		return nil, err
+	}
+	err = res.Content(&entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.MemoryStoreEntry).Remove(id, &removeOpt)
+	if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	query := fmt.Sprintf(`DELETE FROM %s.%s WHERE namespace=$namespace OR recipe=$namespace`, p.scopeName, models.Collections.MemoryStoreEntry)
+	_, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:         ctx,
+		ScanConsistency: gocb.QueryScanConsistencyRequestPlus,
+		NamedParameters: map[string]interface{}{
+			"namespace": namespace,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	query := fmt.Sprintf(`DELETE FROM %s.%s WHERE subject=$subject`, p.scopeName, models.Collections.MemoryStoreEntry)
+	_, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:         ctx,
+		ScanConsistency: gocb.QueryScanConsistencyRequestPlus,
+		NamedParameters: map[string]interface{}{
+			"subject": subject,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	query := fmt.Sprintf(`DELETE FROM %s.%s WHERE expires_at < $expires_at`, p.scopeName, models.Collections.MemoryStoreEntry)
+	_, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:         ctx,
+		ScanConsistency: gocb.QueryScanConsistencyRequestPlus,
+		NamedParameters: map[string]interface{}{
+			"expires_at": expiresAt,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	entry.Key = entry.ID
+	now := time.Now().Unix()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	collection := p.db.Collection(models.Collections.MemoryStoreEntry)
+	// expired entry is not deleted by cleanup yet, cas makes sure that it is not replaced meanwhile
+	if res, err := collection.Get(entry.ID, &gocb.GetOptions{Context: ctx}); err == nil {
+		var existingEntry models.MemoryStoreEntry
+		if err := res.Content(&existingEntry); err != nil {
+			return false, err
+		}
+		if existingEntry.ExpiresAt > now {
+			return false, nil
+		}
+		_, err := collection.Remove(entry.ID, &gocb.RemoveOptions{Context: ctx, Cas: res.Cas()})
+		if err != nil && !errors.Is(err, gocb.ErrDocumentNotFound) && !errors.Is(err, gocb.ErrCasMismatch) {
+			return false, err
+		}
+	} else if !errors.Is(err, gocb.ErrDocumentNotFound) {
+		return false, err
+	}
+	_, err := collection.Insert(entry.ID, entry, &gocb.InsertOptions{Context: ctx})
+	if errors.Is(err, gocb.ErrDocumentExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
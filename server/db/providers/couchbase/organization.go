@@ -0,0 +1,119 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	existingOrganization, _ := p.GetOrganizationByName(ctx, organization.Name)
+	if existingOrganization != nil {
+		return nil, fmt.Errorf("organization with %s name already exists", organization.Name)
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Organization).Insert(organization.ID, organization, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Organization).Upsert(organization.ID, organization, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	query := fmt.Sprintf("SELECT _id, name, roles, default_roles, created_at, updated_at FROM %s.%s WHERE _id = $1 LIMIT 1", p.scopeName, models.Collections.Organization)
+	return p.getOrganization(ctx, query, organizationID)
+}
+
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	query := fmt.Sprintf("SELECT _id, name, roles, default_roles, created_at, updated_at FROM %s.%s WHERE name = $1 LIMIT 1", p.scopeName, models.Collections.Organization)
+	return p.getOrganization(ctx, query, name)
+}
+
+// getOrganization returns the organization selected by query with given parameter
+func (p *provider) getOrganization(ctx context.Context, query string, param string) (*models.Organization, error) {
+	var organization *models.Organization
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{param},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&organization)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+	paginationClone := pagination
+	total, err := p.GetTotalDocs(ctx, models.Collections.Organization)
+	if err != nil {
+		return nil, err
+	}
+	paginationClone.Total = total
+	query := fmt.Sprintf("SELECT _id, name, roles, default_roles, created_at, updated_at FROM %s.%s ORDER BY created_at DESC OFFSET $1 LIMIT $2", p.scopeName, models.Collections.Organization)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:              ctx,
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		PositionalParameters: []interface{}{paginationClone.Offset, paginationClone.Limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var organization models.Organization
+		err := queryResult.Row(&organization)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization.AsAPIOrganization())
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return &model.Organizations{
+		Pagination:    paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+func (p *provider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Organization).Remove(organization.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -36,12 +36,14 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			Email:       otpParam.Email,
 			PhoneNumber: otpParam.PhoneNumber,
 			ExpiresAt:   otpParam.ExpiresAt,
+			Attempts:    otpParam.Attempts,
 			CreatedAt:   time.Now().Unix(),
 			UpdatedAt:   time.Now().Unix(),
 		}
 	} else {
 		otp.Otp = otpParam.Otp
 		otp.ExpiresAt = otpParam.ExpiresAt
+		otp.Attempts = otpParam.Attempts
 	}
 	otp.UpdatedAt = time.Now().Unix()
 	if shouldCreate {
@@ -53,9 +55,9 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			return nil, err
 		}
 	} else {
-		query := fmt.Sprintf(`UPDATE %s.%s SET otp=$1, expires_at=$2, updated_at=$3 WHERE _id=$4`, p.scopeName, models.Collections.OTP)
+		query := fmt.Sprintf(`UPDATE %s.%s SET otp=$1, expires_at=$2, attempts=$3, updated_at=$4 WHERE _id=$5`, p.scopeName, models.Collections.OTP)
 		_, err := p.db.Query(query, &gocb.QueryOptions{
-			PositionalParameters: []interface{}{otp.Otp, otp.ExpiresAt, otp.UpdatedAt, otp.ID},
+			PositionalParameters: []interface{}{otp.Otp, otp.ExpiresAt, otp.Attempts, otp.UpdatedAt, otp.ID},
 		})
 		if err != nil {
 			return nil, err
@@ -67,7 +69,7 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 // GetOTPByEmail to get otp for a given email address
 func (p *provider) GetOTPByEmail(ctx context.Context, emailAddress string) (*models.OTP, error) {
 	otp := models.OTP{}
-	query := fmt.Sprintf(`SELECT _id, email, phone_number, otp, expires_at, created_at, updated_at FROM %s.%s WHERE email = $1 LIMIT 1`, p.scopeName, models.Collections.OTP)
+	query := fmt.Sprintf(`SELECT _id, email, phone_number, otp, expires_at, attempts, created_at, updated_at FROM %s.%s WHERE email = $1 LIMIT 1`, p.scopeName, models.Collections.OTP)
 	q, err := p.db.Query(query, &gocb.QueryOptions{
 		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
 		PositionalParameters: []interface{}{emailAddress},
@@ -85,7 +87,7 @@ func (p *provider) GetOTPByEmail(ctx context.Context, emailAddress string) (*mod
 // GetOTPByPhoneNumber to get otp for a given phone number
 func (p *provider) GetOTPByPhoneNumber(ctx context.Context, phoneNumber string) (*models.OTP, error) {
 	otp := models.OTP{}
-	query := fmt.Sprintf(`SELECT _id, email, phone_number, otp, expires_at, created_at, updated_at FROM %s.%s WHERE phone_number = $1 LIMIT 1`, p.scopeName, models.Collections.OTP)
+	query := fmt.Sprintf(`SELECT _id, email, phone_number, otp, expires_at, attempts, created_at, updated_at FROM %s.%s WHERE phone_number = $1 LIMIT 1`, p.scopeName, models.Collections.OTP)
 	q, err := p.db.Query(query, &gocb.QueryOptions{
 		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
 		PositionalParameters: []interface{}{phoneNumber},
@@ -111,3 +113,21 @@ func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 	}
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	query := fmt.Sprintf(`UPDATE %s.%s SET attempts = attempts + 1, updated_at = $1 WHERE _id = $2 RETURNING attempts`, p.scopeName, models.Collections.OTP)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:              ctx,
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		PositionalParameters: []interface{}{time.Now().Unix(), otp.ID},
+	})
+	if err != nil {
+		return 0, err
+	}
+	var updatedOTP models.OTP
+	if err := q.One(&updatedOTP); err != nil {
+		return 0, err
+	}
+	return updatedOTP.Attempts, nil
+}
@@ -0,0 +1,114 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	if permission.ID == "" {
+		permission.ID = uuid.New().String()
+	}
+	permission.Key = permission.ID
+	permission.CreatedAt = time.Now().Unix()
+	permission.UpdatedAt = time.Now().Unix()
+	existingPermission, _ := p.GetPermissionByName(ctx, permission.Name)
+	if existingPermission != nil {
+		return nil, fmt.Errorf("permission with %s name already exists", permission.Name)
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Permission).Insert(permission.ID, permission, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	permission.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Permission).Upsert(permission.ID, permission, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	query := fmt.Sprintf("SELECT _id, name, description, created_at, updated_at FROM %s.%s WHERE name = $1 LIMIT 1", p.scopeName, models.Collections.Permission)
+	return p.getPermission(ctx, query, name)
+}
+
+// getPermission returns the permission selected by query with given parameter
+func (p *provider) getPermission(ctx context.Context, query string, param string) (*models.Permission, error) {
+	var permission *models.Permission
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{param},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&permission)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	permissions := []*model.Permission{}
+	paginationClone := pagination
+	total, err := p.GetTotalDocs(ctx, models.Collections.Permission)
+	if err != nil {
+		return nil, err
+	}
+	paginationClone.Total = total
+	query := fmt.Sprintf("SELECT _id, name, description, created_at, updated_at FROM %s.%s ORDER BY created_at DESC OFFSET $1 LIMIT $2", p.scopeName, models.Collections.Permission)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:              ctx,
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		PositionalParameters: []interface{}{paginationClone.Offset, paginationClone.Limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var permission models.Permission
+		err := queryResult.Row(&permission)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission.AsAPIPermission())
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return &model.Permissions{
+		Pagination:  paginationClone,
+		Permissions: permissions,
+	}, nil
+}
+
+func (p *provider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Permission).Remove(permission.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
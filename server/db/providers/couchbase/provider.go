@@ -22,6 +22,7 @@ const (
 
 type provider struct {
 	db        *gocb.Scope
+	cluster   *gocb.Cluster
 	scopeName string
 }
 
@@ -86,10 +87,16 @@ func NewProvider() (*provider, error) {
 	}
 	return &provider{
 		db:        scope,
+		cluster:   cluster,
 		scopeName: scopeIdentifier,
 	}, nil
 }
 
+// Close closes the connections to couchbase cluster
+func (p *provider) Close(ctx context.Context) error {
+	return p.cluster.Close(nil)
+}
+
 func CreateBucketAndScope(cluster *gocb.Cluster, bucketName string, scopeName string) (*gocb.Bucket, error) {
 	bucketRAMQuotaMB := memorystore.RequiredEnvStoreObj.GetRequiredEnv().CouchbaseBucketRAMQuotaMB
 	if bucketRAMQuotaMB == "" {
@@ -148,7 +155,8 @@ func GetIndex(scopeName string) map[string][]string {
 
 	// Session index
 	sessionIndex1 := fmt.Sprintf("CREATE INDEX SessionUserIdIndex ON %s.%s(user_id)", scopeName, models.Collections.Session)
-	indices[models.Collections.Session] = []string{sessionIndex1}
+	sessionIndex2 := fmt.Sprintf("CREATE INDEX SessionNonceIndex ON %s.%s(nonce)", scopeName, models.Collections.Session)
+	indices[models.Collections.Session] = []string{sessionIndex1, sessionIndex2}
 
 	// Webhook index
 	webhookIndex1 := fmt.Sprintf("CREATE INDEX webhookEventNameIndex ON %s.%s(event_name)", scopeName, models.Collections.Webhook)
@@ -170,5 +178,55 @@ func GetIndex(scopeName string) map[string][]string {
 	otpIndex2 := fmt.Sprintf("CREATE INDEX OTPPhoneNumberIndex ON %s.%s(phone_number)", scopeName, models.Collections.OTP)
 	indices[models.Collections.OTP] = []string{otpIndex2}
 
+	// Identity index
+	identityIndex1 := fmt.Sprintf("CREATE INDEX IdentityUserIdIndex ON %s.%s(user_id)", scopeName, models.Collections.Identity)
+	identityIndex2 := fmt.Sprintf("CREATE INDEX IdentityProviderUserIdIndex ON %s.%s(provider,provider_user_id)", scopeName, models.Collections.Identity)
+	indices[models.Collections.Identity] = []string{identityIndex1, identityIndex2}
+
+	// Client index
+	clientIndex1 := fmt.Sprintf("CREATE INDEX ClientClientIdIndex ON %s.%s(client_id)", scopeName, models.Collections.Client)
+	indices[models.Collections.Client] = []string{clientIndex1}
+
+	// Grant index
+	grantIndex1 := fmt.Sprintf("CREATE INDEX GrantUserIdClientIdIndex ON %s.%s(user_id,client_id)", scopeName, models.Collections.Grant)
+	indices[models.Collections.Grant] = []string{grantIndex1}
+
+	// Organization index
+	organizationIndex1 := fmt.Sprintf("CREATE INDEX OrganizationNameIndex ON %s.%s(name)", scopeName, models.Collections.Organization)
+	indices[models.Collections.Organization] = []string{organizationIndex1}
+
+	// Membership index
+	membershipIndex1 := fmt.Sprintf("CREATE INDEX MembershipOrganizationIdUserIdIndex ON %s.%s(organization_id,user_id)", scopeName, models.Collections.Membership)
+	membershipIndex2 := fmt.Sprintf("CREATE INDEX MembershipUserIdIndex ON %s.%s(user_id)", scopeName, models.Collections.Membership)
+	indices[models.Collections.Membership] = []string{membershipIndex1, membershipIndex2}
+
+	// Role index
+	roleIndex1 := fmt.Sprintf("CREATE INDEX RoleNameIndex ON %s.%s(name)", scopeName, models.Collections.Role)
+	indices[models.Collections.Role] = []string{roleIndex1}
+
+	// Permission index
+	permissionIndex1 := fmt.Sprintf("CREATE INDEX PermissionNameIndex ON %s.%s(name)", scopeName, models.Collections.Permission)
+	indices[models.Collections.Permission] = []string{permissionIndex1}
+
+	// RoleGrant index
+	roleGrantIndex1 := fmt.Sprintf("CREATE INDEX RoleGrantUserIdRoleIndex ON %s.%s(user_id,role)", scopeName, models.Collections.RoleGrant)
+	roleGrantIndex2 := fmt.Sprintf("CREATE INDEX RoleGrantExpiresAtIndex ON %s.%s(expires_at)", scopeName, models.Collections.RoleGrant)
+	indices[models.Collections.RoleGrant] = []string{roleGrantIndex1, roleGrantIndex2}
+
+	// RoleChange index
+	roleChangeIndex1 := fmt.Sprintf("CREATE INDEX RoleChangeUserIdIndex ON %s.%s(user_id)", scopeName, models.Collections.RoleChange)
+	indices[models.Collections.RoleChange] = []string{roleChangeIndex1}
+
+	// RefreshTokenReuse index
+	refreshTokenReuseIndex1 := fmt.Sprintf("CREATE INDEX RefreshTokenReuseUserIdIndex ON %s.%s(user_id)", scopeName, models.Collections.RefreshTokenReuse)
+	indices[models.Collections.RefreshTokenReuse] = []string{refreshTokenReuseIndex1}
+
+	// MemoryStoreEntry index
+	memoryStoreEntryIndex1 := fmt.Sprintf("CREATE INDEX MemoryStoreEntryNamespaceIndex ON %s.%s(namespace)", scopeName, models.Collections.MemoryStoreEntry)
+	memoryStoreEntryIndex2 := fmt.Sprintf("CREATE INDEX MemoryStoreEntryRecipeIndex ON %s.%s(recipe)", scopeName, models.Collections.MemoryStoreEntry)
+	memoryStoreEntryIndex3 := fmt.Sprintf("CREATE INDEX MemoryStoreEntrySubjectIndex ON %s.%s(subject)", scopeName, models.Collections.MemoryStoreEntry)
+	memoryStoreEntryIndex4 := fmt.Sprintf("CREATE INDEX MemoryStoreEntryExpiresAtIndex ON %s.%s(expires_at)", scopeName, models.Collections.MemoryStoreEntry)
+	indices[models.Collections.MemoryStoreEntry] = []string{memoryStoreEntryIndex1, memoryStoreEntryIndex2, memoryStoreEntryIndex3, memoryStoreEntryIndex4}
+
 	return indices
 }
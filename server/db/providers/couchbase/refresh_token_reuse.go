@@ -0,0 +1,53 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	if refreshTokenReuse.ID == "" {
+		refreshTokenReuse.ID = uuid.New().String()
+	}
+	refreshTokenReuse.Key = refreshTokenReuse.ID
+	refreshTokenReuse.CreatedAt = time.Now().Unix()
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.RefreshTokenReuse).Insert(refreshTokenReuse.ID, refreshTokenReuse, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokenReuse, nil
+}
+
+func (p *provider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	refreshTokenReuses := []*models.RefreshTokenReuse{}
+	query := fmt.Sprintf("SELECT _id, user_id, family_id, revoked_nonce, login_method, ip, user_agent, created_at FROM %s.%s WHERE user_id = $1 ORDER BY created_at ASC", p.scopeName, models.Collections.RefreshTokenReuse)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var refreshTokenReuse models.RefreshTokenReuse
+		err := queryResult.Row(&refreshTokenReuse)
+		if err != nil {
+			return nil, err
+		}
+		refreshTokenReuses = append(refreshTokenReuses, &refreshTokenReuse)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return refreshTokenReuses, nil
+}
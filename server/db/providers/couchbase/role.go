@@ -0,0 +1,114 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.Key = role.ID
+	role.CreatedAt = time.Now().Unix()
+	role.UpdatedAt = time.Now().Unix()
+	existingRole, _ := p.GetRoleByName(ctx, role.Name)
+	if existingRole != nil {
+		return nil, fmt.Errorf("role with %s name already exists", role.Name)
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Role).Insert(role.ID, role, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	role.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Role).Upsert(role.ID, role, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	query := fmt.Sprintf("SELECT _id, name, description, permissions, created_at, updated_at FROM %s.%s WHERE name = $1 LIMIT 1", p.scopeName, models.Collections.Role)
+	return p.getRole(ctx, query, name)
+}
+
+// getRole returns the role selected by query with given parameter
+func (p *provider) getRole(ctx context.Context, query string, param string) (*models.Role, error) {
+	var role *models.Role
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{param},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&role)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	roles := []*model.Role{}
+	paginationClone := pagination
+	total, err := p.GetTotalDocs(ctx, models.Collections.Role)
+	if err != nil {
+		return nil, err
+	}
+	paginationClone.Total = total
+	query := fmt.Sprintf("SELECT _id, name, description, permissions, created_at, updated_at FROM %s.%s ORDER BY created_at DESC OFFSET $1 LIMIT $2", p.scopeName, models.Collections.Role)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		Context:              ctx,
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		PositionalParameters: []interface{}{paginationClone.Offset, paginationClone.Limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var role models.Role
+		err := queryResult.Row(&role)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role.AsAPIRole())
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return &model.Roles{
+		Pagination: paginationClone,
+		Roles:      roles,
+	}, nil
+}
+
+func (p *provider) DeleteRole(ctx context.Context, role *models.Role) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Role).Remove(role.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	if roleChange.ID == "" {
+		roleChange.ID = uuid.New().String()
+	}
+	roleChange.Key = roleChange.ID
+	roleChange.CreatedAt = time.Now().Unix()
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.RoleChange).Insert(roleChange.ID, roleChange, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return roleChange, nil
+}
+
+func (p *provider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	roleChanges := []*models.RoleChange{}
+	query := fmt.Sprintf("SELECT _id, user_id, role, action, changed_by, expires_at, created_at FROM %s.%s WHERE user_id = $1 ORDER BY created_at ASC", p.scopeName, models.Collections.RoleChange)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var roleChange models.RoleChange
+		err := queryResult.Row(&roleChange)
+		if err != nil {
+			return nil, err
+		}
+		roleChanges = append(roleChanges, &roleChange)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return roleChanges, nil
+}
@@ -0,0 +1,109 @@
+package couchbase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/couchbase/gocb/v2"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	if roleGrant.ID == "" {
+		roleGrant.ID = uuid.New().String()
+	}
+	roleGrant.Key = roleGrant.ID
+	roleGrant.CreatedAt = time.Now().Unix()
+	roleGrant.UpdatedAt = time.Now().Unix()
+	existingRoleGrant, _ := p.GetRoleGrantByUserIDAndRole(ctx, roleGrant.UserID, roleGrant.Role)
+	if existingRoleGrant != nil {
+		return nil, fmt.Errorf("role is already granted to user")
+	}
+	insertOpt := gocb.InsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.RoleGrant).Insert(roleGrant.ID, roleGrant, &insertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	roleGrant.UpdatedAt = time.Now().Unix()
+	upsertOpt := gocb.UpsertOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.RoleGrant).Upsert(roleGrant.ID, roleGrant, &upsertOpt)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	var roleGrant *models.RoleGrant
+	query := fmt.Sprintf("SELECT _id, user_id, role, granted_by, expires_at, created_at, updated_at FROM %s.%s WHERE user_id = $1 AND role = $2 LIMIT 1", p.scopeName, models.Collections.RoleGrant)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID, role},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&roleGrant)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	query := fmt.Sprintf("SELECT _id, user_id, role, granted_by, expires_at, created_at, updated_at FROM %s.%s WHERE user_id = $1 ORDER BY created_at ASC", p.scopeName, models.Collections.RoleGrant)
+	return p.listRoleGrants(ctx, query, userID)
+}
+
+func (p *provider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	query := fmt.Sprintf("SELECT _id, user_id, role, granted_by, expires_at, created_at, updated_at FROM %s.%s WHERE expires_at > 0 AND expires_at <= $1", p.scopeName, models.Collections.RoleGrant)
+	return p.listRoleGrants(ctx, query, expiresAt)
+}
+
+// listRoleGrants returns the role grants selected by query with given parameter
+func (p *provider) listRoleGrants(ctx context.Context, query string, param interface{}) ([]*models.RoleGrant, error) {
+	roleGrants := []*models.RoleGrant{}
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{param},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var roleGrant models.RoleGrant
+		err := queryResult.Row(&roleGrant)
+		if err != nil {
+			return nil, err
+		}
+		roleGrants = append(roleGrants, &roleGrant)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return roleGrants, nil
+}
+
+func (p *provider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.RoleGrant).Remove(roleGrant.ID, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,6 +2,9 @@ package couchbase
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/db/models"
@@ -16,6 +19,9 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 	}
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
+	if session.LastActiveAt == 0 {
+		session.LastActiveAt = session.CreatedAt
+	}
 	insertOpt := gocb.InsertOptions{
 		Context: ctx,
 	}
@@ -30,3 +36,107 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 func (p *provider) DeleteSession(ctx context.Context, userId string) error {
 	return nil
 }
+
+// ListSessionsByUserID to get list of sessions for given user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	sessions := []*models.Session{}
+	query := fmt.Sprintf("SELECT _id, user_id, user_agent, ip, login_method, nonce, expires_at, last_active_at, created_at, updated_at FROM %s.%s WHERE user_id = $1 ORDER BY last_active_at DESC", p.scopeName, models.Collections.Session)
+	queryResult, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{userID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for queryResult.Next() {
+		var session models.Session
+		err := queryResult.Row(&session)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+	if err := queryResult.Err(); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// GetSessionByID to get session information from database using session ID
+func (p *provider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	var session *models.Session
+	query := fmt.Sprintf("SELECT _id, user_id, user_agent, ip, login_method, nonce, expires_at, last_active_at, created_at, updated_at FROM %s.%s WHERE _id = $1 LIMIT 1", p.scopeName, models.Collections.Session)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{id},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSessionByNonce to get session information using the nonce of its memory store keys
+func (p *provider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	var session *models.Session
+	query := fmt.Sprintf("SELECT _id, user_id, user_agent, ip, login_method, nonce, expires_at, last_active_at, created_at, updated_at FROM %s.%s WHERE nonce = $1 LIMIT 1", p.scopeName, models.Collections.Session)
+	q, err := p.db.Query(query, &gocb.QueryOptions{
+		ScanConsistency:      gocb.QueryScanConsistencyRequestPlus,
+		Context:              ctx,
+		PositionalParameters: []interface{}{nonce},
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = q.One(&session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// UpdateSession to update session information in database
+func (p *provider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	session.UpdatedAt = time.Now().Unix()
+	bytes, err := json.Marshal(session)
+	if err != nil {
+		return nil, err
+	}
+	// use decoder instead of json.Unmarshall, because it converts int64 -> float64 after unmarshalling
+	decoder := json.NewDecoder(strings.NewReader(string(bytes)))
+	decoder.UseNumber()
+	sessionMap := map[string]interface{}{}
+	err = decoder.Decode(&sessionMap)
+	if err != nil {
+		return nil, err
+	}
+	updateFields, params := GetSetFields(sessionMap)
+	query := fmt.Sprintf("UPDATE %s.%s SET %s WHERE _id = '%s'", p.scopeName, models.Collections.Session, updateFields, session.ID)
+	_, err = p.db.Query(query, &gocb.QueryOptions{
+		Context:         ctx,
+		ScanConsistency: gocb.QueryScanConsistencyRequestPlus,
+		NamedParameters: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// DeleteSessionByID to delete single session information from database
+func (p *provider) DeleteSessionByID(ctx context.Context, id string) error {
+	removeOpt := gocb.RemoveOptions{
+		Context: ctx,
+	}
+	_, err := p.db.Collection(models.Collections.Session).Remove(id, &removeOpt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
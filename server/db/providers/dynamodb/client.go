@@ -0,0 +1,57 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	collection := p.db.Table(models.Collections.Client)
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	err := collection.Put(client).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	collection := p.db.Table(models.Collections.Client)
+	client.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", client.ID, client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var clients []*models.Client
+	collection := p.db.Table(models.Collections.Client)
+	err := collection.Scan().Index("client_id").Filter("'client_id' = ?", clientID).AllWithContext(ctx, &clients)
+	if err != nil {
+		return nil, err
+	}
+	if len(clients) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return clients[0], nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	collection := p.db.Table(models.Collections.Client)
+	err := collection.Delete("id", client.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	collection := p.db.Table(models.Collections.Grant)
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	if grant.GrantedAt == 0 {
+		grant.GrantedAt = grant.CreatedAt
+	}
+	err := collection.Put(grant).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (p *provider) UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	collection := p.db.Table(models.Collections.Grant)
+	grant.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", grant.ID, grant)
+	if err != nil {
+		return nil, err
+	}
+	return grant, nil
+}
+
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error) {
+	var grants []*models.Grant
+	collection := p.db.Table(models.Collections.Grant)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).Filter("'client_id' = ?", clientID).AllWithContext(ctx, &grants)
+	if err != nil {
+		return nil, err
+	}
+	if len(grants) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return grants[0], nil
+}
+
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error) {
+	grants := []*models.Grant{}
+	collection := p.db.Table(models.Collections.Grant)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &grants)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		return grants[i].GrantedAt < grants[j].GrantedAt
+	})
+	return grants, nil
+}
+
+func (p *provider) DeleteGrant(ctx context.Context, grant *models.Grant) error {
+	collection := p.db.Table(models.Collections.Grant)
+	err := collection.Delete("id", grant.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
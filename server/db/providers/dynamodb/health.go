@@ -0,0 +1,13 @@
+package dynamodb
+
+import (
+	"context"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+// HealthCheck describes the users table to check if dynamodb is reachable
+func (p *provider) HealthCheck(ctx context.Context) error {
+	_, err := p.db.Table(models.Collections.User).Describe().RunWithContext(ctx)
+	return err
+}
@@ -0,0 +1,63 @@
+package dynamodb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	collection := p.db.Table(models.Collections.Identity)
+	if identity.ID == "" {
+		identity.ID = models.IdentityID(identity.Provider, identity.ProviderUserID)
+	}
+	identity.CreatedAt = time.Now().Unix()
+	identity.UpdatedAt = time.Now().Unix()
+	if identity.LinkedAt == 0 {
+		identity.LinkedAt = identity.CreatedAt
+	}
+	// id is unique for provider account
+	err := collection.Put(identity).If("attribute_not_exists(id)").RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *provider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	var identity *models.Identity
+	collection := p.db.Table(models.Collections.Identity)
+	iter := collection.Scan().Filter("'provider' = ?", provider).Filter("'provider_user_id' = ?", providerUserID).Iter()
+	for iter.NextWithContext(ctx, &identity) {
+		return identity, nil
+	}
+	err := iter.Err()
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *provider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	identities := []*models.Identity{}
+	collection := p.db.Table(models.Collections.Identity)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &identities)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(identities, func(i, j int) bool {
+		return identities[i].LinkedAt < identities[j].LinkedAt
+	})
+	return identities, nil
+}
+
+func (p *provider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	collection := p.db.Table(models.Collections.Identity)
+	err := collection.Delete("id", identity.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	collection := p.db.Table(models.Collections.Membership)
+	if membership.ID == "" {
+		membership.ID = uuid.New().String()
+	}
+	membership.Key = membership.ID
+	membership.CreatedAt = time.Now().Unix()
+	membership.UpdatedAt = time.Now().Unix()
+	existingMembership, _ := p.GetMembershipByOrganizationIDAndUserID(ctx, membership.OrganizationID, membership.UserID)
+	if existingMembership != nil {
+		return nil, fmt.Errorf("user is already member of organization")
+	}
+	err := collection.Put(membership).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	collection := p.db.Table(models.Collections.Membership)
+	membership.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", membership.ID, membership)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	var memberships []*models.Membership
+	collection := p.db.Table(models.Collections.Membership)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).Filter("'organization_id' = ?", organizationID).AllWithContext(ctx, &memberships)
+	if err != nil {
+		return nil, err
+	}
+	if len(memberships) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return memberships[0], nil
+}
+
+func (p *provider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	memberships := []*models.Membership{}
+	collection := p.db.Table(models.Collections.Membership)
+	err := collection.Scan().Index("organization_id").Filter("'organization_id' = ?", organizationID).AllWithContext(ctx, &memberships)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].CreatedAt < memberships[j].CreatedAt
+	})
+	return memberships, nil
+}
+
+func (p *provider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	memberships := []*models.Membership{}
+	collection := p.db.Table(models.Collections.Membership)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &memberships)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(memberships, func(i, j int) bool {
+		return memberships[i].CreatedAt < memberships[j].CreatedAt
+	})
+	return memberships, nil
+}
+
+func (p *provider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	collection := p.db.Table(models.Collections.Membership)
+	err := collection.Delete("id", membership.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package dynamodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/guregu/dynamo"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	entry.UpdatedAt = time.Now().Unix()
+	err := collection.Put(entry).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	var entry *models.MemoryStoreEntry
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	err := collection.Get("id", id).OneWithContext(ctx, &entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	err := collection.Delete("id", id).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	var entries []*models.MemoryStoreEntry
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	err := collection.Scan().Filter("'namespace' = ? OR 'recipe' = ?", namespace, namespace).AllWithContext(ctx, &entries)
+	if err != nil {
+		return err
+	}
+	return p.deleteMemoryStoreEntries(ctx, entries)
+}
+
+func (p *provider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	var entries []*models.MemoryStoreEntry
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	err := collection.Scan().Filter("'subject' = ?", subject).AllWithContext(ctx, &entries)
+	if err != nil {
+		return err
+	}
+	return p.deleteMemoryStoreEntries(ctx, entries)
+}
+
+func (p *provider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	var entries []*models.MemoryStoreEntry
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	err := collection.Scan().Filter("'expires_at' < ?", expiresAt).AllWithContext(ctx, &entries)
+	if err != nil {
+		return err
+	}
+	return p.deleteMemoryStoreEntries(ctx, entries)
+}
+
+// deleteMemoryStoreEntries deletes the given entries one by one as dynamodb does not support delete by filter
+func (p *provider) deleteMemoryStoreEntries(ctx context.Context, entries []*models.MemoryStoreEntry) error {
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	for _, entry := range entries {
+		err := collection.Delete("id", entry.ID).RunWithContext(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *provider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	collection := p.db.Table(models.Collections.MemoryStoreEntry)
+	now := time.Now().Unix()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	// expired entry is not deleted by cleanup yet
+	err := collection.Put(entry).If("attribute_not_exists(id) OR expires_at <= ?", now).RunWithContext(ctx)
+	if dynamo.IsCondCheckFailed(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,105 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/dynamo"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	collection := p.db.Table(models.Collections.Organization)
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	existingOrganization, _ := p.GetOrganizationByName(ctx, organization.Name)
+	if existingOrganization != nil {
+		return nil, fmt.Errorf("organization with %s name already exists", organization.Name)
+	}
+	err := collection.Put(organization).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	collection := p.db.Table(models.Collections.Organization)
+	organization.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", organization.ID, organization)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	collection := p.db.Table(models.Collections.Organization)
+	var organization *models.Organization
+	err := collection.Get("id", organizationID).OneWithContext(ctx, &organization)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	collection := p.db.Table(models.Collections.Organization)
+	var organizations []*models.Organization
+	err := collection.Scan().Index("name").Filter("'name' = ?", name).Limit(1).AllWithContext(ctx, &organizations)
+	if err != nil {
+		return nil, err
+	}
+	if len(organizations) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return organizations[0], nil
+}
+
+func (p *provider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	var organization *models.Organization
+	var iter dynamo.PagingIter
+	var lastEval dynamo.PagingKey
+	var iteration int64 = 0
+	collection := p.db.Table(models.Collections.Organization)
+	organizations := []*model.Organization{}
+	paginationClone := pagination
+	scanner := collection.Scan()
+	count, err := scanner.Count()
+	if err != nil {
+		return nil, err
+	}
+	for (paginationClone.Offset + paginationClone.Limit) > iteration {
+		iter = scanner.StartFrom(lastEval).Limit(paginationClone.Limit).Iter()
+		for iter.NextWithContext(ctx, &organization) {
+			if paginationClone.Offset == iteration {
+				organizations = append(organizations, organization.AsAPIOrganization())
+			}
+		}
+		lastEval = iter.LastEvaluatedKey()
+		iteration += paginationClone.Limit
+	}
+	paginationClone.Total = count
+	return &model.Organizations{
+		Pagination:    paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+func (p *provider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	collection := p.db.Table(models.Collections.Organization)
+	err := collection.Delete("id", organization.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
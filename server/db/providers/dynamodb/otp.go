@@ -35,12 +35,14 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			Email:       otpParam.Email,
 			PhoneNumber: otpParam.PhoneNumber,
 			ExpiresAt:   otpParam.ExpiresAt,
+			Attempts:    otpParam.Attempts,
 			CreatedAt:   time.Now().Unix(),
 		}
 		shouldCreate = true
 	} else {
 		otp.Otp = otpParam.Otp
 		otp.ExpiresAt = otpParam.ExpiresAt
+		otp.Attempts = otpParam.Attempts
 	}
 	collection := p.db.Table(models.Collections.OTP)
 	otp.UpdatedAt = time.Now().Unix()
@@ -99,3 +101,18 @@ func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 	}
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	collection := p.db.Table(models.Collections.OTP)
+	var updatedOTP models.OTP
+	err := collection.Update("id", otp.ID).
+		Add("attempts", 1).
+		Set("updated_at", time.Now().Unix()).
+		If("attribute_exists(id)").
+		ValueWithContext(ctx, &updatedOTP)
+	if err != nil {
+		return 0, err
+	}
+	return updatedOTP.Attempts, nil
+}
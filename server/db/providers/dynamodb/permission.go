@@ -0,0 +1,95 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/dynamo"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	collection := p.db.Table(models.Collections.Permission)
+	if permission.ID == "" {
+		permission.ID = uuid.New().String()
+	}
+	permission.Key = permission.ID
+	permission.CreatedAt = time.Now().Unix()
+	permission.UpdatedAt = time.Now().Unix()
+	existingPermission, _ := p.GetPermissionByName(ctx, permission.Name)
+	if existingPermission != nil {
+		return nil, fmt.Errorf("permission with %s name already exists", permission.Name)
+	}
+	err := collection.Put(permission).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	collection := p.db.Table(models.Collections.Permission)
+	permission.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", permission.ID, permission)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	collection := p.db.Table(models.Collections.Permission)
+	var permissions []*models.Permission
+	err := collection.Scan().Index("name").Filter("'name' = ?", name).Limit(1).AllWithContext(ctx, &permissions)
+	if err != nil {
+		return nil, err
+	}
+	if len(permissions) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return permissions[0], nil
+}
+
+func (p *provider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	var permission *models.Permission
+	var iter dynamo.PagingIter
+	var lastEval dynamo.PagingKey
+	var iteration int64 = 0
+	collection := p.db.Table(models.Collections.Permission)
+	permissions := []*model.Permission{}
+	paginationClone := pagination
+	scanner := collection.Scan()
+	count, err := scanner.Count()
+	if err != nil {
+		return nil, err
+	}
+	for (paginationClone.Offset + paginationClone.Limit) > iteration {
+		iter = scanner.StartFrom(lastEval).Limit(paginationClone.Limit).Iter()
+		for iter.NextWithContext(ctx, &permission) {
+			if paginationClone.Offset == iteration {
+				permissions = append(permissions, permission.AsAPIPermission())
+			}
+		}
+		lastEval = iter.LastEvaluatedKey()
+		iteration += paginationClone.Limit
+	}
+	paginationClone.Total = count
+	return &model.Permissions{
+		Pagination:  paginationClone,
+		Permissions: permissions,
+	}, nil
+}
+
+func (p *provider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	collection := p.db.Table(models.Collections.Permission)
+	err := collection.Delete("id", permission.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
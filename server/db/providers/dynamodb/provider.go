@@ -1,6 +1,8 @@
 package dynamodb
 
 import (
+	"context"
+
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -53,7 +55,23 @@ func NewProvider() (*provider, error) {
 	db.CreateTable(models.Collections.Webhook, models.Webhook{}).Wait()
 	db.CreateTable(models.Collections.WebhookLog, models.WebhookLog{}).Wait()
 	db.CreateTable(models.Collections.Authenticators, models.Authenticator{}).Wait()
+	db.CreateTable(models.Collections.Identity, models.Identity{}).Wait()
+	db.CreateTable(models.Collections.Client, models.Client{}).Wait()
+	db.CreateTable(models.Collections.Grant, models.Grant{}).Wait()
+	db.CreateTable(models.Collections.Organization, models.Organization{}).Wait()
+	db.CreateTable(models.Collections.Membership, models.Membership{}).Wait()
+	db.CreateTable(models.Collections.Role, models.Role{}).Wait()
+	db.CreateTable(models.Collections.Permission, models.Permission{}).Wait()
+	db.CreateTable(models.Collections.RoleGrant, models.RoleGrant{}).Wait()
+	db.CreateTable(models.Collections.RoleChange, models.RoleChange{}).Wait()
+	db.CreateTable(models.Collections.RefreshTokenReuse, models.RefreshTokenReuse{}).Wait()
+	db.CreateTable(models.Collections.MemoryStoreEntry, models.MemoryStoreEntry{}).Wait()
 	return &provider{
 		db: db,
 	}, nil
 }
+
+// Close is no-op for dynamodb, as requests are made over http
+func (p *provider) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package dynamodb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	collection := p.db.Table(models.Collections.RefreshTokenReuse)
+	if refreshTokenReuse.ID == "" {
+		refreshTokenReuse.ID = uuid.New().String()
+	}
+	refreshTokenReuse.Key = refreshTokenReuse.ID
+	refreshTokenReuse.CreatedAt = time.Now().Unix()
+	err := collection.Put(refreshTokenReuse).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokenReuse, nil
+}
+
+func (p *provider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	refreshTokenReuses := []*models.RefreshTokenReuse{}
+	collection := p.db.Table(models.Collections.RefreshTokenReuse)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &refreshTokenReuses)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(refreshTokenReuses, func(i, j int) bool {
+		return refreshTokenReuses[i].CreatedAt < refreshTokenReuses[j].CreatedAt
+	})
+	return refreshTokenReuses, nil
+}
@@ -0,0 +1,95 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guregu/dynamo"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	collection := p.db.Table(models.Collections.Role)
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.Key = role.ID
+	role.CreatedAt = time.Now().Unix()
+	role.UpdatedAt = time.Now().Unix()
+	existingRole, _ := p.GetRoleByName(ctx, role.Name)
+	if existingRole != nil {
+		return nil, fmt.Errorf("role with %s name already exists", role.Name)
+	}
+	err := collection.Put(role).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	collection := p.db.Table(models.Collections.Role)
+	role.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", role.ID, role)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	collection := p.db.Table(models.Collections.Role)
+	var roles []*models.Role
+	err := collection.Scan().Index("name").Filter("'name' = ?", name).Limit(1).AllWithContext(ctx, &roles)
+	if err != nil {
+		return nil, err
+	}
+	if len(roles) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return roles[0], nil
+}
+
+func (p *provider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	var role *models.Role
+	var iter dynamo.PagingIter
+	var lastEval dynamo.PagingKey
+	var iteration int64 = 0
+	collection := p.db.Table(models.Collections.Role)
+	roles := []*model.Role{}
+	paginationClone := pagination
+	scanner := collection.Scan()
+	count, err := scanner.Count()
+	if err != nil {
+		return nil, err
+	}
+	for (paginationClone.Offset + paginationClone.Limit) > iteration {
+		iter = scanner.StartFrom(lastEval).Limit(paginationClone.Limit).Iter()
+		for iter.NextWithContext(ctx, &role) {
+			if paginationClone.Offset == iteration {
+				roles = append(roles, role.AsAPIRole())
+			}
+		}
+		lastEval = iter.LastEvaluatedKey()
+		iteration += paginationClone.Limit
+	}
+	paginationClone.Total = count
+	return &model.Roles{
+		Pagination: paginationClone,
+		Roles:      roles,
+	}, nil
+}
+
+func (p *provider) DeleteRole(ctx context.Context, role *models.Role) error {
+	collection := p.db.Table(models.Collections.Role)
+	err := collection.Delete("id", role.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package dynamodb
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	collection := p.db.Table(models.Collections.RoleChange)
+	if roleChange.ID == "" {
+		roleChange.ID = uuid.New().String()
+	}
+	roleChange.Key = roleChange.ID
+	roleChange.CreatedAt = time.Now().Unix()
+	err := collection.Put(roleChange).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return roleChange, nil
+}
+
+func (p *provider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	roleChanges := []*models.RoleChange{}
+	collection := p.db.Table(models.Collections.RoleChange)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &roleChanges)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(roleChanges, func(i, j int) bool {
+		return roleChanges[i].CreatedAt < roleChanges[j].CreatedAt
+	})
+	return roleChanges, nil
+}
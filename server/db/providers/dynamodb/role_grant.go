@@ -0,0 +1,87 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	collection := p.db.Table(models.Collections.RoleGrant)
+	if roleGrant.ID == "" {
+		roleGrant.ID = uuid.New().String()
+	}
+	roleGrant.Key = roleGrant.ID
+	roleGrant.CreatedAt = time.Now().Unix()
+	roleGrant.UpdatedAt = time.Now().Unix()
+	existingRoleGrant, _ := p.GetRoleGrantByUserIDAndRole(ctx, roleGrant.UserID, roleGrant.Role)
+	if existingRoleGrant != nil {
+		return nil, fmt.Errorf("role is already granted to user")
+	}
+	err := collection.Put(roleGrant).RunWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	collection := p.db.Table(models.Collections.RoleGrant)
+	roleGrant.UpdatedAt = time.Now().Unix()
+	err := UpdateByHashKey(collection, "id", roleGrant.ID, roleGrant)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	var roleGrants []*models.RoleGrant
+	collection := p.db.Table(models.Collections.RoleGrant)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).Filter("'role' = ?", role).AllWithContext(ctx, &roleGrants)
+	if err != nil {
+		return nil, err
+	}
+	if len(roleGrants) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return roleGrants[0], nil
+}
+
+func (p *provider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	roleGrants := []*models.RoleGrant{}
+	collection := p.db.Table(models.Collections.RoleGrant)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &roleGrants)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(roleGrants, func(i, j int) bool {
+		return roleGrants[i].CreatedAt < roleGrants[j].CreatedAt
+	})
+	return roleGrants, nil
+}
+
+func (p *provider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	roleGrants := []*models.RoleGrant{}
+	collection := p.db.Table(models.Collections.RoleGrant)
+	err := collection.Scan().Filter("'expires_at' > ?", 0).Filter("'expires_at' <= ?", expiresAt).AllWithContext(ctx, &roleGrants)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrants, nil
+}
+
+func (p *provider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	collection := p.db.Table(models.Collections.RoleGrant)
+	err := collection.Delete("id", roleGrant.ID).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
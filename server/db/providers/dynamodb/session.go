@@ -2,6 +2,8 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
+	"sort"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/db/models"
@@ -16,6 +18,9 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 	}
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
+	if session.LastActiveAt == 0 {
+		session.LastActiveAt = session.CreatedAt
+	}
 	err := collection.Put(session).RunWithContext(ctx)
 	return err
 }
@@ -24,3 +29,65 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 func (p *provider) DeleteSession(ctx context.Context, userId string) error {
 	return nil
 }
+
+// ListSessionsByUserID to get list of sessions for given user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	sessions := []*models.Session{}
+	collection := p.db.Table(models.Collections.Session)
+	err := collection.Scan().Index("user_id").Filter("'user_id' = ?", userID).AllWithContext(ctx, &sessions)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastActiveAt > sessions[j].LastActiveAt
+	})
+	return sessions, nil
+}
+
+// GetSessionByID to get session information from database using session ID
+func (p *provider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	var session *models.Session
+	collection := p.db.Table(models.Collections.Session)
+	err := collection.Get("id", id).OneWithContext(ctx, &session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSessionByNonce to get session information using the nonce of its memory store keys
+func (p *provider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	var sessions []*models.Session
+	collection := p.db.Table(models.Collections.Session)
+	err := collection.Scan().Filter("'nonce' = ?", nonce).Limit(1).AllWithContext(ctx, &sessions)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, errors.New("no record found")
+	}
+	return sessions[0], nil
+}
+
+// UpdateSession to update session information in database
+func (p *provider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	collection := p.db.Table(models.Collections.Session)
+	if session.ID != "" {
+		session.UpdatedAt = time.Now().Unix()
+		err := UpdateByHashKey(collection, "id", session.ID, session)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return session, nil
+}
+
+// DeleteSessionByID to delete single session information from database
+func (p *provider) DeleteSessionByID(ctx context.Context, id string) error {
+	collection := p.db.Table(models.Collections.Session)
+	err := collection.Delete("id", id).RunWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return nil
+}
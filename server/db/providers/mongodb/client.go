@@ -0,0 +1,56 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	_, err := clientCollection.InsertOne(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	_, err := clientCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": client.ID}}, bson.M{"$set": client})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client *models.Client
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	err := clientCollection.FindOne(ctx, bson.M{"client_id": clientID}).Decode(&client)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	clientCollection := p.db.Collection(models.Collections.Client, options.Collection())
+	_, err := clientCollection.DeleteOne(ctx, bson.M{"_id": client.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
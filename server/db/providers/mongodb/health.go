@@ -0,0 +1,8 @@
+package mongodb
+
+import "context"
+
+// HealthCheck pings the mongodb server
+func (p *provider) HealthCheck(ctx context.Context) error {
+	return p.db.Client().Ping(ctx, nil)
+}
@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	if identity.ID == "" {
+		identity.ID = uuid.New().String()
+	}
+	identity.Key = identity.ID
+	identity.CreatedAt = time.Now().Unix()
+	identity.UpdatedAt = time.Now().Unix()
+	if identity.LinkedAt == 0 {
+		identity.LinkedAt = identity.CreatedAt
+	}
+	identityCollection := p.db.Collection(models.Collections.Identity, options.Collection())
+	_, err := identityCollection.InsertOne(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *provider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	var identity *models.Identity
+	identityCollection := p.db.Collection(models.Collections.Identity, options.Collection())
+	err := identityCollection.FindOne(ctx, bson.M{"provider": provider, "provider_user_id": providerUserID}).Decode(&identity)
+	if err != nil {
+		return nil, err
+	}
+	return identity, nil
+}
+
+func (p *provider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	var identities []*models.Identity
+	opts := options.Find()
+	opts.SetSort(bson.M{"linked_at": 1})
+	identityCollection := p.db.Collection(models.Collections.Identity, options.Collection())
+	cursor, err := identityCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var identity *models.Identity
+		err := cursor.Decode(&identity)
+		if err != nil {
+			return nil, err
+		}
+		identities = append(identities, identity)
+	}
+	return identities, nil
+}
+
+func (p *provider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	identityCollection := p.db.Collection(models.Collections.Identity, options.Collection())
+	_, err := identityCollection.DeleteOne(ctx, bson.M{"_id": identity.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	if membership.ID == "" {
+		membership.ID = uuid.New().String()
+	}
+	membership.Key = membership.ID
+	membership.CreatedAt = time.Now().Unix()
+	membership.UpdatedAt = time.Now().Unix()
+	membershipCollection := p.db.Collection(models.Collections.Membership, options.Collection())
+	_, err := membershipCollection.InsertOne(ctx, membership)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	membership.UpdatedAt = time.Now().Unix()
+	membershipCollection := p.db.Collection(models.Collections.Membership, options.Collection())
+	_, err := membershipCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": membership.ID}}, bson.M{"$set": membership})
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	var membership *models.Membership
+	membershipCollection := p.db.Collection(models.Collections.Membership, options.Collection())
+	err := membershipCollection.FindOne(ctx, bson.M{"organization_id": organizationID, "user_id": userID}).Decode(&membership)
+	if err != nil {
+		return nil, err
+	}
+	return membership, nil
+}
+
+func (p *provider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	return p.listMemberships(ctx, bson.M{"organization_id": organizationID})
+}
+
+func (p *provider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	return p.listMemberships(ctx, bson.M{"user_id": userID})
+}
+
+// listMemberships returns the memberships matching filter in the order they were created
+func (p *provider) listMemberships(ctx context.Context, filter bson.M) ([]*models.Membership, error) {
+	memberships := []*models.Membership{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": 1})
+	membershipCollection := p.db.Collection(models.Collections.Membership, options.Collection())
+	cursor, err := membershipCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var membership *models.Membership
+		err := cursor.Decode(&membership)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, membership)
+	}
+	return memberships, nil
+}
+
+func (p *provider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	membershipCollection := p.db.Collection(models.Collections.Membership, options.Collection())
+	_, err := membershipCollection.DeleteOne(ctx, bson.M{"_id": membership.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	entry.Key = entry.ID
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	entry.UpdatedAt = time.Now().Unix()
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	_, err := entryCollection.ReplaceOne(ctx, bson.M{"_id": bson.M{"$eq": entry.ID}}, entry, options.Replace().SetUpsert(true))
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	var entry *models.MemoryStoreEntry
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	err := entryCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (p *provider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	_, err := entryCollection.DeleteOne(ctx, bson.M{"_id": id}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	_, err := entryCollection.DeleteMany(ctx, bson.M{"$or": []bson.M{{"namespace": namespace}, {"recipe": namespace}}}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	_, err := entryCollection.DeleteMany(ctx, bson.M{"subject": subject}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	_, err := entryCollection.DeleteMany(ctx, bson.M{"expires_at": bson.M{"$lt": expiresAt}}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (p *provider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	entry.Key = entry.ID
+	now := time.Now().Unix()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	entryCollection := p.db.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	// expired entry is not deleted by cleanup yet
+	_, err := entryCollection.DeleteOne(ctx, bson.M{"_id": entry.ID, "expires_at": bson.M{"$lte": now}}, options.Delete())
+	if err != nil {
+		return false, err
+	}
+	_, err = entryCollection.InsertOne(ctx, entry)
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
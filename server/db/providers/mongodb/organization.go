@@ -0,0 +1,99 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	_, err := organizationCollection.InsertOne(ctx, organization)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	_, err := organizationCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": organization.ID}}, bson.M{"$set": organization})
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	var organization *models.Organization
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	err := organizationCollection.FindOne(ctx, bson.M{"_id": organizationID}).Decode(&organization)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	var organization *models.Organization
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	err := organizationCollection.FindOne(ctx, bson.M{"name": name}).Decode(&organization)
+	if err != nil {
+		return nil, err
+	}
+	return organization, nil
+}
+
+func (p *provider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	organizations := []*model.Organization{}
+	opts := options.Find()
+	opts.SetLimit(pagination.Limit)
+	opts.SetSkip(pagination.Offset)
+	opts.SetSort(bson.M{"created_at": -1})
+	paginationClone := pagination
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	count, err := organizationCollection.CountDocuments(ctx, bson.M{}, options.Count())
+	if err != nil {
+		return nil, err
+	}
+	paginationClone.Total = count
+	cursor, err := organizationCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var organization *models.Organization
+		err := cursor.Decode(&organization)
+		if err != nil {
+			return nil, err
+		}
+		organizations = append(organizations, organization.AsAPIOrganization())
+	}
+	return &model.Organizations{
+		Pagination:    paginationClone,
+		Organizations: organizations,
+	}, nil
+}
+
+func (p *provider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	organizationCollection := p.db.Collection(models.Collections.Organization, options.Collection())
+	_, err := organizationCollection.DeleteOne(ctx, bson.M{"_id": organization.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
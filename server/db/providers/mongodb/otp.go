@@ -37,12 +37,14 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			Email:       otpParam.Email,
 			PhoneNumber: otpParam.PhoneNumber,
 			ExpiresAt:   otpParam.ExpiresAt,
+			Attempts:    otpParam.Attempts,
 			CreatedAt:   time.Now().Unix(),
 		}
 		shouldCreate = true
 	} else {
 		otp.Otp = otpParam.Otp
 		otp.ExpiresAt = otpParam.ExpiresAt
+		otp.Attempts = otpParam.Attempts
 	}
 	otp.UpdatedAt = time.Now().Unix()
 	otpCollection := p.db.Collection(models.Collections.OTP, options.Collection())
@@ -91,3 +93,17 @@ func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	otpCollection := p.db.Collection(models.Collections.OTP, options.Collection())
+	var updatedOTP models.OTP
+	err := otpCollection.FindOneAndUpdate(ctx, bson.M{"_id": otp.ID}, bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"updated_at": time.Now().Unix()},
+	}, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&updatedOTP)
+	if err != nil {
+		return 0, err
+	}
+	return updatedOTP.Attempts, nil
+}
@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	if permission.ID == "" {
+		permission.ID = uuid.New().String()
+	}
+	permission.Key = permission.ID
+	permission.CreatedAt = time.Now().Unix()
+	permission.UpdatedAt = time.Now().Unix()
+	permissionCollection := p.db.Collection(models.Collections.Permission, options.Collection())
+	_, err := permissionCollection.InsertOne(ctx, permission)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	permission.UpdatedAt = time.Now().Unix()
+	permissionCollection := p.db.Collection(models.Collections.Permission, options.Collection())
+	_, err := permissionCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": permission.ID}}, bson.M{"$set": permission})
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	var permission *models.Permission
+	permissionCollection := p.db.Collection(models.Collections.Permission, options.Collection())
+	err := permissionCollection.FindOne(ctx, bson.M{"name": name}).Decode(&permission)
+	if err != nil {
+		return nil, err
+	}
+	return permission, nil
+}
+
+func (p *provider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	permissions := []*model.Permission{}
+	opts := options.Find()
+	opts.SetLimit(pagination.Limit)
+	opts.SetSkip(pagination.Offset)
+	opts.SetSort(bson.M{"created_at": -1})
+	paginationClone := pagination
+	permissionCollection := p.db.Collection(models.Collections.Permission, options.Collection())
+	count, err := permissionCollection.CountDocuments(ctx, bson.M{}, options.Count())
+	if err != nil {
+		return nil, err
+	}
+	paginationClone.Total = count
+	cursor, err := permissionCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var permission *models.Permission
+		err := cursor.Decode(&permission)
+		if err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission.AsAPIPermission())
+	}
+	return &model.Permissions{
+		Pagination:  paginationClone,
+		Permissions: permissions,
+	}, nil
+}
+
+func (p *provider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	permissionCollection := p.db.Collection(models.Collections.Permission, options.Collection())
+	_, err := permissionCollection.DeleteOne(ctx, bson.M{"_id": permission.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
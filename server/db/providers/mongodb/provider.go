@@ -26,7 +26,8 @@ func NewProvider() (*provider, error) {
 	if err != nil {
 		return nil, err
 	}
-	ctx, _ := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
 	err = mongoClient.Connect(ctx)
 	if err != nil {
 		return nil, err
@@ -76,6 +77,10 @@ func NewProvider() (*provider, error) {
 			Keys:    bson.M{"user_id": 1},
 			Options: options.Index().SetSparse(true),
 		},
+		{
+			Keys:    bson.M{"nonce": 1},
+			Options: options.Index().SetSparse(true),
+		},
 	}, options.CreateIndexes())
 
 	mongodb.CreateCollection(ctx, models.Collections.Env, options.CreateCollection())
@@ -131,7 +136,127 @@ func NewProvider() (*provider, error) {
 		},
 	}, options.CreateIndexes())
 
+	mongodb.CreateCollection(ctx, models.Collections.Identity, options.CreateCollection())
+	identityCollection := mongodb.Collection(models.Collections.Identity, options.Collection())
+	identityCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"user_id": 1},
+			Options: options.Index().SetSparse(true),
+		},
+		{
+			Keys:    bson.D{{Key: "provider", Value: 1}, {Key: "provider_user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Client, options.CreateCollection())
+	clientCollection := mongodb.Collection(models.Collections.Client, options.Collection())
+	clientCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"client_id": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Grant, options.CreateCollection())
+	grantCollection := mongodb.Collection(models.Collections.Grant, options.Collection())
+	grantCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "client_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Organization, options.CreateCollection())
+	organizationCollection := mongodb.Collection(models.Collections.Organization, options.Collection())
+	organizationCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"name": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Membership, options.CreateCollection())
+	membershipCollection := mongodb.Collection(models.Collections.Membership, options.Collection())
+	membershipCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "organization_id", Value: 1}, {Key: "user_id", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			Keys: bson.M{"user_id": 1},
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Role, options.CreateCollection())
+	roleCollection := mongodb.Collection(models.Collections.Role, options.Collection())
+	roleCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"name": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.Permission, options.CreateCollection())
+	permissionCollection := mongodb.Collection(models.Collections.Permission, options.Collection())
+	permissionCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.M{"name": 1},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.RoleGrant, options.CreateCollection())
+	roleGrantCollection := mongodb.Collection(models.Collections.RoleGrant, options.Collection())
+	roleGrantCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "user_id", Value: 1}, {Key: "role", Value: 1}},
+			Options: options.Index().SetUnique(true).SetSparse(true),
+		},
+		{
+			Keys: bson.M{"expires_at": 1},
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.RoleChange, options.CreateCollection())
+	roleChangeCollection := mongodb.Collection(models.Collections.RoleChange, options.Collection())
+	roleChangeCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.M{"user_id": 1},
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.RefreshTokenReuse, options.CreateCollection())
+	refreshTokenReuseCollection := mongodb.Collection(models.Collections.RefreshTokenReuse, options.Collection())
+	refreshTokenReuseCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.M{"user_id": 1},
+		},
+	}, options.CreateIndexes())
+
+	mongodb.CreateCollection(ctx, models.Collections.MemoryStoreEntry, options.CreateCollection())
+	memoryStoreEntryCollection := mongodb.Collection(models.Collections.MemoryStoreEntry, options.Collection())
+	memoryStoreEntryCollection.Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.M{"namespace": 1},
+		},
+		{
+			Keys: bson.M{"recipe": 1},
+		},
+		{
+			Keys: bson.M{"subject": 1},
+		},
+		{
+			Keys: bson.M{"expires_at": 1},
+		},
+	}, options.CreateIndexes())
+
 	return &provider{
 		db: mongodb,
 	}, nil
 }
+
+// Close disconnects the mongodb client
+func (p *provider) Close(ctx context.Context) error {
+	return p.db.Client().Disconnect(ctx)
+}
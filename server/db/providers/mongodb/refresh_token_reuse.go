@@ -0,0 +1,47 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	if refreshTokenReuse.ID == "" {
+		refreshTokenReuse.ID = uuid.New().String()
+	}
+	refreshTokenReuse.Key = refreshTokenReuse.ID
+	refreshTokenReuse.CreatedAt = time.Now().Unix()
+	refreshTokenReuseCollection := p.db.Collection(models.Collections.RefreshTokenReuse, options.Collection())
+	_, err := refreshTokenReuseCollection.InsertOne(ctx, refreshTokenReuse)
+	if err != nil {
+		return nil, err
+	}
+	return refreshTokenReuse, nil
+}
+
+func (p *provider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	refreshTokenReuses := []*models.RefreshTokenReuse{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": 1})
+	refreshTokenReuseCollection := p.db.Collection(models.Collections.RefreshTokenReuse, options.Collection())
+	cursor, err := refreshTokenReuseCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var refreshTokenReuse *models.RefreshTokenReuse
+		err := cursor.Decode(&refreshTokenReuse)
+		if err != nil {
+			return nil, err
+		}
+		refreshTokenReuses = append(refreshTokenReuses, refreshTokenReuse)
+	}
+	return refreshTokenReuses, nil
+}
@@ -0,0 +1,89 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.Key = role.ID
+	role.CreatedAt = time.Now().Unix()
+	role.UpdatedAt = time.Now().Unix()
+	roleCollection := p.db.Collection(models.Collections.Role, options.Collection())
+	_, err := roleCollection.InsertOne(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	role.UpdatedAt = time.Now().Unix()
+	roleCollection := p.db.Collection(models.Collections.Role, options.Collection())
+	_, err := roleCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": role.ID}}, bson.M{"$set": role})
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	var role *models.Role
+	roleCollection := p.db.Collection(models.Collections.Role, options.Collection())
+	err := roleCollection.FindOne(ctx, bson.M{"name": name}).Decode(&role)
+	if err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (p *provider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	roles := []*model.Role{}
+	opts := options.Find()
+	opts.SetLimit(pagination.Limit)
+	opts.SetSkip(pagination.Offset)
+	opts.SetSort(bson.M{"created_at": -1})
+	paginationClone := pagination
+	roleCollection := p.db.Collection(models.Collections.Role, options.Collection())
+	count, err := roleCollection.CountDocuments(ctx, bson.M{}, options.Count())
+	if err != nil {
+		return nil, err
+	}
+	paginationClone.Total = count
+	cursor, err := roleCollection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var role *models.Role
+		err := cursor.Decode(&role)
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, role.AsAPIRole())
+	}
+	return &model.Roles{
+		Pagination: paginationClone,
+		Roles:      roles,
+	}, nil
+}
+
+func (p *provider) DeleteRole(ctx context.Context, role *models.Role) error {
+	roleCollection := p.db.Collection(models.Collections.Role, options.Collection())
+	_, err := roleCollection.DeleteOne(ctx, bson.M{"_id": role.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
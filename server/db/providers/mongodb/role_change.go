@@ -0,0 +1,47 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	if roleChange.ID == "" {
+		roleChange.ID = uuid.New().String()
+	}
+	roleChange.Key = roleChange.ID
+	roleChange.CreatedAt = time.Now().Unix()
+	roleChangeCollection := p.db.Collection(models.Collections.RoleChange, options.Collection())
+	_, err := roleChangeCollection.InsertOne(ctx, roleChange)
+	if err != nil {
+		return nil, err
+	}
+	return roleChange, nil
+}
+
+func (p *provider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	roleChanges := []*models.RoleChange{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": 1})
+	roleChangeCollection := p.db.Collection(models.Collections.RoleChange, options.Collection())
+	cursor, err := roleChangeCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var roleChange *models.RoleChange
+		err := cursor.Decode(&roleChange)
+		if err != nil {
+			return nil, err
+		}
+		roleChanges = append(roleChanges, roleChange)
+	}
+	return roleChanges, nil
+}
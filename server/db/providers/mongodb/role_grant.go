@@ -0,0 +1,86 @@
+package mongodb
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	if roleGrant.ID == "" {
+		roleGrant.ID = uuid.New().String()
+	}
+	roleGrant.Key = roleGrant.ID
+	roleGrant.CreatedAt = time.Now().Unix()
+	roleGrant.UpdatedAt = time.Now().Unix()
+	roleGrantCollection := p.db.Collection(models.Collections.RoleGrant, options.Collection())
+	_, err := roleGrantCollection.InsertOne(ctx, roleGrant)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	roleGrant.UpdatedAt = time.Now().Unix()
+	roleGrantCollection := p.db.Collection(models.Collections.RoleGrant, options.Collection())
+	_, err := roleGrantCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": roleGrant.ID}}, bson.M{"$set": roleGrant})
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	var roleGrant *models.RoleGrant
+	roleGrantCollection := p.db.Collection(models.Collections.RoleGrant, options.Collection())
+	err := roleGrantCollection.FindOne(ctx, bson.M{"user_id": userID, "role": role}).Decode(&roleGrant)
+	if err != nil {
+		return nil, err
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	return p.listRoleGrants(ctx, bson.M{"user_id": userID})
+}
+
+func (p *provider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	return p.listRoleGrants(ctx, bson.M{"expires_at": bson.M{"$gt": 0, "$lte": expiresAt}})
+}
+
+// listRoleGrants returns the role grants matching filter in the order they were created
+func (p *provider) listRoleGrants(ctx context.Context, filter bson.M) ([]*models.RoleGrant, error) {
+	roleGrants := []*models.RoleGrant{}
+	opts := options.Find()
+	opts.SetSort(bson.M{"created_at": 1})
+	roleGrantCollection := p.db.Collection(models.Collections.RoleGrant, options.Collection())
+	cursor, err := roleGrantCollection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var roleGrant *models.RoleGrant
+		err := cursor.Decode(&roleGrant)
+		if err != nil {
+			return nil, err
+		}
+		roleGrants = append(roleGrants, roleGrant)
+	}
+	return roleGrants, nil
+}
+
+func (p *provider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	roleGrantCollection := p.db.Collection(models.Collections.RoleGrant, options.Collection())
+	_, err := roleGrantCollection.DeleteOne(ctx, bson.M{"_id": roleGrant.ID}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
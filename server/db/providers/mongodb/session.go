@@ -6,6 +6,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
@@ -18,6 +19,9 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 	session.Key = session.ID
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
+	if session.LastActiveAt == 0 {
+		session.LastActiveAt = session.CreatedAt
+	}
 	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
 	_, err := sessionCollection.InsertOne(ctx, session)
 	if err != nil {
@@ -30,3 +34,68 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 func (p *provider) DeleteSession(ctx context.Context, userId string) error {
 	return nil
 }
+
+// ListSessionsByUserID to get list of sessions for given user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	var sessions []*models.Session
+	opts := options.Find()
+	opts.SetSort(bson.M{"last_active_at": -1})
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	cursor, err := sessionCollection.Find(ctx, bson.M{"user_id": userID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+	for cursor.Next(ctx) {
+		var session *models.Session
+		err := cursor.Decode(&session)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// GetSessionByID to get session information from database using session ID
+func (p *provider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	var session *models.Session
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	err := sessionCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// GetSessionByNonce to get session information using the nonce of its memory store keys
+func (p *provider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	var session *models.Session
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	err := sessionCollection.FindOne(ctx, bson.M{"nonce": nonce}).Decode(&session)
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// UpdateSession to update session information in database
+func (p *provider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	session.UpdatedAt = time.Now().Unix()
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	_, err := sessionCollection.UpdateOne(ctx, bson.M{"_id": bson.M{"$eq": session.ID}}, bson.M{"$set": session}, options.MergeUpdateOptions())
+	if err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// DeleteSessionByID to delete single session information from database
+func (p *provider) DeleteSessionByID(ctx context.Context, id string) error {
+	sessionCollection := p.db.Collection(models.Collections.Session, options.Collection())
+	_, err := sessionCollection.DeleteOne(ctx, bson.M{"_id": id}, options.Delete())
+	if err != nil {
+		return err
+	}
+	return nil
+}
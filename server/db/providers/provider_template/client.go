@@ -0,0 +1,33 @@
+package provider_template
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client *models.Client
+	return client, nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	return nil
+}
@@ -0,0 +1,8 @@
+package provider_template
+
+import "context"
+
+// HealthCheck to check if database is reachable
+func (p *provider) HealthCheck(ctx context.Context) error {
+	return nil
+}
@@ -25,3 +25,8 @@ func (p *provider) GetOTPByPhoneNumber(ctx context.Context, phoneNumber string)
 func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	return 0, nil
+}
@@ -41,6 +41,16 @@ type Provider interface {
 	AddSession(ctx context.Context, session *models.Session) error
 	// DeleteSession to delete session information from database
 	DeleteSession(ctx context.Context, userId string) error
+	// ListSessionsByUserID to get list of sessions for given user
+	ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error)
+	// GetSessionByID to get session information from database using session ID
+	GetSessionByID(ctx context.Context, id string) (*models.Session, error)
+	// GetSessionByNonce to get session information using the nonce of its memory store keys
+	GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error)
+	// UpdateSession to update session information in database
+	UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error)
+	// DeleteSessionByID to delete single session information from database
+	DeleteSessionByID(ctx context.Context, id string) error
 
 	// AddEnv to save environment information in database
 	AddEnv(ctx context.Context, env *models.Env) (*models.Env, error)
@@ -86,6 +96,8 @@ type Provider interface {
 	GetOTPByEmail(ctx context.Context, emailAddress string) (*models.OTP, error)
 	// GetOTPByPhoneNumber to get otp for a given phone number
 	GetOTPByPhoneNumber(ctx context.Context, phoneNumber string) (*models.OTP, error)
+	// IncrementOTPAttempts to increment attempts of otp and return the updated count
+	IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error)
 	// DeleteOTP to delete otp
 	DeleteOTP(ctx context.Context, otp *models.OTP) error
 
@@ -99,4 +111,126 @@ type Provider interface {
 	// GetAuthenticatorDetailsByUserId retrieves details of an authenticator document based on user ID and authenticator type.
 	// If found, the authenticator document is returned, or an error if not found or an error occurs during the retrieval.
 	GetAuthenticatorDetailsByUserId(ctx context.Context, userId string, authenticatorType string) (*models.Authenticator, error)
+
+	// AddIdentity to link an oauth provider account with a user
+	AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error)
+	// GetIdentityByProviderUserID to get identity using oauth provider name and the user id at that provider
+	GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error)
+	// ListIdentitiesByUserID to list identities linked with given user
+	ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error)
+	// DeleteIdentity to unlink identity from user
+	DeleteIdentity(ctx context.Context, identity *models.Identity) error
+
+	// AddClient to save dynamically registered oauth client
+	AddClient(ctx context.Context, client *models.Client) (*models.Client, error)
+	// UpdateClient to update metadata of registered client
+	UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error)
+	// GetClientByClientID to get registered client using its client_id
+	GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error)
+	// DeleteClient to delete registered client
+	DeleteClient(ctx context.Context, client *models.Client) error
+
+	// AddGrant to save the scopes consented by user for a registered client
+	AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error)
+	// UpdateGrant to update the consented scopes
+	UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error)
+	// GetGrantByUserIDAndClientID to get the grant of user for given client
+	GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error)
+	// ListGrantsByUserID to list the grants of user
+	ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error)
+	// DeleteGrant to revoke the grant
+	DeleteGrant(ctx context.Context, grant *models.Grant) error
+
+	// AddOrganization to add organization
+	AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error)
+	// UpdateOrganization to update organization name and roles
+	UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error)
+	// GetOrganizationByID to get organization by its id
+	GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error)
+	// GetOrganizationByName to get organization by its unique name
+	GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error)
+	// ListOrganizations to list organizations
+	ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error)
+	// DeleteOrganization to delete organization, memberships of organization are not deleted
+	DeleteOrganization(ctx context.Context, organization *models.Organization) error
+
+	// AddMembership to add user as member of organization
+	AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error)
+	// UpdateMembership to update roles of member
+	UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error)
+	// GetMembershipByOrganizationIDAndUserID to get membership of user in given organization
+	GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error)
+	// ListMembershipsByOrganizationID to list members of organization
+	ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error)
+	// ListMembershipsByUserID to list organizations user is member of
+	ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error)
+	// DeleteMembership to remove user from organization
+	DeleteMembership(ctx context.Context, membership *models.Membership) error
+
+	// AddRole to add role definition
+	AddRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// UpdateRole to update description and permissions of role
+	UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error)
+	// GetRoleByName to get role by its unique name
+	GetRoleByName(ctx context.Context, name string) (*models.Role, error)
+	// ListRoles to list role definitions
+	ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error)
+	// DeleteRole to delete role definition
+	DeleteRole(ctx context.Context, role *models.Role) error
+
+	// AddPermission to add permission definition
+	AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error)
+	// UpdatePermission to update description of permission
+	UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error)
+	// GetPermissionByName to get permission by its unique name
+	GetPermissionByName(ctx context.Context, name string) (*models.Permission, error)
+	// ListPermissions to list permission definitions
+	ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error)
+	// DeletePermission to delete permission definition, roles granting it are not updated
+	DeletePermission(ctx context.Context, permission *models.Permission) error
+
+	// AddRoleGrant to add grant of role to user
+	AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error)
+	// UpdateRoleGrant to update grantor and expiry of role grant
+	UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error)
+	// GetRoleGrantByUserIDAndRole to get grant of given role to user
+	GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error)
+	// ListRoleGrantsByUserID to list role grants of user
+	ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error)
+	// ListExpiredRoleGrants to list role grants which have expiry before given time
+	ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error)
+	// DeleteRoleGrant to delete role grant
+	DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error
+
+	// AddRoleChange to add entry in role change history of user
+	AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error)
+	// ListRoleChangesByUserID to list role change history of user in the order changes were made
+	ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error)
+
+	// AddRefreshTokenReuse to record the reuse of rotated refresh token
+	AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error)
+	// ListRefreshTokenReusesByUserID to list refresh token reuses of user in the order they were recorded
+	ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error)
+
+	// UpsertMemoryStoreEntry to add or replace the memory store entry with same id
+	UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error)
+	// AddMemoryStoreEntryIfNotExists to add the memory store entry unless an entry with same id exists which is not expired,
+	// it returns false in that case
+	AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error)
+	// GetMemoryStoreEntryByID to get the memory store entry
+	GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error)
+	// DeleteMemoryStoreEntryByID to delete the memory store entry
+	DeleteMemoryStoreEntryByID(ctx context.Context, id string) error
+	// DeleteMemoryStoreEntriesByNamespace to delete the entries of namespace and of the namespaces under it,
+	// eg: google deletes entries of google:<user_id> namespaces
+	DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error
+	// DeleteMemoryStoreEntriesBySubject to delete the entries of subject from all the namespaces
+	DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error
+	// DeleteExpiredMemoryStoreEntries to delete the entries expired before given time
+	DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error
+
+	// HealthCheck to check if database is reachable, used by readiness probe
+	HealthCheck(ctx context.Context) error
+	// Close to close the database connections on shutdown
+	Close(ctx context.Context) error
 }
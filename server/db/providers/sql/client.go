@@ -0,0 +1,52 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	if client.ID == "" {
+		client.ID = uuid.New().String()
+	}
+	client.Key = client.ID
+	client.CreatedAt = time.Now().Unix()
+	client.UpdatedAt = time.Now().Unix()
+	res := p.db.Create(&client)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return client, nil
+}
+
+func (p *provider) UpdateClient(ctx context.Context, client *models.Client) (*models.Client, error) {
+	client.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&client)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return client, nil
+}
+
+func (p *provider) GetClientByClientID(ctx context.Context, clientID string) (*models.Client, error) {
+	var client models.Client
+	result := p.db.Where("client_id = ?", clientID).First(&client)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &client, nil
+}
+
+func (p *provider) DeleteClient(ctx context.Context, client *models.Client) error {
+	result := p.db.Delete(&models.Client{
+		ID: client.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
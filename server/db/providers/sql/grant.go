@@ -0,0 +1,68 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	if grant.ID == "" {
+		grant.ID = uuid.New().String()
+	}
+	grant.Key = grant.ID
+	grant.CreatedAt = time.Now().Unix()
+	grant.UpdatedAt = time.Now().Unix()
+	if grant.GrantedAt == 0 {
+		grant.GrantedAt = grant.CreatedAt
+	}
+	res := p.db.Clauses(
+		clause.OnConflict{
+			DoNothing: true,
+		}).Create(&grant)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return grant, nil
+}
+
+func (p *provider) UpdateGrant(ctx context.Context, grant *models.Grant) (*models.Grant, error) {
+	grant.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&grant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return grant, nil
+}
+
+func (p *provider) GetGrantByUserIDAndClientID(ctx context.Context, userID string, clientID string) (*models.Grant, error) {
+	var grant models.Grant
+	result := p.db.Where("user_id = ?", userID).Where("client_id = ?", clientID).First(&grant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &grant, nil
+}
+
+func (p *provider) ListGrantsByUserID(ctx context.Context, userID string) ([]*models.Grant, error) {
+	var grants []*models.Grant
+	result := p.db.Where("user_id = ?", userID).Order("granted_at ASC").Find(&grants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return grants, nil
+}
+
+func (p *provider) DeleteGrant(ctx context.Context, grant *models.Grant) error {
+	result := p.db.Delete(&models.Grant{
+		ID: grant.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package sql
+
+import "context"
+
+// HealthCheck pings the database using underlying connection pool
+func (p *provider) HealthCheck(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
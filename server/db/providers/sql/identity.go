@@ -0,0 +1,55 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddIdentity(ctx context.Context, identity *models.Identity) (*models.Identity, error) {
+	if identity.ID == "" {
+		identity.ID = uuid.New().String()
+	}
+	identity.Key = identity.ID
+	identity.CreatedAt = time.Now().Unix()
+	identity.UpdatedAt = time.Now().Unix()
+	if identity.LinkedAt == 0 {
+		identity.LinkedAt = identity.CreatedAt
+	}
+	res := p.db.Create(&identity)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return identity, nil
+}
+
+func (p *provider) GetIdentityByProviderUserID(ctx context.Context, provider string, providerUserID string) (*models.Identity, error) {
+	var identity models.Identity
+	result := p.db.Where("provider = ?", provider).Where("provider_user_id = ?", providerUserID).First(&identity)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &identity, nil
+}
+
+func (p *provider) ListIdentitiesByUserID(ctx context.Context, userID string) ([]*models.Identity, error) {
+	var identities []*models.Identity
+	result := p.db.Where("user_id = ?", userID).Order("linked_at ASC").Find(&identities)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return identities, nil
+}
+
+func (p *provider) DeleteIdentity(ctx context.Context, identity *models.Identity) error {
+	result := p.db.Delete(&models.Identity{
+		ID: identity.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
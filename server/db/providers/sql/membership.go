@@ -0,0 +1,70 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	if membership.ID == "" {
+		membership.ID = uuid.New().String()
+	}
+	membership.Key = membership.ID
+	membership.CreatedAt = time.Now().Unix()
+	membership.UpdatedAt = time.Now().Unix()
+	res := p.db.Create(&membership)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return membership, nil
+}
+
+func (p *provider) UpdateMembership(ctx context.Context, membership *models.Membership) (*models.Membership, error) {
+	membership.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&membership)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return membership, nil
+}
+
+func (p *provider) GetMembershipByOrganizationIDAndUserID(ctx context.Context, organizationID string, userID string) (*models.Membership, error) {
+	var membership models.Membership
+	result := p.db.Where("organization_id = ?", organizationID).Where("user_id = ?", userID).First(&membership)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &membership, nil
+}
+
+func (p *provider) ListMembershipsByOrganizationID(ctx context.Context, organizationID string) ([]*models.Membership, error) {
+	var memberships []*models.Membership
+	result := p.db.Where("organization_id = ?", organizationID).Order("created_at ASC").Find(&memberships)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return memberships, nil
+}
+
+func (p *provider) ListMembershipsByUserID(ctx context.Context, userID string) ([]*models.Membership, error) {
+	var memberships []*models.Membership
+	result := p.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&memberships)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return memberships, nil
+}
+
+func (p *provider) DeleteMembership(ctx context.Context, membership *models.Membership) error {
+	result := p.db.Delete(&models.Membership{
+		ID: membership.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error) {
+	entry.Key = entry.ID
+	if entry.CreatedAt == 0 {
+		entry.CreatedAt = time.Now().Unix()
+	}
+	entry.UpdatedAt = time.Now().Unix()
+	res := p.db.Clauses(
+		clause.OnConflict{
+			UpdateAll: true,
+		}).Create(&entry)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return entry, nil
+}
+
+func (p *provider) GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error) {
+	var entry models.MemoryStoreEntry
+	result := p.db.Where("id = ?", id).First(&entry)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &entry, nil
+}
+
+func (p *provider) DeleteMemoryStoreEntryByID(ctx context.Context, id string) error {
+	result := p.db.Delete(&models.MemoryStoreEntry{
+		ID: id,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error {
+	result := p.db.Where("namespace = ?", namespace).Or("recipe = ?", namespace).Delete(&models.MemoryStoreEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (p *provider) DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error {
+	result := p.db.Where("subject = ?", subject).Delete(&models.MemoryStoreEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (p *provider) DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error {
+	result := p.db.Where("expires_at < ?", expiresAt).Delete(&models.MemoryStoreEntry{})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+func (p *provider) AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error) {
+	entry.Key = entry.ID
+	now := time.Now().Unix()
+	entry.CreatedAt = now
+	entry.UpdatedAt = now
+	// expired entry is not deleted by cleanup yet
+	res := p.db.Where("id = ? AND expires_at <= ?", entry.ID, now).Delete(&models.MemoryStoreEntry{})
+	if res.Error != nil {
+		return false, res.Error
+	}
+	res = p.db.Clauses(
+		clause.OnConflict{
+			DoNothing: true,
+		}).Create(&entry)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected > 0, nil
+}
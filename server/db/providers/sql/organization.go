@@ -0,0 +1,88 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	if organization.ID == "" {
+		organization.ID = uuid.New().String()
+	}
+	organization.Key = organization.ID
+	organization.CreatedAt = time.Now().Unix()
+	organization.UpdatedAt = time.Now().Unix()
+	res := p.db.Create(&organization)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return organization, nil
+}
+
+func (p *provider) UpdateOrganization(ctx context.Context, organization *models.Organization) (*models.Organization, error) {
+	organization.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&organization)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return organization, nil
+}
+
+func (p *provider) GetOrganizationByID(ctx context.Context, organizationID string) (*models.Organization, error) {
+	var organization models.Organization
+	result := p.db.Where("id = ?", organizationID).First(&organization)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &organization, nil
+}
+
+func (p *provider) GetOrganizationByName(ctx context.Context, name string) (*models.Organization, error) {
+	var organization models.Organization
+	result := p.db.Where("name = ?", name).First(&organization)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &organization, nil
+}
+
+func (p *provider) ListOrganizations(ctx context.Context, pagination *model.Pagination) (*model.Organizations, error) {
+	var organizations []*models.Organization
+	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&organizations)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var total int64
+	totalRes := p.db.Model(&models.Organization{}).Count(&total)
+	if totalRes.Error != nil {
+		return nil, totalRes.Error
+	}
+
+	paginationClone := pagination
+	paginationClone.Total = total
+
+	responseOrganizations := []*model.Organization{}
+	for _, o := range organizations {
+		responseOrganizations = append(responseOrganizations, o.AsAPIOrganization())
+	}
+	return &model.Organizations{
+		Pagination:    paginationClone,
+		Organizations: responseOrganizations,
+	}, nil
+}
+
+func (p *provider) DeleteOrganization(ctx context.Context, organization *models.Organization) error {
+	result := p.db.Delete(&models.Organization{
+		ID: organization.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // UpsertOTP to add or update otp
@@ -38,12 +39,14 @@ func (p *provider) UpsertOTP(ctx context.Context, otpParam *models.OTP) (*models
 			Email:       otpParam.Email,
 			PhoneNumber: otpParam.PhoneNumber,
 			ExpiresAt:   otpParam.ExpiresAt,
+			Attempts:    otpParam.Attempts,
 			CreatedAt:   time.Now().Unix(),
 		}
 		shouldCreate = true
 	} else {
 		otp.Otp = otpParam.Otp
 		otp.ExpiresAt = otpParam.ExpiresAt
+		otp.Attempts = otpParam.Attempts
 	}
 	otp.UpdatedAt = time.Now().Unix()
 	if shouldCreate {
@@ -90,3 +93,22 @@ func (p *provider) DeleteOTP(ctx context.Context, otp *models.OTP) error {
 	}
 	return nil
 }
+
+// IncrementOTPAttempts increments attempts of otp in database and returns the updated count
+func (p *provider) IncrementOTPAttempts(ctx context.Context, otp *models.OTP) (int64, error) {
+	result := p.db.Model(&models.OTP{}).Where("id = ?", otp.ID).UpdateColumns(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + ?", 1),
+		"updated_at": time.Now().Unix(),
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return 0, errors.New("otp not found")
+	}
+	var updatedOTP models.OTP
+	if err := p.db.Where("id = ?", otp.ID).First(&updatedOTP).Error; err != nil {
+		return 0, err
+	}
+	return updatedOTP.Attempts, nil
+}
@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddPermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	if permission.ID == "" {
+		permission.ID = uuid.New().String()
+	}
+	permission.Key = permission.ID
+	permission.CreatedAt = time.Now().Unix()
+	permission.UpdatedAt = time.Now().Unix()
+	res := p.db.Create(&permission)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return permission, nil
+}
+
+func (p *provider) UpdatePermission(ctx context.Context, permission *models.Permission) (*models.Permission, error) {
+	permission.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&permission)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return permission, nil
+}
+
+func (p *provider) GetPermissionByName(ctx context.Context, name string) (*models.Permission, error) {
+	var permission models.Permission
+	result := p.db.Where("name = ?", name).First(&permission)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &permission, nil
+}
+
+func (p *provider) ListPermissions(ctx context.Context, pagination *model.Pagination) (*model.Permissions, error) {
+	var permissions []*models.Permission
+	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&permissions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var total int64
+	totalRes := p.db.Model(&models.Permission{}).Count(&total)
+	if totalRes.Error != nil {
+		return nil, totalRes.Error
+	}
+
+	paginationClone := pagination
+	paginationClone.Total = total
+
+	responsePermissions := []*model.Permission{}
+	for _, permission := range permissions {
+		responsePermissions = append(responsePermissions, permission.AsAPIPermission())
+	}
+	return &model.Permissions{
+		Pagination:  paginationClone,
+		Permissions: responsePermissions,
+	}, nil
+}
+
+func (p *provider) DeletePermission(ctx context.Context, permission *models.Permission) error {
+	result := p.db.Delete(&models.Permission{
+		ID: permission.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
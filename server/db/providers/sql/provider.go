@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
@@ -80,7 +81,7 @@ func NewProvider() (*provider, error) {
 		logrus.Debug("Failed to drop phone number constraint:", err)
 	}
 
-	err = sqlDB.AutoMigrate(&models.User{}, &models.VerificationRequest{}, &models.Session{}, &models.Env{}, &models.Webhook{}, &models.WebhookLog{}, &models.EmailTemplate{}, &models.OTP{}, &models.Authenticator{})
+	err = sqlDB.AutoMigrate(&models.User{}, &models.VerificationRequest{}, &models.Session{}, &models.Env{}, &models.Webhook{}, &models.WebhookLog{}, &models.EmailTemplate{}, &models.OTP{}, &models.Authenticator{}, &models.Identity{}, &models.Client{}, &models.Grant{}, &models.Organization{}, &models.Membership{}, &models.Role{}, &models.Permission{}, &models.RoleGrant{}, &models.RoleChange{}, &models.RefreshTokenReuse{}, &models.MemoryStoreEntry{})
 	if err != nil {
 		return nil, err
 	}
@@ -115,3 +116,12 @@ func NewProvider() (*provider, error) {
 		db: sqlDB,
 	}, nil
 }
+
+// Close closes the underlying connection pool
+func (p *provider) Close(ctx context.Context) error {
+	sqlDB, err := p.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
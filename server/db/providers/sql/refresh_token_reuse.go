@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRefreshTokenReuse(ctx context.Context, refreshTokenReuse *models.RefreshTokenReuse) (*models.RefreshTokenReuse, error) {
+	if refreshTokenReuse.ID == "" {
+		refreshTokenReuse.ID = uuid.New().String()
+	}
+	refreshTokenReuse.Key = refreshTokenReuse.ID
+	refreshTokenReuse.CreatedAt = time.Now().Unix()
+	res := p.db.Create(&refreshTokenReuse)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return refreshTokenReuse, nil
+}
+
+func (p *provider) ListRefreshTokenReusesByUserID(ctx context.Context, userID string) ([]*models.RefreshTokenReuse, error) {
+	var refreshTokenReuses []*models.RefreshTokenReuse
+	result := p.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&refreshTokenReuses)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return refreshTokenReuses, nil
+}
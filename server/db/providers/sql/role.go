@@ -0,0 +1,79 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+)
+
+func (p *provider) AddRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	if role.ID == "" {
+		role.ID = uuid.New().String()
+	}
+	role.Key = role.ID
+	role.CreatedAt = time.Now().Unix()
+	role.UpdatedAt = time.Now().Unix()
+	res := p.db.Create(&role)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return role, nil
+}
+
+func (p *provider) UpdateRole(ctx context.Context, role *models.Role) (*models.Role, error) {
+	role.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return role, nil
+}
+
+func (p *provider) GetRoleByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	result := p.db.Where("name = ?", name).First(&role)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &role, nil
+}
+
+func (p *provider) ListRoles(ctx context.Context, pagination *model.Pagination) (*model.Roles, error) {
+	var roles []*models.Role
+	result := p.db.Limit(int(pagination.Limit)).Offset(int(pagination.Offset)).Order("created_at DESC").Find(&roles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	var total int64
+	totalRes := p.db.Model(&models.Role{}).Count(&total)
+	if totalRes.Error != nil {
+		return nil, totalRes.Error
+	}
+
+	paginationClone := pagination
+	paginationClone.Total = total
+
+	responseRoles := []*model.Role{}
+	for _, r := range roles {
+		responseRoles = append(responseRoles, r.AsAPIRole())
+	}
+	return &model.Roles{
+		Pagination: paginationClone,
+		Roles:      responseRoles,
+	}, nil
+}
+
+func (p *provider) DeleteRole(ctx context.Context, role *models.Role) error {
+	result := p.db.Delete(&models.Role{
+		ID: role.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleChange(ctx context.Context, roleChange *models.RoleChange) (*models.RoleChange, error) {
+	if roleChange.ID == "" {
+		roleChange.ID = uuid.New().String()
+	}
+	roleChange.Key = roleChange.ID
+	roleChange.CreatedAt = time.Now().Unix()
+	res := p.db.Create(&roleChange)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return roleChange, nil
+}
+
+func (p *provider) ListRoleChangesByUserID(ctx context.Context, userID string) ([]*models.RoleChange, error) {
+	var roleChanges []*models.RoleChange
+	result := p.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&roleChanges)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return roleChanges, nil
+}
@@ -0,0 +1,70 @@
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+func (p *provider) AddRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	if roleGrant.ID == "" {
+		roleGrant.ID = uuid.New().String()
+	}
+	roleGrant.Key = roleGrant.ID
+	roleGrant.CreatedAt = time.Now().Unix()
+	roleGrant.UpdatedAt = time.Now().Unix()
+	res := p.db.Create(&roleGrant)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) UpdateRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) (*models.RoleGrant, error) {
+	roleGrant.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&roleGrant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return roleGrant, nil
+}
+
+func (p *provider) GetRoleGrantByUserIDAndRole(ctx context.Context, userID string, role string) (*models.RoleGrant, error) {
+	var roleGrant models.RoleGrant
+	result := p.db.Where("user_id = ?", userID).Where("role = ?", role).First(&roleGrant)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &roleGrant, nil
+}
+
+func (p *provider) ListRoleGrantsByUserID(ctx context.Context, userID string) ([]*models.RoleGrant, error) {
+	var roleGrants []*models.RoleGrant
+	result := p.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&roleGrants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return roleGrants, nil
+}
+
+func (p *provider) ListExpiredRoleGrants(ctx context.Context, expiresAt int64) ([]*models.RoleGrant, error) {
+	var roleGrants []*models.RoleGrant
+	result := p.db.Where("expires_at > 0").Where("expires_at <= ?", expiresAt).Find(&roleGrants)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return roleGrants, nil
+}
+
+func (p *provider) DeleteRoleGrant(ctx context.Context, roleGrant *models.RoleGrant) error {
+	result := p.db.Delete(&models.RoleGrant{
+		ID: roleGrant.ID,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
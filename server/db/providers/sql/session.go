@@ -18,6 +18,9 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 	session.Key = session.ID
 	session.CreatedAt = time.Now().Unix()
 	session.UpdatedAt = time.Now().Unix()
+	if session.LastActiveAt == 0 {
+		session.LastActiveAt = session.CreatedAt
+	}
 	res := p.db.Clauses(
 		clause.OnConflict{
 			DoNothing: true,
@@ -32,3 +35,54 @@ func (p *provider) AddSession(ctx context.Context, session *models.Session) erro
 func (p *provider) DeleteSession(ctx context.Context, userId string) error {
 	return nil
 }
+
+// ListSessionsByUserID to get list of sessions for given user
+func (p *provider) ListSessionsByUserID(ctx context.Context, userID string) ([]*models.Session, error) {
+	var sessions []*models.Session
+	result := p.db.Where("user_id = ?", userID).Order("last_active_at DESC").Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+// GetSessionByID to get session information from database using session ID
+func (p *provider) GetSessionByID(ctx context.Context, id string) (*models.Session, error) {
+	var session models.Session
+	result := p.db.Where("id = ?", id).First(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// GetSessionByNonce to get session information using the nonce of its memory store keys
+func (p *provider) GetSessionByNonce(ctx context.Context, nonce string) (*models.Session, error) {
+	var session models.Session
+	result := p.db.Where("nonce = ?", nonce).First(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+// UpdateSession to update session information in database
+func (p *provider) UpdateSession(ctx context.Context, session *models.Session) (*models.Session, error) {
+	session.UpdatedAt = time.Now().Unix()
+	result := p.db.Save(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return session, nil
+}
+
+// DeleteSessionByID to delete single session information from database
+func (p *provider) DeleteSessionByID(ctx context.Context, id string) error {
+	result := p.db.Delete(&models.Session{
+		ID: id,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
@@ -9,12 +9,15 @@ import (
 	"text/template"
 
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	gomail "gopkg.in/mail.v2"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/tracing"
 )
 
 func getDefaultTemplate(event string) *model.EmailTemplate {
@@ -39,6 +42,11 @@ func getDefaultTemplate(event string) *model.EmailTemplate {
 			Subject:  otpEmailSubject,
 			Template: otpEmailTemplate,
 		}
+	case constants.VerificationTypeRevertEmailChange:
+		return &model.EmailTemplate{
+			Subject:  revertEmailChangeSubject,
+			Template: revertEmailChangeTemplate,
+		}
 	default:
 		return nil
 	}
@@ -79,7 +87,15 @@ func getEmailTemplate(event string, data map[string]interface{}) (*model.EmailTe
 }
 
 // SendEmail function to send mail
-func SendEmail(to []string, event string, data map[string]interface{}) error {
+func SendEmail(to []string, event string, data map[string]interface{}) (err error) {
+	// email is sent in background without the request context, so it is traced separately
+	_, span := tracing.StartSpan(context.Background(), "email.send", attribute.String("email.event", event))
+	defer func() {
+		if err != nil {
+			metrics.RecordNotificationFailure(metrics.ChannelEmail)
+		}
+		tracing.EndSpan(span, err)
+	}()
 	// dont trigger email sending in case of test
 	envKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyEnv)
 	if err != nil {
@@ -50,6 +50,14 @@ func InitAllEnv() error {
 	osAuthorizerURL := os.Getenv(constants.EnvKeyAuthorizerURL)
 	osPort := os.Getenv(constants.EnvKeyPort)
 	osAccessTokenExpiryTime := os.Getenv(constants.EnvKeyAccessTokenExpiryTime)
+	osSessionExpiryTime := os.Getenv(constants.EnvKeySessionExpiryTime)
+	osRefreshTokenExpiryTime := os.Getenv(constants.EnvKeyRefreshTokenExpiryTime)
+	osSessionIdleTimeout := os.Getenv(constants.EnvKeySessionIdleTimeout)
+	osSessionExpiryTimeByRole := os.Getenv(constants.EnvKeySessionExpiryTimeByRole)
+	osOTPLength := os.Getenv(constants.EnvKeyOTPLength)
+	osOTPCharset := os.Getenv(constants.EnvKeyOTPCharset)
+	osOTPExpiryTime := os.Getenv(constants.EnvKeyOTPExpiryTime)
+	osOTPMaxAttempts := os.Getenv(constants.EnvKeyOTPMaxAttempts)
 	osAdminSecret := os.Getenv(constants.EnvKeyAdminSecret)
 	osSmtpHost := os.Getenv(constants.EnvKeySmtpHost)
 	osSmtpPort := os.Getenv(constants.EnvKeySmtpPort)
@@ -64,6 +72,12 @@ func InitAllEnv() error {
 	osJwtPublicKey := os.Getenv(constants.EnvKeyJwtPublicKey)
 	osJwtRoleClaim := os.Getenv(constants.EnvKeyJwtRoleClaim)
 	osCustomAccessTokenScript := os.Getenv(constants.EnvKeyCustomAccessTokenScript)
+	osCustomIDTokenScript := os.Getenv(constants.EnvKeyCustomIDTokenScript)
+	osCustomUserInfoScript := os.Getenv(constants.EnvKeyCustomUserInfoScript)
+	osTokenExchangePolicy := os.Getenv(constants.EnvKeyTokenExchangePolicy)
+	osBackchannelLogoutURI := os.Getenv(constants.EnvKeyBackchannelLogoutURI)
+	osClientRegistrationInitialAccessToken := os.Getenv(constants.EnvKeyClientRegistrationInitialAccessToken)
+	osCustomScopes := os.Getenv(constants.EnvKeyCustomScopes)
 	osGoogleClientID := os.Getenv(constants.EnvKeyGoogleClientID)
 	osGoogleClientSecret := os.Getenv(constants.EnvKeyGoogleClientSecret)
 	osGithubClientID := os.Getenv(constants.EnvKeyGithubClientID)
@@ -110,6 +124,7 @@ func InitAllEnv() error {
 	osDisableMultiFactorAuthentication := os.Getenv(constants.EnvKeyDisableMultiFactorAuthentication)
 	osDisableTOTPLogin := os.Getenv(constants.EnvKeyDisableTOTPLogin)
 	osDisableMailOTPLogin := os.Getenv(constants.EnvKeyDisableMailOTPLogin)
+	osEnableLoginCode := os.Getenv(constants.EnvKeyEnableLoginCode)
 	// phone verification var
 	osDisablePhoneVerification := os.Getenv(constants.EnvKeyDisablePhoneVerification)
 	osDisablePlayground := os.Getenv(constants.EnvKeyDisablePlayGround)
@@ -225,6 +240,77 @@ func InitAllEnv() error {
 		envData[constants.EnvKeyAccessTokenExpiryTime] = osAccessTokenExpiryTime
 	}
 
+	if val, ok := envData[constants.EnvKeySessionExpiryTime]; !ok || val == "" {
+		envData[constants.EnvKeySessionExpiryTime] = osSessionExpiryTime
+		if envData[constants.EnvKeySessionExpiryTime] == "" {
+			envData[constants.EnvKeySessionExpiryTime] = "8760h"
+		}
+	}
+	if osSessionExpiryTime != "" && envData[constants.EnvKeySessionExpiryTime] != osSessionExpiryTime {
+		envData[constants.EnvKeySessionExpiryTime] = osSessionExpiryTime
+	}
+
+	if val, ok := envData[constants.EnvKeyRefreshTokenExpiryTime]; !ok || val == "" {
+		envData[constants.EnvKeyRefreshTokenExpiryTime] = osRefreshTokenExpiryTime
+		if envData[constants.EnvKeyRefreshTokenExpiryTime] == "" {
+			envData[constants.EnvKeyRefreshTokenExpiryTime] = "8760h"
+		}
+	}
+	if osRefreshTokenExpiryTime != "" && envData[constants.EnvKeyRefreshTokenExpiryTime] != osRefreshTokenExpiryTime {
+		envData[constants.EnvKeyRefreshTokenExpiryTime] = osRefreshTokenExpiryTime
+	}
+
+	if val, ok := envData[constants.EnvKeySessionIdleTimeout]; !ok || val == "" {
+		envData[constants.EnvKeySessionIdleTimeout] = osSessionIdleTimeout
+	}
+	if osSessionIdleTimeout != "" && envData[constants.EnvKeySessionIdleTimeout] != osSessionIdleTimeout {
+		envData[constants.EnvKeySessionIdleTimeout] = osSessionIdleTimeout
+	}
+
+	if val, ok := envData[constants.EnvKeySessionExpiryTimeByRole]; !ok || val == "" {
+		envData[constants.EnvKeySessionExpiryTimeByRole] = osSessionExpiryTimeByRole
+	}
+	if osSessionExpiryTimeByRole != "" && envData[constants.EnvKeySessionExpiryTimeByRole] != osSessionExpiryTimeByRole {
+		envData[constants.EnvKeySessionExpiryTimeByRole] = osSessionExpiryTimeByRole
+	}
+
+	if val, ok := envData[constants.EnvKeyOTPLength]; !ok || val == "" {
+		envData[constants.EnvKeyOTPLength] = osOTPLength
+		if envData[constants.EnvKeyOTPLength] == "" {
+			envData[constants.EnvKeyOTPLength] = strconv.Itoa(constants.DefaultOTPLength)
+		}
+	}
+	if osOTPLength != "" && envData[constants.EnvKeyOTPLength] != osOTPLength {
+		envData[constants.EnvKeyOTPLength] = osOTPLength
+	}
+
+	if val, ok := envData[constants.EnvKeyOTPCharset]; !ok || val == "" {
+		envData[constants.EnvKeyOTPCharset] = osOTPCharset
+		if envData[constants.EnvKeyOTPCharset] == "" {
+			envData[constants.EnvKeyOTPCharset] = constants.OTPCharsetAlphanumeric
+		}
+	}
+	if osOTPCharset != "" && envData[constants.EnvKeyOTPCharset] != osOTPCharset {
+		envData[constants.EnvKeyOTPCharset] = osOTPCharset
+	}
+
+	if val, ok := envData[constants.EnvKeyOTPExpiryTime]; !ok || val == "" {
+		envData[constants.EnvKeyOTPExpiryTime] = osOTPExpiryTime
+	}
+	if osOTPExpiryTime != "" && envData[constants.EnvKeyOTPExpiryTime] != osOTPExpiryTime {
+		envData[constants.EnvKeyOTPExpiryTime] = osOTPExpiryTime
+	}
+
+	if val, ok := envData[constants.EnvKeyOTPMaxAttempts]; !ok || val == "" {
+		envData[constants.EnvKeyOTPMaxAttempts] = osOTPMaxAttempts
+		if envData[constants.EnvKeyOTPMaxAttempts] == "" {
+			envData[constants.EnvKeyOTPMaxAttempts] = strconv.Itoa(constants.DefaultOTPMaxAttempts)
+		}
+	}
+	if osOTPMaxAttempts != "" && envData[constants.EnvKeyOTPMaxAttempts] != osOTPMaxAttempts {
+		envData[constants.EnvKeyOTPMaxAttempts] = osOTPMaxAttempts
+	}
+
 	if val, ok := envData[constants.EnvKeyAdminSecret]; !ok || val == "" {
 		envData[constants.EnvKeyAdminSecret] = osAdminSecret
 	}
@@ -400,6 +486,48 @@ func InitAllEnv() error {
 		envData[constants.EnvKeyCustomAccessTokenScript] = osCustomAccessTokenScript
 	}
 
+	if val, ok := envData[constants.EnvKeyCustomIDTokenScript]; !ok || val == "" {
+		envData[constants.EnvKeyCustomIDTokenScript] = osCustomIDTokenScript
+	}
+	if osCustomIDTokenScript != "" && envData[constants.EnvKeyCustomIDTokenScript] != osCustomIDTokenScript {
+		envData[constants.EnvKeyCustomIDTokenScript] = osCustomIDTokenScript
+	}
+
+	if val, ok := envData[constants.EnvKeyCustomUserInfoScript]; !ok || val == "" {
+		envData[constants.EnvKeyCustomUserInfoScript] = osCustomUserInfoScript
+	}
+	if osCustomUserInfoScript != "" && envData[constants.EnvKeyCustomUserInfoScript] != osCustomUserInfoScript {
+		envData[constants.EnvKeyCustomUserInfoScript] = osCustomUserInfoScript
+	}
+
+	if val, ok := envData[constants.EnvKeyTokenExchangePolicy]; !ok || val == "" {
+		envData[constants.EnvKeyTokenExchangePolicy] = osTokenExchangePolicy
+	}
+	if osTokenExchangePolicy != "" && envData[constants.EnvKeyTokenExchangePolicy] != osTokenExchangePolicy {
+		envData[constants.EnvKeyTokenExchangePolicy] = osTokenExchangePolicy
+	}
+
+	if val, ok := envData[constants.EnvKeyBackchannelLogoutURI]; !ok || val == "" {
+		envData[constants.EnvKeyBackchannelLogoutURI] = osBackchannelLogoutURI
+	}
+	if osBackchannelLogoutURI != "" && envData[constants.EnvKeyBackchannelLogoutURI] != osBackchannelLogoutURI {
+		envData[constants.EnvKeyBackchannelLogoutURI] = osBackchannelLogoutURI
+	}
+
+	if val, ok := envData[constants.EnvKeyClientRegistrationInitialAccessToken]; !ok || val == "" {
+		envData[constants.EnvKeyClientRegistrationInitialAccessToken] = osClientRegistrationInitialAccessToken
+	}
+	if osClientRegistrationInitialAccessToken != "" && envData[constants.EnvKeyClientRegistrationInitialAccessToken] != osClientRegistrationInitialAccessToken {
+		envData[constants.EnvKeyClientRegistrationInitialAccessToken] = osClientRegistrationInitialAccessToken
+	}
+
+	if val, ok := envData[constants.EnvKeyCustomScopes]; !ok || val == "" {
+		envData[constants.EnvKeyCustomScopes] = osCustomScopes
+	}
+	if osCustomScopes != "" && envData[constants.EnvKeyCustomScopes] != osCustomScopes {
+		envData[constants.EnvKeyCustomScopes] = osCustomScopes
+	}
+
 	if val, ok := envData[constants.EnvKeyGoogleClientID]; !ok || val == "" {
 		envData[constants.EnvKeyGoogleClientID] = osGoogleClientID
 	}
@@ -894,6 +1022,19 @@ func InitAllEnv() error {
 		}
 	}
 
+	if _, ok := envData[constants.EnvKeyEnableLoginCode]; !ok {
+		envData[constants.EnvKeyEnableLoginCode] = osEnableLoginCode == "true"
+	}
+	if osEnableLoginCode != "" {
+		boolValue, err := strconv.ParseBool(osEnableLoginCode)
+		if err != nil {
+			return err
+		}
+		if boolValue != envData[constants.EnvKeyEnableLoginCode].(bool) {
+			envData[constants.EnvKeyEnableLoginCode] = boolValue
+		}
+	}
+
 	err = memorystore.Provider.UpdateEnvStore(envData)
 	if err != nil {
 		log.Debug("Error while updating env store: ", err)
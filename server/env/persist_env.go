@@ -108,6 +108,7 @@ func GetEnvData() (map[string]interface{}, error) {
 }
 
 // PersistEnv persists the environment variables to the database
+// and syncs the role definitions with configured roles
 func PersistEnv() error {
 	ctx := context.Background()
 	env, err := db.Provider.GetEnv(ctx)
@@ -140,6 +141,13 @@ func PersistEnv() error {
 			log.Debug("Error while persisting env data to db: ", err)
 			return err
 		}
+
+		jwk, err := crypto.GenerateJWKBasedOnEnv()
+		if err != nil {
+			log.Debug("Error while generating JWK: ", err)
+			return err
+		}
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJWK, jwk)
 	} else {
 		// decrypt the config data from db
 		// decryption can be done using the hash stored in db
@@ -264,5 +272,5 @@ func PersistEnv() error {
 		}
 	}
 
-	return nil
+	return SyncRoles(ctx)
 }
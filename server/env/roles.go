@@ -0,0 +1,111 @@
+package env
+
+import (
+	"context"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// SyncRoles keeps the role definitions stored in db in sync with ROLES and PROTECTED_ROLES.
+// Definitions are added for the roles which do not have one. Role definitions are the
+// source of truth once permissions are granted by them, so such roles are added back
+// to ROLES when they are no longer configured. Definitions of other roles which are
+// no longer configured are deleted.
+func SyncRoles(ctx context.Context) error {
+	configuredRoles := map[string]bool{}
+	rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+	if err != nil {
+		log.Debug("Error while getting roles: ", err)
+		return err
+	}
+	protectedRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyProtectedRoles)
+	if err != nil {
+		log.Debug("Error while getting protected roles: ", err)
+		return err
+	}
+	for _, roles := range []string{rolesString, protectedRolesString} {
+		for _, role := range strings.Split(roles, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				configuredRoles[role] = true
+			}
+		}
+	}
+
+	existingRoles := map[string]bool{}
+	pagination := &model.Pagination{
+		Limit: 100,
+	}
+	for {
+		res, err := db.Provider.ListRoles(ctx, pagination)
+		if err != nil {
+			log.Debug("Error while listing roles: ", err)
+			return err
+		}
+		for _, role := range res.Roles {
+			existingRoles[role.Name] = true
+		}
+		if int64(len(res.Roles)) < pagination.Limit {
+			break
+		}
+		pagination = &model.Pagination{
+			Limit:  pagination.Limit,
+			Offset: pagination.Offset + pagination.Limit,
+		}
+	}
+
+	restoredRoles := []string{}
+	for role := range existingRoles {
+		if configuredRoles[role] {
+			continue
+		}
+		roleData, err := db.Provider.GetRoleByName(ctx, role)
+		if err != nil {
+			log.Debug("Error while getting role: ", err)
+			return err
+		}
+		if len(roleData.GetPermissions()) > 0 {
+			log.Debug("Keeping role with permissions: ", role)
+			restoredRoles = append(restoredRoles, role)
+			continue
+		}
+		err = db.Provider.DeleteRole(ctx, roleData)
+		if err != nil {
+			log.Debug("Error while deleting role: ", err)
+			return err
+		}
+	}
+
+	if len(restoredRoles) > 0 {
+		roles := []string{}
+		for _, role := range strings.Split(rolesString, ",") {
+			if role = strings.TrimSpace(role); role != "" {
+				roles = append(roles, role)
+			}
+		}
+		err := memorystore.Provider.UpdateEnvVariable(constants.EnvKeyRoles, strings.Join(append(roles, restoredRoles...), ","))
+		if err != nil {
+			log.Debug("Error while updating roles: ", err)
+			return err
+		}
+	}
+
+	for role := range configuredRoles {
+		if existingRoles[role] {
+			continue
+		}
+		// role could have been added by other instance in the meantime
+		if _, err := db.Provider.AddRole(ctx, &models.Role{
+			Name: role,
+		}); err != nil {
+			log.Debug("Error while adding role: ", err)
+		}
+	}
+	return nil
+}
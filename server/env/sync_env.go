@@ -0,0 +1,121 @@
+package env
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// defaultEnvSyncInterval is used when ENV_SYNC_INTERVAL is not set
+const defaultEnvSyncInterval = 30 * time.Second
+
+// syncedEnv keeps the encrypted env data that was last loaded by this instance
+var syncedEnv = struct {
+	mutex     sync.Mutex
+	envData   string
+	updatedAt int64
+}{}
+
+// GetEnvSyncInterval returns the interval at which env changes are checked in database.
+// Zero duration means syncing is disabled.
+func GetEnvSyncInterval() (time.Duration, error) {
+	interval := memorystore.RequiredEnvStoreObj.GetRequiredEnv().EnvSyncInterval
+	if interval == "" {
+		return defaultEnvSyncInterval, nil
+	}
+	duration, err := time.ParseDuration(interval)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", constants.EnvKeyEnvSyncInterval, interval)
+	}
+	return duration, nil
+}
+
+// ReloadEnv loads env data from database into the env store
+// and generates JWK again, as signing keys might have changed
+func ReloadEnv() error {
+	envData, err := GetEnvData()
+	if err != nil {
+		log.Debug("Error while getting env data: ", err)
+		return err
+	}
+	err = memorystore.Provider.UpdateEnvStore(envData)
+	if err != nil {
+		log.Debug("Error while updating env store: ", err)
+		return err
+	}
+	jwk, err := crypto.GenerateJWKBasedOnEnv()
+	if err != nil {
+		log.Debug("Error while generating JWK: ", err)
+		return err
+	}
+	return memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJWK, jwk)
+}
+
+// SyncEnv checks if env data in database was changed since it was last synced by this instance.
+// If so, env store is reloaded and onChange is called to re-initialize the dependent providers.
+// It returns true if env was reloaded.
+func SyncEnv(ctx context.Context, onChange func() error) (bool, error) {
+	env, err := db.Provider.GetEnv(ctx)
+	if err != nil || env == nil {
+		log.Debug("Error while getting env from db: ", err)
+		return false, err
+	}
+
+	syncedEnv.mutex.Lock()
+	defer syncedEnv.mutex.Unlock()
+	if env.EnvData == syncedEnv.envData && env.UpdatedAt == syncedEnv.updatedAt {
+		return false, nil
+	}
+	// first check only records the current version, env is already loaded on startup
+	if syncedEnv.envData == "" {
+		syncedEnv.envData = env.EnvData
+		syncedEnv.updatedAt = env.UpdatedAt
+		return false, nil
+	}
+
+	err = ReloadEnv()
+	if err != nil {
+		return false, err
+	}
+	syncedEnv.envData = env.EnvData
+	syncedEnv.updatedAt = env.UpdatedAt
+	if onChange != nil {
+		if err := onChange(); err != nil {
+			log.Debug("Error while re-initializing providers after env change: ", err)
+			return true, err
+		}
+	}
+	log.Info("Reloaded env changes from database")
+	return true, nil
+}
+
+// StartEnvSync checks for env changes at given interval until context is cancelled.
+// It is used to propagate changes done via _update_env on one instance to all the other instances.
+func StartEnvSync(ctx context.Context, interval time.Duration, onChange func() error) {
+	if interval <= 0 {
+		return
+	}
+	// record the version loaded on startup
+	SyncEnv(ctx, onChange)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := SyncEnv(ctx, onChange); err != nil {
+				log.Debug("Error while syncing env: ", err)
+			}
+		}
+	}
+}
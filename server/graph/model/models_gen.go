@@ -9,6 +9,30 @@ type AddEmailTemplateRequest struct {
 	Design    *string `json:"design,omitempty"`
 }
 
+type AddOrganizationMemberRequest struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         *string  `json:"user_id,omitempty"`
+	Email          *string  `json:"email,omitempty"`
+	Roles          []string `json:"roles,omitempty"`
+}
+
+type AddOrganizationRequest struct {
+	Name         string   `json:"name"`
+	Roles        []string `json:"roles,omitempty"`
+	DefaultRoles []string `json:"default_roles,omitempty"`
+}
+
+type AddPermissionRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+type AddRoleRequest struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
 type AddWebhookRequest struct {
 	EventName        string                 `json:"event_name"`
 	EventDescription *string                `json:"event_description,omitempty"`
@@ -40,6 +64,15 @@ type AuthResponse struct {
 	AuthenticatorRecoveryCodes []*string `json:"authenticator_recovery_codes,omitempty"`
 }
 
+type CheckPermissionInput struct {
+	UserID     *string `json:"user_id,omitempty"`
+	Permission string  `json:"permission"`
+}
+
+type CheckPermissionResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
 type DeleteEmailTemplateRequest struct {
 	ID string `json:"id"`
 }
@@ -64,77 +97,92 @@ type EmailTemplates struct {
 }
 
 type Env struct {
-	AccessTokenExpiryTime            *string  `json:"ACCESS_TOKEN_EXPIRY_TIME,omitempty"`
-	AdminSecret                      *string  `json:"ADMIN_SECRET,omitempty"`
-	DatabaseName                     *string  `json:"DATABASE_NAME,omitempty"`
-	DatabaseURL                      *string  `json:"DATABASE_URL,omitempty"`
-	DatabaseType                     *string  `json:"DATABASE_TYPE,omitempty"`
-	DatabaseUsername                 *string  `json:"DATABASE_USERNAME,omitempty"`
-	DatabasePassword                 *string  `json:"DATABASE_PASSWORD,omitempty"`
-	DatabaseHost                     *string  `json:"DATABASE_HOST,omitempty"`
-	DatabasePort                     *string  `json:"DATABASE_PORT,omitempty"`
-	ClientID                         string   `json:"CLIENT_ID"`
-	ClientSecret                     string   `json:"CLIENT_SECRET"`
-	CustomAccessTokenScript          *string  `json:"CUSTOM_ACCESS_TOKEN_SCRIPT,omitempty"`
-	SMTPHost                         *string  `json:"SMTP_HOST,omitempty"`
-	SMTPPort                         *string  `json:"SMTP_PORT,omitempty"`
-	SMTPUsername                     *string  `json:"SMTP_USERNAME,omitempty"`
-	SMTPPassword                     *string  `json:"SMTP_PASSWORD,omitempty"`
-	SMTPLocalName                    *string  `json:"SMTP_LOCAL_NAME,omitempty"`
-	SenderEmail                      *string  `json:"SENDER_EMAIL,omitempty"`
-	SenderName                       *string  `json:"SENDER_NAME,omitempty"`
-	JwtType                          *string  `json:"JWT_TYPE,omitempty"`
-	JwtSecret                        *string  `json:"JWT_SECRET,omitempty"`
-	JwtPrivateKey                    *string  `json:"JWT_PRIVATE_KEY,omitempty"`
-	JwtPublicKey                     *string  `json:"JWT_PUBLIC_KEY,omitempty"`
-	AllowedOrigins                   []string `json:"ALLOWED_ORIGINS,omitempty"`
-	AppURL                           *string  `json:"APP_URL,omitempty"`
-	RedisURL                         *string  `json:"REDIS_URL,omitempty"`
-	ResetPasswordURL                 *string  `json:"RESET_PASSWORD_URL,omitempty"`
-	DisableEmailVerification         bool     `json:"DISABLE_EMAIL_VERIFICATION"`
-	DisableBasicAuthentication       bool     `json:"DISABLE_BASIC_AUTHENTICATION"`
-	DisableMobileBasicAuthentication bool     `json:"DISABLE_MOBILE_BASIC_AUTHENTICATION"`
-	DisableMagicLinkLogin            bool     `json:"DISABLE_MAGIC_LINK_LOGIN"`
-	DisableLoginPage                 bool     `json:"DISABLE_LOGIN_PAGE"`
-	DisableSignUp                    bool     `json:"DISABLE_SIGN_UP"`
-	DisableRedisForEnv               bool     `json:"DISABLE_REDIS_FOR_ENV"`
-	DisableStrongPassword            bool     `json:"DISABLE_STRONG_PASSWORD"`
-	DisableMultiFactorAuthentication bool     `json:"DISABLE_MULTI_FACTOR_AUTHENTICATION"`
-	EnforceMultiFactorAuthentication bool     `json:"ENFORCE_MULTI_FACTOR_AUTHENTICATION"`
-	Roles                            []string `json:"ROLES,omitempty"`
-	ProtectedRoles                   []string `json:"PROTECTED_ROLES,omitempty"`
-	DefaultRoles                     []string `json:"DEFAULT_ROLES,omitempty"`
-	JwtRoleClaim                     *string  `json:"JWT_ROLE_CLAIM,omitempty"`
-	GoogleClientID                   *string  `json:"GOOGLE_CLIENT_ID,omitempty"`
-	GoogleClientSecret               *string  `json:"GOOGLE_CLIENT_SECRET,omitempty"`
-	GithubClientID                   *string  `json:"GITHUB_CLIENT_ID,omitempty"`
-	GithubClientSecret               *string  `json:"GITHUB_CLIENT_SECRET,omitempty"`
-	FacebookClientID                 *string  `json:"FACEBOOK_CLIENT_ID,omitempty"`
-	FacebookClientSecret             *string  `json:"FACEBOOK_CLIENT_SECRET,omitempty"`
-	LinkedinClientID                 *string  `json:"LINKEDIN_CLIENT_ID,omitempty"`
-	LinkedinClientSecret             *string  `json:"LINKEDIN_CLIENT_SECRET,omitempty"`
-	AppleClientID                    *string  `json:"APPLE_CLIENT_ID,omitempty"`
-	AppleClientSecret                *string  `json:"APPLE_CLIENT_SECRET,omitempty"`
-	DiscordClientID                  *string  `json:"DISCORD_CLIENT_ID,omitempty"`
-	DiscordClientSecret              *string  `json:"DISCORD_CLIENT_SECRET,omitempty"`
-	TwitterClientID                  *string  `json:"TWITTER_CLIENT_ID,omitempty"`
-	TwitterClientSecret              *string  `json:"TWITTER_CLIENT_SECRET,omitempty"`
-	MicrosoftClientID                *string  `json:"MICROSOFT_CLIENT_ID,omitempty"`
-	MicrosoftClientSecret            *string  `json:"MICROSOFT_CLIENT_SECRET,omitempty"`
-	MicrosoftActiveDirectoryTenantID *string  `json:"MICROSOFT_ACTIVE_DIRECTORY_TENANT_ID,omitempty"`
-	TwitchClientID                   *string  `json:"TWITCH_CLIENT_ID,omitempty"`
-	TwitchClientSecret               *string  `json:"TWITCH_CLIENT_SECRET,omitempty"`
-	RobloxClientID                   *string  `json:"ROBLOX_CLIENT_ID,omitempty"`
-	RobloxClientSecret               *string  `json:"ROBLOX_CLIENT_SECRET,omitempty"`
-	OrganizationName                 *string  `json:"ORGANIZATION_NAME,omitempty"`
-	OrganizationLogo                 *string  `json:"ORGANIZATION_LOGO,omitempty"`
-	AppCookieSecure                  bool     `json:"APP_COOKIE_SECURE"`
-	AdminCookieSecure                bool     `json:"ADMIN_COOKIE_SECURE"`
-	DefaultAuthorizeResponseType     *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_TYPE,omitempty"`
-	DefaultAuthorizeResponseMode     *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_MODE,omitempty"`
-	DisablePlayground                bool     `json:"DISABLE_PLAYGROUND"`
-	DisableMailOtpLogin              bool     `json:"DISABLE_MAIL_OTP_LOGIN"`
-	DisableTotpLogin                 bool     `json:"DISABLE_TOTP_LOGIN"`
+	AccessTokenExpiryTime                *string  `json:"ACCESS_TOKEN_EXPIRY_TIME,omitempty"`
+	SessionExpiryTime                    *string  `json:"SESSION_EXPIRY_TIME,omitempty"`
+	RefreshTokenExpiryTime               *string  `json:"REFRESH_TOKEN_EXPIRY_TIME,omitempty"`
+	SessionIDLeTimeout                   *string  `json:"SESSION_IDLE_TIMEOUT,omitempty"`
+	SessionExpiryTimeByRole              *string  `json:"SESSION_EXPIRY_TIME_BY_ROLE,omitempty"`
+	OtpLength                            *string  `json:"OTP_LENGTH,omitempty"`
+	OtpCharset                           *string  `json:"OTP_CHARSET,omitempty"`
+	OtpExpiryTime                        *string  `json:"OTP_EXPIRY_TIME,omitempty"`
+	OtpMaxAttempts                       *string  `json:"OTP_MAX_ATTEMPTS,omitempty"`
+	AdminSecret                          *string  `json:"ADMIN_SECRET,omitempty"`
+	DatabaseName                         *string  `json:"DATABASE_NAME,omitempty"`
+	DatabaseURL                          *string  `json:"DATABASE_URL,omitempty"`
+	DatabaseType                         *string  `json:"DATABASE_TYPE,omitempty"`
+	DatabaseUsername                     *string  `json:"DATABASE_USERNAME,omitempty"`
+	DatabasePassword                     *string  `json:"DATABASE_PASSWORD,omitempty"`
+	DatabaseHost                         *string  `json:"DATABASE_HOST,omitempty"`
+	DatabasePort                         *string  `json:"DATABASE_PORT,omitempty"`
+	ClientID                             string   `json:"CLIENT_ID"`
+	ClientSecret                         string   `json:"CLIENT_SECRET"`
+	CustomAccessTokenScript              *string  `json:"CUSTOM_ACCESS_TOKEN_SCRIPT,omitempty"`
+	CustomIDTokenScript                  *string  `json:"CUSTOM_ID_TOKEN_SCRIPT,omitempty"`
+	CustomUserInfoScript                 *string  `json:"CUSTOM_USER_INFO_SCRIPT,omitempty"`
+	TokenExchangePolicy                  *string  `json:"TOKEN_EXCHANGE_POLICY,omitempty"`
+	BackchannelLogoutURI                 *string  `json:"BACKCHANNEL_LOGOUT_URI,omitempty"`
+	ClientRegistrationInitialAccessToken *string  `json:"CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN,omitempty"`
+	CustomScopes                         *string  `json:"CUSTOM_SCOPES,omitempty"`
+	SMTPHost                             *string  `json:"SMTP_HOST,omitempty"`
+	SMTPPort                             *string  `json:"SMTP_PORT,omitempty"`
+	SMTPUsername                         *string  `json:"SMTP_USERNAME,omitempty"`
+	SMTPPassword                         *string  `json:"SMTP_PASSWORD,omitempty"`
+	SMTPLocalName                        *string  `json:"SMTP_LOCAL_NAME,omitempty"`
+	SenderEmail                          *string  `json:"SENDER_EMAIL,omitempty"`
+	SenderName                           *string  `json:"SENDER_NAME,omitempty"`
+	JwtType                              *string  `json:"JWT_TYPE,omitempty"`
+	JwtSecret                            *string  `json:"JWT_SECRET,omitempty"`
+	JwtPrivateKey                        *string  `json:"JWT_PRIVATE_KEY,omitempty"`
+	JwtPublicKey                         *string  `json:"JWT_PUBLIC_KEY,omitempty"`
+	AllowedOrigins                       []string `json:"ALLOWED_ORIGINS,omitempty"`
+	AppURL                               *string  `json:"APP_URL,omitempty"`
+	RedisURL                             *string  `json:"REDIS_URL,omitempty"`
+	ResetPasswordURL                     *string  `json:"RESET_PASSWORD_URL,omitempty"`
+	DisableEmailVerification             bool     `json:"DISABLE_EMAIL_VERIFICATION"`
+	DisableBasicAuthentication           bool     `json:"DISABLE_BASIC_AUTHENTICATION"`
+	DisableMobileBasicAuthentication     bool     `json:"DISABLE_MOBILE_BASIC_AUTHENTICATION"`
+	DisableMagicLinkLogin                bool     `json:"DISABLE_MAGIC_LINK_LOGIN"`
+	DisableLoginPage                     bool     `json:"DISABLE_LOGIN_PAGE"`
+	DisableSignUp                        bool     `json:"DISABLE_SIGN_UP"`
+	DisableRedisForEnv                   bool     `json:"DISABLE_REDIS_FOR_ENV"`
+	DisableStrongPassword                bool     `json:"DISABLE_STRONG_PASSWORD"`
+	DisableMultiFactorAuthentication     bool     `json:"DISABLE_MULTI_FACTOR_AUTHENTICATION"`
+	EnforceMultiFactorAuthentication     bool     `json:"ENFORCE_MULTI_FACTOR_AUTHENTICATION"`
+	Roles                                []string `json:"ROLES,omitempty"`
+	ProtectedRoles                       []string `json:"PROTECTED_ROLES,omitempty"`
+	DefaultRoles                         []string `json:"DEFAULT_ROLES,omitempty"`
+	JwtRoleClaim                         *string  `json:"JWT_ROLE_CLAIM,omitempty"`
+	GoogleClientID                       *string  `json:"GOOGLE_CLIENT_ID,omitempty"`
+	GoogleClientSecret                   *string  `json:"GOOGLE_CLIENT_SECRET,omitempty"`
+	GithubClientID                       *string  `json:"GITHUB_CLIENT_ID,omitempty"`
+	GithubClientSecret                   *string  `json:"GITHUB_CLIENT_SECRET,omitempty"`
+	FacebookClientID                     *string  `json:"FACEBOOK_CLIENT_ID,omitempty"`
+	FacebookClientSecret                 *string  `json:"FACEBOOK_CLIENT_SECRET,omitempty"`
+	LinkedinClientID                     *string  `json:"LINKEDIN_CLIENT_ID,omitempty"`
+	LinkedinClientSecret                 *string  `json:"LINKEDIN_CLIENT_SECRET,omitempty"`
+	AppleClientID                        *string  `json:"APPLE_CLIENT_ID,omitempty"`
+	AppleClientSecret                    *string  `json:"APPLE_CLIENT_SECRET,omitempty"`
+	DiscordClientID                      *string  `json:"DISCORD_CLIENT_ID,omitempty"`
+	DiscordClientSecret                  *string  `json:"DISCORD_CLIENT_SECRET,omitempty"`
+	TwitterClientID                      *string  `json:"TWITTER_CLIENT_ID,omitempty"`
+	TwitterClientSecret                  *string  `json:"TWITTER_CLIENT_SECRET,omitempty"`
+	MicrosoftClientID                    *string  `json:"MICROSOFT_CLIENT_ID,omitempty"`
+	MicrosoftClientSecret                *string  `json:"MICROSOFT_CLIENT_SECRET,omitempty"`
+	MicrosoftActiveDirectoryTenantID     *string  `json:"MICROSOFT_ACTIVE_DIRECTORY_TENANT_ID,omitempty"`
+	TwitchClientID                       *string  `json:"TWITCH_CLIENT_ID,omitempty"`
+	TwitchClientSecret                   *string  `json:"TWITCH_CLIENT_SECRET,omitempty"`
+	RobloxClientID                       *string  `json:"ROBLOX_CLIENT_ID,omitempty"`
+	RobloxClientSecret                   *string  `json:"ROBLOX_CLIENT_SECRET,omitempty"`
+	OrganizationName                     *string  `json:"ORGANIZATION_NAME,omitempty"`
+	OrganizationLogo                     *string  `json:"ORGANIZATION_LOGO,omitempty"`
+	AppCookieSecure                      bool     `json:"APP_COOKIE_SECURE"`
+	AdminCookieSecure                    bool     `json:"ADMIN_COOKIE_SECURE"`
+	DefaultAuthorizeResponseType         *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_TYPE,omitempty"`
+	DefaultAuthorizeResponseMode         *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_MODE,omitempty"`
+	DisablePlayground                    bool     `json:"DISABLE_PLAYGROUND"`
+	DisableMailOtpLogin                  bool     `json:"DISABLE_MAIL_OTP_LOGIN"`
+	DisableTotpLogin                     bool     `json:"DISABLE_TOTP_LOGIN"`
+	EnableLoginCode                      bool     `json:"ENABLE_LOGIN_CODE"`
 }
 
 type Error struct {
@@ -169,9 +217,33 @@ type GetUserRequest struct {
 	Email *string `json:"email,omitempty"`
 }
 
+type Grant struct {
+	ID         string   `json:"id"`
+	ClientID   string   `json:"client_id"`
+	ClientName *string  `json:"client_name,omitempty"`
+	Scopes     []string `json:"scopes"`
+	GrantedAt  *int64   `json:"granted_at,omitempty"`
+}
+
+type GrantRoleInput struct {
+	UserID    string  `json:"user_id"`
+	Role      string  `json:"role"`
+	ExpiresAt *int64  `json:"expires_at,omitempty"`
+	GrantedBy *string `json:"granted_by,omitempty"`
+}
+
+type Identity struct {
+	ID             string  `json:"id"`
+	Provider       string  `json:"provider"`
+	ProviderUserID string  `json:"provider_user_id"`
+	Email          *string `json:"email,omitempty"`
+	LinkedAt       *int64  `json:"linked_at,omitempty"`
+}
+
 type InviteMemberInput struct {
-	Emails      []string `json:"emails"`
-	RedirectURI *string  `json:"redirect_uri,omitempty"`
+	Emails         []string `json:"emails"`
+	RedirectURI    *string  `json:"redirect_uri,omitempty"`
+	OrganizationID *string  `json:"organization_id,omitempty"`
 }
 
 type InviteMembersResponse struct {
@@ -179,6 +251,16 @@ type InviteMembersResponse struct {
 	Users   []*User `json:"Users"`
 }
 
+type LinkIdentityInput struct {
+	Provider    string `json:"provider"`
+	RedirectURI string `json:"redirect_uri"`
+}
+
+type LinkIdentityResponse struct {
+	Message          string `json:"message"`
+	AuthorizationURL string `json:"authorization_url"`
+}
+
 type ListWebhookLogRequest struct {
 	Pagination *PaginationInput `json:"pagination,omitempty"`
 	WebhookID  *string          `json:"webhook_id,omitempty"`
@@ -193,6 +275,15 @@ type LoginInput struct {
 	State       *string  `json:"state,omitempty"`
 }
 
+type LoginWithCodeInput struct {
+	Email       *string  `json:"email,omitempty"`
+	PhoneNumber *string  `json:"phone_number,omitempty"`
+	Code        string   `json:"code"`
+	Roles       []string `json:"roles,omitempty"`
+	Scope       []string `json:"scope,omitempty"`
+	State       *string  `json:"state,omitempty"`
+}
+
 type MagicLinkLoginInput struct {
 	Email       string   `json:"email"`
 	Roles       []string `json:"roles,omitempty"`
@@ -201,6 +292,17 @@ type MagicLinkLoginInput struct {
 	RedirectURI *string  `json:"redirect_uri,omitempty"`
 }
 
+type Membership struct {
+	ID             string        `json:"id"`
+	OrganizationID string        `json:"organization_id"`
+	UserID         string        `json:"user_id"`
+	Roles          []string      `json:"roles"`
+	Organization   *Organization `json:"organization,omitempty"`
+	User           *User         `json:"user,omitempty"`
+	CreatedAt      *int64        `json:"created_at,omitempty"`
+	UpdatedAt      *int64        `json:"updated_at,omitempty"`
+}
+
 type Meta struct {
 	Version                            string `json:"version"`
 	ClientID                           string `json:"client_id"`
@@ -222,6 +324,7 @@ type Meta struct {
 	IsMultiFactorAuthEnabled           bool   `json:"is_multi_factor_auth_enabled"`
 	IsMobileBasicAuthenticationEnabled bool   `json:"is_mobile_basic_authentication_enabled"`
 	IsPhoneVerificationEnabled         bool   `json:"is_phone_verification_enabled"`
+	IsLoginCodeEnabled                 bool   `json:"is_login_code_enabled"`
 }
 
 type MobileLoginInput struct {
@@ -259,6 +362,24 @@ type OAuthRevokeInput struct {
 	RefreshToken string `json:"refresh_token"`
 }
 
+type Organization struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Roles        []string `json:"roles"`
+	DefaultRoles []string `json:"default_roles"`
+	CreatedAt    *int64   `json:"created_at,omitempty"`
+	UpdatedAt    *int64   `json:"updated_at,omitempty"`
+}
+
+type OrganizationRequest struct {
+	ID string `json:"id"`
+}
+
+type Organizations struct {
+	Pagination    *Pagination     `json:"pagination"`
+	Organizations []*Organization `json:"organizations"`
+}
+
 type PaginatedInput struct {
 	Pagination *PaginationInput `json:"pagination,omitempty"`
 }
@@ -275,9 +396,36 @@ type PaginationInput struct {
 	Page  *int64 `json:"page,omitempty"`
 }
 
+type Permission struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	CreatedAt   *int64  `json:"created_at,omitempty"`
+	UpdatedAt   *int64  `json:"updated_at,omitempty"`
+}
+
+type PermissionRequest struct {
+	Name string `json:"name"`
+}
+
+type Permissions struct {
+	Pagination  *Pagination   `json:"pagination"`
+	Permissions []*Permission `json:"permissions"`
+}
+
 type Query struct {
 }
 
+type RemoveOrganizationMemberRequest struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+}
+
+type RequestLoginCodeInput struct {
+	Email       *string `json:"email,omitempty"`
+	PhoneNumber *string `json:"phone_number,omitempty"`
+}
+
 type ResendOTPRequest struct {
 	Email       *string `json:"email,omitempty"`
 	PhoneNumber *string `json:"phone_number,omitempty"`
@@ -302,6 +450,57 @@ type Response struct {
 	Message string `json:"message"`
 }
 
+type RevokeGrantInput struct {
+	ID string `json:"id"`
+}
+
+type RevokeRoleInput struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type RevokeSessionInput struct {
+	ID string `json:"id"`
+}
+
+type Role struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+	CreatedAt   *int64   `json:"created_at,omitempty"`
+	UpdatedAt   *int64   `json:"updated_at,omitempty"`
+}
+
+type RoleChange struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	Action    string `json:"action"`
+	ChangedBy string `json:"changed_by"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+	CreatedAt *int64 `json:"created_at,omitempty"`
+}
+
+type RoleGrant struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	Role      string `json:"role"`
+	GrantedBy string `json:"granted_by"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"`
+	CreatedAt *int64 `json:"created_at,omitempty"`
+	UpdatedAt *int64 `json:"updated_at,omitempty"`
+}
+
+type RoleRequest struct {
+	Name string `json:"name"`
+}
+
+type Roles struct {
+	Pagination *Pagination `json:"pagination"`
+	Roles      []*Role     `json:"roles"`
+}
+
 type SMSVerificationRequests struct {
 	ID            string `json:"id"`
 	Code          string `json:"code"`
@@ -311,6 +510,18 @@ type SMSVerificationRequests struct {
 	UpdatedAt     *int64 `json:"updated_at,omitempty"`
 }
 
+type Session struct {
+	ID           string  `json:"id"`
+	Device       *string `json:"device,omitempty"`
+	UserAgent    *string `json:"user_agent,omitempty"`
+	IP           *string `json:"ip,omitempty"`
+	LoginMethod  *string `json:"login_method,omitempty"`
+	IsCurrent    bool    `json:"is_current"`
+	CreatedAt    *int64  `json:"created_at,omitempty"`
+	LastActiveAt *int64  `json:"last_active_at,omitempty"`
+	ExpiresAt    *int64  `json:"expires_at,omitempty"`
+}
+
 type SessionQueryInput struct {
 	Roles []string `json:"roles,omitempty"`
 	Scope []string `json:"scope,omitempty"`
@@ -336,6 +547,10 @@ type SignUpInput struct {
 	AppData                  map[string]interface{} `json:"app_data,omitempty"`
 }
 
+type SwitchOrganizationInput struct {
+	OrganizationID *string `json:"organization_id,omitempty"`
+}
+
 type TestEndpointRequest struct {
 	Endpoint         string                 `json:"endpoint"`
 	EventName        string                 `json:"event_name"`
@@ -348,6 +563,21 @@ type TestEndpointResponse struct {
 	Response   *string `json:"response,omitempty"`
 }
 
+type TestTokenScriptInput struct {
+	UserID string  `json:"user_id"`
+	Script string  `json:"script"`
+	Type   *string `json:"type,omitempty"`
+}
+
+type TestTokenScriptResponse struct {
+	Claims map[string]interface{} `json:"claims,omitempty"`
+	Error  *string                `json:"error,omitempty"`
+}
+
+type UnlinkIdentityInput struct {
+	ID string `json:"id"`
+}
+
 type UpdateAccessInput struct {
 	UserID string `json:"user_id"`
 }
@@ -361,68 +591,101 @@ type UpdateEmailTemplateRequest struct {
 }
 
 type UpdateEnvInput struct {
-	AccessTokenExpiryTime            *string  `json:"ACCESS_TOKEN_EXPIRY_TIME,omitempty"`
-	AdminSecret                      *string  `json:"ADMIN_SECRET,omitempty"`
-	CustomAccessTokenScript          *string  `json:"CUSTOM_ACCESS_TOKEN_SCRIPT,omitempty"`
-	OldAdminSecret                   *string  `json:"OLD_ADMIN_SECRET,omitempty"`
-	SMTPHost                         *string  `json:"SMTP_HOST,omitempty"`
-	SMTPPort                         *string  `json:"SMTP_PORT,omitempty"`
-	SMTPUsername                     *string  `json:"SMTP_USERNAME,omitempty"`
-	SMTPPassword                     *string  `json:"SMTP_PASSWORD,omitempty"`
-	SMTPLocalName                    *string  `json:"SMTP_LOCAL_NAME,omitempty"`
-	SenderEmail                      *string  `json:"SENDER_EMAIL,omitempty"`
-	SenderName                       *string  `json:"SENDER_NAME,omitempty"`
-	JwtType                          *string  `json:"JWT_TYPE,omitempty"`
-	JwtSecret                        *string  `json:"JWT_SECRET,omitempty"`
-	JwtPrivateKey                    *string  `json:"JWT_PRIVATE_KEY,omitempty"`
-	JwtPublicKey                     *string  `json:"JWT_PUBLIC_KEY,omitempty"`
-	AllowedOrigins                   []string `json:"ALLOWED_ORIGINS,omitempty"`
-	AppURL                           *string  `json:"APP_URL,omitempty"`
-	ResetPasswordURL                 *string  `json:"RESET_PASSWORD_URL,omitempty"`
-	AppCookieSecure                  *bool    `json:"APP_COOKIE_SECURE,omitempty"`
-	AdminCookieSecure                *bool    `json:"ADMIN_COOKIE_SECURE,omitempty"`
-	DisableEmailVerification         *bool    `json:"DISABLE_EMAIL_VERIFICATION,omitempty"`
-	DisableBasicAuthentication       *bool    `json:"DISABLE_BASIC_AUTHENTICATION,omitempty"`
-	DisableMobileBasicAuthentication *bool    `json:"DISABLE_MOBILE_BASIC_AUTHENTICATION,omitempty"`
-	DisableMagicLinkLogin            *bool    `json:"DISABLE_MAGIC_LINK_LOGIN,omitempty"`
-	DisableLoginPage                 *bool    `json:"DISABLE_LOGIN_PAGE,omitempty"`
-	DisableSignUp                    *bool    `json:"DISABLE_SIGN_UP,omitempty"`
-	DisableRedisForEnv               *bool    `json:"DISABLE_REDIS_FOR_ENV,omitempty"`
-	DisableStrongPassword            *bool    `json:"DISABLE_STRONG_PASSWORD,omitempty"`
-	DisableMultiFactorAuthentication *bool    `json:"DISABLE_MULTI_FACTOR_AUTHENTICATION,omitempty"`
-	EnforceMultiFactorAuthentication *bool    `json:"ENFORCE_MULTI_FACTOR_AUTHENTICATION,omitempty"`
-	Roles                            []string `json:"ROLES,omitempty"`
-	ProtectedRoles                   []string `json:"PROTECTED_ROLES,omitempty"`
-	DefaultRoles                     []string `json:"DEFAULT_ROLES,omitempty"`
-	JwtRoleClaim                     *string  `json:"JWT_ROLE_CLAIM,omitempty"`
-	GoogleClientID                   *string  `json:"GOOGLE_CLIENT_ID,omitempty"`
-	GoogleClientSecret               *string  `json:"GOOGLE_CLIENT_SECRET,omitempty"`
-	GithubClientID                   *string  `json:"GITHUB_CLIENT_ID,omitempty"`
-	GithubClientSecret               *string  `json:"GITHUB_CLIENT_SECRET,omitempty"`
-	FacebookClientID                 *string  `json:"FACEBOOK_CLIENT_ID,omitempty"`
-	FacebookClientSecret             *string  `json:"FACEBOOK_CLIENT_SECRET,omitempty"`
-	LinkedinClientID                 *string  `json:"LINKEDIN_CLIENT_ID,omitempty"`
-	LinkedinClientSecret             *string  `json:"LINKEDIN_CLIENT_SECRET,omitempty"`
-	AppleClientID                    *string  `json:"APPLE_CLIENT_ID,omitempty"`
-	AppleClientSecret                *string  `json:"APPLE_CLIENT_SECRET,omitempty"`
-	DiscordClientID                  *string  `json:"DISCORD_CLIENT_ID,omitempty"`
-	DiscordClientSecret              *string  `json:"DISCORD_CLIENT_SECRET,omitempty"`
-	TwitterClientID                  *string  `json:"TWITTER_CLIENT_ID,omitempty"`
-	TwitterClientSecret              *string  `json:"TWITTER_CLIENT_SECRET,omitempty"`
-	MicrosoftClientID                *string  `json:"MICROSOFT_CLIENT_ID,omitempty"`
-	MicrosoftClientSecret            *string  `json:"MICROSOFT_CLIENT_SECRET,omitempty"`
-	MicrosoftActiveDirectoryTenantID *string  `json:"MICROSOFT_ACTIVE_DIRECTORY_TENANT_ID,omitempty"`
-	TwitchClientID                   *string  `json:"TWITCH_CLIENT_ID,omitempty"`
-	TwitchClientSecret               *string  `json:"TWITCH_CLIENT_SECRET,omitempty"`
-	RobloxClientID                   *string  `json:"ROBLOX_CLIENT_ID,omitempty"`
-	RobloxClientSecret               *string  `json:"ROBLOX_CLIENT_SECRET,omitempty"`
-	OrganizationName                 *string  `json:"ORGANIZATION_NAME,omitempty"`
-	OrganizationLogo                 *string  `json:"ORGANIZATION_LOGO,omitempty"`
-	DefaultAuthorizeResponseType     *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_TYPE,omitempty"`
-	DefaultAuthorizeResponseMode     *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_MODE,omitempty"`
-	DisablePlayground                *bool    `json:"DISABLE_PLAYGROUND,omitempty"`
-	DisableMailOtpLogin              *bool    `json:"DISABLE_MAIL_OTP_LOGIN,omitempty"`
-	DisableTotpLogin                 *bool    `json:"DISABLE_TOTP_LOGIN,omitempty"`
+	AccessTokenExpiryTime                *string  `json:"ACCESS_TOKEN_EXPIRY_TIME,omitempty"`
+	SessionExpiryTime                    *string  `json:"SESSION_EXPIRY_TIME,omitempty"`
+	RefreshTokenExpiryTime               *string  `json:"REFRESH_TOKEN_EXPIRY_TIME,omitempty"`
+	SessionIDLeTimeout                   *string  `json:"SESSION_IDLE_TIMEOUT,omitempty"`
+	SessionExpiryTimeByRole              *string  `json:"SESSION_EXPIRY_TIME_BY_ROLE,omitempty"`
+	OtpLength                            *string  `json:"OTP_LENGTH,omitempty"`
+	OtpCharset                           *string  `json:"OTP_CHARSET,omitempty"`
+	OtpExpiryTime                        *string  `json:"OTP_EXPIRY_TIME,omitempty"`
+	OtpMaxAttempts                       *string  `json:"OTP_MAX_ATTEMPTS,omitempty"`
+	AdminSecret                          *string  `json:"ADMIN_SECRET,omitempty"`
+	CustomAccessTokenScript              *string  `json:"CUSTOM_ACCESS_TOKEN_SCRIPT,omitempty"`
+	CustomIDTokenScript                  *string  `json:"CUSTOM_ID_TOKEN_SCRIPT,omitempty"`
+	CustomUserInfoScript                 *string  `json:"CUSTOM_USER_INFO_SCRIPT,omitempty"`
+	TokenExchangePolicy                  *string  `json:"TOKEN_EXCHANGE_POLICY,omitempty"`
+	BackchannelLogoutURI                 *string  `json:"BACKCHANNEL_LOGOUT_URI,omitempty"`
+	ClientRegistrationInitialAccessToken *string  `json:"CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN,omitempty"`
+	CustomScopes                         *string  `json:"CUSTOM_SCOPES,omitempty"`
+	OldAdminSecret                       *string  `json:"OLD_ADMIN_SECRET,omitempty"`
+	SMTPHost                             *string  `json:"SMTP_HOST,omitempty"`
+	SMTPPort                             *string  `json:"SMTP_PORT,omitempty"`
+	SMTPUsername                         *string  `json:"SMTP_USERNAME,omitempty"`
+	SMTPPassword                         *string  `json:"SMTP_PASSWORD,omitempty"`
+	SMTPLocalName                        *string  `json:"SMTP_LOCAL_NAME,omitempty"`
+	SenderEmail                          *string  `json:"SENDER_EMAIL,omitempty"`
+	SenderName                           *string  `json:"SENDER_NAME,omitempty"`
+	JwtType                              *string  `json:"JWT_TYPE,omitempty"`
+	JwtSecret                            *string  `json:"JWT_SECRET,omitempty"`
+	JwtPrivateKey                        *string  `json:"JWT_PRIVATE_KEY,omitempty"`
+	JwtPublicKey                         *string  `json:"JWT_PUBLIC_KEY,omitempty"`
+	AllowedOrigins                       []string `json:"ALLOWED_ORIGINS,omitempty"`
+	AppURL                               *string  `json:"APP_URL,omitempty"`
+	ResetPasswordURL                     *string  `json:"RESET_PASSWORD_URL,omitempty"`
+	AppCookieSecure                      *bool    `json:"APP_COOKIE_SECURE,omitempty"`
+	AdminCookieSecure                    *bool    `json:"ADMIN_COOKIE_SECURE,omitempty"`
+	DisableEmailVerification             *bool    `json:"DISABLE_EMAIL_VERIFICATION,omitempty"`
+	DisableBasicAuthentication           *bool    `json:"DISABLE_BASIC_AUTHENTICATION,omitempty"`
+	DisableMobileBasicAuthentication     *bool    `json:"DISABLE_MOBILE_BASIC_AUTHENTICATION,omitempty"`
+	DisableMagicLinkLogin                *bool    `json:"DISABLE_MAGIC_LINK_LOGIN,omitempty"`
+	DisableLoginPage                     *bool    `json:"DISABLE_LOGIN_PAGE,omitempty"`
+	DisableSignUp                        *bool    `json:"DISABLE_SIGN_UP,omitempty"`
+	DisableRedisForEnv                   *bool    `json:"DISABLE_REDIS_FOR_ENV,omitempty"`
+	DisableStrongPassword                *bool    `json:"DISABLE_STRONG_PASSWORD,omitempty"`
+	DisableMultiFactorAuthentication     *bool    `json:"DISABLE_MULTI_FACTOR_AUTHENTICATION,omitempty"`
+	EnforceMultiFactorAuthentication     *bool    `json:"ENFORCE_MULTI_FACTOR_AUTHENTICATION,omitempty"`
+	Roles                                []string `json:"ROLES,omitempty"`
+	ProtectedRoles                       []string `json:"PROTECTED_ROLES,omitempty"`
+	DefaultRoles                         []string `json:"DEFAULT_ROLES,omitempty"`
+	JwtRoleClaim                         *string  `json:"JWT_ROLE_CLAIM,omitempty"`
+	GoogleClientID                       *string  `json:"GOOGLE_CLIENT_ID,omitempty"`
+	GoogleClientSecret                   *string  `json:"GOOGLE_CLIENT_SECRET,omitempty"`
+	GithubClientID                       *string  `json:"GITHUB_CLIENT_ID,omitempty"`
+	GithubClientSecret                   *string  `json:"GITHUB_CLIENT_SECRET,omitempty"`
+	FacebookClientID                     *string  `json:"FACEBOOK_CLIENT_ID,omitempty"`
+	FacebookClientSecret                 *string  `json:"FACEBOOK_CLIENT_SECRET,omitempty"`
+	LinkedinClientID                     *string  `json:"LINKEDIN_CLIENT_ID,omitempty"`
+	LinkedinClientSecret                 *string  `json:"LINKEDIN_CLIENT_SECRET,omitempty"`
+	AppleClientID                        *string  `json:"APPLE_CLIENT_ID,omitempty"`
+	AppleClientSecret                    *string  `json:"APPLE_CLIENT_SECRET,omitempty"`
+	DiscordClientID                      *string  `json:"DISCORD_CLIENT_ID,omitempty"`
+	DiscordClientSecret                  *string  `json:"DISCORD_CLIENT_SECRET,omitempty"`
+	TwitterClientID                      *string  `json:"TWITTER_CLIENT_ID,omitempty"`
+	TwitterClientSecret                  *string  `json:"TWITTER_CLIENT_SECRET,omitempty"`
+	MicrosoftClientID                    *string  `json:"MICROSOFT_CLIENT_ID,omitempty"`
+	MicrosoftClientSecret                *string  `json:"MICROSOFT_CLIENT_SECRET,omitempty"`
+	MicrosoftActiveDirectoryTenantID     *string  `json:"MICROSOFT_ACTIVE_DIRECTORY_TENANT_ID,omitempty"`
+	TwitchClientID                       *string  `json:"TWITCH_CLIENT_ID,omitempty"`
+	TwitchClientSecret                   *string  `json:"TWITCH_CLIENT_SECRET,omitempty"`
+	RobloxClientID                       *string  `json:"ROBLOX_CLIENT_ID,omitempty"`
+	RobloxClientSecret                   *string  `json:"ROBLOX_CLIENT_SECRET,omitempty"`
+	OrganizationName                     *string  `json:"ORGANIZATION_NAME,omitempty"`
+	OrganizationLogo                     *string  `json:"ORGANIZATION_LOGO,omitempty"`
+	DefaultAuthorizeResponseType         *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_TYPE,omitempty"`
+	DefaultAuthorizeResponseMode         *string  `json:"DEFAULT_AUTHORIZE_RESPONSE_MODE,omitempty"`
+	DisablePlayground                    *bool    `json:"DISABLE_PLAYGROUND,omitempty"`
+	DisableMailOtpLogin                  *bool    `json:"DISABLE_MAIL_OTP_LOGIN,omitempty"`
+	DisableTotpLogin                     *bool    `json:"DISABLE_TOTP_LOGIN,omitempty"`
+	EnableLoginCode                      *bool    `json:"ENABLE_LOGIN_CODE,omitempty"`
+}
+
+type UpdateOrganizationMemberRequest struct {
+	OrganizationID string   `json:"organization_id"`
+	UserID         string   `json:"user_id"`
+	Roles          []string `json:"roles"`
+}
+
+type UpdateOrganizationRequest struct {
+	ID           string   `json:"id"`
+	Name         *string  `json:"name,omitempty"`
+	Roles        []string `json:"roles,omitempty"`
+	DefaultRoles []string `json:"default_roles,omitempty"`
+}
+
+type UpdatePermissionRequest struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
 }
 
 type UpdateProfileInput struct {
@@ -442,6 +705,12 @@ type UpdateProfileInput struct {
 	AppData                  map[string]interface{} `json:"app_data,omitempty"`
 }
 
+type UpdateRoleRequest struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
 type UpdateUserInput struct {
 	ID                       string                 `json:"id"`
 	Email                    *string                `json:"email,omitempty"`
@@ -490,6 +759,11 @@ type User struct {
 	RevokedTimestamp         *int64                 `json:"revoked_timestamp,omitempty"`
 	IsMultiFactorAuthEnabled *bool                  `json:"is_multi_factor_auth_enabled,omitempty"`
 	AppData                  map[string]interface{} `json:"app_data,omitempty"`
+	Identities               []*Identity            `json:"identities,omitempty"`
+}
+
+type UserRolesRequest struct {
+	UserID string `json:"user_id"`
 }
 
 type Users struct {
@@ -498,9 +772,12 @@ type Users struct {
 }
 
 type ValidateJWTTokenInput struct {
-	TokenType string   `json:"token_type"`
-	Token     string   `json:"token"`
-	Roles     []string `json:"roles,omitempty"`
+	TokenType  string   `json:"token_type"`
+	Token      string   `json:"token"`
+	Roles      []string `json:"roles,omitempty"`
+	DpopProof  *string  `json:"dpop_proof,omitempty"`
+	HTTPMethod *string  `json:"http_method,omitempty"`
+	HTTPURL    *string  `json:"http_url,omitempty"`
 }
 
 type ValidateJWTTokenResponse struct {
@@ -548,6 +825,11 @@ type VerifyOTPRequest struct {
 	State       *string `json:"state,omitempty"`
 }
 
+type VerifyPhoneNumberChangeInput struct {
+	PhoneNumber string `json:"phone_number"`
+	Otp         string `json:"otp"`
+}
+
 type Webhook struct {
 	ID               string                 `json:"id"`
 	EventName        *string                `json:"event_name,omitempty"`
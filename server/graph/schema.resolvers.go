@@ -37,6 +37,16 @@ func (r *mutationResolver) MagicLinkLogin(ctx context.Context, params model.Magi
 	return resolvers.MagicLinkLoginResolver(ctx, params)
 }
 
+// RequestLoginCode is the resolver for the request_login_code field.
+func (r *mutationResolver) RequestLoginCode(ctx context.Context, params model.RequestLoginCodeInput) (*model.Response, error) {
+	return resolvers.RequestLoginCodeResolver(ctx, params)
+}
+
+// LoginWithCode is the resolver for the login_with_code field.
+func (r *mutationResolver) LoginWithCode(ctx context.Context, params model.LoginWithCodeInput) (*model.AuthResponse, error) {
+	return resolvers.LoginWithCodeResolver(ctx, params)
+}
+
 // Logout is the resolver for the logout field.
 func (r *mutationResolver) Logout(ctx context.Context) (*model.Response, error) {
 	return resolvers.LogoutResolver(ctx)
@@ -47,6 +57,11 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, params model.Updat
 	return resolvers.UpdateProfileResolver(ctx, params)
 }
 
+// VerifyPhoneNumberChange is the resolver for the verify_phone_number_change field.
+func (r *mutationResolver) VerifyPhoneNumberChange(ctx context.Context, params model.VerifyPhoneNumberChangeInput) (*model.Response, error) {
+	return resolvers.VerifyPhoneNumberChangeResolver(ctx, params)
+}
+
 // VerifyEmail is the resolver for the verify_email field.
 func (r *mutationResolver) VerifyEmail(ctx context.Context, params model.VerifyEmailInput) (*model.AuthResponse, error) {
 	return resolvers.VerifyEmailResolver(ctx, params)
@@ -87,6 +102,31 @@ func (r *mutationResolver) DeactivateAccount(ctx context.Context) (*model.Respon
 	return resolvers.DeactivateAccountResolver(ctx)
 }
 
+// LinkIdentity is the resolver for the link_identity field.
+func (r *mutationResolver) LinkIdentity(ctx context.Context, params model.LinkIdentityInput) (*model.LinkIdentityResponse, error) {
+	return resolvers.LinkIdentityResolver(ctx, params)
+}
+
+// UnlinkIdentity is the resolver for the unlink_identity field.
+func (r *mutationResolver) UnlinkIdentity(ctx context.Context, params model.UnlinkIdentityInput) (*model.Response, error) {
+	return resolvers.UnlinkIdentityResolver(ctx, params)
+}
+
+// RevokeSession is the resolver for the revoke_session field.
+func (r *mutationResolver) RevokeSession(ctx context.Context, params model.RevokeSessionInput) (*model.Response, error) {
+	return resolvers.RevokeSessionResolver(ctx, params)
+}
+
+// RevokeGrant is the resolver for the revoke_grant field.
+func (r *mutationResolver) RevokeGrant(ctx context.Context, params model.RevokeGrantInput) (*model.Response, error) {
+	return resolvers.RevokeGrantResolver(ctx, params)
+}
+
+// SwitchOrganization is the resolver for the switch_organization field.
+func (r *mutationResolver) SwitchOrganization(ctx context.Context, params model.SwitchOrganizationInput) (*model.AuthResponse, error) {
+	return resolvers.SwitchOrganizationResolver(ctx, params)
+}
+
 // DeleteUser is the resolver for the _delete_user field.
 func (r *mutationResolver) DeleteUser(ctx context.Context, params model.DeleteUserInput) (*model.Response, error) {
 	return resolvers.DeleteUserResolver(ctx, params)
@@ -172,6 +212,81 @@ func (r *mutationResolver) DeleteEmailTemplate(ctx context.Context, params model
 	return resolvers.DeleteEmailTemplateResolver(ctx, params)
 }
 
+// RevokeUserSession is the resolver for the _revoke_user_session field.
+func (r *mutationResolver) RevokeUserSession(ctx context.Context, params model.RevokeSessionInput) (*model.Response, error) {
+	return resolvers.RevokeUserSessionResolver(ctx, params)
+}
+
+// AddOrganization is the resolver for the _add_organization field.
+func (r *mutationResolver) AddOrganization(ctx context.Context, params model.AddOrganizationRequest) (*model.Organization, error) {
+	return resolvers.AddOrganizationResolver(ctx, params)
+}
+
+// UpdateOrganization is the resolver for the _update_organization field.
+func (r *mutationResolver) UpdateOrganization(ctx context.Context, params model.UpdateOrganizationRequest) (*model.Organization, error) {
+	return resolvers.UpdateOrganizationResolver(ctx, params)
+}
+
+// DeleteOrganization is the resolver for the _delete_organization field.
+func (r *mutationResolver) DeleteOrganization(ctx context.Context, params model.OrganizationRequest) (*model.Response, error) {
+	return resolvers.DeleteOrganizationResolver(ctx, params)
+}
+
+// AddOrganizationMember is the resolver for the _add_organization_member field.
+func (r *mutationResolver) AddOrganizationMember(ctx context.Context, params model.AddOrganizationMemberRequest) (*model.Membership, error) {
+	return resolvers.AddOrganizationMemberResolver(ctx, params)
+}
+
+// UpdateOrganizationMember is the resolver for the _update_organization_member field.
+func (r *mutationResolver) UpdateOrganizationMember(ctx context.Context, params model.UpdateOrganizationMemberRequest) (*model.Membership, error) {
+	return resolvers.UpdateOrganizationMemberResolver(ctx, params)
+}
+
+// RemoveOrganizationMember is the resolver for the _remove_organization_member field.
+func (r *mutationResolver) RemoveOrganizationMember(ctx context.Context, params model.RemoveOrganizationMemberRequest) (*model.Response, error) {
+	return resolvers.RemoveOrganizationMemberResolver(ctx, params)
+}
+
+// AddRole is the resolver for the _add_role field.
+func (r *mutationResolver) AddRole(ctx context.Context, params model.AddRoleRequest) (*model.Role, error) {
+	return resolvers.AddRoleResolver(ctx, params)
+}
+
+// UpdateRole is the resolver for the _update_role field.
+func (r *mutationResolver) UpdateRole(ctx context.Context, params model.UpdateRoleRequest) (*model.Role, error) {
+	return resolvers.UpdateRoleResolver(ctx, params)
+}
+
+// DeleteRole is the resolver for the _delete_role field.
+func (r *mutationResolver) DeleteRole(ctx context.Context, params model.RoleRequest) (*model.Response, error) {
+	return resolvers.DeleteRoleResolver(ctx, params)
+}
+
+// AddPermission is the resolver for the _add_permission field.
+func (r *mutationResolver) AddPermission(ctx context.Context, params model.AddPermissionRequest) (*model.Permission, error) {
+	return resolvers.AddPermissionResolver(ctx, params)
+}
+
+// UpdatePermission is the resolver for the _update_permission field.
+func (r *mutationResolver) UpdatePermission(ctx context.Context, params model.UpdatePermissionRequest) (*model.Permission, error) {
+	return resolvers.UpdatePermissionResolver(ctx, params)
+}
+
+// DeletePermission is the resolver for the _delete_permission field.
+func (r *mutationResolver) DeletePermission(ctx context.Context, params model.PermissionRequest) (*model.Response, error) {
+	return resolvers.DeletePermissionResolver(ctx, params)
+}
+
+// GrantRole is the resolver for the _grant_role field.
+func (r *mutationResolver) GrantRole(ctx context.Context, params model.GrantRoleInput) (*model.RoleGrant, error) {
+	return resolvers.GrantRoleResolver(ctx, params)
+}
+
+// RevokeRole is the resolver for the _revoke_role field.
+func (r *mutationResolver) RevokeRole(ctx context.Context, params model.RevokeRoleInput) (*model.Response, error) {
+	return resolvers.RevokeRoleResolver(ctx, params)
+}
+
 // Meta is the resolver for the meta field.
 func (r *queryResolver) Meta(ctx context.Context) (*model.Meta, error) {
 	return resolvers.MetaResolver(ctx)
@@ -197,6 +312,26 @@ func (r *queryResolver) ValidateSession(ctx context.Context, params *model.Valid
 	return resolvers.ValidateSessionResolver(ctx, params)
 }
 
+// MySessions is the resolver for the my_sessions field.
+func (r *queryResolver) MySessions(ctx context.Context) ([]*model.Session, error) {
+	return resolvers.MySessionsResolver(ctx)
+}
+
+// MyGrants is the resolver for the my_grants field.
+func (r *queryResolver) MyGrants(ctx context.Context) ([]*model.Grant, error) {
+	return resolvers.MyGrantsResolver(ctx)
+}
+
+// MyOrganizations is the resolver for the my_organizations field.
+func (r *queryResolver) MyOrganizations(ctx context.Context) ([]*model.Membership, error) {
+	return resolvers.MyOrganizationsResolver(ctx)
+}
+
+// CheckPermission is the resolver for the check_permission field.
+func (r *queryResolver) CheckPermission(ctx context.Context, params model.CheckPermissionInput) (*model.CheckPermissionResponse, error) {
+	return resolvers.CheckPermissionResolver(ctx, params)
+}
+
 // Users is the resolver for the _users field.
 func (r *queryResolver) Users(ctx context.Context, params *model.PaginatedInput) (*model.Users, error) {
 	return resolvers.UsersResolver(ctx, params)
@@ -222,6 +357,11 @@ func (r *queryResolver) Env(ctx context.Context) (*model.Env, error) {
 	return resolvers.EnvResolver(ctx)
 }
 
+// TestTokenScript is the resolver for the _test_token_script field.
+func (r *queryResolver) TestTokenScript(ctx context.Context, params model.TestTokenScriptInput) (*model.TestTokenScriptResponse, error) {
+	return resolvers.TestTokenScriptResolver(ctx, params)
+}
+
 // Webhook is the resolver for the _webhook field.
 func (r *queryResolver) Webhook(ctx context.Context, params model.WebhookRequest) (*model.Webhook, error) {
 	return resolvers.WebhookResolver(ctx, params)
@@ -242,6 +382,46 @@ func (r *queryResolver) EmailTemplates(ctx context.Context, params *model.Pagina
 	return resolvers.EmailTemplatesResolver(ctx, params)
 }
 
+// UserSessions is the resolver for the _user_sessions field.
+func (r *queryResolver) UserSessions(ctx context.Context, params model.GetUserRequest) ([]*model.Session, error) {
+	return resolvers.UserSessionsResolver(ctx, params)
+}
+
+// Organizations is the resolver for the _organizations field.
+func (r *queryResolver) Organizations(ctx context.Context, params *model.PaginatedInput) (*model.Organizations, error) {
+	return resolvers.OrganizationsResolver(ctx, params)
+}
+
+// Organization is the resolver for the _organization field.
+func (r *queryResolver) Organization(ctx context.Context, params model.OrganizationRequest) (*model.Organization, error) {
+	return resolvers.OrganizationResolver(ctx, params)
+}
+
+// OrganizationMembers is the resolver for the _organization_members field.
+func (r *queryResolver) OrganizationMembers(ctx context.Context, params model.OrganizationRequest) ([]*model.Membership, error) {
+	return resolvers.OrganizationMembersResolver(ctx, params)
+}
+
+// Roles is the resolver for the _roles field.
+func (r *queryResolver) Roles(ctx context.Context, params *model.PaginatedInput) (*model.Roles, error) {
+	return resolvers.RolesResolver(ctx, params)
+}
+
+// Permissions is the resolver for the _permissions field.
+func (r *queryResolver) Permissions(ctx context.Context, params *model.PaginatedInput) (*model.Permissions, error) {
+	return resolvers.PermissionsResolver(ctx, params)
+}
+
+// UserRoleGrants is the resolver for the _user_role_grants field.
+func (r *queryResolver) UserRoleGrants(ctx context.Context, params model.UserRolesRequest) ([]*model.RoleGrant, error) {
+	return resolvers.UserRoleGrantsResolver(ctx, params)
+}
+
+// UserRoleHistory is the resolver for the _user_role_history field.
+func (r *queryResolver) UserRoleHistory(ctx context.Context, params model.UserRolesRequest) ([]*model.RoleChange, error) {
+	return resolvers.UserRoleHistoryResolver(ctx, params)
+}
+
 // Mutation returns generated.MutationResolver implementation.
 func (r *Resolver) Mutation() generated.MutationResolver { return &mutationResolver{r} }
 
@@ -34,6 +34,7 @@ jargons
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
@@ -44,8 +45,10 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
 )
 
 // Check the flow for generating and verifying codes: https://developer.okta.com/blog/2019/08/22/okta-authjs-pkce#:~:text=PKCE%20works%20by%20having%20the,is%20called%20the%20Code%20Challenge.
@@ -55,6 +58,7 @@ import (
 const (
 	authorizeWebMessageTemplate = "authorize_web_message.tmpl"
 	authorizeFormPostTemplate   = "authorize_form_post.tmpl"
+	authorizeConsentTemplate    = "authorize_consent.tmpl"
 	baseAppPath                 = "/app"
 	signupPath                  = "/app/signup"
 )
@@ -68,281 +72,346 @@ const (
 // code_challenge_method = to prevent CSRF attack [only sh256 is supported]
 func AuthorizeHandler() gin.HandlerFunc {
 	return func(gc *gin.Context) {
-		redirectURI := strings.TrimSpace(gc.Query("redirect_uri"))
-		responseType := strings.TrimSpace(gc.Query("response_type"))
-		state := strings.TrimSpace(gc.Query("state"))
-		codeChallenge := strings.TrimSpace(gc.Query("code_challenge"))
-		scopeString := strings.TrimSpace(gc.Query("scope"))
-		clientID := strings.TrimSpace(gc.Query("client_id"))
-		responseMode := strings.TrimSpace(gc.Query("response_mode"))
-		nonce := strings.TrimSpace(gc.Query("nonce"))
-		screenHint := strings.TrimSpace(gc.Query("screen_hint"))
-
-		var scope []string
-		if scopeString == "" {
-			scope = []string{"openid", "profile", "email"}
-		} else {
-			scope = strings.Split(scopeString, " ")
+		// params can be pushed via back channel (request_uri) or sent as signed request object
+		params, client, err := getAuthorizeRequestParams(gc)
+		if err != nil {
+			log.Debug("invalid authorization request: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
 		}
+		authorize(gc, params, client)
+	}
+}
 
-		if responseMode == "" {
-			if val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultAuthorizeResponseMode); err == nil {
-				responseMode = val
-			} else {
-				responseMode = constants.ResponseModeQuery
-			}
-		}
+// authorize handles the authorization request with params resolved from
+// query string, pushed authorization request or request object
+func authorize(gc *gin.Context, params url.Values, client *models.Client) {
+	redirectURI := strings.TrimSpace(params.Get("redirect_uri"))
+	responseType := strings.TrimSpace(params.Get("response_type"))
+	state := strings.TrimSpace(params.Get("state"))
+	codeChallenge := strings.TrimSpace(params.Get("code_challenge"))
+	scopeString := strings.TrimSpace(params.Get("scope"))
+	clientID := strings.TrimSpace(params.Get("client_id"))
+	responseMode := strings.TrimSpace(params.Get("response_mode"))
+	nonce := strings.TrimSpace(params.Get("nonce"))
+	screenHint := strings.TrimSpace(params.Get("screen_hint"))
+
+	var scope []string
+	if scopeString == "" {
+		scope = []string{"openid", "profile", "email"}
+	} else {
+		scope = strings.Split(scopeString, " ")
+	}
 
-		if redirectURI == "" {
-			redirectURI = "/app"
+	if responseMode == "" {
+		if val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultAuthorizeResponseMode); err == nil {
+			responseMode = val
+		} else {
+			responseMode = constants.ResponseModeQuery
 		}
+	}
 
-		if responseType == "" {
-			if val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultAuthorizeResponseType); err == nil {
-				responseType = val
-			} else {
-				responseType = constants.ResponseTypeToken
-			}
+	if responseType == "" {
+		if val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultAuthorizeResponseType); err == nil {
+			responseType = val
+		} else {
+			responseType = constants.ResponseTypeToken
 		}
+	}
+
+	if err := validateAuthorizeRequest(responseType, responseMode, state, codeChallenge); err != nil {
+		log.Debug("invalid authorization request: ", err)
+		gc.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 
-		if err := validateAuthorizeRequest(responseType, responseMode, clientID, state, codeChallenge); err != nil {
+	// dynamically registered clients can only use their registered redirect uris
+	if client != nil {
+		if redirectURIs := client.GetRedirectURIs(); redirectURI == "" && len(redirectURIs) == 1 {
+			redirectURI = redirectURIs[0]
+		}
+		if err := validateClientAuthorizeRequest(client, responseType, redirectURI, scope); err != nil {
 			log.Debug("invalid authorization request: ", err)
 			gc.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 			return
 		}
+	}
 
-		code := uuid.New().String()
-		if nonce == "" {
-			nonce = uuid.New().String()
-		}
+	if redirectURI == "" {
+		redirectURI = "/app"
+	}
 
-		log := log.WithFields(log.Fields{
-			"response_mode": responseMode,
-			"response_type": responseType,
-		})
+	code := uuid.New().String()
+	if nonce == "" {
+		nonce = uuid.New().String()
+	}
 
-		// TODO add state with timeout
-		// used for response mode query or fragment
-		authState := "state=" + state + "&scope=" + scopeString + "&redirect_uri=" + redirectURI
-		if responseType == constants.ResponseTypeCode {
-			authState += "&code=" + code
-			if err := memorystore.Provider.SetState(state, code+"@@"+codeChallenge); err != nil {
-				log.Debug("Error setting temp code", err)
-			}
-		} else {
-			authState += "&nonce=" + nonce
-			if err := memorystore.Provider.SetState(state, nonce); err != nil {
-				log.Debug("Error setting temp code", err)
+	log := log.WithFields(log.Fields{
+		"response_mode": responseMode,
+		"response_type": responseType,
+	})
+
+	// TODO add state with timeout
+	// used for response mode query or fragment
+	authState := "state=" + state + "&scope=" + scopeString + "&redirect_uri=" + redirectURI
+	if responseType == constants.ResponseTypeCode {
+		authState += "&code=" + code
+		if err := memorystore.Provider.SetState(state, code+"@@"+codeChallenge); err != nil {
+			log.Debug("Error setting temp code", err)
+		}
+		if client != nil {
+			if err := memorystore.Provider.SetState(codeClientStateKey(code), clientID); err != nil {
+				log.Debug("Error setting code client", err)
 			}
 		}
+	} else {
+		authState += "&nonce=" + nonce
+		if err := memorystore.Provider.SetState(state, nonce); err != nil {
+			log.Debug("Error setting temp code", err)
+		}
+	}
 
-		authURL := baseAppPath + "?" + authState
+	authURL := baseAppPath + "?" + authState
 
-		if screenHint == constants.ScreenHintSignUp {
-			authURL = signupPath + "?" + authState
-		}
+	if screenHint == constants.ScreenHintSignUp {
+		authURL = signupPath + "?" + authState
+	}
 
-		if responseMode == constants.ResponseModeFragment && screenHint == constants.ScreenHintSignUp {
-			authURL = signupPath + "#" + authState
-		} else if responseMode == constants.ResponseModeFragment {
-			authURL = baseAppPath + "#" + authState
-		}
+	if responseMode == constants.ResponseModeFragment && screenHint == constants.ScreenHintSignUp {
+		authURL = signupPath + "#" + authState
+	} else if responseMode == constants.ResponseModeFragment {
+		authURL = baseAppPath + "#" + authState
+	}
 
-		if responseType == constants.ResponseTypeCode && codeChallenge == "" {
-			handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
-				"type": "authorization_response",
-				"response": map[string]interface{}{
-					"error":             "code_challenge_required",
-					"error_description": "code challenge is required",
-				},
-			}, http.StatusOK)
-			return
-		}
+	if responseType == constants.ResponseTypeCode && codeChallenge == "" {
+		handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
+			"type": "authorization_response",
+			"response": map[string]interface{}{
+				"error":             "code_challenge_required",
+				"error_description": "code challenge is required",
+			},
+		}, http.StatusOK)
+		return
+	}
+
+	loginError := map[string]interface{}{
+		"type": "authorization_response",
+		"response": map[string]interface{}{
+			"error":             "login_required",
+			"error_description": "Login is required",
+		},
+	}
+	sessionToken, err := cookie.GetSession(gc)
+	if err != nil {
+		log.Debug("GetSession failed: ", err)
+		handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+		return
+	}
+
+	// get session from cookie
+	claims, err := token.ValidateBrowserSession(gc, sessionToken)
+	if err != nil {
+		log.Debug("ValidateBrowserSession failed: ", err)
+		handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+		return
+	}
 
-		loginError := map[string]interface{}{
+	userID := claims.Subject
+	user, err := db.Provider.GetUserByID(gc, userID)
+	if err != nil {
+		log.Debug("GetUserByID failed: ", err)
+		handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
 			"type": "authorization_response",
 			"response": map[string]interface{}{
-				"error":             "login_required",
-				"error_description": "Login is required",
+				"error":             "signup_required",
+				"error_description": "Sign up required",
 			},
-		}
-		sessionToken, err := cookie.GetSession(gc)
-		if err != nil {
-			log.Debug("GetSession failed: ", err)
-			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+		}, http.StatusOK)
+		return
+	}
+
+	// third party clients can only get the scopes user has consented to
+	if client != nil {
+		if grant, err := db.Provider.GetGrantByUserIDAndClientID(gc, user.ID, client.ClientID); err != nil || grant == nil || !grant.HasScopes(scope) {
+			if responseMode == constants.ResponseModeWebMessage {
+				handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
+					"type": "authorization_response",
+					"response": map[string]interface{}{
+						"error":             "consent_required",
+						"error_description": "Consent is required",
+					},
+				}, http.StatusOK)
+				return
+			}
+			consentParams, _ := url.ParseQuery(params.Encode())
+			consentParams.Set("redirect_uri", redirectURI)
+			consentParams.Set("response_type", responseType)
+			consentParams.Set("response_mode", responseMode)
+			consentParams.Set("scope", strings.Join(scope, " "))
+			showConsent(gc, client, user.ID, scope, consentParams)
 			return
 		}
+	}
+
+	sessionKey := user.ID
+	if claims.LoginMethod != "" {
+		sessionKey = claims.LoginMethod + ":" + user.ID
+	}
 
-		// get session from cookie
-		claims, err := token.ValidateBrowserSession(gc, sessionToken)
+	// rollover the session for security
+	go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+	if responseType == constants.ResponseTypeCode {
+		newSessionTokenData, newSessionToken, newSessionExpiresAt, err := token.CreateSessionToken(user, nonce, claims.Roles, scope, claims.LoginMethod, token.AuthTokenOptions{
+			AuthTime:       claims.AuthTime,
+			OrganizationID: claims.OrganizationID,
+		})
 		if err != nil {
-			log.Debug("ValidateBrowserSession failed: ", err)
+			log.Debug("CreateSessionToken failed: ", err)
 			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
 			return
 		}
 
-		userID := claims.Subject
-		user, err := db.Provider.GetUserByID(gc, userID)
-		if err != nil {
-			log.Debug("GetUserByID failed: ", err)
-			handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
-				"type": "authorization_response",
-				"response": map[string]interface{}{
-					"error":             "signup_required",
-					"error_description": "Sign up required",
-				},
-			}, http.StatusOK)
+		// TODO: add state with timeout
+		// if err := memorystore.Provider.SetState(codeChallenge, code+"@"+newSessionToken); err != nil {
+		// 	log.Debug("SetState failed: ", err)
+		// 	handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+		// 	return
+		// }
+
+		// TODO: add state with timeout
+		if err := memorystore.Provider.SetState(code, codeChallenge+"@@"+newSessionToken); err != nil {
+			log.Debug("SetState failed: ", err)
+			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
 			return
 		}
 
-		sessionKey := user.ID
-		if claims.LoginMethod != "" {
-			sessionKey = claims.LoginMethod + ":" + user.ID
+		if err := memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+newSessionTokenData.Nonce, newSessionToken, newSessionExpiresAt); err != nil {
+			log.Debug("SetUserSession failed: ", err)
+			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+			return
 		}
 
-		// rollover the session for security
-		go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
-		if responseType == constants.ResponseTypeCode {
-			newSessionTokenData, newSessionToken, newSessionExpiresAt, err := token.CreateSessionToken(user, nonce, claims.Roles, scope, claims.LoginMethod)
-			if err != nil {
-				log.Debug("CreateSessionToken failed: ", err)
-				handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-				return
-			}
-
-			// TODO: add state with timeout
-			// if err := memorystore.Provider.SetState(codeChallenge, code+"@"+newSessionToken); err != nil {
-			// 	log.Debug("SetState failed: ", err)
-			// 	handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-			// 	return
-			// }
-
-			// TODO: add state with timeout
-			if err := memorystore.Provider.SetState(code, codeChallenge+"@@"+newSessionToken); err != nil {
-				log.Debug("SetState failed: ", err)
-				handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-				return
+		cookie.SetSession(gc, newSessionToken)
+		go utils.RolloverSession(gc, claims.Nonce, newSessionTokenData.Nonce, newSessionExpiresAt)
+
+		// in case, response type is code and user is already logged in send the code and state
+		// and cookie session will already be rolled over and set
+		// gc.HTML(http.StatusOK, authorizeWebMessageTemplate, gin.H{
+		// 	"target_origin": redirectURI,
+		// 	"authorization_response": map[string]interface{}{
+		// 		"type": "authorization_response",
+		// 		"response": map[string]string{
+		// 			"code":  code,
+		// 			"state": state,
+		// 		},
+		// 	},
+		// })
+
+		params := "code=" + code + "&state=" + state + "&nonce=" + nonce
+		if responseMode == constants.ResponseModeQuery {
+			if strings.Contains(redirectURI, "?") {
+				redirectURI = redirectURI + "&" + params
+			} else {
+				redirectURI = redirectURI + "?" + params
 			}
-
-			if err := memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+newSessionTokenData.Nonce, newSessionToken, newSessionExpiresAt); err != nil {
-				log.Debug("SetUserSession failed: ", err)
-				handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-				return
+		} else if responseMode == constants.ResponseModeFragment {
+			if strings.Contains(redirectURI, "#") {
+				redirectURI = redirectURI + "&" + params
+			} else {
+				redirectURI = redirectURI + "#" + params
 			}
+		}
 
-			cookie.SetSession(gc, newSessionToken)
-
-			// in case, response type is code and user is already logged in send the code and state
-			// and cookie session will already be rolled over and set
-			// gc.HTML(http.StatusOK, authorizeWebMessageTemplate, gin.H{
-			// 	"target_origin": redirectURI,
-			// 	"authorization_response": map[string]interface{}{
-			// 		"type": "authorization_response",
-			// 		"response": map[string]string{
-			// 			"code":  code,
-			// 			"state": state,
-			// 		},
-			// 	},
-			// })
-
-			params := "code=" + code + "&state=" + state + "&nonce=" + nonce
-			if responseMode == constants.ResponseModeQuery {
-				if strings.Contains(redirectURI, "?") {
-					redirectURI = redirectURI + "&" + params
-				} else {
-					redirectURI = redirectURI + "?" + params
-				}
-			} else if responseMode == constants.ResponseModeFragment {
-				if strings.Contains(redirectURI, "#") {
-					redirectURI = redirectURI + "&" + params
-				} else {
-					redirectURI = redirectURI + "#" + params
-				}
-			}
+		handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
+			"type": "authorization_response",
+			"response": map[string]interface{}{
+				"code":  code,
+				"state": state,
+			},
+		}, http.StatusOK)
 
-			handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
-				"type": "authorization_response",
-				"response": map[string]interface{}{
-					"code":  code,
-					"state": state,
-				},
-			}, http.StatusOK)
+		return
+	}
 
+	if responseType == constants.ResponseTypeToken || responseType == constants.ResponseTypeIDToken {
+		// rollover the session for security
+		authTokenOptions := token.AuthTokenOptions{
+			AuthTime:       claims.AuthTime,
+			OrganizationID: claims.OrganizationID,
+		}
+		if client != nil {
+			authTokenOptions.ClientID = client.ClientID
+		}
+		authToken, err := token.CreateAuthTokenWithOptions(gc, user, claims.Roles, scope, claims.LoginMethod, nonce, "", authTokenOptions)
+		if err != nil {
+			log.Debug("CreateAuthToken failed: ", err)
+			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
 			return
 		}
 
-		if responseType == constants.ResponseTypeToken || responseType == constants.ResponseTypeIDToken {
-			// rollover the session for security
-			authToken, err := token.CreateAuthToken(gc, user, claims.Roles, scope, claims.LoginMethod, nonce, "")
-			if err != nil {
-				log.Debug("CreateAuthToken failed: ", err)
-				handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-				return
-			}
+		if err := memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+nonce, authToken.FingerPrintHash, authToken.SessionTokenExpiresAt); err != nil {
+			log.Debug("SetUserSession failed: ", err)
+			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+			return
+		}
 
-			if err := memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+nonce, authToken.FingerPrintHash, authToken.SessionTokenExpiresAt); err != nil {
-				log.Debug("SetUserSession failed: ", err)
-				handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-				return
-			}
+		if err := memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+nonce, authToken.AccessToken.Token, authToken.AccessToken.ExpiresAt); err != nil {
+			log.Debug("SetUserSession failed: ", err)
+			handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+			return
+		}
 
-			if err := memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+nonce, authToken.AccessToken.Token, authToken.AccessToken.ExpiresAt); err != nil {
-				log.Debug("SetUserSession failed: ", err)
-				handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
-				return
-			}
+		cookie.SetSession(gc, authToken.FingerPrintHash)
+		go utils.RolloverSession(gc, claims.Nonce, nonce, authToken.SessionTokenExpiresAt)
 
-			cookie.SetSession(gc, authToken.FingerPrintHash)
+		// used of query mode
+		params := "access_token=" + authToken.AccessToken.Token + "&token_type=bearer&expires_in=" + strconv.FormatInt(authToken.IDToken.ExpiresAt, 10) + "&state=" + state + "&id_token=" + authToken.IDToken.Token
 
-			// used of query mode
-			params := "access_token=" + authToken.AccessToken.Token + "&token_type=bearer&expires_in=" + strconv.FormatInt(authToken.IDToken.ExpiresAt, 10) + "&state=" + state + "&id_token=" + authToken.IDToken.Token
+		res := map[string]interface{}{
+			"access_token": authToken.AccessToken.Token,
+			"id_token":     authToken.IDToken.Token,
+			"state":        state,
+			"scope":        strings.Join(scope, " "),
+			"token_type":   "Bearer",
+			"expires_in":   authToken.AccessToken.ExpiresAt,
+		}
 
-			res := map[string]interface{}{
-				"access_token": authToken.AccessToken.Token,
-				"id_token":     authToken.IDToken.Token,
-				"state":        state,
-				"scope":        strings.Join(scope, " "),
-				"token_type":   "Bearer",
-				"expires_in":   authToken.AccessToken.ExpiresAt,
-			}
+		if nonce != "" {
+			params += "&nonce=" + nonce
+			res["nonce"] = nonce
+		}
 
-			if nonce != "" {
-				params += "&nonce=" + nonce
-				res["nonce"] = nonce
-			}
+		if authToken.RefreshToken != nil {
+			res["refresh_token"] = authToken.RefreshToken.Token
+			params += "&refresh_token=" + authToken.RefreshToken.Token
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token, authToken.RefreshToken.ExpiresAt)
+		}
 
-			if authToken.RefreshToken != nil {
-				res["refresh_token"] = authToken.RefreshToken.Token
-				params += "&refresh_token=" + authToken.RefreshToken.Token
-				memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token, authToken.RefreshToken.ExpiresAt)
+		if responseMode == constants.ResponseModeQuery {
+			if strings.Contains(redirectURI, "?") {
+				redirectURI = redirectURI + "&" + params
+			} else {
+				redirectURI = redirectURI + "?" + params
 			}
-
-			if responseMode == constants.ResponseModeQuery {
-				if strings.Contains(redirectURI, "?") {
-					redirectURI = redirectURI + "&" + params
-				} else {
-					redirectURI = redirectURI + "?" + params
-				}
-			} else if responseMode == constants.ResponseModeFragment {
-				if strings.Contains(redirectURI, "#") {
-					redirectURI = redirectURI + "&" + params
-				} else {
-					redirectURI = redirectURI + "#" + params
-				}
+		} else if responseMode == constants.ResponseModeFragment {
+			if strings.Contains(redirectURI, "#") {
+				redirectURI = redirectURI + "&" + params
+			} else {
+				redirectURI = redirectURI + "#" + params
 			}
-
-			handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
-				"type":     "authorization_response",
-				"response": res,
-			}, http.StatusOK)
-			return
 		}
 
-		handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
+		handleResponse(gc, responseMode, authURL, redirectURI, map[string]interface{}{
+			"type":     "authorization_response",
+			"response": res,
+		}, http.StatusOK)
+		return
 	}
+
+	handleResponse(gc, responseMode, authURL, redirectURI, loginError, http.StatusOK)
 }
 
-func validateAuthorizeRequest(responseType, responseMode, clientID, state, codeChallenge string) error {
+func validateAuthorizeRequest(responseType, responseMode, state, codeChallenge string) error {
 	if strings.TrimSpace(state) == "" {
 		return fmt.Errorf("invalid state. state is required to prevent csrf attack")
 	}
@@ -354,10 +423,6 @@ func validateAuthorizeRequest(responseType, responseMode, clientID, state, codeC
 		return fmt.Errorf("invalid response mode %s. 'query', 'fragment', 'form_post' and 'web_message' are valid response_mode", responseMode)
 	}
 
-	if client, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID); client != clientID || err != nil {
-		return fmt.Errorf("invalid client_id %s", clientID)
-	}
-
 	return nil
 }
 
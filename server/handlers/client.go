@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// getClient returns the dynamically registered client with given client id.
+// nil client is returned for the client configured with CLIENT_ID env.
+func getClient(gc *gin.Context, clientID string) (*models.Client, error) {
+	if envClientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID); err == nil && envClientID == clientID {
+		return nil, nil
+	}
+	if clientID == "" {
+		return nil, fmt.Errorf("invalid client_id %s", clientID)
+	}
+	client, err := db.Provider.GetClientByClientID(gc, clientID)
+	if err != nil || client == nil {
+		return nil, fmt.Errorf("invalid client_id %s", clientID)
+	}
+	return client, nil
+}
+
+// validateClientAuthorizeRequest validates the authorize request against
+// the metadata of registered client
+func validateClientAuthorizeRequest(client *models.Client, responseType, redirectURI string, scope []string) error {
+	if !client.HasResponseType(responseType) {
+		return fmt.Errorf("response type %s is not allowed for the client", responseType)
+	}
+	if !client.HasRedirectURI(redirectURI) {
+		return fmt.Errorf("invalid redirect_uri %s, it is not registered for the client", redirectURI)
+	}
+	if client.Scope != "" {
+		for _, s := range scope {
+			if !utils.StringSliceContains(strings.Fields(client.Scope), s) {
+				return fmt.Errorf("scope %s is not allowed for the client", s)
+			}
+		}
+	}
+	return nil
+}
+
+// authenticateClient authenticates the registered client at token endpoint,
+// public clients (token_endpoint_auth_method none) cannot send a secret
+func authenticateClient(client *models.Client, clientSecret string) bool {
+	if client.TokenEndpointAuthMethod == clientAuthMethodNone {
+		return clientSecret == ""
+	}
+	return verifyClientCredential(clientSecret, client.ClientSecret)
+}
+
+// codeClientStateKey returns the key of state which binds authorization code
+// to the registered client it was issued for
+func codeClientStateKey(code string) string {
+	return "client_id:" + code
+}
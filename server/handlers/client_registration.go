@@ -0,0 +1,455 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+const (
+	clientAuthMethodSecretBasic = "client_secret_basic"
+	clientAuthMethodSecretPost  = "client_secret_post"
+	clientAuthMethodNone        = "none"
+)
+
+// clientMetadata is the metadata of client sent in registration requests (RFC 7591)
+type clientMetadata struct {
+	RedirectURIs            []string        `json:"redirect_uris"`
+	GrantTypes              []string        `json:"grant_types"`
+	ResponseTypes           []string        `json:"response_types"`
+	TokenEndpointAuthMethod string          `json:"token_endpoint_auth_method"`
+	ClientName              string          `json:"client_name"`
+	Scope                   string          `json:"scope"`
+	JWKS                    json.RawMessage `json:"jwks"`
+	JWKSURI                 string          `json:"jwks_uri"`
+	// RequirePushedAuthorizationRequests is defined by RFC 9126
+	RequirePushedAuthorizationRequests bool `json:"require_pushed_authorization_requests"`
+	// BackchannelLogoutURI and BackchannelLogoutSessionRequired are defined by OIDC back-channel logout
+	BackchannelLogoutURI             string `json:"backchannel_logout_uri"`
+	BackchannelLogoutSessionRequired bool   `json:"backchannel_logout_session_required"`
+}
+
+// clientMetadataError is the error returned for invalid client metadata,
+// code is one of the error codes defined by RFC 7591
+type clientMetadataError struct {
+	code        string
+	description string
+}
+
+func (e *clientMetadataError) Error() string {
+	return e.description
+}
+
+func invalidClientMetadata(format string, args ...interface{}) *clientMetadataError {
+	return &clientMetadataError{code: "invalid_client_metadata", description: fmt.Sprintf(format, args...)}
+}
+
+// validate sets the defaults for missing metadata and validates it
+func (m *clientMetadata) validate() *clientMetadataError {
+	if len(m.GrantTypes) == 0 {
+		m.GrantTypes = []string{"authorization_code"}
+	}
+	if len(m.ResponseTypes) == 0 {
+		m.ResponseTypes = []string{}
+		for _, grantType := range m.GrantTypes {
+			if grantType == "authorization_code" {
+				m.ResponseTypes = append(m.ResponseTypes, constants.ResponseTypeCode)
+			}
+			if grantType == "implicit" {
+				m.ResponseTypes = append(m.ResponseTypes, constants.ResponseTypeToken, constants.ResponseTypeIDToken)
+			}
+		}
+	}
+	if m.TokenEndpointAuthMethod == "" {
+		m.TokenEndpointAuthMethod = clientAuthMethodSecretBasic
+	}
+
+	hasGrantType := func(grantType string) bool {
+		for _, v := range m.GrantTypes {
+			if v == grantType {
+				return true
+			}
+		}
+		return false
+	}
+	for _, grantType := range m.GrantTypes {
+		switch grantType {
+		case "authorization_code", "implicit", "refresh_token", token.GrantTypeTokenExchange:
+		default:
+			return invalidClientMetadata("unsupported grant type %s", grantType)
+		}
+	}
+	for _, responseType := range m.ResponseTypes {
+		switch responseType {
+		case constants.ResponseTypeCode:
+			if !hasGrantType("authorization_code") {
+				return invalidClientMetadata("response type code requires authorization_code grant type")
+			}
+		case constants.ResponseTypeToken, constants.ResponseTypeIDToken:
+			if !hasGrantType("implicit") {
+				return invalidClientMetadata("response type %s requires implicit grant type", responseType)
+			}
+		default:
+			return invalidClientMetadata("unsupported response type %s", responseType)
+		}
+	}
+
+	switch m.TokenEndpointAuthMethod {
+	case clientAuthMethodSecretBasic, clientAuthMethodSecretPost:
+	case clientAuthMethodNone:
+		if hasGrantType(token.GrantTypeTokenExchange) {
+			return invalidClientMetadata("token exchange grant type requires client authentication")
+		}
+	default:
+		return invalidClientMetadata("unsupported token endpoint auth method %s", m.TokenEndpointAuthMethod)
+	}
+
+	if hasGrantType("authorization_code") || hasGrantType("implicit") {
+		if len(m.RedirectURIs) == 0 {
+			return &clientMetadataError{code: "invalid_redirect_uri", description: "redirect_uris are required"}
+		}
+	}
+	for _, redirectURI := range m.RedirectURIs {
+		u, err := url.Parse(redirectURI)
+		if err != nil || !u.IsAbs() || u.Fragment != "" || strings.Contains(redirectURI, ",") {
+			return &clientMetadataError{code: "invalid_redirect_uri", description: fmt.Sprintf("invalid redirect uri %s, it should be an absolute uri without fragment", redirectURI)}
+		}
+		if !isAllowedRedirectURI(u) {
+			return &clientMetadataError{code: "invalid_redirect_uri", description: fmt.Sprintf("invalid redirect uri %s, it should be a https uri, a http uri of loopback host or a private-use uri scheme", redirectURI)}
+		}
+	}
+
+	if len(m.JWKS) > 0 && string(m.JWKS) != "null" {
+		if m.JWKSURI != "" {
+			return invalidClientMetadata("jwks and jwks_uri cannot be used together")
+		}
+		var keySet jose.JSONWebKeySet
+		if err := json.Unmarshal(m.JWKS, &keySet); err != nil || len(keySet.Keys) == 0 {
+			return invalidClientMetadata("invalid jwks, it should be a json web key set")
+		}
+		for _, key := range keySet.Keys {
+			if !key.Valid() || !key.IsPublic() {
+				return invalidClientMetadata("invalid jwks, it should only contain valid public keys")
+			}
+		}
+	} else {
+		m.JWKS = nil
+	}
+	if m.JWKSURI != "" {
+		if u, err := url.ParseRequestURI(m.JWKSURI); err != nil || u.Scheme != "https" {
+			return invalidClientMetadata("invalid jwks_uri, it should be a https url")
+		}
+	}
+	if m.BackchannelLogoutURI != "" {
+		if u, err := url.ParseRequestURI(m.BackchannelLogoutURI); err != nil || !isAllowedBackchannelLogoutURI(u) {
+			return invalidClientMetadata("invalid backchannel_logout_uri, it should be a https url of public host without fragment")
+		}
+	}
+	return nil
+}
+
+// isAllowedRedirectURI returns true for https uri, http uri of loopback host
+// and private-use uri scheme in reverse domain name form, eg: com.example.app:/callback (RFC 8252).
+// Other schemes like javascript: and data: are never allowed.
+func isAllowedRedirectURI(u *url.URL) bool {
+	switch strings.ToLower(u.Scheme) {
+	case "https":
+		return u.Host != ""
+	case "http":
+		hostname := u.Hostname()
+		if hostname == "localhost" {
+			return true
+		}
+		ip := net.ParseIP(hostname)
+		return ip != nil && ip.IsLoopback()
+	default:
+		return strings.Contains(u.Scheme, ".")
+	}
+}
+
+// isAllowedBackchannelLogoutURI returns true for https uri without fragment whose host is not
+// a loopback, private or link-local ip address, as server calls it with logout token.
+// Loopback host is allowed with http as well when not running in production.
+func isAllowedBackchannelLogoutURI(u *url.URL) bool {
+	if u.Host == "" || u.Fragment != "" {
+		return false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	hostname := u.Hostname()
+	ip := net.ParseIP(hostname)
+	if hostname == "localhost" || (ip != nil && ip.IsLoopback()) {
+		isProd, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyIsProd)
+		return err == nil && !isProd && (scheme == "https" || scheme == "http")
+	}
+	return scheme == "https" && (ip == nil || utils.IsPublicIP(ip))
+}
+
+// applyTo copies the metadata to client model
+func (m *clientMetadata) applyTo(client *models.Client) {
+	client.ClientName = strings.TrimSpace(m.ClientName)
+	client.RedirectURIs = strings.Join(m.RedirectURIs, ",")
+	client.GrantTypes = strings.Join(m.GrantTypes, ",")
+	client.ResponseTypes = strings.Join(m.ResponseTypes, ",")
+	client.TokenEndpointAuthMethod = m.TokenEndpointAuthMethod
+	client.Scope = strings.Join(strings.Fields(m.Scope), " ")
+	client.JWKS = string(m.JWKS)
+	client.JWKSURI = m.JWKSURI
+	client.RequirePushedAuthorizationRequests = m.RequirePushedAuthorizationRequests
+	client.BackchannelLogoutURI = m.BackchannelLogoutURI
+	client.BackchannelLogoutSessionRequired = m.BackchannelLogoutSessionRequired
+}
+
+// generateClientCredential returns random value used as client secret and registration access token
+func generateClientCredential() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashClientCredential returns the hash of client secret or registration access token
+// which is stored in db. Credentials are random, so unsalted hash is sufficient.
+func hashClientCredential(value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(hash[:])
+}
+
+// verifyClientCredential compares the credential with stored hash in constant time
+func verifyClientCredential(value, hash string) bool {
+	if value == "" || hash == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashClientCredential(value)), []byte(hash)) == 1
+}
+
+// getBearerToken returns the token of bearer authorization header
+func getBearerToken(gc *gin.Context) string {
+	authHeader := gc.GetHeader("Authorization")
+	if len(authHeader) < 7 || !strings.EqualFold(authHeader[:7], "bearer ") {
+		return ""
+	}
+	return strings.TrimSpace(authHeader[7:])
+}
+
+// clientRegistrationResponse returns the client information response,
+// secrets are only available when they are generated
+func clientRegistrationResponse(gc *gin.Context, client *models.Client, clientSecret, registrationAccessToken string) gin.H {
+	res := gin.H{
+		"client_id":                             client.ClientID,
+		"client_id_issued_at":                   client.CreatedAt,
+		"client_name":                           client.ClientName,
+		"redirect_uris":                         client.GetRedirectURIs(),
+		"grant_types":                           client.GetGrantTypes(),
+		"response_types":                        client.GetResponseTypes(),
+		"token_endpoint_auth_method":            client.TokenEndpointAuthMethod,
+		"registration_client_uri":               parsers.GetHost(gc) + "/oauth/register/" + client.ClientID,
+		"require_pushed_authorization_requests": client.RequirePushedAuthorizationRequests,
+	}
+	if client.Scope != "" {
+		res["scope"] = client.Scope
+	}
+	if client.JWKS != "" {
+		res["jwks"] = json.RawMessage(client.JWKS)
+	}
+	if client.JWKSURI != "" {
+		res["jwks_uri"] = client.JWKSURI
+	}
+	if client.BackchannelLogoutURI != "" {
+		res["backchannel_logout_uri"] = client.BackchannelLogoutURI
+		res["backchannel_logout_session_required"] = client.BackchannelLogoutSessionRequired
+	}
+	if clientSecret != "" {
+		res["client_secret"] = clientSecret
+		// client secret does not expire
+		res["client_secret_expires_at"] = 0
+	}
+	if registrationAccessToken != "" {
+		res["registration_access_token"] = registrationAccessToken
+	}
+	return res
+}
+
+// ClientRegistrationHandler is the handler for /oauth/register route
+// It registers oauth client as per RFC 7591. Request should be authorized
+// with CLIENT_REGISTRATION_INITIAL_ACCESS_TOKEN as bearer token.
+func ClientRegistrationHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		initialAccessToken, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken)
+		if err != nil || initialAccessToken == "" {
+			log.Debug("Client registration is disabled")
+			gc.JSON(http.StatusNotFound, gin.H{
+				"error":             "not_found",
+				"error_description": "Client registration is disabled",
+			})
+			return
+		}
+		if bearerToken := getBearerToken(gc); subtle.ConstantTimeCompare([]byte(bearerToken), []byte(initialAccessToken)) != 1 {
+			log.Debug("Invalid initial access token")
+			gc.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			gc.JSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_token",
+				"error_description": "The initial access token is invalid",
+			})
+			return
+		}
+
+		var metadata clientMetadata
+		if err := json.NewDecoder(gc.Request.Body).Decode(&metadata); err != nil {
+			log.Debug("Error decoding client metadata: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_client_metadata",
+				"error_description": "The client metadata should be a json object",
+			})
+			return
+		}
+		if metadataErr := metadata.validate(); metadataErr != nil {
+			log.Debug("Invalid client metadata: ", metadataErr)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             metadataErr.code,
+				"error_description": metadataErr.description,
+			})
+			return
+		}
+
+		client := &models.Client{
+			ClientID: uuid.New().String(),
+		}
+		metadata.applyTo(client)
+		clientSecret := ""
+		if client.TokenEndpointAuthMethod != clientAuthMethodNone {
+			if clientSecret, err = generateClientCredential(); err != nil {
+				log.Debug("Error generating client secret: ", err)
+				gc.JSON(http.StatusInternalServerError, gin.H{
+					"error": "server_error",
+				})
+				return
+			}
+			client.ClientSecret = hashClientCredential(clientSecret)
+		}
+		registrationAccessToken, err := generateClientCredential()
+		if err != nil {
+			log.Debug("Error generating registration access token: ", err)
+			gc.JSON(http.StatusInternalServerError, gin.H{
+				"error": "server_error",
+			})
+			return
+		}
+		client.RegistrationAccessToken = hashClientCredential(registrationAccessToken)
+
+		client, err = db.Provider.AddClient(gc, client)
+		if err != nil {
+			log.Debug("Error adding client: ", err)
+			gc.JSON(http.StatusInternalServerError, gin.H{
+				"error": "server_error",
+			})
+			return
+		}
+
+		gc.Header("Cache-Control", "no-store")
+		gc.JSON(http.StatusCreated, clientRegistrationResponse(gc, client, clientSecret, registrationAccessToken))
+	}
+}
+
+// ClientConfigurationHandler is the handler for /oauth/register/:client_id route
+// It reads, updates and deletes the registered client as per RFC 7592.
+// Request should be authorized with registration_access_token of the client.
+func ClientConfigurationHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		clientID := gc.Param("client_id")
+		client, err := db.Provider.GetClientByClientID(gc, clientID)
+		if err != nil || client == nil || !verifyClientCredential(getBearerToken(gc), client.RegistrationAccessToken) {
+			log.Debug("Invalid registration access token for client: ", clientID)
+			gc.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			gc.JSON(http.StatusUnauthorized, gin.H{
+				"error":             "invalid_token",
+				"error_description": "The registration access token is invalid",
+			})
+			return
+		}
+		log := log.WithField("client_id", clientID)
+		gc.Header("Cache-Control", "no-store")
+
+		switch gc.Request.Method {
+		case http.MethodGet:
+			gc.JSON(http.StatusOK, clientRegistrationResponse(gc, client, "", ""))
+		case http.MethodPut:
+			var metadata clientMetadata
+			if err := json.NewDecoder(gc.Request.Body).Decode(&metadata); err != nil {
+				log.Debug("Error decoding client metadata: ", err)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_client_metadata",
+					"error_description": "The client metadata should be a json object",
+				})
+				return
+			}
+			if metadataErr := metadata.validate(); metadataErr != nil {
+				log.Debug("Invalid client metadata: ", metadataErr)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             metadataErr.code,
+					"error_description": metadataErr.description,
+				})
+				return
+			}
+			previousAuthMethod := client.TokenEndpointAuthMethod
+			metadata.applyTo(client)
+			// secret is issued when public client becomes confidential
+			clientSecret := ""
+			if client.TokenEndpointAuthMethod == clientAuthMethodNone {
+				client.ClientSecret = ""
+			} else if previousAuthMethod == clientAuthMethodNone || client.ClientSecret == "" {
+				if clientSecret, err = generateClientCredential(); err != nil {
+					log.Debug("Error generating client secret: ", err)
+					gc.JSON(http.StatusInternalServerError, gin.H{
+						"error": "server_error",
+					})
+					return
+				}
+				client.ClientSecret = hashClientCredential(clientSecret)
+			}
+			client, err = db.Provider.UpdateClient(gc, client)
+			if err != nil {
+				log.Debug("Error updating client: ", err)
+				gc.JSON(http.StatusInternalServerError, gin.H{
+					"error": "server_error",
+				})
+				return
+			}
+			gc.JSON(http.StatusOK, clientRegistrationResponse(gc, client, clientSecret, ""))
+		case http.MethodDelete:
+			if err := db.Provider.DeleteClient(gc, client); err != nil {
+				log.Debug("Error deleting client: ", err)
+				gc.JSON(http.StatusInternalServerError, gin.H{
+					"error": "server_error",
+				})
+				return
+			}
+			gc.AbortWithStatus(http.StatusNoContent)
+		default:
+			gc.JSON(http.StatusMethodNotAllowed, gin.H{
+				"error": "method_not_allowed",
+			})
+		}
+	}
+}
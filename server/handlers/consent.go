@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+const (
+	// consentStoreNamespace is the memory store namespace for pending consent requests of user
+	consentStoreNamespace = "authorize_consent"
+	// consentExpiresIn is the time in seconds for which user can respond to consent screen
+	consentExpiresIn = 10 * 60
+)
+
+// showConsent saves the authorization request and renders the consent screen
+// with the client name and requested scopes
+func showConsent(gc *gin.Context, client *models.Client, userID string, scope []string, params url.Values) {
+	consentID, err := generateClientCredential()
+	if err != nil {
+		log.Debug("Failed to generate consent id: ", err)
+		gc.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+	expiresAt := time.Now().Add(consentExpiresIn * time.Second).Unix()
+	if err := memorystore.Provider.SetUserSession(consentStoreNamespace+":"+userID, consentID, params.Encode(), expiresAt); err != nil {
+		log.Debug("Failed to store consent request: ", err)
+		gc.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+	descriptions := token.GetScopeDescriptions()
+	scopeDetails := []gin.H{}
+	for _, s := range scope {
+		scopeDetails = append(scopeDetails, gin.H{
+			"name":        s,
+			"description": descriptions[s],
+		})
+	}
+	clientName := client.ClientName
+	if clientName == "" {
+		clientName = client.ClientID
+	}
+	gc.Header("Cache-Control", "no-store")
+	// consent screen should not be framed by other sites to trick user in granting access
+	gc.Header("X-Frame-Options", "DENY")
+	gc.HTML(http.StatusOK, authorizeConsentTemplate, gin.H{
+		"client_name": clientName,
+		"scopes":      scopeDetails,
+		"consent_id":  consentID,
+	})
+}
+
+// ConsentHandler is the handler for /authorize/consent route.
+// It saves the grant when user allows the client and continues with
+// the authorization request, else redirects with access_denied error
+func ConsentHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		consentID := strings.TrimSpace(gc.PostForm("consent_id"))
+		action := strings.TrimSpace(gc.PostForm("action"))
+
+		sessionToken, err := cookie.GetSession(gc)
+		if err != nil {
+			log.Debug("GetSession failed: ", err)
+			gc.JSON(http.StatusUnauthorized, gin.H{"error": "login_required"})
+			return
+		}
+		claims, err := token.ValidateBrowserSession(gc, sessionToken)
+		if err != nil {
+			log.Debug("ValidateBrowserSession failed: ", err)
+			gc.JSON(http.StatusUnauthorized, gin.H{"error": "login_required"})
+			return
+		}
+		userID := claims.Subject
+		log := log.WithFields(log.Fields{
+			"user_id": userID,
+		})
+
+		storeKey := consentStoreNamespace + ":" + userID
+		value, err := memorystore.Provider.GetUserSession(storeKey, consentID)
+		if consentID == "" || err != nil || value == "" {
+			log.Debug("Consent request not found: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{"error": "consent request is invalid or expired"})
+			return
+		}
+		// consent request can only be answered once
+		if err := memorystore.Provider.DeleteAllUserSessions(storeKey); err != nil {
+			log.Debug("Failed to delete consent request: ", err)
+		}
+		params, err := url.ParseQuery(value)
+		if err != nil {
+			log.Debug("Failed to parse consent request: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{"error": "consent request is invalid or expired"})
+			return
+		}
+		client, err := getClient(gc, params.Get("client_id"))
+		if err != nil || client == nil {
+			log.Debug("Failed to get client: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{"error": "invalid client"})
+			return
+		}
+
+		if action != "allow" {
+			handleAccessDenied(gc, params.Get("response_mode"), params.Get("redirect_uri"), params.Get("state"))
+			return
+		}
+
+		scope := strings.Fields(params.Get("scope"))
+		grant, err := db.Provider.GetGrantByUserIDAndClientID(gc, userID, client.ClientID)
+		if err != nil || grant == nil {
+			_, err = db.Provider.AddGrant(gc, &models.Grant{
+				UserID:   userID,
+				ClientID: client.ClientID,
+				Scope:    strings.Join(scope, " "),
+			})
+		} else {
+			grantedScopes := grant.GetScopes()
+			for _, s := range scope {
+				if !grant.HasScopes([]string{s}) {
+					grantedScopes = append(grantedScopes, s)
+				}
+			}
+			grant.Scope = strings.Join(grantedScopes, " ")
+			_, err = db.Provider.UpdateGrant(gc, grant)
+		}
+		if err != nil {
+			log.Debug("Failed to save grant: ", err)
+			gc.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+			return
+		}
+
+		authorize(gc, params, client)
+	}
+}
+
+// handleAccessDenied sends access_denied error to the client when user
+// does not allow access on consent screen
+func handleAccessDenied(gc *gin.Context, responseMode, redirectURI, state string) {
+	response := map[string]interface{}{
+		"error":             "access_denied",
+		"error_description": "User denied access to the client",
+		"state":             state,
+	}
+	params := url.Values{}
+	for key, value := range response {
+		params.Set(key, value.(string))
+	}
+	switch responseMode {
+	case constants.ResponseModeFormPost:
+		gc.HTML(http.StatusOK, authorizeFormPostTemplate, gin.H{
+			"target_origin":          redirectURI,
+			"authorization_response": response,
+		})
+	case constants.ResponseModeFragment:
+		gc.Redirect(http.StatusFound, redirectURI+"#"+params.Encode())
+	default:
+		if strings.Contains(redirectURI, "?") {
+			gc.Redirect(http.StatusFound, redirectURI+"&"+params.Encode())
+		} else {
+			gc.Redirect(http.StatusFound, redirectURI+"?"+params.Encode())
+		}
+	}
+}
@@ -1,10 +1,19 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/authorizerdev/authorizer/server/graph"
 	"github.com/authorizerdev/authorizer/server/graph/generated"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/tracing"
 	"github.com/gin-gonic/gin"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // GraphqlHandler is the main handler that handels all the graphql requests
@@ -12,8 +21,50 @@ func GraphqlHandler() gin.HandlerFunc {
 	// NewExecutableSchema and Config are in the generated.go file
 	// Resolver is in the resolver.go file
 	h := handler.NewDefaultServer(generated.NewExecutableSchema(generated.Config{Resolvers: &graph.Resolver{}}))
+	h.AroundResponses(graphqlMetrics)
+	h.AroundFields(graphqlTracing)
 
 	return func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
 	}
 }
+
+// graphqlMetrics records latency of graphql operations by their root fields,
+// as operation names are set by the clients
+func graphqlMetrics(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	start := time.Now()
+	resp := next(ctx)
+	if resp == nil || !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+	oc := graphql.GetOperationContext(ctx)
+	if oc.Operation == nil {
+		return resp
+	}
+	var err error
+	if len(resp.Errors) > 0 {
+		err = errors.New(resp.Errors.Error())
+	}
+	duration := time.Since(start)
+	for _, selection := range oc.Operation.SelectionSet {
+		if field, ok := selection.(*ast.Field); ok {
+			metrics.RecordGraphQLOperation(field.Name, err, duration)
+		}
+	}
+	return resp
+}
+
+// graphqlTracing creates span for each field which has resolver,
+// fields which are just read from the parent object are skipped
+func graphqlTracing(ctx context.Context, next graphql.Resolver) (interface{}, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || !fc.IsResolver {
+		return next(ctx)
+	}
+	ctx, span := tracing.StartSpan(ctx, "graphql."+fc.Object+"."+fc.Field.Name,
+		attribute.String("graphql.field.path", fc.Path().String()),
+	)
+	res, err := next(ctx)
+	tracing.EndSpan(span, err)
+	return res, err
+}
@@ -1,11 +1,34 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+// healthCheckTimeout is the max time given to each dependency check of readiness probe
+const healthCheckTimeout = 5 * time.Second
+
+const (
+	healthStatusOK          = "ok"
+	healthStatusError       = "error"
+	healthStatusUnavailable = "unavailable"
 )
 
+// componentHealth is the status of a dependency in readiness response
+type componentHealth struct {
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+}
+
 // HealthHandler is the handler for /health route.
 // It states if server is in healthy state or not
 func HealthHandler() gin.HandlerFunc {
@@ -13,3 +36,79 @@ func HealthHandler() gin.HandlerFunc {
 		c.String(http.StatusOK, "OK")
 	}
 }
+
+// LivenessHandler is the handler for /healthz route.
+// It only states that the process is running and able to serve requests,
+// dependencies are not checked so that pod is not restarted when they are down.
+func LivenessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"status": healthStatusOK,
+		})
+	}
+}
+
+// ReadinessHandler is the handler for /readyz route.
+// It checks database, memory store and jwt keys and responds with 503
+// if any of them is not healthy, so that traffic is not routed to this instance.
+func ReadinessHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		checks := []struct {
+			name  string
+			check func(ctx context.Context) error
+		}{
+			{"database", func(ctx context.Context) error {
+				if db.Provider == nil {
+					return errors.New("database provider is not initialized")
+				}
+				return db.Provider.HealthCheck(ctx)
+			}},
+			{"memory_store", func(ctx context.Context) error {
+				if memorystore.Provider == nil {
+					return errors.New("memory store provider is not initialized")
+				}
+				return memorystore.Provider.HealthCheck(ctx)
+			}},
+			{"jwt_keys", func(ctx context.Context) error {
+				return token.CheckJWTKeys()
+			}},
+		}
+
+		status := healthStatusOK
+		components := make(map[string]componentHealth, len(checks))
+		for _, check := range checks {
+			components[check.name] = checkComponent(c.Request.Context(), check.name, check.check)
+			if components[check.name].Status != healthStatusOK {
+				status = healthStatusUnavailable
+			}
+		}
+
+		code := http.StatusOK
+		if status != healthStatusOK {
+			code = http.StatusServiceUnavailable
+		}
+		c.JSON(code, gin.H{
+			"status":     status,
+			"components": components,
+		})
+	}
+}
+
+// checkComponent runs the check with timeout and returns its status with latency
+func checkComponent(ctx context.Context, name string, check func(ctx context.Context) error) componentHealth {
+	ctx, cancel := context.WithTimeout(ctx, healthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	res := componentHealth{
+		Status:    healthStatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		// error is only logged as probes are not authenticated
+		log.Debug("Health check failed for ", name, ": ", err)
+		res.Status = healthStatusError
+	}
+	return res
+}
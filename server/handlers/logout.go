@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -10,37 +12,68 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/validators"
 )
 
 // Handler to logout user
+// It supports OIDC RP-initiated logout, where session can be identified by
+// id_token_hint and user is redirected to post_logout_redirect_uri with state.
+// Params can be sent as query or as form in POST request.
 func LogoutHandler() gin.HandlerFunc {
 	return func(gc *gin.Context) {
-		redirectURL := strings.TrimSpace(gc.Query("redirect_uri"))
-		// get fingerprint hash
-		fingerprintHash, err := cookie.GetSession(gc)
-		if err != nil {
-			log.Debug("Failed to get session: ", err)
-			gc.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
-			})
-			return
+		redirectURL := strings.TrimSpace(gc.Request.FormValue("post_logout_redirect_uri"))
+		if redirectURL == "" {
+			// kept for backward compatibility
+			redirectURL = strings.TrimSpace(gc.Request.FormValue("redirect_uri"))
 		}
+		state := strings.TrimSpace(gc.Request.FormValue("state"))
+		idTokenHint := strings.TrimSpace(gc.Request.FormValue("id_token_hint"))
 
-		decryptedFingerPrint, err := crypto.DecryptAES(fingerprintHash)
-		if err != nil {
-			log.Debug("Failed to decrypt fingerprint: ", err)
-			gc.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
+		if redirectURL != "" && !validators.IsValidOrigin(redirectURL) {
+			log.Debug("Invalid post logout redirect uri: ", redirectURL)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error": "invalid post_logout_redirect_uri",
 			})
 			return
 		}
 
-		var sessionData token.SessionData
-		err = json.Unmarshal([]byte(decryptedFingerPrint), &sessionData)
+		// get fingerprint hash
+		sessionData, err := getLogoutSessionData(gc)
+		if idTokenHint != "" {
+			hintSessionData, hintExpired, hintErr := getIDTokenHintSessionData(gc, idTokenHint)
+			if hintErr != nil {
+				log.Debug("Invalid id token hint: ", hintErr)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error": hintErr.Error(),
+				})
+				return
+			}
+			if err == nil && sessionData.Subject != hintSessionData.Subject {
+				log.Debug("Id token hint does not match the session")
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error": "id_token_hint does not match the session",
+				})
+				return
+			}
+			// expired hint could have been leaked long ago, so it can only
+			// confirm the session of cookie and cannot end a session by itself
+			if hintExpired && (err != nil || (hintSessionData.Nonce != "" && hintSessionData.Nonce != sessionData.Nonce)) {
+				log.Debug("Expired id token hint does not match the session")
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error": "expired id_token_hint does not match the session",
+				})
+				return
+			}
+			if err != nil {
+				sessionData, err = hintSessionData, nil
+			}
+		}
 		if err != nil {
-			log.Debug("Failed to decrypt fingerprint: ", err)
+			log.Debug("Failed to get session: ", err)
 			gc.JSON(http.StatusUnauthorized, gin.H{
 				"error": err.Error(),
 			})
@@ -54,10 +87,21 @@ func LogoutHandler() gin.HandlerFunc {
 			sessionToken = loginMethod + ":" + userID
 		}
 
-		memorystore.Provider.DeleteUserSession(sessionToken, sessionData.Nonce)
+		if sessionData.Nonce != "" {
+			memorystore.Provider.DeleteUserSession(sessionToken, sessionData.Nonce)
+			go logout.SendBackchannelLogout(parsers.GetHost(gc), userID, sessionData.Nonce)
+		}
 		cookie.DeleteSession(gc)
 
 		if redirectURL != "" {
+			if state != "" {
+				if u, err := url.Parse(redirectURL); err == nil {
+					query := u.Query()
+					query.Set("state", state)
+					u.RawQuery = query.Encode()
+					redirectURL = u.String()
+				}
+			}
 			gc.Redirect(http.StatusFound, redirectURL)
 		} else {
 			gc.JSON(http.StatusOK, gin.H{
@@ -66,3 +110,45 @@ func LogoutHandler() gin.HandlerFunc {
 		}
 	}
 }
+
+// getLogoutSessionData returns the session data from session cookie
+func getLogoutSessionData(gc *gin.Context) (*token.SessionData, error) {
+	fingerprintHash, err := cookie.GetSession(gc)
+	if err != nil {
+		return nil, err
+	}
+	decryptedFingerPrint, err := crypto.DecryptAES(fingerprintHash)
+	if err != nil {
+		return nil, err
+	}
+	var sessionData token.SessionData
+	if err := json.Unmarshal([]byte(decryptedFingerPrint), &sessionData); err != nil {
+		return nil, err
+	}
+	return &sessionData, nil
+}
+
+// getIDTokenHintSessionData returns the session identified by id token hint and whether the hint has expired,
+// nonce is empty if id token was issued without session id
+func getIDTokenHintSessionData(gc *gin.Context, idTokenHint string) (*token.SessionData, bool, error) {
+	claims, expired, err := token.ParseIDTokenHint(idTokenHint)
+	if err != nil {
+		return nil, false, errors.New("invalid id_token_hint")
+	}
+	// audience is either CLIENT_ID env or a registered client
+	audience, _ := claims["aud"].(string)
+	if _, err := getClient(gc, audience); err != nil {
+		return nil, false, errors.New("invalid id_token_hint audience")
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, false, errors.New("invalid id_token_hint subject")
+	}
+	loginMethod, _ := claims["login_method"].(string)
+	sid, _ := claims["sid"].(string)
+	return &token.SessionData{
+		Subject:     subject,
+		LoginMethod: loginMethod,
+		Nonce:       sid,
+	}, expired, nil
+}
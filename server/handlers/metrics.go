@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/authorizerdev/authorizer/server/metrics"
+)
+
+// MetricsHandler is the handler for /metrics route.
+// It exposes metrics in prometheus format
+func MetricsHandler() gin.HandlerFunc {
+	h := metrics.Handler()
+	return func(c *gin.Context) {
+		h.ServeHTTP(c.Writer, c.Request)
+	}
+}
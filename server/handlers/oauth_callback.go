@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -23,9 +24,11 @@ import (
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/oauth"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/tracing"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
 
@@ -38,9 +41,30 @@ type AppleUserInfo struct {
 	} `json:"name"`
 }
 
+// oauthUserInfo is the user information fetched from an oauth provider
+type oauthUserInfo struct {
+	User *models.User
+	// ProviderUserID is the unique id of user at the oauth provider
+	ProviderUserID string
+	// IsEmailVerified is true when provider asserts that user owns the email
+	IsEmailVerified bool
+}
+
 func OAuthCallbackHandler() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		provider := ctx.Param("oauth_provider")
+		defer func() {
+			recipe := provider
+			// avoid unknown providers in metric labels
+			if !utils.StringSliceContains(constants.OAuthRecipeMethods, recipe) {
+				recipe = "unknown"
+			}
+			outcome := metrics.OutcomeSuccess
+			if ctx.Writer.Status() >= http.StatusBadRequest {
+				outcome = metrics.OutcomeFailure
+			}
+			metrics.RecordAuthEvent(metrics.EventLogin, recipe, outcome)
+		}()
 		state := ctx.Request.FormValue("state")
 		sessionState, err := memorystore.Provider.GetState(state)
 		if sessionState == "" || err != nil {
@@ -71,37 +95,44 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 				scopes = strings.Split(scopeString, " ")
 			}
 		}
-		var user *models.User
+		// link token is only present when a logged in user is linking a new provider account
+		linkToken := ""
+		if len(sessionSplit) > 4 {
+			linkToken = sessionSplit[4]
+		}
+		var userInfo *oauthUserInfo
 		oauthCode := ctx.Request.FormValue("code")
 		if oauthCode == "" {
 			log.Debug("Invalid oauth code: ", oauthCode)
 			ctx.JSON(400, gin.H{"error": "invalid oauth code"})
 			return
 		}
+		// calls to the provider are traced as part of this request
+		oauthCtx := tracing.OAuth2Context(ctx.Request.Context())
 		switch provider {
 		case constants.AuthRecipeMethodGoogle:
-			user, err = processGoogleUserInfo(ctx, oauthCode)
+			userInfo, err = processGoogleUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodGithub:
-			user, err = processGithubUserInfo(ctx, oauthCode)
+			userInfo, err = processGithubUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodFacebook:
-			user, err = processFacebookUserInfo(ctx, oauthCode)
+			userInfo, err = processFacebookUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodLinkedIn:
-			user, err = processLinkedInUserInfo(ctx, oauthCode)
+			userInfo, err = processLinkedInUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodApple:
 			user_ := AppleUserInfo{}
 			userRaw := ctx.Request.FormValue("user")
 			err = json.Unmarshal([]byte(userRaw), &user_)
-			user, err = processAppleUserInfo(ctx, oauthCode, &user_)
+			userInfo, err = processAppleUserInfo(oauthCtx, oauthCode, &user_)
 		case constants.AuthRecipeMethodDiscord:
-			user, err = processDiscordUserInfo(ctx, oauthCode)
+			userInfo, err = processDiscordUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodTwitter:
-			user, err = processTwitterUserInfo(ctx, oauthCode, sessionState)
+			userInfo, err = processTwitterUserInfo(oauthCtx, oauthCode, sessionState)
 		case constants.AuthRecipeMethodMicrosoft:
-			user, err = processMicrosoftUserInfo(ctx, oauthCode)
+			userInfo, err = processMicrosoftUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodTwitch:
-			user, err = processTwitchUserInfo(ctx, oauthCode)
+			userInfo, err = processTwitchUserInfo(oauthCtx, oauthCode)
 		case constants.AuthRecipeMethodRoblox:
-			user, err = processRobloxUserInfo(ctx, oauthCode, sessionState)
+			userInfo, err = processRobloxUserInfo(oauthCtx, oauthCode, sessionState)
 		default:
 			log.Info("Invalid oauth provider")
 			err = fmt.Errorf(`invalid oauth provider`)
@@ -112,7 +143,7 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 			ctx.JSON(400, gin.H{"error": err.Error()})
 			return
 		}
-		if user == nil {
+		if userInfo == nil || userInfo.User == nil {
 			log.Debug("User is nil")
 			ctx.JSON(
 				500,
@@ -120,11 +151,17 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 			)
 			return
 		}
-		existingUser, err := db.Provider.GetUserByEmail(ctx, refs.StringValue(user.Email))
+		user := userInfo.User
 		log := log.WithField("user", user.Email)
+		existingUser, err := getOAuthExistingUser(ctx, provider, userInfo, linkToken)
+		if err != nil {
+			log.Debug("Failed to get existing user: ", err)
+			ctx.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 		isSignUp := false
 
-		if err != nil {
+		if existingUser == nil {
 			isSignupDisabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisableSignUp)
 			if err != nil {
 				log.Debug("Failed to get signup disabled env variable: ", err)
@@ -163,7 +200,23 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 			user.Roles = strings.Join(inputRoles, ",")
 			now := time.Now().Unix()
 			user.EmailVerifiedAt = &now
-			user, _ = db.Provider.AddUser(ctx, user)
+			user, err = db.Provider.AddUser(ctx, user)
+			if err != nil {
+				log.Debug("Failed to add user: ", err)
+				ctx.JSON(500, gin.H{"error": err.Error()})
+				return
+			}
+			if userInfo.ProviderUserID != "" {
+				_, err = db.Provider.AddIdentity(ctx, &models.Identity{
+					UserID:         user.ID,
+					Provider:       provider,
+					ProviderUserID: userInfo.ProviderUserID,
+					Email:          refs.StringValue(user.Email),
+				})
+				if err != nil {
+					log.Debug("Failed to add identity: ", err)
+				}
+			}
 			isSignUp = true
 		} else {
 			user = existingUser
@@ -181,7 +234,8 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 			}
 			user.SignupMethods = signupMethod
 
-			if user.EmailVerifiedAt == nil {
+			// only trust the provider for email verification when it asserts ownership of the same email
+			if user.EmailVerifiedAt == nil && userInfo.IsEmailVerified && refs.StringValue(user.Email) == refs.StringValue(userInfo.User.Email) {
 				now := time.Now().Unix()
 				user.EmailVerifiedAt = &now
 			}
@@ -304,9 +358,12 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 				utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, provider, user)
 			}
 			db.Provider.AddSession(ctx, &models.Session{
-				UserID:    user.ID,
-				UserAgent: utils.GetUserAgent(ctx.Request),
-				IP:        utils.GetIP(ctx.Request),
+				UserID:      user.ID,
+				UserAgent:   utils.GetUserAgent(ctx.Request),
+				IP:          utils.GetIP(ctx.Request),
+				LoginMethod: provider,
+				Nonce:       authToken.FingerPrint,
+				ExpiresAt:   authToken.SessionTokenExpiresAt,
 			})
 		}()
 		if strings.Contains(redirectURL, "?") {
@@ -319,7 +376,94 @@ func OAuthCallbackHandler() gin.HandlerFunc {
 	}
 }
 
-func processGoogleUserInfo(ctx context.Context, code string) (*models.User, error) {
+// getOAuthExistingUser returns the user that the oauth account belongs to.
+// It links the oauth account with the user when it is not linked yet and
+// returns nil user when a new user should be signed up.
+func getOAuthExistingUser(ctx *gin.Context, provider string, userInfo *oauthUserInfo, linkToken string) (*models.User, error) {
+	log := log.WithFields(log.Fields{
+		"provider":         provider,
+		"provider_user_id": userInfo.ProviderUserID,
+	})
+	var identity *models.Identity
+	if userInfo.ProviderUserID != "" {
+		identity, _ = db.Provider.GetIdentityByProviderUserID(ctx, provider, userInfo.ProviderUserID)
+	}
+
+	// logged in user is linking a new account via link_identity mutation
+	if linkToken != "" {
+		userID, err := token.ValidateLinkToken(ctx, linkToken)
+		if err != nil {
+			return nil, err
+		}
+		if identity != nil && identity.UserID != userID {
+			log.Debug("Identity is already linked with another user")
+			return nil, fmt.Errorf("%s account is already linked with another user", provider)
+		}
+		user, err := db.Provider.GetUserByID(ctx, userID)
+		if err != nil {
+			log.Debug("Failed to get user by id: ", err)
+			return nil, err
+		}
+		if identity == nil {
+			if err := linkOAuthIdentity(ctx, user, provider, userInfo); err != nil {
+				return nil, err
+			}
+		}
+		return user, nil
+	}
+
+	if identity != nil {
+		user, err := db.Provider.GetUserByID(ctx, identity.UserID)
+		if err != nil {
+			log.Debug("Failed to get user by id: ", err)
+			return nil, err
+		}
+		return user, nil
+	}
+
+	email := refs.StringValue(userInfo.User.Email)
+	if email == "" {
+		return nil, nil
+	}
+	user, err := db.Provider.GetUserByEmail(ctx, email)
+	if err != nil || user == nil {
+		return nil, nil
+	}
+	// Accounts merged before identities were stored already contain the provider
+	// in signup methods. For others, only link automatically when both sides have
+	// verified the email, otherwise anyone able to register the email with the
+	// provider could take over the account.
+	isLinkedBefore := utils.StringSliceContains(strings.Split(user.SignupMethods, ","), provider)
+	if !isLinkedBefore && (!userInfo.IsEmailVerified || user.EmailVerifiedAt == nil) {
+		log.Debug("Email is not verified by provider or user, account linking requires re-authentication")
+		return nil, fmt.Errorf("an account with this email already exists. Please login with your existing login method and link your %s account from your profile", provider)
+	}
+	if err := linkOAuthIdentity(ctx, user, provider, userInfo); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// linkOAuthIdentity stores the oauth account as identity of given user
+func linkOAuthIdentity(ctx context.Context, user *models.User, provider string, userInfo *oauthUserInfo) error {
+	if userInfo.ProviderUserID == "" {
+		return nil
+	}
+	_, err := db.Provider.AddIdentity(ctx, &models.Identity{
+		UserID:         user.ID,
+		Provider:       provider,
+		ProviderUserID: userInfo.ProviderUserID,
+		Email:          refs.StringValue(userInfo.User.Email),
+	})
+	if err != nil {
+		log.Debug("Failed to add identity: ", err)
+		return err
+	}
+	go utils.RegisterEvent(ctx, constants.UserIdentityLinkedWebhookEvent, provider, user)
+	return nil
+}
+
+func processGoogleUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.GoogleConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
@@ -345,18 +489,28 @@ func processGoogleUserInfo(ctx context.Context, code string) (*models.User, erro
 		log.Debug("Failed to parse ID Token claims: ", err)
 		return nil, fmt.Errorf("unable to extract claims")
 	}
+	emailClaims := struct {
+		EmailVerified bool `json:"email_verified"`
+	}{}
+	if err := idToken.Claims(&emailClaims); err != nil {
+		log.Debug("Failed to parse email_verified claim: ", err)
+	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:            user,
+		ProviderUserID:  idToken.Subject,
+		IsEmailVerified: emailClaims.EmailVerified,
+	}, nil
 }
 
-func processGithubUserInfo(ctx context.Context, code string) (*models.User, error) {
+func processGithubUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.GithubConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
 		return nil, fmt.Errorf("invalid github exchange code: %s", err.Error())
 	}
-	client := http.Client{}
-	req, err := http.NewRequest("GET", constants.GithubUserInfoURL, nil)
+	client := tracing.HTTPClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", constants.GithubUserInfoURL, nil)
 	if err != nil {
 		log.Debug("Failed to create github user info request: ", err)
 		return nil, fmt.Errorf("error creating github user info request: %s", err.Error())
@@ -384,6 +538,11 @@ func processGithubUserInfo(ctx context.Context, code string) (*models.User, erro
 
 	userRawData := make(map[string]string)
 	json.Unmarshal(body, &userRawData)
+	// github user id is numeric and gets dropped by the string map above
+	githubUser := struct {
+		ID int64 `json:"id"`
+	}{}
+	json.Unmarshal(body, &githubUser)
 
 	name := strings.Split(userRawData["name"], " ")
 	firstName := ""
@@ -397,15 +556,18 @@ func processGithubUserInfo(ctx context.Context, code string) (*models.User, erro
 
 	picture := userRawData["avatar_url"]
 	email := userRawData["email"]
+	// github only allows verified addresses to be set as public profile email
+	isEmailVerified := email != ""
 
 	if email == "" {
 		type GithubUserEmails struct {
-			Email   string `json:"email"`
-			Primary bool   `json:"primary"`
+			Email    string `json:"email"`
+			Primary  bool   `json:"primary"`
+			Verified bool   `json:"verified"`
 		}
 
 		// fetch using /users/email endpoint
-		req, err := http.NewRequest(http.MethodGet, constants.GithubUserEmails, nil)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, constants.GithubUserEmails, nil)
 		if err != nil {
 			log.Debug("Failed to create github emails request: ", err)
 			return nil, fmt.Errorf("error creating github user info request: %s", err.Error())
@@ -440,6 +602,7 @@ func processGithubUserInfo(ctx context.Context, code string) (*models.User, erro
 
 		for _, userEmail := range emailData {
 			email = userEmail.Email
+			isEmailVerified = userEmail.Verified
 			if userEmail.Primary {
 				break
 			}
@@ -453,17 +616,21 @@ func processGithubUserInfo(ctx context.Context, code string) (*models.User, erro
 		Email:      &email,
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:            user,
+		ProviderUserID:  strconv.FormatInt(githubUser.ID, 10),
+		IsEmailVerified: isEmailVerified,
+	}, nil
 }
 
-func processFacebookUserInfo(ctx context.Context, code string) (*models.User, error) {
+func processFacebookUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.FacebookConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Invalid facebook exchange code: ", err)
 		return nil, fmt.Errorf("invalid facebook exchange code: %s", err.Error())
 	}
-	client := http.Client{}
-	req, err := http.NewRequest("GET", constants.FacebookUserInfoURL+oauth2Token.AccessToken, nil)
+	client := tracing.HTTPClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", constants.FacebookUserInfoURL+oauth2Token.AccessToken, nil)
 	if err != nil {
 		log.Debug("Error creating facebook user info request: ", err)
 		return nil, fmt.Errorf("error creating facebook user info request: %s", err.Error())
@@ -503,18 +670,21 @@ func processFacebookUserInfo(ctx context.Context, code string) (*models.User, er
 		Email:      &email,
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:           user,
+		ProviderUserID: fmt.Sprintf("%v", userRawData["id"]),
+	}, nil
 }
 
-func processLinkedInUserInfo(ctx context.Context, code string) (*models.User, error) {
+func processLinkedInUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.LinkedInConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
 		return nil, fmt.Errorf("invalid linkedin exchange code: %s", err.Error())
 	}
 
-	client := http.Client{}
-	req, err := http.NewRequest("GET", constants.LinkedInUserInfoURL, nil)
+	client := tracing.HTTPClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", constants.LinkedInUserInfoURL, nil)
 	if err != nil {
 		log.Debug("Failed to create linkedin user info request: ", err)
 		return nil, fmt.Errorf("error creating linkedin user info request: %s", err.Error())
@@ -544,7 +714,7 @@ func processLinkedInUserInfo(ctx context.Context, code string) (*models.User, er
 	userRawData := make(map[string]interface{})
 	json.Unmarshal(body, &userRawData)
 
-	req, err = http.NewRequest("GET", constants.LinkedInEmailURL, nil)
+	req, err = http.NewRequestWithContext(ctx, "GET", constants.LinkedInEmailURL, nil)
 	if err != nil {
 		log.Debug("Failed to create linkedin email info request: ", err)
 		return nil, fmt.Errorf("error creating linkedin user info request: %s", err.Error())
@@ -584,22 +754,25 @@ func processLinkedInUserInfo(ctx context.Context, code string) (*models.User, er
 		Email:      &emailAddress,
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:           user,
+		ProviderUserID: fmt.Sprintf("%v", userRawData["id"]),
+	}, nil
 }
 
-func processAppleUserInfo(ctx context.Context, code string, user_ *AppleUserInfo) (*models.User, error) {
+func processAppleUserInfo(ctx context.Context, code string, user_ *AppleUserInfo) (*oauthUserInfo, error) {
 	var user = &models.User{}
 	oauth2Token, err := oauth.OAuthProviders.AppleConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
-		return user, fmt.Errorf("invalid apple exchange code: %s", err.Error())
+		return nil, fmt.Errorf("invalid apple exchange code: %s", err.Error())
 	}
 
 	// Extract the ID Token from OAuth2 token.
 	rawIDToken, ok := oauth2Token.Extra("id_token").(string)
 	if !ok {
 		log.Debug("Failed to extract ID Token from OAuth2 token")
-		return user, fmt.Errorf("unable to extract id_token")
+		return nil, fmt.Errorf("unable to extract id_token")
 	}
 
 	tokenSplit := strings.Split(rawIDToken, ".")
@@ -607,18 +780,18 @@ func processAppleUserInfo(ctx context.Context, code string, user_ *AppleUserInfo
 	decodedClaimsData, err := base64.RawURLEncoding.DecodeString(claimsData)
 	if err != nil {
 		log.Debugf("Failed to decrypt claims %s: %s", claimsData, err.Error())
-		return user, fmt.Errorf("failed to decrypt claims data: %s", err.Error())
+		return nil, fmt.Errorf("failed to decrypt claims data: %s", err.Error())
 	}
 
 	claims := make(map[string]interface{})
 	err = json.Unmarshal(decodedClaimsData, &claims)
 	if err != nil {
 		log.Debug("Failed to unmarshal claims data: ", err)
-		return user, fmt.Errorf("failed to unmarshal claims data: %s", err.Error())
+		return nil, fmt.Errorf("failed to unmarshal claims data: %s", err.Error())
 	}
 	if val, ok := claims["email"]; !ok || val == nil {
 		log.Debug("Failed to extract email from claims.")
-		return user, fmt.Errorf("unable to extract email, please check the scopes enabled for your app. It needs `email`, `name` scopes")
+		return nil, fmt.Errorf("unable to extract email, please check the scopes enabled for your app. It needs `email`, `name` scopes")
 	} else {
 		email := val.(string)
 		user.Email = &email
@@ -639,18 +812,24 @@ func processAppleUserInfo(ctx context.Context, code string, user_ *AppleUserInfo
 	user.GivenName = &user_.Name.FirstName
 	user.FamilyName = &user_.Name.LastName
 
-	return user, err
+	// apple sends email_verified either as boolean or as string
+	isEmailVerified := fmt.Sprintf("%v", claims["email_verified"]) == "true"
+	return &oauthUserInfo{
+		User:            user,
+		ProviderUserID:  fmt.Sprintf("%v", claims["sub"]),
+		IsEmailVerified: isEmailVerified,
+	}, err
 }
 
-func processDiscordUserInfo(ctx context.Context, code string) (*models.User, error) {
+func processDiscordUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.DiscordConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
 		return nil, fmt.Errorf("invalid discord exchange code: %s", err.Error())
 	}
 
-	client := http.Client{}
-	req, err := http.NewRequest("GET", constants.DiscordUserInfoURL, nil)
+	client := tracing.HTTPClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", constants.DiscordUserInfoURL, nil)
 	if err != nil {
 		log.Debug("Failed to create Discord user info request: ", err)
 		return nil, fmt.Errorf("error creating Discord user info request: %s", err.Error())
@@ -704,18 +883,21 @@ func processDiscordUserInfo(ctx context.Context, code string) (*models.User, err
 		Picture:   &profilePicture,
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:           user,
+		ProviderUserID: fmt.Sprintf("%v", userRawData["id"]),
+	}, nil
 }
 
-func processTwitterUserInfo(ctx context.Context, code, verifier string) (*models.User, error) {
+func processTwitterUserInfo(ctx context.Context, code, verifier string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.TwitterConfig.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
 		return nil, fmt.Errorf("invalid twitter exchange code: %s", err.Error())
 	}
 
-	client := http.Client{}
-	req, err := http.NewRequest("GET", constants.TwitterUserInfoURL, nil)
+	client := tracing.HTTPClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", constants.TwitterUserInfoURL, nil)
 	if err != nil {
 		log.Debug("Failed to create Twitter user info request: ", err)
 		return nil, fmt.Errorf("error creating Twitter user info request: %s", err.Error())
@@ -770,11 +952,14 @@ func processTwitterUserInfo(ctx context.Context, code, verifier string) (*models
 		Nickname:   &nickname,
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:           user,
+		ProviderUserID: fmt.Sprintf("%v", userRawData["id"]),
+	}, nil
 }
 
 // process microsoft user information
-func processMicrosoftUserInfo(ctx context.Context, code string) (*models.User, error) {
+func processMicrosoftUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.MicrosoftConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
@@ -803,11 +988,14 @@ func processMicrosoftUserInfo(ctx context.Context, code string) (*models.User, e
 		return nil, fmt.Errorf("unable to extract claims")
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:           user,
+		ProviderUserID: idToken.Subject,
+	}, nil
 }
 
 // process twitch user information
-func processTwitchUserInfo(ctx context.Context, code string) (*models.User, error) {
+func processTwitchUserInfo(ctx context.Context, code string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.TwitchConfig.Exchange(ctx, code)
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
@@ -837,20 +1025,30 @@ func processTwitchUserInfo(ctx context.Context, code string) (*models.User, erro
 		log.Debug("Failed to parse ID Token claims: ", err)
 		return nil, fmt.Errorf("unable to extract claims")
 	}
+	emailClaims := struct {
+		EmailVerified bool `json:"email_verified"`
+	}{}
+	if err := idToken.Claims(&emailClaims); err != nil {
+		log.Debug("Failed to parse email_verified claim: ", err)
+	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:            user,
+		ProviderUserID:  idToken.Subject,
+		IsEmailVerified: emailClaims.EmailVerified,
+	}, nil
 }
 
 // process roblox user information
-func processRobloxUserInfo(ctx context.Context, code, verifier string) (*models.User, error) {
+func processRobloxUserInfo(ctx context.Context, code, verifier string) (*oauthUserInfo, error) {
 	oauth2Token, err := oauth.OAuthProviders.RobloxConfig.Exchange(ctx, code, oauth2.SetAuthURLParam("code_verifier", verifier))
 	if err != nil {
 		log.Debug("Failed to exchange code for token: ", err)
 		return nil, fmt.Errorf("invalid roblox exchange code: %s", err.Error())
 	}
 
-	client := http.Client{}
-	req, err := http.NewRequest("GET", constants.RobloxUserInfoURL, nil)
+	client := tracing.HTTPClient()
+	req, err := http.NewRequestWithContext(ctx, "GET", constants.RobloxUserInfoURL, nil)
 	if err != nil {
 		log.Debug("Failed to create roblox user info request: ", err)
 		return nil, fmt.Errorf("error creating roblox user info request: %s", err.Error())
@@ -903,5 +1101,8 @@ func processRobloxUserInfo(ctx context.Context, code, verifier string) (*models.
 		Email:      &email,
 	}
 
-	return user, nil
+	return &oauthUserInfo{
+		User:           user,
+		ProviderUserID: fmt.Sprintf("%v", userRawData["sub"]),
+	}, nil
 }
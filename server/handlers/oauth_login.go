@@ -96,6 +96,19 @@ func OAuthLoginHandler() gin.HandlerFunc {
 		}
 
 		oauthStateString := state + "___" + redirectURI + "___" + roles + "___" + strings.Join(scope, " ")
+		// link_token is issued by link_identity mutation for linking the account with logged in user
+		linkToken := strings.TrimSpace(c.Query("link_token"))
+		if linkToken != "" {
+			linkUserID, err := memorystore.Provider.GetState(linkToken)
+			if err != nil || linkUserID == "" {
+				log.Debug("Invalid link token: ", err)
+				c.JSON(400, gin.H{
+					"error": "invalid link token",
+				})
+				return
+			}
+			oauthStateString += "___" + linkToken
+		}
 
 		provider := c.Param("oauth_provider")
 		isProviderConfigured := true
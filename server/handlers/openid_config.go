@@ -6,27 +6,74 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
 )
 
 // OpenIDConfigurationHandler handler for open-id configurations
+// Optional features are advertised only when they are configured
 func OpenIDConfigurationHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		issuer := parsers.GetHost(c)
 		jwtType, _ := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
+		roleClaim, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtRoleClaim)
+		if err != nil || roleClaim == "" {
+			roleClaim = "roles"
+		}
 
-		c.JSON(200, gin.H{
-			"issuer":                                issuer,
-			"authorization_endpoint":                issuer + "/authorize",
-			"token_endpoint":                        issuer + "/oauth/token",
-			"userinfo_endpoint":                     issuer + "/userinfo",
-			"jwks_uri":                              issuer + "/.well-known/jwks.json",
-			"registration_endpoint":                 issuer + "/app",
-			"response_types_supported":              []string{"code", "token", "id_token"},
-			"scopes_supported":                      []string{"openid", "email", "profile"},
-			"response_modes_supported":              []string{"query", "fragment", "form_post", "web_message"},
-			"subject_types_supported":               []string{"public"},
-			"id_token_signing_alg_values_supported": []string{jwtType},
-			"claims_supported":                      []string{"aud", "exp", "iss", "iat", "sub", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "picture", "email", "email_verified", "roles", "role", "gender", "birthdate", "phone_number", "phone_number_verified", "nonce", "updated_at", "created_at", "revoked_timestamp", "login_method", "signup_methods", "token_type"},
-		})
+		grantTypes := []string{"authorization_code", "refresh_token", "implicit"}
+		if policy, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyTokenExchangePolicy); err == nil && policy != "" {
+			grantTypes = append(grantTypes, token.GrantTypeTokenExchange)
+		}
+
+		claims := []string{"aud", "exp", "iss", "iat", "sub", "sid", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "picture", "email", "email_verified", "roles", "role", "gender", "birthdate", "phone_number", "phone_number_verified", "nonce", "updated_at", "created_at", "revoked_timestamp", "login_method", "signup_methods", "token_type"}
+		if roleClaim != "roles" && roleClaim != "role" {
+			claims = append(claims, roleClaim)
+		}
+		for _, claim := range token.GetCustomScopeClaims() {
+			if !utils.StringSliceContains(claims, claim) {
+				claims = append(claims, claim)
+			}
+		}
+
+		res := gin.H{
+			"issuer":                                      issuer,
+			"authorization_endpoint":                      issuer + "/authorize",
+			"token_endpoint":                              issuer + "/oauth/token",
+			"userinfo_endpoint":                           issuer + "/userinfo",
+			"jwks_uri":                                    issuer + "/.well-known/jwks.json",
+			"revocation_endpoint":                         issuer + "/oauth/revoke",
+			"end_session_endpoint":                        issuer + "/logout",
+			"response_types_supported":                    []string{"code", "token", "id_token"},
+			"response_modes_supported":                    []string{"query", "fragment", "form_post", "web_message"},
+			"grant_types_supported":                       grantTypes,
+			"scopes_supported":                            token.GetSupportedScopes(),
+			"subject_types_supported":                     []string{"public"},
+			"id_token_signing_alg_values_supported":       []string{jwtType},
+			"token_endpoint_auth_methods_supported":       []string{"client_secret_basic", "client_secret_post", "none"},
+			"revocation_endpoint_auth_methods_supported":  []string{"none"},
+			"code_challenge_methods_supported":            []string{"S256"},
+			"dpop_signing_alg_values_supported":           token.DPoPSigningAlgValuesSupported(),
+			"pushed_authorization_request_endpoint":       issuer + "/oauth/par",
+			"require_pushed_authorization_requests":       false,
+			"request_parameter_supported":                 true,
+			"request_uri_parameter_supported":             false,
+			"request_object_signing_alg_values_supported": token.RequestObjectSigningAlgValuesSupported(),
+			"claims_supported":                            claims,
+			"frontchannel_logout_supported":               false,
+			"backchannel_logout_supported":                false,
+		}
+		// registered clients can have their own back-channel logout uri
+		if initialAccessToken, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken); err == nil && initialAccessToken != "" {
+			res["registration_endpoint"] = issuer + "/oauth/register"
+			res["backchannel_logout_supported"] = true
+			res["backchannel_logout_session_supported"] = true
+		}
+		if logoutURI, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyBackchannelLogoutURI); err == nil && logoutURI != "" {
+			res["backchannel_logout_supported"] = true
+			res["backchannel_logout_session_supported"] = true
+		}
+
+		c.JSON(200, res)
 	}
 }
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+const (
+	// parRequestURIPrefix is the prefix of request_uri issued by pushed authorization request endpoint
+	parRequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+	// parStoreNamespace is the memory store namespace for pushed authorization requests
+	parStoreNamespace = "pushed_authorization_request"
+	// parUsedStoreNamespace is the memory store namespace for request_uri which are used already
+	parUsedStoreNamespace = "pushed_authorization_request_used"
+	// parExpiresIn is the lifetime of request_uri in seconds
+	parExpiresIn = 60
+)
+
+// PushedAuthorizationRequestHandler is the handler for /oauth/par route (RFC 9126).
+// It stores the authorization request params sent via back channel and
+// returns request_uri which can be used with /authorize
+func PushedAuthorizationRequestHandler() gin.HandlerFunc {
+	return func(gc *gin.Context) {
+		if err := gc.Request.ParseForm(); err != nil {
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "error parsing request body",
+			})
+			return
+		}
+		params := gc.Request.PostForm
+		clientID := strings.TrimSpace(params.Get("client_id"))
+		clientSecret := strings.TrimSpace(params.Get("client_secret"))
+		if basicClientID, basicClientSecret, ok := gc.Request.BasicAuth(); ok {
+			clientID = basicClientID
+			clientSecret = basicClientSecret
+		}
+
+		invalidClient := gin.H{
+			"error":             "invalid_client",
+			"error_description": "client authentication failed",
+		}
+		client, err := getClient(gc, clientID)
+		if err != nil {
+			log.Debug("Invalid client: ", err)
+			gc.JSON(http.StatusUnauthorized, invalidClient)
+			return
+		}
+		if client != nil && !authenticateClient(client, clientSecret) {
+			log.Debug("Client authentication failed for client: ", clientID)
+			gc.JSON(http.StatusUnauthorized, invalidClient)
+			return
+		}
+		if client == nil && clientSecret != "" {
+			if envClientSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientSecret); clientSecret != envClientSecret || err != nil {
+				log.Debug("Client secret is invalid: ", clientID)
+				gc.JSON(http.StatusUnauthorized, invalidClient)
+				return
+			}
+		}
+
+		if params.Get("request_uri") != "" {
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "request_uri is not allowed in pushed authorization request",
+			})
+			return
+		}
+		if requestObject := params.Get("request"); requestObject != "" {
+			params, err = parseRequestObject(gc, client, requestObject)
+			if err != nil {
+				log.Debug("Invalid request object: ", err)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_request_object",
+					"error_description": err.Error(),
+				})
+				return
+			}
+		}
+		params.Set("client_id", clientID)
+		params.Del("client_secret")
+
+		if client != nil {
+			redirectURI := strings.TrimSpace(params.Get("redirect_uri"))
+			if redirectURIs := client.GetRedirectURIs(); redirectURI == "" && len(redirectURIs) == 1 {
+				redirectURI = redirectURIs[0]
+			}
+			if !client.HasRedirectURI(redirectURI) {
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_request",
+					"error_description": "redirect_uri is not registered for the client",
+				})
+				return
+			}
+		}
+
+		requestID, err := generateClientCredential()
+		if err != nil {
+			log.Debug("Failed to generate request uri: ", err)
+			gc.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+			return
+		}
+		expiresAt := time.Now().Add(parExpiresIn * time.Second).Unix()
+		if err := memorystore.Provider.SetUserSession(parStoreNamespace, requestID, params.Encode(), expiresAt); err != nil {
+			log.Debug("Failed to store pushed authorization request: ", err)
+			gc.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+			return
+		}
+
+		gc.Header("Cache-Control", "no-store")
+		gc.JSON(http.StatusCreated, gin.H{
+			"request_uri": parRequestURIPrefix + requestID,
+			"expires_in":  parExpiresIn,
+		})
+	}
+}
+
+// getPushedAuthorizationRequest returns the authorization request params stored
+// for request_uri, it can only be used once by the client which pushed the request
+func getPushedAuthorizationRequest(requestURI, clientID string) (url.Values, error) {
+	if !strings.HasPrefix(requestURI, parRequestURIPrefix) {
+		return nil, errors.New("invalid request_uri")
+	}
+	requestID := strings.TrimPrefix(requestURI, parRequestURIPrefix)
+	value, err := memorystore.Provider.GetUserSession(parStoreNamespace, requestID)
+	if err != nil || value == "" {
+		return nil, errors.New("request_uri is invalid or expired")
+	}
+	params, err := url.ParseQuery(value)
+	if err != nil {
+		return nil, errors.New("invalid request_uri")
+	}
+	if params.Get("client_id") != clientID {
+		return nil, errors.New("request_uri was not issued to the client")
+	}
+	// request_uri can only be used once, marking it used is atomic
+	// so that concurrent requests with same request_uri cannot both use it
+	marked, err := memorystore.Provider.SetUserSessionIfNotExists(parUsedStoreNamespace, requestID, requestID, time.Now().Add(parExpiresIn*time.Second).Unix())
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, errors.New("request_uri is invalid or expired")
+	}
+	if err := memorystore.Provider.DeleteUserSession(parStoreNamespace, requestID); err != nil {
+		log.Debug("Failed to delete pushed authorization request: ", err)
+	}
+	return params, nil
+}
+
+// getAuthorizeRequestParams returns the params of authorization request and the
+// registered client sending it. Params are read from the pushed authorization
+// request, signed request object or the query string in that order.
+func getAuthorizeRequestParams(gc *gin.Context) (url.Values, *models.Client, error) {
+	query := gc.Request.URL.Query()
+	clientID := strings.TrimSpace(query.Get("client_id"))
+	client, err := getClient(gc, clientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if requestURI := strings.TrimSpace(query.Get("request_uri")); requestURI != "" {
+		params, err := getPushedAuthorizationRequest(requestURI, clientID)
+		return params, client, err
+	}
+	if client != nil && client.RequirePushedAuthorizationRequests {
+		return nil, nil, errors.New("pushed authorization request is required for the client")
+	}
+	if requestObject := strings.TrimSpace(query.Get("request")); requestObject != "" {
+		params, err := parseRequestObject(gc, client, requestObject)
+		return params, client, err
+	}
+	return query, client, nil
+}
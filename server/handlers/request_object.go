@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+const (
+	// maxJWKSResponseSize is the max size of key set fetched from jwks_uri of client
+	maxJWKSResponseSize = 64 * 1024
+	// clientJWKSStoreNamespace is the memory store namespace for key sets fetched from jwks_uri
+	clientJWKSStoreNamespace = "client_jwks"
+	// clientJWKSCacheTime is the time for which key set fetched from jwks_uri is cached
+	clientJWKSCacheTime = 5 * time.Minute
+	// maxRequestObjectLifetime is the max time for which request object is accepted
+	maxRequestObjectLifetime = time.Hour
+	// requestObjectJTIStoreNamespace is the memory store namespace for jti of used request objects
+	requestObjectJTIStoreNamespace = "request_object_jti"
+)
+
+// requestObjectClaims are the claims of request object which are not authorization request params
+var requestObjectClaims = []string{"iss", "aud", "exp", "iat", "nbf", "jti", "sub"}
+
+// getClientJWKS returns the public keys of client from jwks or jwks_uri metadata.
+// Key set fetched from jwks_uri is cached, refresh skips the cache, eg: when client rotated its keys.
+func getClientJWKS(client *models.Client, refresh bool) (*jose.JSONWebKeySet, error) {
+	var keySet jose.JSONWebKeySet
+	if client.JWKS != "" {
+		if err := json.Unmarshal([]byte(client.JWKS), &keySet); err != nil {
+			return nil, err
+		}
+		return &keySet, nil
+	}
+	if client.JWKSURI == "" {
+		return nil, errors.New("client has no registered keys")
+	}
+	// cache is keyed by uri as well, so that updating jwks_uri of client takes effect immediately
+	cacheKey := client.ClientID + "@" + client.JWKSURI
+	if !refresh {
+		if cached, err := memorystore.Provider.GetUserSession(clientJWKSStoreNamespace, cacheKey); err == nil && cached != "" {
+			if err := json.Unmarshal([]byte(cached), &keySet); err == nil {
+				return &keySet, nil
+			}
+		}
+	}
+	// jwks_uri is registered by client, so it cannot point to internal network
+	httpClient := utils.NewPublicHTTPClient(5 * time.Second)
+	res, err := httpClient.Get(client.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get client keys, status %d", res.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(res.Body, maxJWKSResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &keySet); err != nil {
+		return nil, err
+	}
+	if err := memorystore.Provider.SetUserSession(clientJWKSStoreNamespace, cacheKey, string(body), time.Now().Add(clientJWKSCacheTime).Unix()); err != nil {
+		log.Debug("Failed to cache client keys: ", err)
+	}
+	return &keySet, nil
+}
+
+// parseRequestObject verifies the signed request object (RFC 9101) with the keys of
+// registered client and returns the authorization request params in it.
+// Params sent outside of request object are ignored.
+func parseRequestObject(gc *gin.Context, client *models.Client, requestObject string) (url.Values, error) {
+	if client == nil {
+		return nil, errors.New("request object is only supported for registered clients")
+	}
+	jws, err := jose.ParseSigned(requestObject)
+	if err != nil || len(jws.Signatures) != 1 {
+		return nil, errors.New("invalid request object")
+	}
+	header := jws.Signatures[0].Header
+	if !utils.StringSliceContains(token.RequestObjectSigningAlgValuesSupported(), header.Algorithm) {
+		return nil, fmt.Errorf("unsupported request object signing alg %s", header.Algorithm)
+	}
+	keySet, err := getClientJWKS(client, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client keys: %s", err.Error())
+	}
+	keys := keySet.Keys
+	if header.KeyID != "" {
+		keys = keySet.Key(header.KeyID)
+		// key could have been added after key set was cached
+		if len(keys) == 0 && client.JWKSURI != "" {
+			if keySet, err = getClientJWKS(client, true); err != nil {
+				return nil, fmt.Errorf("failed to get client keys: %s", err.Error())
+			}
+			keys = keySet.Key(header.KeyID)
+		}
+	}
+	var payload []byte
+	for _, key := range keys {
+		if key.Algorithm != "" && key.Algorithm != header.Algorithm {
+			continue
+		}
+		if payload, err = jws.Verify(key); err == nil {
+			break
+		}
+	}
+	if payload == nil {
+		return nil, errors.New("invalid request object signature")
+	}
+
+	claims := map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, errors.New("invalid request object claims")
+	}
+	if iss, ok := claims["iss"]; ok && iss != client.ClientID {
+		return nil, errors.New("invalid request object issuer")
+	}
+	if clientID, ok := claims["client_id"]; ok && clientID != client.ClientID {
+		return nil, errors.New("invalid request object client_id")
+	}
+	if aud, ok := claims["aud"]; ok {
+		issuer := parsers.GetHost(gc)
+		if aud != issuer && !utils.StringSliceContains(interfaceSliceToStrings(aud), issuer) {
+			return nil, errors.New("invalid request object audience")
+		}
+	}
+	exp, err := strconv.ParseInt(fmt.Sprint(claims["exp"]), 10, 64)
+	now := time.Now().Unix()
+	if err != nil || exp < now || exp > now+int64(maxRequestObjectLifetime.Seconds()) {
+		return nil, errors.New("invalid request object expiry")
+	}
+	if nbf, ok := claims["nbf"]; ok {
+		if nbfTime, err := strconv.ParseInt(fmt.Sprint(nbf), 10, 64); err != nil || nbfTime > now {
+			return nil, errors.New("request object is not valid yet")
+		}
+	}
+	// request object can only be used once, jti is remembered until the request object expires
+	jti, ok := claims["jti"].(string)
+	if !ok || jti == "" {
+		return nil, errors.New("request object jti is required")
+	}
+	marked, err := memorystore.Provider.SetUserSessionIfNotExists(requestObjectJTIStoreNamespace, client.ClientID+":"+jti, jti, exp)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, errors.New("request object jti already used")
+	}
+
+	params := url.Values{}
+	for key, value := range claims {
+		if utils.StringSliceContains(requestObjectClaims, key) {
+			continue
+		}
+		switch v := value.(type) {
+		case string:
+			params.Set(key, v)
+		case json.Number:
+			params.Set(key, v.String())
+		default:
+			// structured params like claims are sent as json
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			params.Set(key, string(encoded))
+		}
+	}
+	params.Set("client_id", client.ClientID)
+	return params, nil
+}
+
+// interfaceSliceToStrings returns the string values of json array
+func interfaceSliceToStrings(value interface{}) []string {
+	res := []string{}
+	for _, v := range utils.ConvertInterfaceToSlice(value) {
+		if s, ok := v.(string); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevertChangeHandler handles the revert change route.
+// It restores the previous email or phone number of user based on JWT token in query string,
+// which is sent to previous email or phone number once it is changed, and ends all the sessions of user.
+// Verification request of revert is saved with user id, as user is no longer found with previous value.
+func RevertChangeHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		redirectURL := strings.TrimSpace(c.Query("redirect_uri"))
+		errorRes := gin.H{
+			"error": "token is required",
+		}
+		tokenInQuery := c.Query("token")
+		if tokenInQuery == "" {
+			log.Debug("Token is empty")
+			utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+			return
+		}
+
+		verificationRequest, err := db.Provider.GetVerificationRequestByToken(c, tokenInQuery)
+		if err != nil || (verificationRequest.Identifier != constants.VerificationTypeRevertEmailChange && verificationRequest.Identifier != constants.VerificationTypeRevertPhoneNumberChange) {
+			log.Debug("Error getting verification request: ", err)
+			errorRes["error"] = "invalid token"
+			utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+			return
+		}
+
+		hostname := parsers.GetHost(c)
+		claim, err := token.ParseJWTToken(tokenInQuery)
+		if err != nil {
+			log.Debug("Error parsing token: ", err)
+			errorRes["error"] = err.Error()
+			utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+			return
+		}
+		if ok, err := token.ValidateJWTClaims(claim, hostname, verificationRequest.Nonce, verificationRequest.Email); !ok || err != nil {
+			log.Debug("Error validating jwt claims: ", err)
+			errorRes["error"] = err.Error()
+			utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+			return
+		}
+		if redirectURL == "" {
+			redirectURL, _ = claim["redirect_uri"].(string)
+		}
+
+		previousValue, _ := claim["previous_value"].(string)
+		user, err := db.Provider.GetUserByID(c, verificationRequest.Email)
+		if err != nil {
+			log.Debug("Error getting user: ", err)
+			errorRes["error"] = "user not found"
+			utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+			return
+		}
+
+		log := log.WithField("user_id", user.ID)
+		now := time.Now().Unix()
+		if verificationRequest.Identifier == constants.VerificationTypeRevertEmailChange {
+			if existingUser, err := db.Provider.GetUserByEmail(c, previousValue); err == nil && existingUser.ID != user.ID {
+				log.Debug("Previous email is used by other user")
+				errorRes["error"] = "email is already used by other user"
+				utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+				return
+			}
+			// changed email is not verified anymore
+			if pendingRequest, err := db.Provider.GetVerificationRequestByEmail(c, refs.StringValue(user.Email), constants.VerificationTypeUpdateEmail); err == nil {
+				db.Provider.DeleteVerificationRequest(c, pendingRequest)
+			}
+			user.Email = refs.NewStringRef(previousValue)
+			user.EmailVerifiedAt = &now
+		} else {
+			if existingUser, err := db.Provider.GetUserByPhoneNumber(c, previousValue); err == nil && existingUser.ID != user.ID {
+				log.Debug("Previous phone number is used by other user")
+				errorRes["error"] = "phone number is already used by other user"
+				utils.HandleRedirectORJsonResponse(c, http.StatusBadRequest, errorRes, generateRedirectURL(redirectURL, errorRes))
+				return
+			}
+			user.PhoneNumber = refs.NewStringRef(previousValue)
+			user.PhoneNumberVerifiedAt = &now
+		}
+		user, err = db.Provider.UpdateUser(c, user)
+		if err != nil {
+			log.Debug("Error updating user: ", err)
+			errorRes["error"] = err.Error()
+			utils.HandleRedirectORJsonResponse(c, http.StatusInternalServerError, errorRes, generateRedirectURL(redirectURL, errorRes))
+			return
+		}
+		db.Provider.DeleteVerificationRequest(c, verificationRequest)
+
+		// change might have been done with a stolen session
+		go func() {
+			memorystore.Provider.DeleteAllUserSessions(user.ID)
+			logout.SendBackchannelLogout(hostname, user.ID, "")
+		}()
+
+		res := gin.H{
+			"message": "change reverted successfully, please login again",
+		}
+		utils.HandleRedirectORJsonResponse(c, http.StatusOK, res, generateRedirectURL(redirectURL, res))
+	}
+}
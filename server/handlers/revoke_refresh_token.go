@@ -8,7 +8,9 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
 )
 
@@ -68,6 +70,7 @@ func RevokeRefreshTokenHandler() gin.HandlerFunc {
 		}
 
 		memorystore.Provider.DeleteUserSession(sessionToken, claims["nonce"].(string))
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), userID, claims["nonce"].(string))
 
 		gc.JSON(http.StatusOK, gin.H{
 			"message": "Token revoked successfully",
@@ -3,6 +3,7 @@ package handlers
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -14,8 +15,13 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
 )
 
 type RequestBody struct {
@@ -26,6 +32,13 @@ type RequestBody struct {
 	GrantType    string `form:"grant_type" json:"grant_type"`
 	RefreshToken string `form:"refresh_token" json:"refresh_token"`
 	RedirectURI  string `form:"redirect_uri" json:"redirect_uri"`
+	// token exchange grant params
+	SubjectToken     string `form:"subject_token" json:"subject_token"`
+	SubjectTokenType string `form:"subject_token_type" json:"subject_token_type"`
+	ActorToken       string `form:"actor_token" json:"actor_token"`
+	ActorTokenType   string `form:"actor_token_type" json:"actor_token_type"`
+	Audience         string `form:"audience" json:"audience"`
+	Scope            string `form:"scope" json:"scope"`
 }
 
 // TokenHandler to handle /oauth/token requests
@@ -55,13 +68,15 @@ func TokenHandler() gin.HandlerFunc {
 
 		isRefreshTokenGrant := grantType == "refresh_token"
 		isAuthorizationCodeGrant := grantType == "authorization_code"
+		isTokenExchangeGrant := grantType == token.GrantTypeTokenExchange
 
-		if !isRefreshTokenGrant && !isAuthorizationCodeGrant {
+		if !isRefreshTokenGrant && !isAuthorizationCodeGrant && !isTokenExchangeGrant {
 			log.Debug("Invalid grant type: ", grantType)
 			gc.JSON(http.StatusBadRequest, gin.H{
 				"error":             "invalid_grant_type",
 				"error_description": "grant_type is invalid",
 			})
+			return
 		}
 
 		// check if clientID & clientSecret are present as part of
@@ -79,7 +94,8 @@ func TokenHandler() gin.HandlerFunc {
 			return
 		}
 
-		if client, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID); clientID != client || err != nil {
+		registeredClient, err := getClient(gc, clientID)
+		if err != nil {
 			log.Debug("Client ID is invalid: ", clientID)
 			gc.JSON(http.StatusBadRequest, gin.H{
 				"error":             "invalid_client_id",
@@ -87,11 +103,67 @@ func TokenHandler() gin.HandlerFunc {
 			})
 			return
 		}
+		if registeredClient != nil {
+			if !authenticateClient(registeredClient, clientSecret) {
+				log.Debug("Client authentication failed: ", clientID)
+				gc.JSON(http.StatusUnauthorized, gin.H{
+					"error":             "invalid_client",
+					"error_description": "The client authentication failed",
+				})
+				return
+			}
+			if !registeredClient.HasGrantType(grantType) {
+				log.Debug("Grant type not allowed for client: ", grantType)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "unauthorized_client",
+					"error_description": "The client is not allowed to use the grant type",
+				})
+				return
+			}
+		}
+
+		// sender constrain the tokens when DPoP proof is present
+		dpopJKT := ""
+		if dpopProof := gc.GetHeader(token.DPoPHeader); dpopProof != "" {
+			var err error
+			dpopJKT, err = token.ValidateDPoPProof(dpopProof, http.MethodPost, token.GetDPoPRequestURL(gc), "")
+			if err != nil {
+				log.Debug("Error validating DPoP proof: ", err)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_dpop_proof",
+					"error_description": err.Error(),
+				})
+				return
+			}
+		}
+
+		if isTokenExchangeGrant {
+			exchangeToken(gc, reqBody, registeredClient, clientID, clientSecret, dpopJKT)
+			return
+		}
 
 		var userID string
 		var roles, scope []string
 		loginMethod := ""
 		sessionKey := ""
+		// nonce of the session being rolled over
+		sessionNonce := ""
+		// time of authentication of the session being rolled over
+		var authTime int64
+		// family of the refresh token being rotated
+		refreshTokenFamilyID := ""
+		// organization selected for the session
+		organizationID := ""
+
+		if isRefreshTokenGrant {
+			defer func() {
+				outcome := metrics.OutcomeSuccess
+				if gc.Writer.Status() >= http.StatusBadRequest {
+					outcome = metrics.OutcomeFailure
+				}
+				metrics.RecordAuthEvent(metrics.EventTokenRefresh, loginMethod, outcome)
+			}()
+		}
 
 		if isAuthorizationCodeGrant {
 			if code == "" {
@@ -127,6 +199,20 @@ func TokenHandler() gin.HandlerFunc {
 
 			go memorystore.Provider.RemoveState(code)
 
+			// code issued to registered client can only be used by it
+			codeClientID, _ := memorystore.Provider.GetState(codeClientStateKey(code))
+			if codeClientID != "" {
+				go memorystore.Provider.RemoveState(codeClientStateKey(code))
+			}
+			if (registeredClient == nil && codeClientID != "") || (registeredClient != nil && codeClientID != clientID) {
+				log.Debug("Code was issued to another client: ", clientID)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_grant",
+					"error_description": "The code was issued to another client",
+				})
+				return
+			}
+
 			if codeVerifier != "" {
 				hash := sha256.New()
 				hash.Write([]byte(codeVerifier))
@@ -141,7 +227,7 @@ func TokenHandler() gin.HandlerFunc {
 					return
 				}
 
-			} else {
+			} else if registeredClient == nil {
 				if clientHash, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientSecret); clientSecret != clientHash || err != nil {
 					log.Debug("Client Secret is invalid: ", clientID)
 					gc.JSON(http.StatusBadRequest, gin.H{
@@ -174,7 +260,16 @@ func TokenHandler() gin.HandlerFunc {
 				sessionKey = loginMethod + ":" + userID
 			}
 
-			go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+			sessionNonce = claims.Nonce
+			authTime = claims.AuthTime
+			organizationID = claims.OrganizationID
+			if err := memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce); err != nil {
+				log.Debug("Error deleting user session: ", err)
+				gc.JSON(http.StatusInternalServerError, gin.H{
+					"error": "server_error",
+				})
+				return
+			}
 
 		} else {
 			// validate refresh token
@@ -190,13 +285,46 @@ func TokenHandler() gin.HandlerFunc {
 			claims, err := token.ValidateRefreshToken(gc, refreshToken)
 			if err != nil {
 				log.Debug("Error validating refresh token: ", err)
+				if errors.Is(err, token.ErrRefreshTokenReused) {
+					revokeRefreshTokenFamily(gc, claims)
+				}
 				gc.JSON(http.StatusUnauthorized, gin.H{
 					"error":             "unauthorized",
 					"error_description": err.Error(),
 				})
 				return
 			}
+			// refresh token bound to DPoP key can only be used with proof of the same key
+			if boundJKT := token.GetDPoPJKT(claims); boundJKT != "" && boundJKT != dpopJKT {
+				log.Debug("DPoP proof is missing or does not match refresh token")
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_dpop_proof",
+					"error_description": "DPoP proof is required for the refresh token",
+				})
+				return
+			}
+			// refresh token issued to registered client can only be used by it
+			if tokenClientID, _ := claims["client_id"].(string); (registeredClient == nil && tokenClientID != "") || (registeredClient != nil && tokenClientID != clientID) {
+				log.Debug("Refresh token was issued to another client: ", clientID)
+				gc.JSON(http.StatusBadRequest, gin.H{
+					"error":             "invalid_grant",
+					"error_description": "The refresh token was issued to another client",
+				})
+				return
+			}
 			userID = claims["sub"].(string)
+			// refresh tokens issued before the user revoked the grant of client are not valid
+			if registeredClient != nil {
+				grant, err := db.Provider.GetGrantByUserIDAndClientID(gc, userID, registeredClient.ClientID)
+				if iat, _ := claims["iat"].(int64); err != nil || grant == nil || iat < grant.GrantedAt {
+					log.Debug("Grant for the client is revoked: ", clientID)
+					gc.JSON(http.StatusBadRequest, gin.H{
+						"error":             "invalid_grant",
+						"error_description": "The grant for the client has been revoked",
+					})
+					return
+				}
+			}
 			claimLoginMethod := claims["login_method"]
 			rolesInterface := claims["roles"].([]interface{})
 			scopeInterface := claims["scope"].([]interface{})
@@ -214,7 +342,37 @@ func TokenHandler() gin.HandlerFunc {
 			}
 
 			// remove older refresh token and rotate it for security
-			go memorystore.Provider.DeleteUserSession(sessionKey, claims["nonce"].(string))
+			sessionNonce = claims["nonce"].(string)
+			if val, ok := claims["auth_time"].(float64); ok {
+				authTime = int64(val)
+			}
+			refreshTokenFamilyID = token.GetRefreshTokenFamilyID(claims)
+			organizationID = token.GetOrganizationID(claims)
+			usedRefreshTokenExpiresAt, _ := claims["exp"].(int64)
+			// refresh token is marked used before new one is issued, so that it cannot be rotated twice.
+			// Concurrent request which could not mark it is reuse of the token.
+			if err := token.MarkRefreshTokenUsed(sessionKey, refreshTokenFamilyID, sessionNonce, usedRefreshTokenExpiresAt); err != nil {
+				log.Debug("Error marking refresh token used: ", err)
+				if errors.Is(err, token.ErrRefreshTokenReused) {
+					revokeRefreshTokenFamily(gc, claims)
+					gc.JSON(http.StatusUnauthorized, gin.H{
+						"error":             "unauthorized",
+						"error_description": err.Error(),
+					})
+					return
+				}
+				gc.JSON(http.StatusInternalServerError, gin.H{
+					"error": "server_error",
+				})
+				return
+			}
+			if err := memorystore.Provider.DeleteUserSession(sessionKey, sessionNonce); err != nil {
+				log.Debug("Error deleting user session: ", err)
+				gc.JSON(http.StatusInternalServerError, gin.H{
+					"error": "server_error",
+				})
+				return
+			}
 		}
 
 		if sessionKey == "" {
@@ -237,7 +395,16 @@ func TokenHandler() gin.HandlerFunc {
 		}
 
 		nonce := uuid.New().String() + "@@" + code
-		authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod, nonce, code)
+		authTokenOptions := token.AuthTokenOptions{
+			AuthTime:             authTime,
+			RefreshTokenFamilyID: refreshTokenFamilyID,
+			DPoPJKT:              dpopJKT,
+			OrganizationID:       organizationID,
+		}
+		if registeredClient != nil {
+			authTokenOptions.ClientID = registeredClient.ClientID
+		}
+		authToken, err := token.CreateAuthTokenWithOptions(gc, user, roles, scope, loginMethod, nonce, code, authTokenOptions)
 		if err != nil {
 			log.Debug("Error creating auth token: ", err)
 			gc.JSON(http.StatusUnauthorized, gin.H{
@@ -249,15 +416,37 @@ func TokenHandler() gin.HandlerFunc {
 
 		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash, authToken.SessionTokenExpiresAt)
 		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token, authToken.AccessToken.ExpiresAt)
+		if authToken.RefreshToken != nil {
+			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token, authToken.RefreshToken.ExpiresAt)
+			if refreshTokenFamilyID != "" {
+				if err := token.RotateRefreshTokenFamily(sessionKey, refreshTokenFamilyID, authToken.FingerPrint, authToken.RefreshToken.ExpiresAt); err != nil {
+					// family was revoked while the refresh token was rotated
+					log.Debug("Error rotating refresh token family: ", err)
+					memorystore.Provider.DeleteUserSession(sessionKey, authToken.FingerPrint)
+					gc.JSON(http.StatusUnauthorized, gin.H{
+						"error":             "unauthorized",
+						"error_description": err.Error(),
+					})
+					return
+				}
+			}
+		}
 		cookie.SetSession(gc, authToken.FingerPrintHash)
+		go utils.RolloverSession(gc, sessionNonce, authToken.FingerPrint, authToken.SessionTokenExpiresAt)
 
 		expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
 		if expiresIn <= 0 {
 			expiresIn = 1
 		}
 
+		tokenType := "Bearer"
+		if dpopJKT != "" {
+			tokenType = token.TokenTypeDPoP
+		}
+
 		res := map[string]interface{}{
 			"access_token": authToken.AccessToken.Token,
+			"token_type":   tokenType,
 			"id_token":     authToken.IDToken.Token,
 			"scope":        strings.Join(scope, " "),
 			"roles":        roles,
@@ -265,8 +454,171 @@ func TokenHandler() gin.HandlerFunc {
 		}
 		if authToken.RefreshToken != nil {
 			res["refresh_token"] = authToken.RefreshToken.Token
-			memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token, authToken.RefreshToken.ExpiresAt)
 		}
 		gc.JSON(http.StatusOK, res)
 	}
 }
+
+// revokeRefreshTokenFamily revokes all the tokens of refresh token family when
+// already rotated refresh token is presented, as it might have been stolen
+// and it is not possible to know if legitimate client or attacker is using it.
+// The reuse is recorded in db, so that it can be audited later.
+func revokeRefreshTokenFamily(gc *gin.Context, claims map[string]interface{}) {
+	userID, _ := claims["sub"].(string)
+	loginMethod, _ := claims["login_method"].(string)
+	sessionKey := userID
+	if loginMethod != "" {
+		sessionKey = loginMethod + ":" + userID
+	}
+	familyID := token.GetRefreshTokenFamilyID(claims)
+	authTime, _ := claims["auth_time"].(float64)
+	log := log.WithFields(log.Fields{
+		"user_id":   userID,
+		"family_id": familyID,
+	})
+	log.Warn("Refresh token reuse detected, revoking token family")
+	nonce, err := token.RevokeRefreshTokenFamily(sessionKey, familyID, int64(authTime))
+	if err != nil {
+		log.Debug("Error revoking refresh token family: ", err)
+	}
+	if nonce != "" {
+		if session, err := db.Provider.GetSessionByNonce(gc, nonce); err == nil && session != nil {
+			if err := db.Provider.DeleteSessionByID(gc, session.ID); err != nil {
+				log.Debug("Error deleting session: ", err)
+			}
+		}
+	}
+	_, err = db.Provider.AddRefreshTokenReuse(gc, &models.RefreshTokenReuse{
+		UserID:       userID,
+		FamilyID:     familyID,
+		RevokedNonce: nonce,
+		LoginMethod:  loginMethod,
+		IP:           utils.GetIP(gc.Request),
+		UserAgent:    utils.GetUserAgent(gc.Request),
+	})
+	if err != nil {
+		log.Debug("Error adding refresh token reuse: ", err)
+	}
+	if nonce != "" {
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), userID, nonce)
+	}
+	user, err := db.Provider.GetUserByID(gc, userID)
+	if err != nil {
+		log.Debug("Error getting user: ", err)
+		return
+	}
+	go utils.RegisterEvent(gc, constants.UserRefreshTokenReusedWebhookEvent, loginMethod, user)
+}
+
+// exchangeToken issues an access token for another audience on behalf of the
+// subject token (RFC 8693). Only confidential clients allowed by
+// TOKEN_EXCHANGE_POLICY can exchange tokens. Registered clients are
+// already authenticated by the caller.
+func exchangeToken(gc *gin.Context, reqBody RequestBody, registeredClient *models.Client, clientID, clientSecret, dpopJKT string) {
+	if clientHash, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientSecret); registeredClient == nil && (clientSecret == "" || clientSecret != clientHash || err != nil) {
+		log.Debug("Client Secret is invalid: ", clientID)
+		gc.JSON(http.StatusUnauthorized, gin.H{
+			"error":             "invalid_client",
+			"error_description": "The client secret is invalid",
+		})
+		return
+	}
+
+	audience := strings.TrimSpace(reqBody.Audience)
+	if audience == "" {
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "The audience is required",
+		})
+		return
+	}
+	if !token.IsTokenExchangeAllowed(clientID, audience) {
+		log.Debug("Token exchange not allowed for audience: ", audience)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_target",
+			"error_description": "The client is not allowed to exchange tokens for the audience",
+		})
+		return
+	}
+
+	subjectToken := strings.TrimSpace(reqBody.SubjectToken)
+	if subjectToken == "" || strings.TrimSpace(reqBody.SubjectTokenType) != token.TokenTypeURIAccessToken {
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "The subject token of type " + token.TokenTypeURIAccessToken + " is required",
+		})
+		return
+	}
+	subjectClaims, err := token.ValidateAccessTokenWithoutProof(gc, subjectToken)
+	if err != nil {
+		log.Debug("Error validating subject token: ", err)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_grant",
+			"error_description": "The subject token is invalid",
+		})
+		return
+	}
+	// DPoP bound token can only be exchanged with proof of the same key
+	if boundJKT := token.GetDPoPJKT(subjectClaims); boundJKT != "" && boundJKT != dpopJKT {
+		log.Debug("DPoP proof is missing or does not match subject token")
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_dpop_proof",
+			"error_description": "DPoP proof is required for the subject token",
+		})
+		return
+	}
+
+	var actorClaims map[string]interface{}
+	if actorToken := strings.TrimSpace(reqBody.ActorToken); actorToken != "" {
+		if strings.TrimSpace(reqBody.ActorTokenType) != token.TokenTypeURIAccessToken {
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": "The actor token type should be " + token.TokenTypeURIAccessToken,
+			})
+			return
+		}
+		actorClaims, err = token.ValidateAccessTokenWithoutProof(gc, actorToken)
+		if err != nil {
+			log.Debug("Error validating actor token: ", err)
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_grant",
+				"error_description": "The actor token is invalid",
+			})
+			return
+		}
+		if boundJKT := token.GetDPoPJKT(actorClaims); boundJKT != "" && boundJKT != dpopJKT {
+			log.Debug("DPoP proof is missing or does not match actor token")
+			gc.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_dpop_proof",
+				"error_description": "DPoP proof is required for the actor token",
+			})
+			return
+		}
+	}
+
+	exchangedToken, err := token.CreateExchangedToken(gc, subjectClaims, actorClaims, clientID, audience, strings.Fields(reqBody.Scope), dpopJKT)
+	if err != nil {
+		log.Debug("Error creating exchanged token: ", err)
+		gc.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_scope",
+			"error_description": err.Error(),
+		})
+		return
+	}
+
+	expiresIn := exchangedToken.ExpiresAt - time.Now().Unix()
+	if expiresIn <= 0 {
+		expiresIn = 1
+	}
+	tokenType := "Bearer"
+	if dpopJKT != "" {
+		tokenType = token.TokenTypeDPoP
+	}
+	gc.JSON(http.StatusOK, gin.H{
+		"access_token":      exchangedToken.Token,
+		"issued_token_type": token.TokenTypeURIAccessToken,
+		"token_type":        tokenType,
+		"expires_in":        expiresIn,
+		"scope":             strings.Join(exchangedToken.Scope, " "),
+	})
+}
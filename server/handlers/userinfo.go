@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -24,6 +24,9 @@ func UserInfoHandler() gin.HandlerFunc {
 		claims, err := token.ValidateAccessToken(gc, accessToken)
 		if err != nil {
 			log.Debug("Error validating access token: ", err)
+			if errors.Is(err, token.ErrInvalidDPoPProof) {
+				gc.Header("WWW-Authenticate", `DPoP error="invalid_dpop_proof"`)
+			}
 			gc.JSON(http.StatusUnauthorized, gin.H{
 				"error": err.Error(),
 			})
@@ -38,27 +41,23 @@ func UserInfoHandler() gin.HandlerFunc {
 			})
 			return
 		}
-		apiUser := user.AsAPIUser()
-		userBytes, err := json.Marshal(apiUser)
-		if err != nil {
-			log.Debug("Error marshalling user: ", err)
-			gc.JSON(http.StatusUnauthorized, gin.H{
-				"error": err.Error(),
-			})
-			return
+		scopes := []string{}
+		if scopeClaim, ok := claims["scope"].([]interface{}); ok {
+			for _, s := range scopeClaim {
+				if scope, ok := s.(string); ok {
+					scopes = append(scopes, scope)
+				}
+			}
 		}
-		res := map[string]interface{}{}
-		err = json.Unmarshal(userBytes, &res)
+		res, err := token.GetUserInfoClaims(user, scopes)
 		if err != nil {
-			log.Debug("Error un-marshalling user: ", err)
+			log.Debug("Error getting user info claims: ", err)
 			gc.JSON(http.StatusUnauthorized, gin.H{
 				"error": err.Error(),
 			})
 			return
 		}
-		// add sub field to user as per openid standards
-		// https://github.com/authorizerdev/authorizer/issues/327
-		res["sub"] = userID
+		token.ApplyUserInfoScript(user, res)
 		gc.JSON(http.StatusOK, res)
 	}
 }
@@ -187,9 +187,12 @@ func VerifyEmailHandler() gin.HandlerFunc {
 				utils.RegisterEvent(c, constants.UserLoginWebhookEvent, loginMethod, user)
 			}
 			db.Provider.AddSession(c, &models.Session{
-				UserID:    user.ID,
-				UserAgent: utils.GetUserAgent(c.Request),
-				IP:        utils.GetIP(c.Request),
+				UserID:      user.ID,
+				UserAgent:   utils.GetUserAgent(c.Request),
+				IP:          utils.GetIP(c.Request),
+				LoginMethod: loginMethod,
+				Nonce:       authToken.FingerPrint,
+				ExpiresAt:   authToken.SessionTokenExpiresAt,
 			})
 		}()
 
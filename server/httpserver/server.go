@@ -0,0 +1,99 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// Default timeouts of http server, used when envs are not set
+const (
+	defaultReadTimeout       = 30 * time.Second
+	defaultReadHeaderTimeout = 10 * time.Second
+	defaultWriteTimeout      = 60 * time.Second
+	defaultIdleTimeout       = 120 * time.Second
+	defaultShutdownTimeout   = 30 * time.Second
+)
+
+// parseDuration returns the duration set in env or default value if it is not set
+func parseDuration(key, value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	return duration, nil
+}
+
+// NewServer returns http server for given address with timeouts and tls configured from envs
+func NewServer(addr string, handler http.Handler) (*http.Server, error) {
+	requiredEnvs := memorystore.RequiredEnvStoreObj.GetRequiredEnv()
+	readTimeout, err := parseDuration(constants.EnvKeyHTTPReadTimeout, requiredEnvs.HTTPReadTimeout, defaultReadTimeout)
+	if err != nil {
+		return nil, err
+	}
+	readHeaderTimeout, err := parseDuration(constants.EnvKeyHTTPReadHeaderTimeout, requiredEnvs.HTTPReadHeaderTimeout, defaultReadHeaderTimeout)
+	if err != nil {
+		return nil, err
+	}
+	writeTimeout, err := parseDuration(constants.EnvKeyHTTPWriteTimeout, requiredEnvs.HTTPWriteTimeout, defaultWriteTimeout)
+	if err != nil {
+		return nil, err
+	}
+	idleTimeout, err := parseDuration(constants.EnvKeyHTTPIdleTimeout, requiredEnvs.HTTPIdleTimeout, defaultIdleTimeout)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := NewTLSConfig(requiredEnvs.TLSCertFile, requiredEnvs.TLSKeyFile, requiredEnvs.TLSClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadTimeout:       readTimeout,
+		ReadHeaderTimeout: readHeaderTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}, nil
+}
+
+// GetShutdownTimeout returns the max time to wait for in-flight requests
+// and background work to finish on shutdown
+func GetShutdownTimeout() (time.Duration, error) {
+	return parseDuration(constants.EnvKeyShutdownTimeout, memorystore.RequiredEnvStoreObj.GetRequiredEnv().ShutdownTimeout, defaultShutdownTimeout)
+}
+
+// ListenAndServe starts the server with https if tls is configured, else with http.
+// It blocks till server fails to serve or context is done, the server is not shut down
+// in latter case so that caller can drain requests with server.Shutdown.
+func ListenAndServe(ctx context.Context, server *http.Server) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if server.TLSConfig != nil {
+			// certificates are provided by tls config
+			errCh <- server.ListenAndServeTLS("", "")
+			return
+		}
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
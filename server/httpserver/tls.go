@@ -0,0 +1,140 @@
+package httpserver
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"os"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// certReloader keeps the tls config for server certificate and client CA files,
+// files are loaded again when they are modified, so that renewed certificates
+// are used without restarting the server
+type certReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+
+	mutex   sync.RWMutex
+	config  *tls.Config
+	modTime time.Time
+}
+
+// NewTLSConfig returns tls config for given certificate and key files.
+// If client CA file is set, clients are required to present a certificate signed by it.
+// It returns nil if certificate and key files are not set.
+func NewTLSConfig(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" && keyFile == "" {
+		if clientCAFile != "" {
+			return nil, errors.New("tls client CA file requires tls cert and key files")
+		}
+		return nil, nil
+	}
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("both tls cert and key files are required")
+	}
+
+	r := &certReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+	}
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetCertificate:     r.getCertificate,
+		GetConfigForClient: r.getConfigForClient,
+	}, nil
+}
+
+// latestModTime returns the latest modification time of the files
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var modTime time.Time
+	for _, file := range []string{r.certFile, r.keyFile, r.clientCAFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return modTime, err
+		}
+		if info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+// load reads the files and replaces the config used for new connections
+func (r *certReloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return err
+	}
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		NextProtos:   []string{"h2", "http/1.1"},
+	}
+	if r.clientCAFile != "" {
+		caCert, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return err
+		}
+		clientCAs := x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(caCert) {
+			return errors.New("invalid tls client CA file")
+		}
+		config.ClientCAs = clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.config = config
+	r.modTime = modTime
+	return nil
+}
+
+// currentConfig returns the config, after loading the files again if they were modified.
+// If files can't be loaded, e.g. while they are being replaced, previous config is used.
+func (r *certReloader) currentConfig() *tls.Config {
+	modTime, err := r.latestModTime()
+	r.mutex.RLock()
+	config, loadedModTime := r.config, r.modTime
+	r.mutex.RUnlock()
+	if err != nil {
+		log.Debug("Error checking tls files: ", err)
+		return config
+	}
+	if !modTime.After(loadedModTime) {
+		return config
+	}
+
+	if err := r.load(modTime); err != nil {
+		log.Debug("Error reloading tls files: ", err)
+		return config
+	}
+	log.Info("Reloaded tls certificates")
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.config
+}
+
+func (r *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return &r.currentConfig().Certificates[0], nil
+}
+
+func (r *certReloader) getConfigForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	return r.currentConfig(), nil
+}
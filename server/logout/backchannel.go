@@ -0,0 +1,138 @@
+package logout
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// backchannelLogoutTimeout is the time for which client logout uri is awaited
+const backchannelLogoutTimeout = 5 * time.Second
+
+// BackchannelLogoutClient is the client notified with back-channel logout
+type BackchannelLogoutClient struct {
+	ClientID  string
+	LogoutURI string
+	// SessionRequired is true when client needs sid claim in logout token
+	SessionRequired bool
+	// IsRegistered is true for dynamically registered client, its logout uri is chosen by client
+	// and is only called on public addresses
+	IsRegistered bool
+}
+
+// GetBackchannelLogoutClients returns the clients with back-channel logout uri that user can have sessions with,
+// ie. the client of CLIENT_ID env and the registered clients user has granted access to
+func GetBackchannelLogoutClients(ctx context.Context, userID string) []*BackchannelLogoutClient {
+	clients := []*BackchannelLogoutClient{}
+	if logoutURI, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyBackchannelLogoutURI); err == nil && logoutURI != "" {
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		if err != nil {
+			log.Debug("Failed to get client id: ", err)
+		} else {
+			clients = append(clients, &BackchannelLogoutClient{
+				ClientID:        clientID,
+				LogoutURI:       logoutURI,
+				SessionRequired: true,
+			})
+		}
+	}
+	grants, err := db.Provider.ListGrantsByUserID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to list grants of user: ", err)
+		return clients
+	}
+	for _, grant := range grants {
+		client, err := db.Provider.GetClientByClientID(ctx, grant.ClientID)
+		if err != nil || client == nil || client.BackchannelLogoutURI == "" {
+			continue
+		}
+		clients = append(clients, &BackchannelLogoutClient{
+			ClientID:        client.ClientID,
+			LogoutURI:       client.BackchannelLogoutURI,
+			SessionRequired: client.BackchannelLogoutSessionRequired,
+			IsRegistered:    true,
+		})
+	}
+	return clients
+}
+
+// SendBackchannelLogout notifies the clients that have back-channel logout uri
+// that the session of user has ended. sid is empty when all sessions of user end.
+// It is meant to be called in go routine as it waits for the clients.
+func SendBackchannelLogout(hostname, userID, sid string) {
+	NotifyBackchannelLogoutClients(hostname, GetBackchannelLogoutClients(context.Background(), userID), userID, sid)
+}
+
+// NotifyBackchannelLogoutClients sends logout token to each of the clients and waits for them.
+// Clients can be listed with GetBackchannelLogoutClients before the grants of user are deleted.
+func NotifyBackchannelLogoutClients(hostname string, clients []*BackchannelLogoutClient, userID, sid string) {
+	var wg sync.WaitGroup
+	for _, client := range clients {
+		clientSID := sid
+		if !client.SessionRequired {
+			clientSID = ""
+		}
+		wg.Add(1)
+		go func(client *BackchannelLogoutClient) {
+			defer wg.Done()
+			notifyClient(hostname, client, userID, clientSID)
+		}(client)
+	}
+	wg.Wait()
+}
+
+// newBackchannelLogoutHTTPClient returns the http client to call logout uri of client.
+// Logout uri of registered client can only be on public addresses, so that it cannot be used
+// to reach internal network. Loopback addresses are allowed as well when not running in production.
+func newBackchannelLogoutHTTPClient(client *BackchannelLogoutClient) *http.Client {
+	if !client.IsRegistered {
+		return &http.Client{Timeout: backchannelLogoutTimeout}
+	}
+	if isProd, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyIsProd); err == nil && !isProd {
+		return utils.NewPublicOrLoopbackHTTPClient(backchannelLogoutTimeout)
+	}
+	return utils.NewPublicHTTPClient(backchannelLogoutTimeout)
+}
+
+// notifyClient posts the logout token to logout uri of client
+func notifyClient(hostname string, client *BackchannelLogoutClient, userID, sid string) {
+	log := log.WithFields(log.Fields{
+		"client_id":  client.ClientID,
+		"logout_uri": client.LogoutURI,
+		"user_id":    userID,
+	})
+	logoutToken, err := token.CreateLogoutToken(hostname, client.ClientID, userID, sid)
+	if err != nil {
+		log.Debug("Failed to create logout token: ", err)
+		return
+	}
+	form := url.Values{}
+	form.Set("logout_token", logoutToken)
+	req, err := http.NewRequest(http.MethodPost, client.LogoutURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		log.Debug("Failed to create back-channel logout request: ", err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Cache-Control", "no-store")
+	res, err := newBackchannelLogoutHTTPClient(client).Do(req)
+	if err != nil {
+		log.Warn("Failed to send back-channel logout: ", err)
+		return
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		log.Warn("Back-channel logout failed with status: ", res.StatusCode)
+	}
+}
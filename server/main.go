@@ -1,18 +1,26 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/authorizerdev/authorizer/server/authenticators"
 
 	"github.com/authorizerdev/authorizer/server/cli"
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/env"
+	"github.com/authorizerdev/authorizer/server/httpserver"
 	"github.com/authorizerdev/authorizer/server/logs"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/oauth"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/routes"
+	"github.com/authorizerdev/authorizer/server/tracing"
+	"github.com/authorizerdev/authorizer/server/utils"
 	"github.com/sirupsen/logrus"
 )
 
@@ -28,7 +36,7 @@ func main() {
 	flag.Parse()
 
 	// global log level
-	logrus.SetFormatter(logs.LogUTCFormatter{&logrus.JSONFormatter{}})
+	logrus.SetFormatter(logs.LogUTCFormatter{Formatter: &logrus.JSONFormatter{}})
 
 	constants.VERSION = VERSION
 
@@ -40,6 +48,16 @@ func main() {
 
 	log := logs.InitLog(refs.StringValue(cli.ARG_LOG_LEVEL))
 
+	// ctx is cancelled on SIGINT / SIGTERM to gracefully shutdown the server
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// initialize tracing, traces are exported only if exporter is configured
+	shutdownTracer, err := tracing.InitTracer(context.Background(), memorystore.RequiredEnvStoreObj.GetRequiredEnv().OtelTracesExporter)
+	if err != nil {
+		log.Fatal("Error while initializing tracing: ", err)
+	}
+
 	// initialize memory store
 	err = memorystore.InitMemStore()
 	if err != nil {
@@ -52,6 +70,12 @@ func main() {
 		log.Fatalln("Error while initializing db: ", err)
 	}
 
+	// use database for sessions if enabled, so that they are shared by all instances
+	err = memorystore.InitDatabaseMemStore(db.Provider)
+	if err != nil {
+		log.Fatalln("Error while initializing database memory store: ", err)
+	}
+
 	// initialize all envs
 	// (get if present from db else construct from os env + defaults)
 	err = env.InitAllEnv()
@@ -76,6 +100,26 @@ func main() {
 		log.Fatalln("Error while initializing authenticator: ", err)
 	}
 
+	// reload env changes made by other instances and re-initialize providers depending on it
+	envSyncInterval, err := env.GetEnvSyncInterval()
+	if err != nil {
+		log.Fatalln("Error while getting env sync interval: ", err)
+	}
+	go env.StartEnvSync(ctx, envSyncInterval, func() error {
+		err := oauth.InitOAuth()
+		if err != nil {
+			return err
+		}
+		return authenticators.InitTOTPStore()
+	})
+
+	// remove expired role grants from users
+	roleGrantSweepInterval, err := utils.GetRoleGrantSweepInterval()
+	if err != nil {
+		log.Fatalln("Error while getting role grant sweep interval: ", err)
+	}
+	go utils.StartRoleGrantSweep(ctx, roleGrantSweepInterval)
+
 	router := routes.InitRouter(log)
 	log.Info("Starting Authorizer: ", VERSION)
 	port, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyPort)
@@ -85,5 +129,36 @@ func main() {
 		port = "8080"
 	}
 
-	router.Run(":" + port)
+	server, err := httpserver.NewServer(":"+port, router)
+	if err != nil {
+		log.Fatalln("Error while creating http server: ", err)
+	}
+	shutdownTimeout, err := httpserver.GetShutdownTimeout()
+	if err != nil {
+		log.Fatalln("Error while getting shutdown timeout: ", err)
+	}
+	err = httpserver.ListenAndServe(ctx, server)
+	if err != nil {
+		log.Fatalln("Error while running http server: ", err)
+	}
+
+	// drain in-flight requests and background work before closing the connections they use
+	log.Info("Shutting down Authorizer")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Info("Error while draining in-flight requests: ", err)
+	}
+	if err := utils.WaitForWebhookDeliveries(shutdownCtx); err != nil {
+		log.Info("Error while waiting for webhook deliveries: ", err)
+	}
+	if err := memorystore.Provider.Close(); err != nil {
+		log.Info("Error while closing memory store: ", err)
+	}
+	if err := db.Provider.Close(shutdownCtx); err != nil {
+		log.Info("Error while closing db: ", err)
+	}
+	if err := shutdownTracer(shutdownCtx); err != nil {
+		log.Info("Error while flushing traces: ", err)
+	}
 }
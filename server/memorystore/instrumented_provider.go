@@ -0,0 +1,179 @@
+package memorystore
+
+import (
+	"context"
+
+	"github.com/authorizerdev/authorizer/server/memorystore/providers"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/tracing"
+)
+
+// instrumentedProvider wraps the memory store provider to count errors and create trace span of each method.
+// Memory store methods don't receive request context, so the spans are not part of the request trace.
+// Env variable getters are not traced as they are called many times in each request.
+type instrumentedProvider struct {
+	provider providers.Provider
+}
+
+// newInstrumentedProvider returns memory store provider which records metrics and traces for given provider
+func newInstrumentedProvider(provider providers.Provider) providers.Provider {
+	return &instrumentedProvider{
+		provider: provider,
+	}
+}
+
+func (p *instrumentedProvider) SetUserSession(userId string, key string, token string, expiration int64) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.SetUserSession")
+	err := p.provider.SetUserSession(userId, key, token, expiration)
+	metrics.RecordMemoryStoreError("SetUserSession", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) SetUserSessionIfNotExists(userId string, key string, token string, expiration int64) (bool, error) {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.SetUserSessionIfNotExists")
+	res, err := p.provider.SetUserSessionIfNotExists(userId, key, token, expiration)
+	metrics.RecordMemoryStoreError("SetUserSessionIfNotExists", err)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetUserSession(userId string, key string) (string, error) {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.GetUserSession")
+	res, err := p.provider.GetUserSession(userId, key)
+	metrics.RecordMemoryStoreError("GetUserSession", err)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) ExtendUserSession(userId string, key string, expiration int64) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.ExtendUserSession")
+	err := p.provider.ExtendUserSession(userId, key, expiration)
+	metrics.RecordMemoryStoreError("ExtendUserSession", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteUserSession(userId string, key string) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.DeleteUserSession")
+	err := p.provider.DeleteUserSession(userId, key)
+	metrics.RecordMemoryStoreError("DeleteUserSession", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteAllUserSessions(userId string) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.DeleteAllUserSessions")
+	err := p.provider.DeleteAllUserSessions(userId)
+	metrics.RecordMemoryStoreError("DeleteAllUserSessions", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) DeleteSessionForNamespace(namespace string) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.DeleteSessionForNamespace")
+	err := p.provider.DeleteSessionForNamespace(namespace)
+	metrics.RecordMemoryStoreError("DeleteSessionForNamespace", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) SetMfaSession(userId string, key string, expiration int64) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.SetMfaSession")
+	err := p.provider.SetMfaSession(userId, key, expiration)
+	metrics.RecordMemoryStoreError("SetMfaSession", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) GetMfaSession(userId string, key string) (string, error) {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.GetMfaSession")
+	res, err := p.provider.GetMfaSession(userId, key)
+	metrics.RecordMemoryStoreError("GetMfaSession", err)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) DeleteMfaSession(userId string, key string) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.DeleteMfaSession")
+	err := p.provider.DeleteMfaSession(userId, key)
+	metrics.RecordMemoryStoreError("DeleteMfaSession", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) SetState(key string, state string) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.SetState")
+	err := p.provider.SetState(key, state)
+	metrics.RecordMemoryStoreError("SetState", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) GetState(key string) (string, error) {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.GetState")
+	res, err := p.provider.GetState(key)
+	metrics.RecordMemoryStoreError("GetState", err)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) RemoveState(key string) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.RemoveState")
+	err := p.provider.RemoveState(key)
+	metrics.RecordMemoryStoreError("RemoveState", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) UpdateEnvStore(store map[string]interface{}) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.UpdateEnvStore")
+	err := p.provider.UpdateEnvStore(store)
+	metrics.RecordMemoryStoreError("UpdateEnvStore", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) GetEnvStore() (map[string]interface{}, error) {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.GetEnvStore")
+	res, err := p.provider.GetEnvStore()
+	metrics.RecordMemoryStoreError("GetEnvStore", err)
+	tracing.EndSpan(span, err)
+	return res, err
+}
+
+func (p *instrumentedProvider) UpdateEnvVariable(key string, value interface{}) error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.UpdateEnvVariable")
+	err := p.provider.UpdateEnvVariable(key, value)
+	metrics.RecordMemoryStoreError("UpdateEnvVariable", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) GetStringStoreEnvVariable(key string) (string, error) {
+	res, err := p.provider.GetStringStoreEnvVariable(key)
+	metrics.RecordMemoryStoreError("GetStringStoreEnvVariable", err)
+	return res, err
+}
+
+func (p *instrumentedProvider) GetBoolStoreEnvVariable(key string) (bool, error) {
+	res, err := p.provider.GetBoolStoreEnvVariable(key)
+	metrics.RecordMemoryStoreError("GetBoolStoreEnvVariable", err)
+	return res, err
+}
+
+func (p *instrumentedProvider) HealthCheck(ctx context.Context) error {
+	ctx, span := tracing.StartSpan(ctx, "memorystore.HealthCheck")
+	err := p.provider.HealthCheck(ctx)
+	metrics.RecordMemoryStoreError("HealthCheck", err)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+func (p *instrumentedProvider) Close() error {
+	_, span := tracing.StartSpan(context.Background(), "memorystore.Close")
+	err := p.provider.Close()
+	metrics.RecordMemoryStoreError("Close", err)
+	tracing.EndSpan(span, err)
+	return err
+}
@@ -2,11 +2,15 @@ package memorystore
 
 import (
 	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/memorystore/providers"
+	"github.com/authorizerdev/authorizer/server/memorystore/providers/database"
 	"github.com/authorizerdev/authorizer/server/memorystore/providers/inmemory"
 	"github.com/authorizerdev/authorizer/server/memorystore/providers/redis"
 )
@@ -64,10 +68,15 @@ func InitMemStore() error {
 	redisURL := requiredEnvs.RedisURL
 	if redisURL != "" && !requiredEnvs.DisableRedisForEnv {
 		log.Info("Initializing Redis memory store")
-		Provider, err = redis.NewRedisProvider(redisURL)
+		redisOptions, err := getRedisOptions(requiredEnvs)
 		if err != nil {
 			return err
 		}
+		redisProvider, err := redis.NewRedisProviderWithOptions(redisURL, redisOptions)
+		if err != nil {
+			return err
+		}
+		Provider = newInstrumentedProvider(redisProvider)
 
 		// set default envs in redis
 		Provider.UpdateEnvStore(defaultEnvs)
@@ -75,13 +84,75 @@ func InitMemStore() error {
 		return nil
 	}
 
-	log.Info("using in memory store to save sessions")
+	if requiredEnvs.EnableDatabaseMemoryStore {
+		log.Info("using in memory store till database is initialized")
+	} else {
+		log.Info("using in memory store to save sessions")
+	}
 	// if redis url is not set use in memory store
-	Provider, err = inmemory.NewInMemoryProvider()
+	inMemoryProvider, err := inmemory.NewInMemoryProvider()
 	if err != nil {
 		return err
 	}
+	Provider = newInstrumentedProvider(inMemoryProvider)
 	// set default envs in local env
 	Provider.UpdateEnvStore(defaultEnvs)
 	return nil
 }
+
+// InitDatabaseMemStore switches the memory store to database, if it is enabled
+// and redis is not configured. It is called once the database is initialized,
+// env store of the current provider is copied to the database provider.
+func InitDatabaseMemStore(store database.Store) error {
+	requiredEnvs := RequiredEnvStoreObj.GetRequiredEnv()
+	if !requiredEnvs.EnableDatabaseMemoryStore || (requiredEnvs.RedisURL != "" && !requiredEnvs.DisableRedisForEnv) {
+		return nil
+	}
+	log.Info("Initializing database memory store")
+	envStore, err := Provider.GetEnvStore()
+	if err != nil {
+		return err
+	}
+	dbProvider, err := database.NewDatabaseProvider(store)
+	if err != nil {
+		return err
+	}
+	err = dbProvider.UpdateEnvStore(envStore)
+	if err != nil {
+		return err
+	}
+	Provider = newInstrumentedProvider(dbProvider)
+	return nil
+}
+
+// getRedisOptions returns the redis connection options from required envs
+func getRedisOptions(requiredEnvs RequiredEnv) (redis.Options, error) {
+	opts := redis.Options{
+		SentinelMasterName: requiredEnvs.RedisSentinelMasterName,
+		SentinelPassword:   requiredEnvs.RedisSentinelPassword,
+		Cert:               requiredEnvs.RedisCert,
+		CertKey:            requiredEnvs.RedisCertKey,
+		CACert:             requiredEnvs.RedisCACert,
+		KeyPrefix:          requiredEnvs.RedisKeyPrefix,
+	}
+	var err error
+	if requiredEnvs.RedisPoolSize != "" {
+		opts.PoolSize, err = strconv.Atoi(requiredEnvs.RedisPoolSize)
+		if err != nil || opts.PoolSize <= 0 {
+			return opts, fmt.Errorf("invalid %s: %s", constants.EnvKeyRedisPoolSize, requiredEnvs.RedisPoolSize)
+		}
+	}
+	if requiredEnvs.RedisMinIdleConns != "" {
+		opts.MinIdleConns, err = strconv.Atoi(requiredEnvs.RedisMinIdleConns)
+		if err != nil || opts.MinIdleConns < 0 {
+			return opts, fmt.Errorf("invalid %s: %s", constants.EnvKeyRedisMinIdleConns, requiredEnvs.RedisMinIdleConns)
+		}
+	}
+	if requiredEnvs.RedisPoolTimeout != "" {
+		opts.PoolTimeout, err = time.ParseDuration(requiredEnvs.RedisPoolTimeout)
+		if err != nil || opts.PoolTimeout <= 0 {
+			return opts, fmt.Errorf("invalid %s: %s", constants.EnvKeyRedisPoolTimeout, requiredEnvs.RedisPoolTimeout)
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore/providers/inmemory/stores"
+)
+
+const (
+	// cleanupInterval is the interval to delete expired entries from database
+	cleanupInterval = 10 * time.Minute
+)
+
+// Store is the database used to keep the memory store entries,
+// it is implemented by the db providers
+type Store interface {
+	UpsertMemoryStoreEntry(ctx context.Context, entry *models.MemoryStoreEntry) (*models.MemoryStoreEntry, error)
+	AddMemoryStoreEntryIfNotExists(ctx context.Context, entry *models.MemoryStoreEntry) (bool, error)
+	GetMemoryStoreEntryByID(ctx context.Context, id string) (*models.MemoryStoreEntry, error)
+	DeleteMemoryStoreEntryByID(ctx context.Context, id string) error
+	DeleteMemoryStoreEntriesByNamespace(ctx context.Context, namespace string) error
+	DeleteMemoryStoreEntriesBySubject(ctx context.Context, subject string) error
+	DeleteExpiredMemoryStoreEntries(ctx context.Context, expiresAt int64) error
+	HealthCheck(ctx context.Context) error
+}
+
+type provider struct {
+	ctx   context.Context
+	store Store
+	// env is loaded from database by each instance, so it is kept in memory
+	envStore *stores.EnvStore
+	// stop is closed to stop the cleanup of expired entries
+	stop chan struct{}
+}
+
+// NewDatabaseProvider returns a new memory store provider which keeps
+// sessions, states and mfa sessions in the database, so that they
+// are shared by all the instances of authorizer
+func NewDatabaseProvider(store Store) (*provider, error) {
+	p := &provider{
+		ctx:      context.Background(),
+		store:    store,
+		envStore: stores.NewEnvStore(),
+		stop:     make(chan struct{}),
+	}
+	go p.cleanup()
+	return p, nil
+}
+
+// cleanup deletes the expired entries periodically
+func (c *provider) cleanup() {
+	t := time.NewTicker(cleanupInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-t.C:
+			if err := c.store.DeleteExpiredMemoryStoreEntries(c.ctx, time.Now().Unix()); err != nil {
+				log.Debug("Error deleting expired memory store entries: ", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+const (
+	// stateNamespace is the namespace of states
+	stateNamespace = "authorizer_state"
+	// stateExpiresIn is the time in seconds after which unused states are deleted
+	stateExpiresIn = 24 * 60 * 60
+	// mfaSessionPrefix is the prefix of mfa session namespace
+	mfaSessionPrefix = "mfa_sess_"
+)
+
+// entryID returns the id of entry for namespace and key.
+// Hash is used as keys can be longer than the id columns or have characters not allowed by some databases.
+func entryID(namespace, key string) string {
+	hash := sha256.Sum256([]byte(namespace + ":" + key))
+	return hex.EncodeToString(hash[:])
+}
+
+// setEntry saves the value for namespace and key till given time
+func (c *provider) setEntry(namespace, key, value string, expiresAt int64) error {
+	parts := strings.Split(namespace, ":")
+	_, err := c.store.UpsertMemoryStoreEntry(c.ctx, &models.MemoryStoreEntry{
+		ID:        entryID(namespace, key),
+		Namespace: namespace,
+		Recipe:    parts[0],
+		Subject:   parts[len(parts)-1],
+		Value:     value,
+		ExpiresAt: expiresAt,
+	})
+	return err
+}
+
+// getEntry returns the entry if it is not expired
+func (c *provider) getEntry(namespace, key string) (*models.MemoryStoreEntry, error) {
+	entry, err := c.store.GetMemoryStoreEntryByID(c.ctx, entryID(namespace, key))
+	if err != nil || entry == nil {
+		return nil, fmt.Errorf("not found")
+	}
+	// expired entries are deleted by cleanup
+	if entry.ExpiresAt <= time.Now().Unix() {
+		return nil, fmt.Errorf("not found")
+	}
+	return entry, nil
+}
+
+// SetUserSession sets the user session for given user identifier in form recipe:user_id
+func (c *provider) SetUserSession(userId, key, token string, expiration int64) error {
+	if expiration <= time.Now().Unix() {
+		return nil
+	}
+	err := c.setEntry(userId, key, token, expiration)
+	if err != nil {
+		log.Debug("Error saving user session to database: ", err)
+		return err
+	}
+	return nil
+}
+
+// SetUserSessionIfNotExists sets the user session in database if it does not exist
+func (c *provider) SetUserSessionIfNotExists(userId, key, token string, expiration int64) (bool, error) {
+	if expiration <= time.Now().Unix() {
+		return false, nil
+	}
+	parts := strings.Split(userId, ":")
+	ok, err := c.store.AddMemoryStoreEntryIfNotExists(c.ctx, &models.MemoryStoreEntry{
+		ID:        entryID(userId, key),
+		Namespace: userId,
+		Recipe:    parts[0],
+		Subject:   parts[len(parts)-1],
+		Value:     token,
+		ExpiresAt: expiration,
+	})
+	if err != nil {
+		log.Debug("Error saving user session to database: ", err)
+		return false, err
+	}
+	return ok, nil
+}
+
+// GetUserSession returns the user session from database.
+func (c *provider) GetUserSession(userId, key string) (string, error) {
+	entry, err := c.getEntry(userId, key)
+	if err != nil {
+		return "", err
+	}
+	return entry.Value, nil
+}
+
+// ExtendUserSession updates the expiry of existing user session in database.
+func (c *provider) ExtendUserSession(userId, key string, expiration int64) error {
+	entry, err := c.getEntry(userId, key)
+	if err != nil {
+		return err
+	}
+	// id returned by some databases includes the collection name
+	entry.ID = entryID(userId, key)
+	entry.ExpiresAt = expiration
+	_, err = c.store.UpsertMemoryStoreEntry(c.ctx, entry)
+	if err != nil {
+		log.Debug("Error extending user session in database: ", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteUserSession deletes the user session from database.
+func (c *provider) DeleteUserSession(userId, key string) error {
+	for _, tokenType := range []string{constants.TokenTypeSessionToken, constants.TokenTypeAccessToken, constants.TokenTypeRefreshToken} {
+		if err := c.store.DeleteMemoryStoreEntryByID(c.ctx, entryID(userId, tokenType+"_"+key)); err != nil {
+			log.Debug("Error deleting user session from database: ", err)
+			// continue
+		}
+	}
+	return nil
+}
+
+// DeleteAllUserSessions deletes all the user sessions from database.
+// It can be called with user id or with the namespace of entries.
+func (c *provider) DeleteAllUserSessions(userId string) error {
+	var err error
+	if strings.Contains(userId, ":") {
+		err = c.store.DeleteMemoryStoreEntriesByNamespace(c.ctx, userId)
+	} else {
+		err = c.store.DeleteMemoryStoreEntriesBySubject(c.ctx, userId)
+	}
+	if err != nil {
+		log.Debug("Error deleting all user sessions from database: ", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteSessionForNamespace to delete session for a given namespace example google,github
+func (c *provider) DeleteSessionForNamespace(namespace string) error {
+	err := c.store.DeleteMemoryStoreEntriesByNamespace(c.ctx, namespace)
+	if err != nil {
+		log.Debug("Error deleting sessions for namespace from database: ", err)
+		return err
+	}
+	return nil
+}
+
+// SetMfaSession sets the mfa session with key and value of userId
+func (c *provider) SetMfaSession(userId, key string, expiration int64) error {
+	err := c.setEntry(mfaSessionPrefix+userId, key, userId, expiration)
+	if err != nil {
+		log.Debug("Error saving mfa session to database: ", err)
+		return err
+	}
+	return nil
+}
+
+// GetMfaSession returns value of given mfa session
+func (c *provider) GetMfaSession(userId, key string) (string, error) {
+	entry, err := c.getEntry(mfaSessionPrefix+userId, key)
+	if err != nil {
+		return "", err
+	}
+	return entry.Value, nil
+}
+
+// DeleteMfaSession deletes given mfa session from database.
+func (c *provider) DeleteMfaSession(userId, key string) error {
+	if err := c.store.DeleteMemoryStoreEntryByID(c.ctx, entryID(mfaSessionPrefix+userId, key)); err != nil {
+		log.Debug("Error deleting mfa session from database: ", err)
+		// continue
+	}
+	return nil
+}
+
+// SetState sets the state in database.
+func (c *provider) SetState(key, value string) error {
+	err := c.setEntry(stateNamespace, key, value, time.Now().Add(stateExpiresIn*time.Second).Unix())
+	if err != nil {
+		log.Debug("Error saving state to database: ", err)
+		return err
+	}
+	return nil
+}
+
+// GetState gets the state from database.
+func (c *provider) GetState(key string) (string, error) {
+	entry, err := c.getEntry(stateNamespace, key)
+	if err != nil {
+		return "", err
+	}
+	return entry.Value, nil
+}
+
+// RemoveState removes the state from database.
+func (c *provider) RemoveState(key string) error {
+	err := c.store.DeleteMemoryStoreEntryByID(c.ctx, entryID(stateNamespace, key))
+	if err != nil {
+		log.Debug("Error deleting state from database: ", err)
+		return err
+	}
+	return nil
+}
+
+// UpdateEnvStore to update the whole env store object
+func (c *provider) UpdateEnvStore(store map[string]interface{}) error {
+	c.envStore.UpdateStore(store)
+	return nil
+}
+
+// GetEnvStore returns the env store object
+func (c *provider) GetEnvStore() (map[string]interface{}, error) {
+	return c.envStore.GetStore(), nil
+}
+
+// UpdateEnvVariable to update the particular env variable
+func (c *provider) UpdateEnvVariable(key string, value interface{}) error {
+	c.envStore.Set(key, value)
+	return nil
+}
+
+// GetStringStoreEnvVariable to get the env variable from string store object
+func (c *provider) GetStringStoreEnvVariable(key string) (string, error) {
+	res := c.envStore.Get(key)
+	if res == nil {
+		return "", nil
+	}
+	return fmt.Sprintf("%v", res), nil
+}
+
+// GetBoolStoreEnvVariable to get the env variable from bool store object
+func (c *provider) GetBoolStoreEnvVariable(key string) (bool, error) {
+	res := c.envStore.Get(key)
+	if res == nil {
+		return false, nil
+	}
+	return res.(bool), nil
+}
+
+// HealthCheck checks if the database which holds the entries is reachable
+func (c *provider) HealthCheck(ctx context.Context) error {
+	return c.store.HealthCheck(ctx)
+}
+
+// Close stops the cleanup of expired entries,
+// database connections are closed by the database provider
+func (c *provider) Close() error {
+	close(c.stop)
+	return nil
+}
@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -13,6 +14,11 @@ func (c *provider) SetUserSession(userId, key, token string, expiration int64) e
 	return nil
 }
 
+// SetUserSessionIfNotExists sets the user session in in-memory store if it does not exist
+func (c *provider) SetUserSessionIfNotExists(userId, key, token string, expiration int64) (bool, error) {
+	return c.sessionStore.SetIfNotExists(userId, key, token, expiration), nil
+}
+
 // GetUserSession returns value for given session token
 func (c *provider) GetUserSession(userId, sessionToken string) (string, error) {
 	val := c.sessionStore.Get(userId, sessionToken)
@@ -22,6 +28,14 @@ func (c *provider) GetUserSession(userId, sessionToken string) (string, error) {
 	return val, nil
 }
 
+// ExtendUserSession updates the expiry of existing user session in in-memory store.
+func (c *provider) ExtendUserSession(userId, key string, expiration int64) error {
+	if !c.sessionStore.Extend(userId, key, expiration) {
+		return fmt.Errorf("Not found")
+	}
+	return nil
+}
+
 // DeleteAllUserSessions deletes all the user sessions from in-memory store.
 func (c *provider) DeleteAllUserSessions(userId string) error {
 	c.sessionStore.RemoveAll(userId)
@@ -119,3 +133,13 @@ func (c *provider) GetBoolStoreEnvVariable(key string) (bool, error) {
 	}
 	return res.(bool), nil
 }
+
+// HealthCheck always succeeds as in-memory store is part of the process
+func (c *provider) HealthCheck(ctx context.Context) error {
+	return nil
+}
+
+// Close is no-op for in-memory store
+func (c *provider) Close() error {
+	return nil
+}
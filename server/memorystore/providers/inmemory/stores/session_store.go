@@ -87,9 +87,26 @@ func (s *SessionStore) Get(key, subKey string) string {
 
 // Set sets the value of the key in state store
 func (s *SessionStore) Set(key string, subKey, value string, expiration int64) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.set(fmt.Sprintf("%s:%s", key, subKey), value, expiration)
+}
+
+// SetIfNotExists sets the value of the key in state store if it does not exist or is expired.
+// It returns false if the key exists.
+func (s *SessionStore) SetIfNotExists(key string, subKey, value string, expiration int64) bool {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
 	k := fmt.Sprintf("%s:%s", key, subKey)
+	if v, ok := s.store[k]; ok && v.ExpiresAt > time.Now().Unix() {
+		return false
+	}
+	s.set(k, value, expiration)
+	return true
+}
+
+// set sets the value of the key, caller should hold the lock
+func (s *SessionStore) set(k, value string, expiration int64) {
 	if _, ok := s.store[k]; !ok {
 		// check if there is enough space in cache
 		// else delete entries based on FIFO
@@ -112,6 +129,21 @@ func (s *SessionStore) Set(key string, subKey, value string, expiration int64) {
 	s.keyIndex[expiration] = k
 }
 
+// Extend updates the expiration of the key if it exists and is not expired
+func (s *SessionStore) Extend(key, subKey string, expiration int64) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	k := fmt.Sprintf("%s:%s", key, subKey)
+	v, ok := s.store[k]
+	if !ok || v.ExpiresAt <= time.Now().Unix() {
+		return false
+	}
+	delete(s.keyIndex, v.ExpiresAt)
+	v.ExpiresAt = expiration
+	s.keyIndex[expiration] = k
+	return true
+}
+
 // RemoveAll all values for given key
 func (s *SessionStore) RemoveAll(key string) {
 	s.mutex.Lock()
@@ -47,8 +47,18 @@ func ProviderTests(t *testing.T, p Provider) {
 	key, err = p.GetUserSession("auth_provider:124", "access_token_key")
 	assert.NoError(t, err)
 	assert.Equal(t, "test_jwt124", key)
+	// Extend session, it should outlive the expired tokens
+	err = p.SetUserSession("auth_provider:125", "session_token_key", "test_hash125", time.Now().Add(5*time.Second).Unix())
+	assert.NoError(t, err)
+	err = p.ExtendUserSession("auth_provider:125", "session_token_key", time.Now().Add(60*time.Second).Unix())
+	assert.NoError(t, err)
+	err = p.ExtendUserSession("auth_provider:125", "invalid_key", time.Now().Add(60*time.Second).Unix())
+	assert.Error(t, err)
 	// Expire some tokens and make sure they are empty
 	time.Sleep(5 * time.Second)
+	key, err = p.GetUserSession("auth_provider:125", "session_token_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "test_hash125", key)
 	key, err = p.GetUserSession("auth_provider:124", "session_token_key")
 	assert.Empty(t, key)
 	assert.Error(t, err)
@@ -123,4 +133,17 @@ func ProviderTests(t *testing.T, p Provider) {
 	key, err = p.GetMfaSession("auth_provider:123", "session123")
 	assert.Error(t, err)
 	assert.Empty(t, key)
+
+	// only first of the callers sets the session
+	ok, err := p.SetUserSessionIfNotExists("auth_provider:125", "used_key", "first", time.Now().Add(60*time.Second).Unix())
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = p.SetUserSessionIfNotExists("auth_provider:125", "used_key", "second", time.Now().Add(60*time.Second).Unix())
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	key, err = p.GetUserSession("auth_provider:125", "used_key")
+	assert.NoError(t, err)
+	assert.Equal(t, "first", key)
+	err = p.DeleteAllUserSessions("125")
+	assert.NoError(t, err)
 }
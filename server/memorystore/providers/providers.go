@@ -1,11 +1,18 @@
 package providers
 
+import "context"
+
 // Provider defines current memory store provider
 type Provider interface {
 	// SetUserSession sets the user session for given user identifier in form recipe:user_id
 	SetUserSession(userId, key, token string, expiration int64) error
+	// SetUserSessionIfNotExists sets the user session only if it does not exist already.
+	// It returns false when session exists, so that only one of the concurrent callers can set it.
+	SetUserSessionIfNotExists(userId, key, token string, expiration int64) (bool, error)
 	// GetUserSession returns the session token for given token
 	GetUserSession(userId, key string) (string, error)
+	// ExtendUserSession updates the expiry of existing user session, used for idle timeout
+	ExtendUserSession(userId, key string, expiration int64) error
 	// DeleteUserSession deletes the user session
 	DeleteUserSession(userId, key string) error
 	// DeleteAllSessions deletes all the sessions from the session store
@@ -38,4 +45,9 @@ type Provider interface {
 	GetStringStoreEnvVariable(key string) (string, error)
 	// GetBoolStoreEnvVariable to get the bool env variable from env store
 	GetBoolStoreEnvVariable(key string) (bool, error)
+
+	// HealthCheck checks if the store is reachable, used by readiness probe
+	HealthCheck(ctx context.Context) error
+	// Close closes the connections of store on shutdown
+	Close() error
 }
@@ -2,6 +2,11 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
 	"strings"
 	"time"
 
@@ -23,50 +28,56 @@ type RedisClient interface {
 	HGet(ctx context.Context, key, field string) *redis.StringCmd
 	HGetAll(ctx context.Context, key string) *redis.MapStringStringCmd
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.BoolCmd
 	Get(ctx context.Context, key string) *redis.StringCmd
+	ExpireAt(ctx context.Context, key string, tm time.Time) *redis.BoolCmd
 	Scan(ctx context.Context, cursor uint64, match string, count int64) *redis.ScanCmd
 	Keys(ctx context.Context, pattern string) *redis.StringSliceCmd
+	Ping(ctx context.Context) *redis.StatusCmd
+	Close() error
+}
+
+// Options are the optional settings for redis connection
+type Options struct {
+	// SentinelMasterName enables sentinel failover,
+	// hosts of redis url are used as sentinel addresses in that case
+	SentinelMasterName string
+	SentinelPassword   string
+	// Cert, CertKey and CACert are base64 encoded PEM used for TLS connection
+	Cert    string
+	CertKey string
+	CACert  string
+	// KeyPrefix is prepended to all the keys written by the store,
+	// so that redis instance can be shared with other apps
+	KeyPrefix string
+	// PoolSize, MinIdleConns and PoolTimeout tune the connection pool,
+	// defaults of redis client are used for zero values
+	PoolSize     int
+	MinIdleConns int
+	PoolTimeout  time.Duration
 }
 
 type provider struct {
-	ctx   context.Context
-	store RedisClient
+	ctx       context.Context
+	store     RedisClient
+	keyPrefix string
 }
 
 // NewRedisProvider returns a new redis provider
 func NewRedisProvider(redisURL string) (*provider, error) {
-	redisURLHostPortsList := strings.Split(redisURL, ",")
-	if len(redisURLHostPortsList) > 1 {
-		opt, err := redis.ParseURL(redisURLHostPortsList[0])
-		if err != nil {
-			log.Debug("error parsing redis url: ", err)
-			return nil, err
-		}
-		urls := []string{opt.Addr}
-		urlList := redisURLHostPortsList[1:]
-		urls = append(urls, urlList...)
-		clusterOpt := &redis.ClusterOptions{Addrs: urls, DialTimeout: dialTimeout}
-		rdb := redis.NewClusterClient(clusterOpt)
-		ctx := context.Background()
-		_, err = rdb.Ping(ctx).Result()
-		if err != nil {
-			log.Debug("error connecting to redis: ", err)
-			return nil, err
-		}
-
-		return &provider{
-			ctx:   ctx,
-			store: rdb,
-		}, nil
-	}
+	return NewRedisProviderWithOptions(redisURL, Options{})
+}
 
-	opt, err := redis.ParseURL(redisURL)
+// NewRedisProviderWithOptions returns a new redis provider for
+// single node, cluster or sentinel setup based on url and options.
+// Comma separated hosts can be passed after first url for cluster or sentinel.
+func NewRedisProviderWithOptions(redisURL string, opts Options) (*provider, error) {
+	universalOpt, err := getUniversalOptions(redisURL, opts)
 	if err != nil {
-		log.Debug("error parsing redis url: ", err)
+		log.Debug("error parsing redis options: ", err)
 		return nil, err
 	}
-	opt.DialTimeout = dialTimeout
-	rdb := redis.NewClient(opt)
+	rdb := redis.NewUniversalClient(universalOpt)
 	ctx := context.Background()
 	_, err = rdb.Ping(ctx).Result()
 	if err != nil {
@@ -74,7 +85,81 @@ func NewRedisProvider(redisURL string) (*provider, error) {
 		return nil, err
 	}
 	return &provider{
-		ctx:   ctx,
-		store: rdb,
+		ctx:       ctx,
+		store:     rdb,
+		keyPrefix: opts.KeyPrefix,
 	}, nil
 }
+
+// getUniversalOptions returns the client options for given url and options.
+// Failover client is used when sentinel master name is set,
+// cluster client for multiple hosts and simple client otherwise.
+func getUniversalOptions(redisURL string, opts Options) (*redis.UniversalOptions, error) {
+	redisURLHostPortsList := strings.Split(redisURL, ",")
+	opt, err := redis.ParseURL(strings.TrimSpace(redisURLHostPortsList[0]))
+	if err != nil {
+		return nil, err
+	}
+	addrs := []string{opt.Addr}
+	for _, addr := range redisURLHostPortsList[1:] {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	tlsConfig := opt.TLSConfig
+	if opts.Cert != "" || opts.CertKey != "" || opts.CACert != "" {
+		if tlsConfig == nil {
+			host, _, _ := net.SplitHostPort(opt.Addr)
+			tlsConfig = &tls.Config{
+				MinVersion: tls.VersionTLS12,
+				ServerName: host,
+			}
+		}
+		if opts.CACert != "" {
+			caPEM, err := base64.StdEncoding.DecodeString(opts.CACert)
+			if err != nil {
+				return nil, err
+			}
+			certPool := x509.NewCertPool()
+			if ok := certPool.AppendCertsFromPEM(caPEM); !ok {
+				return nil, fmt.Errorf("invalid redis ca certificate")
+			}
+			tlsConfig.RootCAs = certPool
+		}
+		if opts.Cert != "" || opts.CertKey != "" {
+			certPEM, err := base64.StdEncoding.DecodeString(opts.Cert)
+			if err != nil {
+				return nil, err
+			}
+			keyPEM, err := base64.StdEncoding.DecodeString(opts.CertKey)
+			if err != nil {
+				return nil, err
+			}
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			if err != nil {
+				return nil, err
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+
+	return &redis.UniversalOptions{
+		Addrs:            addrs,
+		DB:               opt.DB,
+		Username:         opt.Username,
+		Password:         opt.Password,
+		MasterName:       opts.SentinelMasterName,
+		SentinelPassword: opts.SentinelPassword,
+		DialTimeout:      dialTimeout,
+		PoolSize:         opts.PoolSize,
+		MinIdleConns:     opts.MinIdleConns,
+		PoolTimeout:      opts.PoolTimeout,
+		TLSConfig:        tlsConfig,
+	}, nil
+}
+
+// key returns the key with configured prefix
+func (c *provider) key(key string) string {
+	return c.keyPrefix + key
+}
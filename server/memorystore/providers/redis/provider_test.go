@@ -1,7 +1,17 @@
 package redis
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -13,3 +23,84 @@ func TestRedisProvider(t *testing.T) {
 	assert.NoError(t, err)
 	providers.ProviderTests(t, p)
 }
+
+func TestRedisProviderWithKeyPrefix(t *testing.T) {
+	p, err := NewRedisProviderWithOptions("redis://127.0.0.1:6379", Options{
+		KeyPrefix:    "authorizer_test:",
+		PoolSize:     5,
+		MinIdleConns: 1,
+		PoolTimeout:  time.Second,
+	})
+	assert.NoError(t, err)
+	providers.ProviderTests(t, p)
+
+	err = p.SetState("prefixed_state", "value")
+	assert.NoError(t, err)
+	val, err := p.store.Get(context.Background(), "authorizer_test:"+stateStorePrefix+"prefixed_state").Result()
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+	err = p.store.Get(context.Background(), stateStorePrefix+"prefixed_state").Err()
+	assert.Error(t, err)
+	assert.NoError(t, p.RemoveState("prefixed_state"))
+}
+
+func TestRedisUniversalOptions(t *testing.T) {
+	t.Run("should use sentinel hosts with master name", func(t *testing.T) {
+		opt, err := getUniversalOptions("redis://:secret@sentinel-1:26379/2,sentinel-2:26379, sentinel-3:26379", Options{
+			SentinelMasterName: "mymaster",
+			SentinelPassword:   "sentinel-secret",
+			PoolSize:           20,
+			MinIdleConns:       2,
+			PoolTimeout:        5 * time.Second,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"sentinel-1:26379", "sentinel-2:26379", "sentinel-3:26379"}, opt.Addrs)
+		assert.Equal(t, "mymaster", opt.MasterName)
+		assert.Equal(t, "sentinel-secret", opt.SentinelPassword)
+		assert.Equal(t, "secret", opt.Password)
+		assert.Equal(t, 2, opt.DB)
+		assert.Equal(t, 20, opt.PoolSize)
+		assert.Equal(t, 2, opt.MinIdleConns)
+		assert.Equal(t, 5*time.Second, opt.PoolTimeout)
+		assert.Nil(t, opt.TLSConfig)
+	})
+
+	t.Run("should configure client certificate and ca", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "redis-client"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+			IsCA:         true,
+		}
+		certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+		assert.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		assert.NoError(t, err)
+		certPEM := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+		keyPEM := base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+
+		opt, err := getUniversalOptions("redis://redis.example.com:6380", Options{
+			Cert:    certPEM,
+			CertKey: keyPEM,
+			CACert:  certPEM,
+		})
+		assert.NoError(t, err)
+		if assert.NotNil(t, opt.TLSConfig) {
+			assert.Equal(t, "redis.example.com", opt.TLSConfig.ServerName)
+			assert.Len(t, opt.TLSConfig.Certificates, 1)
+			assert.NotNil(t, opt.TLSConfig.RootCAs)
+		}
+
+		_, err = getUniversalOptions("redis://redis.example.com:6380", Options{
+			CACert: base64.StdEncoding.EncodeToString([]byte("invalid")),
+		})
+		assert.Error(t, err)
+		_, err = getUniversalOptions("redis://redis.example.com:6380", Options{
+			Cert: certPEM,
+		})
+		assert.Error(t, err)
+	})
+}
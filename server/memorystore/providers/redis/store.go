@@ -1,6 +1,7 @@
 package redis
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"time"
@@ -23,7 +24,11 @@ func (c *provider) SetUserSession(userId, key, token string, expiration int64) e
 	currentTime := time.Now()
 	expireTime := time.Unix(expiration, 0)
 	duration := expireTime.Sub(currentTime)
-	err := c.store.Set(c.ctx, fmt.Sprintf("%s:%s", userId, key), token, duration).Err()
+	// redis persists keys without expiry for negative duration
+	if duration <= 0 {
+		return nil
+	}
+	err := c.store.Set(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, key)), token, duration).Err()
 	if err != nil {
 		log.Debug("Error saving user session to redis: ", err)
 		return err
@@ -31,26 +36,54 @@ func (c *provider) SetUserSession(userId, key, token string, expiration int64) e
 	return nil
 }
 
+// SetUserSessionIfNotExists sets the user session in redis store if it does not exist
+func (c *provider) SetUserSessionIfNotExists(userId, key, token string, expiration int64) (bool, error) {
+	duration := time.Until(time.Unix(expiration, 0))
+	// redis persists keys without expiry for negative duration
+	if duration <= 0 {
+		return false, nil
+	}
+	ok, err := c.store.SetNX(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, key)), token, duration).Result()
+	if err != nil {
+		log.Debug("Error saving user session to redis: ", err)
+		return false, err
+	}
+	return ok, nil
+}
+
 // GetUserSession returns the user session from redis store.
 func (c *provider) GetUserSession(userId, key string) (string, error) {
-	data, err := c.store.Get(c.ctx, fmt.Sprintf("%s:%s", userId, key)).Result()
+	data, err := c.store.Get(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, key))).Result()
 	if err != nil {
 		return "", err
 	}
 	return data, nil
 }
 
+// ExtendUserSession updates the expiry of existing user session in redis store.
+func (c *provider) ExtendUserSession(userId, key string, expiration int64) error {
+	ok, err := c.store.ExpireAt(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, key)), time.Unix(expiration, 0)).Result()
+	if err != nil {
+		log.Debug("Error extending user session in redis: ", err)
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("session not found")
+	}
+	return nil
+}
+
 // DeleteUserSession deletes the user session from redis store.
 func (c *provider) DeleteUserSession(userId, key string) error {
-	if err := c.store.Del(c.ctx, fmt.Sprintf("%s:%s", userId, constants.TokenTypeSessionToken+"_"+key)).Err(); err != nil {
+	if err := c.store.Del(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, constants.TokenTypeSessionToken+"_"+key))).Err(); err != nil {
 		log.Debug("Error deleting user session from redis: ", err)
 		// continue
 	}
-	if err := c.store.Del(c.ctx, fmt.Sprintf("%s:%s", userId, constants.TokenTypeAccessToken+"_"+key)).Err(); err != nil {
+	if err := c.store.Del(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, constants.TokenTypeAccessToken+"_"+key))).Err(); err != nil {
 		log.Debug("Error deleting user session from redis: ", err)
 		// continue
 	}
-	if err := c.store.Del(c.ctx, fmt.Sprintf("%s:%s", userId, constants.TokenTypeRefreshToken+"_"+key)).Err(); err != nil {
+	if err := c.store.Del(c.ctx, c.key(fmt.Sprintf("%s:%s", userId, constants.TokenTypeRefreshToken+"_"+key))).Err(); err != nil {
 		log.Debug("Error deleting user session from redis: ", err)
 		// continue
 	}
@@ -59,7 +92,7 @@ func (c *provider) DeleteUserSession(userId, key string) error {
 
 // DeleteAllUserSessions deletes all the user session from redis
 func (c *provider) DeleteAllUserSessions(userID string) error {
-	res := c.store.Keys(c.ctx, fmt.Sprintf("*%s*", userID))
+	res := c.store.Keys(c.ctx, c.key(fmt.Sprintf("*%s*", userID)))
 	if res.Err() != nil {
 		log.Debug("Error getting all user sessions from redis: ", res.Err())
 		return res.Err()
@@ -77,7 +110,7 @@ func (c *provider) DeleteAllUserSessions(userID string) error {
 
 // DeleteSessionForNamespace to delete session for a given namespace example google,github
 func (c *provider) DeleteSessionForNamespace(namespace string) error {
-	res := c.store.Keys(c.ctx, fmt.Sprintf("%s:*", namespace))
+	res := c.store.Keys(c.ctx, c.key(fmt.Sprintf("%s:*", namespace)))
 	if res.Err() != nil {
 		log.Debug("Error getting all user sessions from redis: ", res.Err())
 		return res.Err()
@@ -98,7 +131,7 @@ func (c *provider) SetMfaSession(userId, key string, expiration int64) error {
 	currentTime := time.Now()
 	expireTime := time.Unix(expiration, 0)
 	duration := expireTime.Sub(currentTime)
-	err := c.store.Set(c.ctx, fmt.Sprintf("%s%s:%s", mfaSessionPrefix, userId, key), userId, duration).Err()
+	err := c.store.Set(c.ctx, c.key(fmt.Sprintf("%s%s:%s", mfaSessionPrefix, userId, key)), userId, duration).Err()
 	if err != nil {
 		log.Debug("Error saving user session to redis: ", err)
 		return err
@@ -108,7 +141,7 @@ func (c *provider) SetMfaSession(userId, key string, expiration int64) error {
 
 // GetMfaSession returns value of given mfa session
 func (c *provider) GetMfaSession(userId, key string) (string, error) {
-	data, err := c.store.Get(c.ctx, fmt.Sprintf("%s%s:%s", mfaSessionPrefix, userId, key)).Result()
+	data, err := c.store.Get(c.ctx, c.key(fmt.Sprintf("%s%s:%s", mfaSessionPrefix, userId, key))).Result()
 	if err != nil {
 		return "", err
 	}
@@ -117,7 +150,7 @@ func (c *provider) GetMfaSession(userId, key string) (string, error) {
 
 // DeleteMfaSession deletes given mfa session from in-memory store.
 func (c *provider) DeleteMfaSession(userId, key string) error {
-	if err := c.store.Del(c.ctx, fmt.Sprintf("%s%s:%s", mfaSessionPrefix, userId, key)).Err(); err != nil {
+	if err := c.store.Del(c.ctx, c.key(fmt.Sprintf("%s%s:%s", mfaSessionPrefix, userId, key))).Err(); err != nil {
 		log.Debug("Error deleting user session from redis: ", err)
 		// continue
 	}
@@ -126,7 +159,7 @@ func (c *provider) DeleteMfaSession(userId, key string) error {
 
 // SetState sets the state in redis store.
 func (c *provider) SetState(key, value string) error {
-	err := c.store.Set(c.ctx, stateStorePrefix+key, value, 0).Err()
+	err := c.store.Set(c.ctx, c.key(stateStorePrefix+key), value, 0).Err()
 	if err != nil {
 		log.Debug("Error saving redis token: ", err)
 		return err
@@ -137,7 +170,7 @@ func (c *provider) SetState(key, value string) error {
 
 // GetState gets the state from redis store.
 func (c *provider) GetState(key string) (string, error) {
-	data, err := c.store.Get(c.ctx, stateStorePrefix+key).Result()
+	data, err := c.store.Get(c.ctx, c.key(stateStorePrefix+key)).Result()
 	if err != nil {
 		log.Debug("error getting token from redis store: ", err)
 		return "", err
@@ -148,7 +181,7 @@ func (c *provider) GetState(key string) (string, error) {
 
 // RemoveState removes the state from redis store.
 func (c *provider) RemoveState(key string) error {
-	err := c.store.Del(c.ctx, stateStorePrefix+key).Err()
+	err := c.store.Del(c.ctx, c.key(stateStorePrefix+key)).Err()
 	if err != nil {
 		log.Fatalln("Error deleting redis token: ", err)
 		return err
@@ -160,7 +193,7 @@ func (c *provider) RemoveState(key string) error {
 // UpdateEnvStore to update the whole env store object
 func (c *provider) UpdateEnvStore(store map[string]interface{}) error {
 	for key, value := range store {
-		err := c.store.HSet(c.ctx, envStorePrefix, key, value).Err()
+		err := c.store.HSet(c.ctx, c.key(envStorePrefix), key, value).Err()
 		if err != nil {
 			return err
 		}
@@ -171,7 +204,7 @@ func (c *provider) UpdateEnvStore(store map[string]interface{}) error {
 // GetEnvStore returns the whole env store object
 func (c *provider) GetEnvStore() (map[string]interface{}, error) {
 	res := make(map[string]interface{})
-	data, err := c.store.HGetAll(c.ctx, envStorePrefix).Result()
+	data, err := c.store.HGetAll(c.ctx, c.key(envStorePrefix)).Result()
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +224,7 @@ func (c *provider) GetEnvStore() (map[string]interface{}, error) {
 
 // UpdateEnvVariable to update the particular env variable
 func (c *provider) UpdateEnvVariable(key string, value interface{}) error {
-	err := c.store.HSet(c.ctx, envStorePrefix, key, value).Err()
+	err := c.store.HSet(c.ctx, c.key(envStorePrefix), key, value).Err()
 	if err != nil {
 		log.Debug("Error saving redis token: ", err)
 		return err
@@ -201,7 +234,7 @@ func (c *provider) UpdateEnvVariable(key string, value interface{}) error {
 
 // GetStringStoreEnvVariable to get the string env variable from env store
 func (c *provider) GetStringStoreEnvVariable(key string) (string, error) {
-	data, err := c.store.HGet(c.ctx, envStorePrefix, key).Result()
+	data, err := c.store.HGet(c.ctx, c.key(envStorePrefix), key).Result()
 	if err != nil {
 		return "", nil
 	}
@@ -211,10 +244,20 @@ func (c *provider) GetStringStoreEnvVariable(key string) (string, error) {
 
 // GetBoolStoreEnvVariable to get the bool env variable from env store
 func (c *provider) GetBoolStoreEnvVariable(key string) (bool, error) {
-	data, err := c.store.HGet(c.ctx, envStorePrefix, key).Result()
+	data, err := c.store.HGet(c.ctx, c.key(envStorePrefix), key).Result()
 	if err != nil {
 		return false, nil
 	}
 
 	return data == "1", nil
 }
+
+// HealthCheck pings the redis server
+func (c *provider) HealthCheck(ctx context.Context) error {
+	return c.store.Ping(ctx).Err()
+}
+
+// Close closes the redis connection pool
+func (c *provider) Close() error {
+	return c.store.Close()
+}
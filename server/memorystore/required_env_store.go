@@ -28,6 +28,33 @@ type RequiredEnv struct {
 	DatabaseCACert     string `json:"DATABASE_CA_CERT"`
 	RedisURL           string `json:"REDIS_URL"`
 	DisableRedisForEnv bool   `json:"DISABLE_REDIS_FOR_ENV"`
+	// EnableDatabaseMemoryStore to use database as memory store when redis url is not set
+	EnableDatabaseMemoryStore bool `json:"ENABLE_DATABASE_MEMORY_STORE"`
+	// Redis related envs
+	RedisSentinelMasterName string `json:"REDIS_SENTINEL_MASTER_NAME"`
+	RedisSentinelPassword   string `json:"REDIS_SENTINEL_PASSWORD"`
+	RedisCert               string `json:"REDIS_CERT"`
+	RedisCertKey            string `json:"REDIS_CERT_KEY"`
+	RedisCACert             string `json:"REDIS_CA_CERT"`
+	RedisKeyPrefix          string `json:"REDIS_KEY_PREFIX"`
+	RedisPoolSize           string `json:"REDIS_POOL_SIZE"`
+	RedisMinIdleConns       string `json:"REDIS_MIN_IDLE_CONNS"`
+	RedisPoolTimeout        string `json:"REDIS_POOL_TIMEOUT"`
+	// EnvSyncInterval is the interval to reload env changes made by other instances
+	EnvSyncInterval string `json:"ENV_SYNC_INTERVAL"`
+	// RoleGrantSweepInterval is the interval to remove expired role grants
+	RoleGrantSweepInterval string `json:"ROLE_GRANT_SWEEP_INTERVAL"`
+	// OtelTracesExporter is the exporter used for tracing, otlp endpoint is configured with OTEL_EXPORTER_OTLP_* envs
+	OtelTracesExporter string `json:"OTEL_TRACES_EXPORTER"`
+	// HTTP server related envs, timeouts are in duration format eg. 30s
+	TLSCertFile           string `json:"TLS_CERT_FILE"`
+	TLSKeyFile            string `json:"TLS_KEY_FILE"`
+	TLSClientCAFile       string `json:"TLS_CLIENT_CA_FILE"`
+	HTTPReadTimeout       string `json:"HTTP_READ_TIMEOUT"`
+	HTTPReadHeaderTimeout string `json:"HTTP_READ_HEADER_TIMEOUT"`
+	HTTPWriteTimeout      string `json:"HTTP_WRITE_TIMEOUT"`
+	HTTPIdleTimeout       string `json:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout       string `json:"SHUTDOWN_TIMEOUT"`
 	// AWS Related Envs
 	AwsRegion          string `json:"AWS_REGION"`
 	AwsAccessKeyID     string `json:"AWS_ACCESS_KEY_ID"`
@@ -94,6 +121,27 @@ func InitRequiredEnv() error {
 	dbCACert := os.Getenv(constants.EnvKeyDatabaseCACert)
 	redisURL := os.Getenv(constants.EnvKeyRedisURL)
 	disableRedisForEnv := os.Getenv(constants.EnvKeyDisableRedisForEnv) == "true"
+	enableDatabaseMemoryStore := os.Getenv(constants.EnvKeyEnableDatabaseMemoryStore) == "true"
+	redisSentinelMasterName := os.Getenv(constants.EnvKeyRedisSentinelMasterName)
+	redisSentinelPassword := os.Getenv(constants.EnvKeyRedisSentinelPassword)
+	redisCert := os.Getenv(constants.EnvKeyRedisCert)
+	redisCertKey := os.Getenv(constants.EnvKeyRedisCertKey)
+	redisCACert := os.Getenv(constants.EnvKeyRedisCACert)
+	redisKeyPrefix := os.Getenv(constants.EnvKeyRedisKeyPrefix)
+	redisPoolSize := os.Getenv(constants.EnvKeyRedisPoolSize)
+	redisMinIdleConns := os.Getenv(constants.EnvKeyRedisMinIdleConns)
+	redisPoolTimeout := os.Getenv(constants.EnvKeyRedisPoolTimeout)
+	envSyncInterval := os.Getenv(constants.EnvKeyEnvSyncInterval)
+	roleGrantSweepInterval := os.Getenv(constants.EnvKeyRoleGrantSweepInterval)
+	otelTracesExporter := os.Getenv(constants.EnvKeyOtelTracesExporter)
+	tlsCertFile := os.Getenv(constants.EnvKeyTLSCertFile)
+	tlsKeyFile := os.Getenv(constants.EnvKeyTLSKeyFile)
+	tlsClientCAFile := os.Getenv(constants.EnvKeyTLSClientCAFile)
+	httpReadTimeout := os.Getenv(constants.EnvKeyHTTPReadTimeout)
+	httpReadHeaderTimeout := os.Getenv(constants.EnvKeyHTTPReadHeaderTimeout)
+	httpWriteTimeout := os.Getenv(constants.EnvKeyHTTPWriteTimeout)
+	httpIdleTimeout := os.Getenv(constants.EnvKeyHTTPIdleTimeout)
+	shutdownTimeout := os.Getenv(constants.EnvKeyShutdownTimeout)
 	awsRegion := os.Getenv(constants.EnvAwsRegion)
 	awsAccessKeyID := os.Getenv(constants.EnvAwsAccessKeyID)
 	awsSecretAccessKey := os.Getenv(constants.EnvAwsSecretAccessKey)
@@ -155,6 +203,27 @@ func InitRequiredEnv() error {
 		DatabaseCACert:            dbCACert,
 		RedisURL:                  redisURL,
 		DisableRedisForEnv:        disableRedisForEnv,
+		EnableDatabaseMemoryStore: enableDatabaseMemoryStore,
+		RedisSentinelMasterName:   redisSentinelMasterName,
+		RedisSentinelPassword:     redisSentinelPassword,
+		RedisCert:                 redisCert,
+		RedisCertKey:              redisCertKey,
+		RedisCACert:               redisCACert,
+		RedisKeyPrefix:            redisKeyPrefix,
+		RedisPoolSize:             redisPoolSize,
+		RedisMinIdleConns:         redisMinIdleConns,
+		RedisPoolTimeout:          redisPoolTimeout,
+		EnvSyncInterval:           envSyncInterval,
+		RoleGrantSweepInterval:    roleGrantSweepInterval,
+		OtelTracesExporter:        otelTracesExporter,
+		TLSCertFile:               tlsCertFile,
+		TLSKeyFile:                tlsKeyFile,
+		TLSClientCAFile:           tlsClientCAFile,
+		HTTPReadTimeout:           httpReadTimeout,
+		HTTPReadHeaderTimeout:     httpReadHeaderTimeout,
+		HTTPWriteTimeout:          httpWriteTimeout,
+		HTTPIdleTimeout:           httpIdleTimeout,
+		ShutdownTimeout:           shutdownTimeout,
 		AwsRegion:                 awsRegion,
 		AwsAccessKeyID:            awsAccessKeyID,
 		AwsSecretAccessKey:        awsSecretAccessKey,
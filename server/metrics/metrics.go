@@ -0,0 +1,168 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Auth events recorded in authorizer_auth_events_total
+const (
+	EventLogin        = "login"
+	EventSignup       = "signup"
+	EventMFAChallenge = "mfa_challenge"
+	EventOTPSend      = "otp_send"
+	EventTokenIssue   = "token_issue"
+	EventTokenRefresh = "token_refresh"
+)
+
+// Outcomes of auth events
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	// OutcomePending is used when user needs to complete an extra step, eg. verify email or otp
+	OutcomePending = "pending"
+)
+
+// Notification channels used in authorizer_notification_failures_total
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+)
+
+// Registry is the prometheus registry which holds all authorizer metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "authorizer_http_request_duration_seconds",
+		Help:    "Latency of HTTP requests by route",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	graphqlOperationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "authorizer_graphql_operation_duration_seconds",
+		Help:    "Latency of GraphQL operations",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "status"})
+
+	authEventsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authorizer_auth_events_total",
+		Help: "Number of auth events like login, signup, mfa challenges, otp sends and token issuance",
+	}, []string{"event", "recipe", "outcome"})
+
+	webhookDeliveriesTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authorizer_webhook_deliveries_total",
+		Help: "Number of webhook deliveries by event and outcome",
+	}, []string{"event", "outcome"})
+
+	webhookDeliveryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "authorizer_webhook_delivery_duration_seconds",
+		Help:    "Latency of webhook deliveries",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"event"})
+
+	notificationFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authorizer_notification_failures_total",
+		Help: "Number of failed email and sms sends",
+	}, []string{"channel"})
+
+	dbCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "authorizer_db_call_duration_seconds",
+		Help:    "Latency of database provider calls by method",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "status"})
+
+	memoryStoreErrorsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authorizer_memory_store_errors_total",
+		Help: "Number of memory store errors by method",
+	}, []string{"method"})
+
+	customScriptFailuresTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "authorizer_custom_script_failures_total",
+		Help: "Number of custom script runs that failed while issuing tokens or userinfo, by script type",
+	}, []string{"type"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		graphqlOperationDuration,
+		authEventsTotal,
+		webhookDeliveriesTotal,
+		webhookDeliveryDuration,
+		notificationFailuresTotal,
+		dbCallDuration,
+		memoryStoreErrorsTotal,
+		customScriptFailuresTotal,
+	)
+}
+
+// Handler returns http handler which exposes metrics in prometheus format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// statusFromError returns the status label for given error
+func statusFromError(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "ok"
+}
+
+// OutcomeFromError returns failure outcome if err is not nil, else success
+func OutcomeFromError(err error) string {
+	if err != nil {
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
+
+// RecordHTTPRequest records latency of http request for given route
+func RecordHTTPRequest(method, route, status string, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(method, route, status).Observe(duration.Seconds())
+}
+
+// RecordGraphQLOperation records latency of graphql operation
+func RecordGraphQLOperation(operation string, err error, duration time.Duration) {
+	graphqlOperationDuration.WithLabelValues(operation, statusFromError(err)).Observe(duration.Seconds())
+}
+
+// RecordAuthEvent increments the auth event counter for given recipe and outcome
+func RecordAuthEvent(event, recipe, outcome string) {
+	authEventsTotal.WithLabelValues(event, recipe, outcome).Inc()
+}
+
+// RecordWebhookDelivery records the outcome and latency of webhook delivery
+func RecordWebhookDelivery(event, outcome string, duration time.Duration) {
+	webhookDeliveriesTotal.WithLabelValues(event, outcome).Inc()
+	webhookDeliveryDuration.WithLabelValues(event).Observe(duration.Seconds())
+}
+
+// RecordNotificationFailure increments failures for email or sms channel
+func RecordNotificationFailure(channel string) {
+	notificationFailuresTotal.WithLabelValues(channel).Inc()
+}
+
+// RecordDBCall records latency of database provider method
+func RecordDBCall(method string, err error, start time.Time) {
+	dbCallDuration.WithLabelValues(method, statusFromError(err)).Observe(time.Since(start).Seconds())
+}
+
+// RecordMemoryStoreError increments memory store errors for given method
+func RecordMemoryStoreError(method string, err error) {
+	if err != nil {
+		memoryStoreErrorsTotal.WithLabelValues(method).Inc()
+	}
+}
+
+// RecordCustomScriptFailure increments failures of custom script of given type
+func RecordCustomScriptFailure(scriptType string) {
+	customScriptFailuresTotal.WithLabelValues(scriptType).Inc()
+}
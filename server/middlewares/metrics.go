@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/authorizerdev/authorizer/server/metrics"
+)
+
+// MetricsMiddleware records latency of http requests by route
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		// use route pattern instead of path to keep the cardinality low
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.RecordHTTPRequest(c.Request.Method, route, strconv.Itoa(c.Writer.Status()), time.Since(start))
+	}
+}
@@ -0,0 +1,90 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// AddOrganizationResolver resolver for add organization mutation
+// Roles and default roles of organization default to ROLES and DEFAULT_ROLES
+func AddOrganizationResolver(ctx context.Context, params model.AddOrganizationRequest) (*model.Organization, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		log.Debug("Organization name is required")
+		return nil, fmt.Errorf("organization name is required")
+	}
+	log := log.WithField("organization_name", name)
+	if organization, _ := db.Provider.GetOrganizationByName(ctx, name); organization != nil {
+		log.Debug("Organization already exists")
+		return nil, fmt.Errorf("organization with %s name already exists", name)
+	}
+
+	roles := params.Roles
+	if roles == nil {
+		rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+		if err != nil {
+			log.Debug("Error getting roles: ", err)
+			return nil, err
+		}
+		roles = strings.Split(rolesString, ",")
+	}
+	defaultRoles := params.DefaultRoles
+	if defaultRoles == nil {
+		defaultRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+		if err != nil {
+			log.Debug("Error getting default roles: ", err)
+			return nil, err
+		}
+		defaultRoles = strings.Split(defaultRolesString, ",")
+	}
+	organization := &models.Organization{
+		Name:         name,
+		Roles:        joinOrganizationRoles(roles),
+		DefaultRoles: joinOrganizationRoles(defaultRoles),
+	}
+	if !validators.IsValidRoles(organization.GetDefaultRoles(), organization.GetRoles()) {
+		log.Debug("Invalid default roles: ", defaultRoles)
+		return nil, fmt.Errorf("default roles should be subset of roles")
+	}
+
+	organization, err = db.Provider.AddOrganization(ctx, organization)
+	if err != nil {
+		log.Debug("Failed to add organization: ", err)
+		return nil, err
+	}
+	return organization.AsAPIOrganization(), nil
+}
+
+// joinOrganizationRoles returns the comma separated list of roles
+// after removing the empty and duplicate roles
+func joinOrganizationRoles(roles []string) string {
+	res := []string{}
+	for _, role := range roles {
+		if role = strings.TrimSpace(role); role != "" {
+			res = append(res, role)
+		}
+	}
+	return strings.Join(utils.RemoveDuplicateString(res), ",")
+}
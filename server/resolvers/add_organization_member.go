@@ -0,0 +1,81 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// AddOrganizationMemberResolver resolver for add organization member mutation
+// User is added with default roles of organization when roles are not given
+func AddOrganizationMemberResolver(ctx context.Context, params model.AddOrganizationMemberRequest) (*model.Membership, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.OrganizationID)
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, fmt.Errorf("organization not found")
+	}
+
+	var user *models.User
+	if userID := refs.StringValue(params.UserID); userID != "" {
+		user, err = db.Provider.GetUserByID(ctx, userID)
+	} else if email := refs.StringValue(params.Email); email != "" {
+		user, err = db.Provider.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	} else {
+		log.Debug("User id or email is required")
+		return nil, fmt.Errorf("user id or email is required")
+	}
+	if err != nil || user == nil {
+		log.Debug("Failed to get user: ", err)
+		return nil, fmt.Errorf("user not found")
+	}
+	log = log.WithField("user_id", user.ID)
+
+	if membership, _ := db.Provider.GetMembershipByOrganizationIDAndUserID(ctx, organization.ID, user.ID); membership != nil {
+		log.Debug("User is already member of organization")
+		return nil, fmt.Errorf("user is already member of organization")
+	}
+
+	roles := organization.GetDefaultRoles()
+	if params.Roles != nil {
+		roles = params.Roles
+		if !validators.IsValidRoles(roles, organization.GetRoles()) {
+			log.Debug("Invalid roles: ", roles)
+			return nil, fmt.Errorf("invalid roles")
+		}
+	}
+
+	membership, err := db.Provider.AddMembership(ctx, &models.Membership{
+		OrganizationID: organization.ID,
+		UserID:         user.ID,
+		Roles:          joinOrganizationRoles(roles),
+	})
+	if err != nil {
+		log.Debug("Failed to add membership: ", err)
+		return nil, err
+	}
+	res := membership.AsAPIMembership()
+	res.Organization = organization.AsAPIOrganization()
+	res.User = user.AsAPIUser()
+	return res, nil
+}
@@ -0,0 +1,50 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// AddPermissionResolver resolver for add permission mutation
+func AddPermissionResolver(ctx context.Context, params model.AddPermissionRequest) (*model.Permission, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" || strings.Contains(name, ",") {
+		log.Debug("Invalid permission name: ", params.Name)
+		return nil, fmt.Errorf("invalid permission name")
+	}
+	log := log.WithField("permission", name)
+	if permission, _ := db.Provider.GetPermissionByName(ctx, name); permission != nil {
+		log.Debug("Permission already exists")
+		return nil, fmt.Errorf("permission with %s name already exists", name)
+	}
+
+	permission, err := db.Provider.AddPermission(ctx, &models.Permission{
+		Name:        name,
+		Description: refs.StringValue(params.Description),
+	})
+	if err != nil {
+		log.Debug("Failed to add permission: ", err)
+		return nil, err
+	}
+	return permission.AsAPIPermission(), nil
+}
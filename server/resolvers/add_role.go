@@ -0,0 +1,98 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// AddRoleResolver resolver for add role mutation
+// Role is also added to ROLES, so that it can be assigned to users
+func AddRoleResolver(ctx context.Context, params model.AddRoleRequest) (*model.Role, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" || strings.Contains(name, ",") {
+		log.Debug("Invalid role name: ", params.Name)
+		return nil, fmt.Errorf("invalid role name")
+	}
+	log := log.WithField("role", name)
+	if role, _ := db.Provider.GetRoleByName(ctx, name); role != nil {
+		log.Debug("Role already exists")
+		return nil, fmt.Errorf("role with %s name already exists", name)
+	}
+
+	permissions, err := joinPermissions(ctx, params.Permissions)
+	if err != nil {
+		log.Debug("Invalid permissions: ", err)
+		return nil, err
+	}
+
+	rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+	if err != nil {
+		log.Debug("Error getting roles: ", err)
+		return nil, err
+	}
+	protectedRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyProtectedRoles)
+	if err != nil {
+		log.Debug("Error getting protected roles: ", err)
+		return nil, err
+	}
+	roles := strings.Split(rolesString, ",")
+	if !utils.StringSliceContains(roles, name) && !utils.StringSliceContains(strings.Split(protectedRolesString, ","), name) {
+		err = updateEnvVariables(ctx, map[string]interface{}{
+			constants.EnvKeyRoles: joinOrganizationRoles(append(roles, name)),
+		})
+		if err != nil {
+			log.Debug("Failed to update roles: ", err)
+			return nil, err
+		}
+	}
+
+	role, err := db.Provider.AddRole(ctx, &models.Role{
+		Name:        name,
+		Description: refs.StringValue(params.Description),
+		Permissions: permissions,
+	})
+	if err != nil {
+		log.Debug("Failed to add role: ", err)
+		return nil, err
+	}
+	return role.AsAPIRole(), nil
+}
+
+// joinPermissions returns the comma separated list of permissions
+// after validating that all of them are defined
+func joinPermissions(ctx context.Context, permissions []string) (string, error) {
+	res := []string{}
+	for _, permission := range permissions {
+		permission = strings.TrimSpace(permission)
+		if permission == "" {
+			continue
+		}
+		if _, err := db.Provider.GetPermissionByName(ctx, permission); err != nil {
+			return "", fmt.Errorf("permission %s not found", permission)
+		}
+		res = append(res, permission)
+	}
+	return strings.Join(utils.RemoveDuplicateString(res), ","), nil
+}
@@ -0,0 +1,69 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// CheckPermissionResolver is a resolver for check permission query
+// Super admin can check the permission of any user,
+// otherwise permission is checked for the user of session or access token.
+// Permissions are granted by the roles assigned to user.
+func CheckPermissionResolver(ctx context.Context, params model.CheckPermissionInput) (*model.CheckPermissionResponse, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+
+	userID := strings.TrimSpace(refs.StringValue(params.UserID))
+	if token.IsSuperAdmin(gc) {
+		if userID == "" {
+			log.Debug("User ID is required")
+			return nil, fmt.Errorf("user_id is required")
+		}
+	} else {
+		tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+		if err != nil {
+			log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+			return nil, fmt.Errorf("unauthorized")
+		}
+		if userID != "" && userID != tokenData.UserID {
+			log.Debug("Not allowed to check permission of other user")
+			return nil, fmt.Errorf("unauthorized")
+		}
+		userID = tokenData.UserID
+	}
+
+	log := log.WithFields(log.Fields{
+		"user_id":    userID,
+		"permission": params.Permission,
+	})
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return nil, fmt.Errorf("user not found")
+	}
+	if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked")
+		return &model.CheckPermissionResponse{
+			Allowed: false,
+		}, nil
+	}
+
+	// roles of expired grants are not removed from user until they are swept
+	user, _ = token.DropExpiredRoles(user, nil)
+	permissions := token.GetPermissions(ctx, strings.Split(user.Roles, ","))
+	return &model.CheckPermissionResponse{
+		Allowed: utils.StringSliceContains(permissions, strings.TrimSpace(params.Permission)),
+	}, nil
+}
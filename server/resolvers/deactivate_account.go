@@ -7,7 +7,9 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 	log "github.com/sirupsen/logrus"
@@ -41,8 +43,10 @@ func DeactivateAccountResolver(ctx context.Context) (*model.Response, error) {
 		log.Debug("Failed to update user: ", err)
 		return res, err
 	}
+	hostname := parsers.GetHost(gc)
 	go func() {
 		memorystore.Provider.DeleteAllUserSessions(user.ID)
+		logout.SendBackchannelLogout(hostname, user.ID, "")
 		utils.RegisterEvent(ctx, constants.UserDeactivatedWebhookEvent, "", user)
 	}()
 	res = &model.Response{
@@ -0,0 +1,83 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// DeletePermissionResolver resolver for delete permission mutation
+// Permission is also removed from the roles granting it
+func DeletePermissionResolver(ctx context.Context, params model.PermissionRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	log := log.WithField("permission", name)
+	permission, err := db.Provider.GetPermissionByName(ctx, name)
+	if err != nil {
+		log.Debug("Failed to get permission: ", err)
+		return nil, fmt.Errorf("permission not found")
+	}
+
+	// collect the roles first, as updating them while paginating could shift the pages
+	roleNames := []string{}
+	pagination := &model.Pagination{
+		Limit: 100,
+	}
+	for {
+		res, err := db.Provider.ListRoles(ctx, pagination)
+		if err != nil {
+			log.Debug("Failed to list roles: ", err)
+			return nil, err
+		}
+		for _, role := range res.Roles {
+			if utils.StringSliceContains(role.Permissions, name) {
+				roleNames = append(roleNames, role.Name)
+			}
+		}
+		if int64(len(res.Roles)) < pagination.Limit {
+			break
+		}
+		pagination = &model.Pagination{
+			Limit:  pagination.Limit,
+			Offset: pagination.Offset + pagination.Limit,
+		}
+	}
+	for _, roleName := range roleNames {
+		role, err := db.Provider.GetRoleByName(ctx, roleName)
+		if err != nil {
+			log.Debug("Failed to get role: ", err)
+			return nil, err
+		}
+		role.Permissions = strings.Join(utils.DeleteFromArray(role.GetPermissions(), []string{name}), ",")
+		if _, err := db.Provider.UpdateRole(ctx, role); err != nil {
+			log.Debug("Failed to update role: ", err)
+			return nil, err
+		}
+	}
+
+	err = db.Provider.DeletePermission(ctx, permission)
+	if err != nil {
+		log.Debug("Failed to delete permission: ", err)
+		return nil, err
+	}
+
+	return &model.Response{
+		Message: "Permission deleted successfully",
+	}, nil
+}
@@ -0,0 +1,64 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// DeleteRoleResolver resolver for delete role mutation
+// Role is removed from ROLES, DEFAULT_ROLES and PROTECTED_ROLES and from the users having it
+func DeleteRoleResolver(ctx context.Context, params model.RoleRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	log := log.WithField("role", name)
+	role, err := db.Provider.GetRoleByName(ctx, name)
+	if err != nil {
+		log.Debug("Failed to get role: ", err)
+		return nil, fmt.Errorf("role not found")
+	}
+
+	data := map[string]interface{}{}
+	for _, key := range []string{constants.EnvKeyRoles, constants.EnvKeyDefaultRoles, constants.EnvKeyProtectedRoles} {
+		roles, err := memorystore.Provider.GetStringStoreEnvVariable(key)
+		if err != nil {
+			log.Debug("Error getting roles: ", err)
+			return nil, err
+		}
+		data[key] = strings.Join(utils.DeleteFromArray(strings.Split(roles, ","), []string{name}), ",")
+	}
+	err = updateEnvVariables(ctx, data)
+	if err != nil {
+		log.Debug("Failed to update roles: ", err)
+		return nil, err
+	}
+
+	err = db.Provider.DeleteRole(ctx, role)
+	if err != nil {
+		log.Debug("Failed to delete role: ", err)
+		return nil, err
+	}
+	go updateRoles(ctx, []string{name})
+
+	return &model.Response{
+		Message: "Role deleted successfully",
+	}, nil
+}
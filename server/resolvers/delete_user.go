@@ -9,7 +9,9 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
@@ -50,11 +52,15 @@ func DeleteUserResolver(ctx context.Context, params model.DeleteUserInput) (*mod
 		Message: `user deleted successfully`,
 	}
 
+	hostname := parsers.GetHost(gc)
 	go func() {
+		// clients are listed before the grants of user are deleted
+		backchannelLogoutClients := logout.GetBackchannelLogoutClients(ctx, user.ID)
+
 		// delete otp for given email
 		otp, err := db.Provider.GetOTPByEmail(ctx, refs.StringValue(user.Email))
 		if err != nil {
-			log.Infof("No OTP found for email (%s): %v", user.Email, err)
+			log.Infof("No OTP found for email (%s): %v", refs.StringValue(user.Email), err)
 			// continue
 		} else {
 			err := db.Provider.DeleteOTP(ctx, otp)
@@ -64,6 +70,58 @@ func DeleteUserResolver(ctx context.Context, params model.DeleteUserInput) (*mod
 			}
 		}
 
+		// delete linked oauth identities
+		identities, err := db.Provider.ListIdentitiesByUserID(ctx, user.ID)
+		if err != nil {
+			log.Debug("Failed to list identities: ", err)
+			// continue
+		}
+		for _, identity := range identities {
+			if err := db.Provider.DeleteIdentity(ctx, identity); err != nil {
+				log.Debug("Failed to delete identity: ", err)
+				// continue
+			}
+		}
+
+		// delete consents given to clients
+		grants, err := db.Provider.ListGrantsByUserID(ctx, user.ID)
+		if err != nil {
+			log.Debug("Failed to list grants: ", err)
+			// continue
+		}
+		for _, grant := range grants {
+			if err := db.Provider.DeleteGrant(ctx, grant); err != nil {
+				log.Debug("Failed to delete grant: ", err)
+				// continue
+			}
+		}
+
+		// delete organization memberships
+		memberships, err := db.Provider.ListMembershipsByUserID(ctx, user.ID)
+		if err != nil {
+			log.Debug("Failed to list memberships: ", err)
+			// continue
+		}
+		for _, membership := range memberships {
+			if err := db.Provider.DeleteMembership(ctx, membership); err != nil {
+				log.Debug("Failed to delete membership: ", err)
+				// continue
+			}
+		}
+
+		// delete role grants, role history is kept for audit
+		roleGrants, err := db.Provider.ListRoleGrantsByUserID(ctx, user.ID)
+		if err != nil {
+			log.Debug("Failed to list role grants: ", err)
+			// continue
+		}
+		for _, roleGrant := range roleGrants {
+			if err := db.Provider.DeleteRoleGrant(ctx, roleGrant); err != nil {
+				log.Debug("Failed to delete role grant: ", err)
+				// continue
+			}
+		}
+
 		// delete otp for given phone number
 		otp, err = db.Provider.GetOTPByPhoneNumber(ctx, refs.StringValue(user.PhoneNumber))
 		if err != nil {
@@ -92,7 +150,20 @@ func DeleteUserResolver(ctx context.Context, params model.DeleteUserInput) (*mod
 			}
 		}
 
+		// delete pending reverts of email and phone number change and pending phone number change, they are saved with user id
+		for _, vt := range []string{constants.VerificationTypeRevertEmailChange, constants.VerificationTypeRevertPhoneNumberChange, constants.VerificationTypeUpdatePhoneNumber} {
+			verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, user.ID, vt)
+			if err != nil {
+				continue
+			}
+			if err := db.Provider.DeleteVerificationRequest(ctx, verificationRequest); err != nil {
+				log.Debugf("Failed to DeleteVerificationRequest for user: %s, verification_request_type: %s. %v", user.ID, vt, err)
+				// continue
+			}
+		}
+
 		memorystore.Provider.DeleteAllUserSessions(user.ID)
+		logout.NotifyBackchannelLogoutClients(hostname, backchannelLogoutClients, user.ID, "")
 		utils.RegisterEvent(ctx, constants.UserDeletedWebhookEvent, "", user)
 	}()
 
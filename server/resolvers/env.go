@@ -41,6 +41,30 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 	if val, ok := store[constants.EnvKeyAccessTokenExpiryTime]; ok {
 		res.AccessTokenExpiryTime = refs.NewStringRef(val.(string))
 	}
+	if val, ok := store[constants.EnvKeySessionExpiryTime]; ok {
+		res.SessionExpiryTime = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyRefreshTokenExpiryTime]; ok {
+		res.RefreshTokenExpiryTime = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySessionIdleTimeout]; ok {
+		res.SessionIDLeTimeout = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeySessionExpiryTimeByRole]; ok {
+		res.SessionExpiryTimeByRole = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyOTPLength]; ok {
+		res.OtpLength = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyOTPCharset]; ok {
+		res.OtpCharset = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyOTPExpiryTime]; ok {
+		res.OtpExpiryTime = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyOTPMaxAttempts]; ok {
+		res.OtpMaxAttempts = refs.NewStringRef(val.(string))
+	}
 	if val, ok := store[constants.EnvKeyAdminSecret]; ok {
 		res.AdminSecret = refs.NewStringRef(val.(string))
 	}
@@ -74,6 +98,24 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 	if val, ok := store[constants.EnvKeyCustomAccessTokenScript]; ok {
 		res.CustomAccessTokenScript = refs.NewStringRef(val.(string))
 	}
+	if val, ok := store[constants.EnvKeyCustomIDTokenScript]; ok {
+		res.CustomIDTokenScript = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyCustomUserInfoScript]; ok {
+		res.CustomUserInfoScript = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyTokenExchangePolicy]; ok {
+		res.TokenExchangePolicy = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyBackchannelLogoutURI]; ok {
+		res.BackchannelLogoutURI = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyClientRegistrationInitialAccessToken]; ok {
+		res.ClientRegistrationInitialAccessToken = refs.NewStringRef(val.(string))
+	}
+	if val, ok := store[constants.EnvKeyCustomScopes]; ok {
+		res.CustomScopes = refs.NewStringRef(val.(string))
+	}
 	if val, ok := store[constants.EnvKeySmtpHost]; ok {
 		res.SMTPHost = refs.NewStringRef(val.(string))
 	}
@@ -223,6 +265,7 @@ func EnvResolver(ctx context.Context) (*model.Env, error) {
 	res.DisablePlayground = store[constants.EnvKeyDisablePlayGround].(bool)
 	res.DisableMailOtpLogin = store[constants.EnvKeyDisableMailOTPLogin].(bool)
 	res.DisableTotpLogin = store[constants.EnvKeyDisableTOTPLogin].(bool)
+	res.EnableLoginCode = store[constants.EnvKeyEnableLoginCode].(bool)
 
 	return res, nil
 }
@@ -134,14 +134,8 @@ func ForgotPasswordResolver(ctx context.Context, params model.ForgotPasswordInpu
 		}, nil
 	}
 	if isMobileLogin {
-		expiresAt := time.Now().Add(1 * time.Minute).Unix()
-		otp := utils.GenerateOTP()
-		otpData, err := db.Provider.UpsertOTP(ctx, &models.OTP{
-			Email:       refs.StringValue(user.Email),
-			PhoneNumber: refs.StringValue(user.PhoneNumber),
-			Otp:         otp,
-			ExpiresAt:   expiresAt,
-		})
+		expiresAt := utils.GetOTPExpiresAt(time.Minute)
+		otp, err := utils.CreateOTP(ctx, refs.StringValue(user.Email), refs.StringValue(user.PhoneNumber), true, expiresAt)
 		if err != nil {
 			log.Debug("Failed to add otp: ", err)
 			return nil, err
@@ -155,8 +149,10 @@ func ForgotPasswordResolver(ctx context.Context, params model.ForgotPasswordInpu
 		cookie.SetMfaSession(gc, mfaSession)
 		smsBody := strings.Builder{}
 		smsBody.WriteString("Your verification code is: ")
-		smsBody.WriteString(otpData.Otp)
-		if err := smsproviders.SendSMS(phoneNumber, smsBody.String()); err != nil {
+		smsBody.WriteString(otp)
+		err = smsproviders.SendSMS(phoneNumber, smsBody.String())
+		recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
+		if err != nil {
 			log.Debug("Failed to send sms: ", err)
 			// continue
 		}
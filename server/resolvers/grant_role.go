@@ -0,0 +1,112 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// GrantRoleResolver resolver for grant role mutation
+// Role is added to user and the grant records who granted it and until when.
+// Granting a role which user already has updates the expiry of grant.
+func GrantRoleResolver(ctx context.Context, params model.GrantRoleInput) (*model.RoleGrant, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	role := strings.TrimSpace(params.Role)
+	log := log.WithFields(log.Fields{
+		"user_id": params.UserID,
+		"role":    role,
+	})
+	rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+	if err != nil {
+		log.Debug("Error getting roles: ", err)
+		return nil, err
+	}
+	protectedRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyProtectedRoles)
+	if err != nil {
+		log.Debug("Error getting protected roles: ", err)
+		return nil, err
+	}
+	if role == "" || (!utils.StringSliceContains(strings.Split(rolesString, ","), role) && !utils.StringSliceContains(strings.Split(protectedRolesString, ","), role)) {
+		log.Debug("Invalid role")
+		return nil, fmt.Errorf("invalid role")
+	}
+	expiresAt := refs.Int64Value(params.ExpiresAt)
+	if expiresAt < 0 || (expiresAt > 0 && expiresAt <= time.Now().Unix()) {
+		log.Debug("Invalid expires_at: ", expiresAt)
+		return nil, fmt.Errorf("expires_at should be in future")
+	}
+	grantedBy := strings.TrimSpace(refs.StringValue(params.GrantedBy))
+	if grantedBy == "" {
+		grantedBy = constants.RoleChangedBySuperAdmin
+	}
+
+	user, err := db.Provider.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return nil, fmt.Errorf("user not found")
+	}
+	roles := []string{}
+	if user.Roles != "" {
+		roles = strings.Split(user.Roles, ",")
+	}
+	if !utils.StringSliceContains(roles, role) {
+		user.Roles = strings.Join(append(roles, role), ",")
+		user, err = db.Provider.UpdateUser(ctx, user)
+		if err != nil {
+			log.Debug("Failed to update user: ", err)
+			return nil, err
+		}
+	}
+
+	roleGrant, _ := db.Provider.GetRoleGrantByUserIDAndRole(ctx, user.ID, role)
+	if roleGrant != nil {
+		roleGrant.GrantedBy = grantedBy
+		roleGrant.ExpiresAt = expiresAt
+		roleGrant, err = db.Provider.UpdateRoleGrant(ctx, roleGrant)
+	} else {
+		roleGrant, err = db.Provider.AddRoleGrant(ctx, &models.RoleGrant{
+			UserID:    user.ID,
+			Role:      role,
+			GrantedBy: grantedBy,
+			ExpiresAt: expiresAt,
+		})
+	}
+	if err != nil {
+		log.Debug("Failed to save role grant: ", err)
+		return nil, err
+	}
+
+	_, err = db.Provider.AddRoleChange(ctx, &models.RoleChange{
+		UserID:    user.ID,
+		Role:      role,
+		Action:    constants.RoleChangeActionGranted,
+		ChangedBy: grantedBy,
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		log.Debug("Failed to add role change: ", err)
+		return nil, err
+	}
+	return roleGrant.AsAPIRoleGrant(), nil
+}
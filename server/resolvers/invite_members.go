@@ -62,6 +62,15 @@ func InviteMembersResolver(ctx context.Context, params model.InviteMemberInput)
 		return nil, errors.New("either basic authentication or magic link login is required")
 	}
 
+	var organization *models.Organization
+	if organizationID := refs.StringValue(params.OrganizationID); organizationID != "" {
+		organization, err = db.Provider.GetOrganizationByID(ctx, organizationID)
+		if err != nil {
+			log.Debug("Failed to get organization: ", err)
+			return nil, errors.New("organization not found")
+		}
+	}
+
 	// filter valid emails
 	emails := []string{}
 	for _, email := range params.Emails {
@@ -78,17 +87,25 @@ func InviteMembersResolver(ctx context.Context, params model.InviteMemberInput)
 	// TODO: optimise to use like query instead of looping through emails and getting user individually
 	// for each emails check if emails exists in db
 	newEmails := []string{}
+	// existing users invited to organization are added as its members
+	newMembers := []*models.User{}
 	for _, email := range emails {
-		_, err := db.Provider.GetUserByEmail(ctx, email)
+		user, err := db.Provider.GetUserByEmail(ctx, email)
 		if err != nil {
 			log.Debugf("User with %s email not found, so inviting user", email)
 			newEmails = append(newEmails, email)
+		} else if organization != nil {
+			if membership, _ := db.Provider.GetMembershipByOrganizationIDAndUserID(ctx, organization.ID, user.ID); membership == nil {
+				newMembers = append(newMembers, user)
+			} else {
+				log.Debugf("User with %s email is already member of organization", email)
+			}
 		} else {
 			log.Debugf("User with %s email already exists, so not inviting user", email)
 		}
 	}
 
-	if len(newEmails) == 0 {
+	if len(newEmails) == 0 && len(newMembers) == 0 {
 		log.Debug("No new emails found.")
 		return nil, errors.New("all emails already exist")
 	}
@@ -170,6 +187,10 @@ func InviteMembersResolver(ctx context.Context, params model.InviteMemberInput)
 			return nil, err
 		}
 
+		if organization != nil {
+			newMembers = append(newMembers, user)
+		}
+
 		// exec it as go routine so that we can reduce the api latency
 		go emailservice.SendEmail([]string{refs.StringValue(user.Email)}, constants.VerificationTypeInviteMember, map[string]interface{}{
 			"user":             user.ToMap(),
@@ -178,6 +199,18 @@ func InviteMembersResolver(ctx context.Context, params model.InviteMemberInput)
 		})
 	}
 
+	for _, user := range newMembers {
+		_, err := db.Provider.AddMembership(ctx, &models.Membership{
+			OrganizationID: organization.ID,
+			UserID:         user.ID,
+			Roles:          organization.DefaultRoles,
+		})
+		if err != nil {
+			log.Debugf("Error adding membership: %s, err: %v", refs.StringValue(user.Email), err)
+			return nil, err
+		}
+	}
+
 	InvitedUsers := []*model.User{}
 
 	for _, email := range newEmails {
@@ -195,8 +228,18 @@ func InviteMembersResolver(ctx context.Context, params model.InviteMemberInput)
 
 	}
 
+	// existing users added to organization are not sent invitation email
+	for _, user := range newMembers {
+		if !utils.StringSliceContains(newEmails, refs.StringValue(user.Email)) {
+			InvitedUsers = append(InvitedUsers, &model.User{
+				Email: user.Email,
+				ID:    user.ID,
+			})
+		}
+	}
+
 	return &model.InviteMembersResponse{
-		Message: fmt.Sprintf("%d user(s) invited successfully.", len(newEmails)),
+		Message: fmt.Sprintf("%d user(s) invited successfully.", len(InvitedUsers)),
 		Users:   InvitedUsers,
 	}, nil
 }
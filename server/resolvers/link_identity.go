@@ -0,0 +1,73 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// LinkIdentityResolver is the resolver for the link_identity field.
+// It returns the oauth login url which links the provider account with logged in user
+// instead of logging in with it. Oauth flow has to be completed in the browser having
+// session of user, as the link token is checked against it in oauth callback.
+func LinkIdentityResolver(ctx context.Context, params model.LinkIdentityInput) (*model.LinkIdentityResponse, error) {
+	var res *model.LinkIdentityResponse
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return res, err
+	}
+	log := log.WithFields(log.Fields{
+		"user_id":  tokenData.UserID,
+		"provider": params.Provider,
+	})
+	provider := strings.TrimSpace(params.Provider)
+	if !utils.StringSliceContains(constants.OAuthRecipeMethods, provider) {
+		log.Debug("Invalid oauth provider")
+		return res, fmt.Errorf("invalid oauth provider")
+	}
+	redirectURI := strings.TrimSpace(params.RedirectURI)
+	if redirectURI == "" || !validators.IsValidOrigin(redirectURI) {
+		log.Debug("Invalid redirect uri: ", redirectURI)
+		return res, fmt.Errorf("invalid redirect uri")
+	}
+	user, err := db.Provider.GetUserByID(ctx, tokenData.UserID)
+	if err != nil {
+		log.Debug("Failed to get user by id: ", err)
+		return res, err
+	}
+	if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked")
+		return res, fmt.Errorf("user access has been revoked")
+	}
+	linkToken := uuid.New().String()
+	if err := memorystore.Provider.SetState(linkToken, user.ID); err != nil {
+		log.Debug("Failed to set link token state: ", err)
+		return res, err
+	}
+	hostname := parsers.GetHost(gc)
+	authorizationURL := hostname + "/oauth_login/" + provider + "?redirect_uri=" + url.QueryEscape(redirectURI) + "&link_token=" + linkToken
+	res = &model.LinkIdentityResponse{
+		Message:          `Redirect to authorization url to link the account`,
+		AuthorizationURL: authorizationURL,
+	}
+	return res, nil
+}
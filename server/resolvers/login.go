@@ -19,6 +19,7 @@ import (
 	mailService "github.com/authorizerdev/authorizer/server/email"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/smsproviders"
 	"github.com/authorizerdev/authorizer/server/token"
@@ -28,8 +29,14 @@ import (
 
 // LoginResolver is a resolver for login mutation
 // User can login with email or phone number, but not both
-func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthResponse, error) {
-	var res *model.AuthResponse
+func LoginResolver(ctx context.Context, params model.LoginInput) (res *model.AuthResponse, err error) {
+	defer func() {
+		recipe := constants.AuthRecipeMethodBasicAuth
+		if refs.StringValue(params.Email) == "" && refs.StringValue(params.PhoneNumber) != "" {
+			recipe = constants.AuthRecipeMethodMobileBasicAuth
+		}
+		recordAuthResponse(metrics.EventLogin, recipe, res, err)
+	}()
 
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
@@ -92,19 +99,13 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 		log.Debug("SMS service not enabled: ", err)
 	}
 	// If multi factor authentication is enabled and we need to generate OTP for mail / sms based MFA
-	generateOTP := func(expiresAt int64) (*models.OTP, error) {
-		otp := utils.GenerateOTP()
-		otpData, err := db.Provider.UpsertOTP(ctx, &models.OTP{
-			Email:       refs.StringValue(user.Email),
-			PhoneNumber: refs.StringValue(user.PhoneNumber),
-			Otp:         otp,
-			ExpiresAt:   expiresAt,
-		})
+	generateOTP := func(isSMS bool, expiresAt int64) (string, error) {
+		otp, err := utils.CreateOTP(ctx, refs.StringValue(user.Email), refs.StringValue(user.PhoneNumber), isSMS, expiresAt)
 		if err != nil {
 			log.Debug("Failed to add otp: ", err)
-			return nil, err
+			return "", err
 		}
-		return otpData, nil
+		return otp, nil
 	}
 	setOTPMFaSession := func(expiresAt int64) error {
 		mfaSession := uuid.NewString()
@@ -142,8 +143,8 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 						return res, fmt.Errorf(`email verification pending`)
 					}
 				}
-				expiresAt := time.Now().Add(1 * time.Minute).Unix()
-				otpData, err := generateOTP(expiresAt)
+				expiresAt := utils.GetOTPExpiresAt(time.Minute)
+				otp, err := generateOTP(false, expiresAt)
 				if err != nil {
 					log.Debug("Failed to generate otp: ", err)
 					return nil, err
@@ -154,11 +155,13 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 				}
 				go func() {
 					// exec it as go routine so that we can reduce the api latency
-					if err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
+					err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
 						"user":         user.ToMap(),
 						"organization": utils.GetOrganization(),
-						"otp":          otpData.Otp,
-					}); err != nil {
+						"otp":          otp,
+					})
+					recordOTPSend(constants.AuthRecipeMethodBasicAuth, err)
+					if err != nil {
 						log.Debug("Failed to send otp email: ", err)
 					}
 					utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
@@ -180,8 +183,8 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 				log.Debug("User phone number is not verified")
 				return res, fmt.Errorf(`phone number is not verified and sms service is not enabled`)
 			} else {
-				expiresAt := time.Now().Add(1 * time.Minute).Unix()
-				otpData, err := generateOTP(expiresAt)
+				expiresAt := utils.GetOTPExpiresAt(time.Minute)
+				otp, err := generateOTP(true, expiresAt)
 				if err != nil {
 					log.Debug("Failed to generate otp: ", err)
 					return nil, err
@@ -193,9 +196,11 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 				go func() {
 					smsBody := strings.Builder{}
 					smsBody.WriteString("Your verification code is: ")
-					smsBody.WriteString(otpData.Otp)
+					smsBody.WriteString(otp)
 					utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
-					if err := smsproviders.SendSMS(phoneNumber, smsBody.String()); err != nil {
+					err := smsproviders.SendSMS(phoneNumber, smsBody.String())
+					recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
+					if err != nil {
 						log.Debug("Failed to send sms: ", err)
 					}
 				}()
@@ -254,8 +259,8 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 
 	// If multi factor authentication is enabled and is email based login and email otp is enabled
 	if refs.BoolValue(user.IsMultiFactorAuthEnabled) && !isMFADisabled && !isMailOTPDisabled && isEmailServiceEnabled && isEmailLogin {
-		expiresAt := time.Now().Add(1 * time.Minute).Unix()
-		otpData, err := generateOTP(expiresAt)
+		expiresAt := utils.GetOTPExpiresAt(time.Minute)
+		otp, err := generateOTP(false, expiresAt)
 		if err != nil {
 			log.Debug("Failed to generate otp: ", err)
 			return nil, err
@@ -266,11 +271,13 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 		}
 		go func() {
 			// exec it as go routine so that we can reduce the api latency
-			if err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
+			err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
 				"user":         user.ToMap(),
 				"organization": utils.GetOrganization(),
-				"otp":          otpData.Otp,
-			}); err != nil {
+				"otp":          otp,
+			})
+			recordOTPSend(constants.AuthRecipeMethodBasicAuth, err)
+			if err != nil {
 				log.Debug("Failed to send otp email: ", err)
 			}
 			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
@@ -282,8 +289,8 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 	}
 	// If multi factor authentication is enabled and is sms based login and sms otp is enabled
 	if refs.BoolValue(user.IsMultiFactorAuthEnabled) && !isMFADisabled && !isSMSOTPDisabled && isSMSServiceEnabled && isMobileLogin {
-		expiresAt := time.Now().Add(1 * time.Minute).Unix()
-		otpData, err := generateOTP(expiresAt)
+		expiresAt := utils.GetOTPExpiresAt(time.Minute)
+		otp, err := generateOTP(true, expiresAt)
 		if err != nil {
 			log.Debug("Failed to generate otp: ", err)
 			return nil, err
@@ -295,9 +302,11 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 		go func() {
 			smsBody := strings.Builder{}
 			smsBody.WriteString("Your verification code is: ")
-			smsBody.WriteString(otpData.Otp)
+			smsBody.WriteString(otp)
 			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
-			if err := smsproviders.SendSMS(phoneNumber, smsBody.String()); err != nil {
+			err := smsproviders.SendSMS(phoneNumber, smsBody.String())
+			recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
+			if err != nil {
 				log.Debug("Failed to send sms: ", err)
 			}
 		}()
@@ -413,9 +422,12 @@ func LoginResolver(ctx context.Context, params model.LoginInput) (*model.AuthRes
 		}
 		// Record session
 		db.Provider.AddSession(ctx, &models.Session{
-			UserID:    user.ID,
-			UserAgent: utils.GetUserAgent(gc.Request),
-			IP:        utils.GetIP(gc.Request),
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: constants.AuthRecipeMethodBasicAuth,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
 		})
 	}()
 
@@ -0,0 +1,253 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// LoginWithCodeResolver is a resolver for login with code mutation.
+// User is signed up if it does not exist, and email or phone number is marked
+// verified as the code proves access to it, similar to magic link login.
+func LoginWithCodeResolver(ctx context.Context, params model.LoginWithCodeInput) (res *model.AuthResponse, err error) {
+	isSignUp := false
+	defer func() {
+		event := metrics.EventLogin
+		if isSignUp {
+			event = metrics.EventSignup
+		}
+		metrics.RecordAuthEvent(event, constants.AuthRecipeMethodLoginCode, metrics.OutcomeFromError(err))
+	}()
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	isLoginCodeEnabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyEnableLoginCode)
+	if err != nil || !isLoginCodeEnabled {
+		log.Debug("Login with code is disabled: ", err)
+		return res, fmt.Errorf(`login with code is disabled for this instance`)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(refs.StringValue(params.Email)))
+	phoneNumber := strings.TrimSpace(refs.StringValue(params.PhoneNumber))
+	if email == "" && phoneNumber == "" {
+		log.Debug("Email or phone number is required")
+		return res, fmt.Errorf(`email or phone number is required`)
+	}
+	isEmailLogin := email != ""
+
+	log := log.WithFields(log.Fields{
+		"email":        email,
+		"phone_number": phoneNumber,
+	})
+
+	var otp *models.OTP
+	if isEmailLogin {
+		otp, err = db.Provider.GetOTPByEmail(ctx, email)
+	} else {
+		otp, err = db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+	}
+	if err != nil || otp == nil {
+		log.Debug("Failed to get login code: ", err)
+		return res, fmt.Errorf(`invalid code`)
+	}
+	if err = utils.VerifyOTP(ctx, otp, params.Code); err != nil {
+		log.Debug("Failed to verify login code: ", err)
+		return res, err
+	}
+	if err := db.Provider.DeleteOTP(ctx, otp); err != nil {
+		log.Debug("Failed to delete login code: ", err)
+	}
+
+	var user *models.User
+	if isEmailLogin {
+		user, err = db.Provider.GetUserByEmail(ctx, email)
+	} else {
+		user, err = db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
+	}
+	now := time.Now().Unix()
+	roles := []string{}
+	if err != nil || user == nil {
+		isSignupDisabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisableSignUp)
+		if err != nil {
+			log.Debug("Error getting signup disabled: ", err)
+		}
+		if isSignupDisabled {
+			log.Debug("Signup is disabled.")
+			return res, fmt.Errorf(`signup is disabled for this instance`)
+		}
+
+		if len(params.Roles) > 0 {
+			rolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+			if err != nil {
+				log.Debug("Error getting roles: ", err)
+				return res, err
+			}
+			if !validators.IsValidRoles(params.Roles, strings.Split(rolesString, ",")) {
+				log.Debug("Invalid roles: ", params.Roles)
+				return res, fmt.Errorf(`invalid roles`)
+			}
+			roles = params.Roles
+		} else {
+			defaultRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+			if err != nil {
+				log.Debug("Error getting default roles: ", err)
+				return res, fmt.Errorf(`invalid roles`)
+			}
+			roles = strings.Split(defaultRolesString, ",")
+		}
+
+		user = &models.User{
+			SignupMethods: constants.AuthRecipeMethodLoginCode,
+			Roles:         strings.Join(roles, ","),
+		}
+		if isEmailLogin {
+			user.Email = refs.NewStringRef(email)
+			user.EmailVerifiedAt = &now
+		} else {
+			user.PhoneNumber = refs.NewStringRef(phoneNumber)
+			user.PhoneNumberVerifiedAt = &now
+		}
+		user, err = db.Provider.AddUser(ctx, user)
+		if err != nil {
+			log.Debug("Failed to add user: ", err)
+			return res, err
+		}
+		isSignUp = true
+	} else {
+		if user.RevokedTimestamp != nil {
+			log.Debug("User access is revoked at: ", user.RevokedTimestamp)
+			return res, fmt.Errorf(`user access has been revoked`)
+		}
+
+		defaultRolesString, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+		if err != nil {
+			log.Debug("Error getting default roles: ", err)
+		} else {
+			roles = strings.Split(defaultRolesString, ",")
+		}
+		if len(params.Roles) > 0 {
+			if !validators.IsValidRoles(params.Roles, strings.Split(user.Roles, ",")) {
+				log.Debug("Invalid roles: ", params.Roles)
+				return res, fmt.Errorf(`invalid roles`)
+			}
+			roles = params.Roles
+		}
+
+		if !strings.Contains(user.SignupMethods, constants.AuthRecipeMethodLoginCode) {
+			user.SignupMethods = user.SignupMethods + "," + constants.AuthRecipeMethodLoginCode
+		}
+		if isEmailLogin && user.EmailVerifiedAt == nil {
+			user.EmailVerifiedAt = &now
+		}
+		if !isEmailLogin && user.PhoneNumberVerifiedAt == nil {
+			user.PhoneNumberVerifiedAt = &now
+		}
+		user, err = db.Provider.UpdateUser(ctx, user)
+		if err != nil {
+			log.Debug("Failed to update user: ", err)
+			return res, err
+		}
+	}
+
+	loginMethod := constants.AuthRecipeMethodLoginCode
+	scope := []string{"openid", "email", "profile"}
+	if len(params.Scope) > 0 {
+		scope = params.Scope
+	}
+	code := ""
+	codeChallenge := ""
+	nonce := ""
+	if params.State != nil {
+		// Get state from store
+		authorizeState, _ := memorystore.Provider.GetState(refs.StringValue(params.State))
+		if authorizeState != "" {
+			authorizeStateSplit := strings.Split(authorizeState, "@@")
+			if len(authorizeStateSplit) > 1 {
+				code = authorizeStateSplit[0]
+				codeChallenge = authorizeStateSplit[1]
+			} else {
+				nonce = authorizeState
+			}
+			go memorystore.Provider.RemoveState(refs.StringValue(params.State))
+		}
+	}
+	if nonce == "" {
+		nonce = uuid.New().String()
+	}
+	authToken, err := token.CreateAuthToken(gc, user, roles, scope, loginMethod, nonce, code)
+	if err != nil {
+		log.Debug("Failed to create auth token: ", err)
+		return res, err
+	}
+
+	// Code challenge could be optional if PKCE flow is not used
+	if code != "" {
+		if err := memorystore.Provider.SetState(code, codeChallenge+"@@"+authToken.FingerPrintHash); err != nil {
+			log.Debug("Failed to set code state: ", err)
+			return res, err
+		}
+	}
+
+	go func() {
+		if isSignUp {
+			utils.RegisterEvent(ctx, constants.UserSignUpWebhookEvent, loginMethod, user)
+			// User is also logged in with signup
+			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, loginMethod, user)
+		} else {
+			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, loginMethod, user)
+		}
+
+		db.Provider.AddSession(ctx, &models.Session{
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: loginMethod,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
+		})
+	}()
+
+	authTokenExpiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+	if authTokenExpiresIn <= 0 {
+		authTokenExpiresIn = 1
+	}
+
+	res = &model.AuthResponse{
+		Message:     `Logged in successfully`,
+		AccessToken: &authToken.AccessToken.Token,
+		IDToken:     &authToken.IDToken.Token,
+		ExpiresIn:   &authTokenExpiresIn,
+		User:        user.AsAPIUser(),
+	}
+
+	sessionKey := loginMethod + ":" + user.ID
+	cookie.SetSession(gc, authToken.FingerPrintHash)
+	memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash, authToken.SessionTokenExpiresAt)
+	memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token, authToken.AccessToken.ExpiresAt)
+
+	if authToken.RefreshToken != nil {
+		res.RefreshToken = &authToken.RefreshToken.Token
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token, authToken.RefreshToken.ExpiresAt)
+	}
+	return res, nil
+}
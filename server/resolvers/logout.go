@@ -7,7 +7,9 @@ import (
 
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
@@ -32,6 +34,7 @@ func LogoutResolver(ctx context.Context) (*model.Response, error) {
 	}
 
 	memorystore.Provider.DeleteUserSession(sessionKey, tokenData.Nonce)
+	go logout.SendBackchannelLogout(parsers.GetHost(gc), tokenData.UserID, tokenData.Nonce)
 	cookie.DeleteSession(gc)
 
 	res := &model.Response{
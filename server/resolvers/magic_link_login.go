@@ -14,6 +14,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/email"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
@@ -22,8 +23,15 @@ import (
 )
 
 // MagicLinkLoginResolver is a resolver for magic link login mutation
-func MagicLinkLoginResolver(ctx context.Context, params model.MagicLinkLoginInput) (*model.Response, error) {
-	var res *model.Response
+func MagicLinkLoginResolver(ctx context.Context, params model.MagicLinkLoginInput) (res *model.Response, err error) {
+	defer func() {
+		// user is logged in once the link is verified
+		outcome := metrics.OutcomePending
+		if err != nil {
+			outcome = metrics.OutcomeFailure
+		}
+		metrics.RecordAuthEvent(metrics.EventLogin, constants.AuthRecipeMethodMagicLinkLogin, outcome)
+	}()
 
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
@@ -171,6 +171,12 @@ func MetaResolver(ctx context.Context) (*model.Meta, error) {
 		isSignUpDisabled = true
 	}
 
+	isLoginCodeEnabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyEnableLoginCode)
+	if err != nil {
+		log.Debug("Failed to get Enable Login Code from environment variable", err)
+		isLoginCodeEnabled = false
+	}
+
 	metaInfo := model.Meta{
 		Version:                            constants.VERSION,
 		ClientID:                           clientID,
@@ -191,6 +197,7 @@ func MetaResolver(ctx context.Context) (*model.Meta, error) {
 		IsPhoneVerificationEnabled:         !isMobileVerificationDisabled,
 		IsTwitchLoginEnabled:               twitchClientID != "" && twitchClientSecret != "",
 		IsRobloxLoginEnabled:               robloxClientID != "" && robloxClientSecret != "",
+		IsLoginCodeEnabled:                 isLoginCodeEnabled,
 	}
 	return &metaInfo, nil
 }
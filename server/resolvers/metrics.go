@@ -0,0 +1,30 @@
+package resolvers
+
+import (
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/refs"
+)
+
+// recordAuthResponse records the auth event for the response of login and signup resolvers.
+// Response without access token means user still has to verify email / otp,
+// which is also recorded as mfa challenge when otp or totp screen is shown.
+func recordAuthResponse(event, recipe string, res *model.AuthResponse, err error) {
+	if err != nil || res == nil {
+		metrics.RecordAuthEvent(event, recipe, metrics.OutcomeFailure)
+		return
+	}
+	if res.AccessToken != nil {
+		metrics.RecordAuthEvent(event, recipe, metrics.OutcomeSuccess)
+		return
+	}
+	metrics.RecordAuthEvent(event, recipe, metrics.OutcomePending)
+	if refs.BoolValue(res.ShouldShowEmailOtpScreen) || refs.BoolValue(res.ShouldShowMobileOtpScreen) || refs.BoolValue(res.ShouldShowTotpScreen) {
+		metrics.RecordAuthEvent(metrics.EventMFAChallenge, recipe, metrics.OutcomePending)
+	}
+}
+
+// recordOTPSend records otp sent via email or sms
+func recordOTPSend(recipe string, err error) {
+	metrics.RecordAuthEvent(metrics.EventOTPSend, recipe, metrics.OutcomeFromError(err))
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/smsproviders"
 	"github.com/authorizerdev/authorizer/server/token"
@@ -24,8 +25,10 @@ import (
 )
 
 // MobileLoginResolver is a resolver for mobile login mutation
-func MobileLoginResolver(ctx context.Context, params model.MobileLoginInput) (*model.AuthResponse, error) {
-	var res *model.AuthResponse
+func MobileLoginResolver(ctx context.Context, params model.MobileLoginInput) (res *model.AuthResponse, err error) {
+	defer func() {
+		recordAuthResponse(metrics.EventLogin, constants.AuthRecipeMethodMobileBasicAuth, res, err)
+	}()
 
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
@@ -116,18 +119,8 @@ func MobileLoginResolver(ctx context.Context, params model.MobileLoginInput) (*m
 		log.Debug("MFA service not enabled: ", err)
 	}
 	if !disablePhoneVerification && isSMSServiceEnabled && !isMFADisabled {
-		duration, _ := time.ParseDuration("10m")
-		smsCode := utils.GenerateOTP()
-
-		smsBody := strings.Builder{}
-		smsBody.WriteString("Your verification code is: ")
-		smsBody.WriteString(smsCode)
-		expires := time.Now().Add(duration).Unix()
-		_, err := db.Provider.UpsertOTP(ctx, &models.OTP{
-			PhoneNumber: params.PhoneNumber,
-			Otp:         smsCode,
-			ExpiresAt:   expires,
-		})
+		expires := utils.GetOTPExpiresAt(10 * time.Minute)
+		smsCode, err := utils.CreateOTP(ctx, "", params.PhoneNumber, true, expires)
 		if err != nil {
 			log.Debug("error while upserting OTP: ", err.Error())
 			return nil, err
@@ -141,9 +134,13 @@ func MobileLoginResolver(ctx context.Context, params model.MobileLoginInput) (*m
 		}
 		cookie.SetMfaSession(gc, mfaSession)
 
+		smsBody := strings.Builder{}
+		smsBody.WriteString("Your verification code is: ")
+		smsBody.WriteString(smsCode)
 		go func() {
 			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
-			smsproviders.SendSMS(params.PhoneNumber, smsBody.String())
+			err := smsproviders.SendSMS(params.PhoneNumber, smsBody.String())
+			recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
 		}()
 		return &model.AuthResponse{
 			Message:                   "Please check the OTP",
@@ -219,9 +216,12 @@ func MobileLoginResolver(ctx context.Context, params model.MobileLoginInput) (*m
 	go func() {
 		utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
 		db.Provider.AddSession(ctx, &models.Session{
-			UserID:    user.ID,
-			UserAgent: utils.GetUserAgent(gc.Request),
-			IP:        utils.GetIP(gc.Request),
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: constants.AuthRecipeMethodMobileBasicAuth,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
 		})
 	}()
 
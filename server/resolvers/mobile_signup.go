@@ -16,6 +16,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/smsproviders"
 	"github.com/authorizerdev/authorizer/server/token"
@@ -24,8 +25,10 @@ import (
 )
 
 // MobileSignupResolver is a resolver for mobile_basic_auth_signup mutation
-func MobileSignupResolver(ctx context.Context, params *model.MobileSignUpInput) (*model.AuthResponse, error) {
-	var res *model.AuthResponse
+func MobileSignupResolver(ctx context.Context, params *model.MobileSignUpInput) (res *model.AuthResponse, err error) {
+	defer func() {
+		recordAuthResponse(metrics.EventSignup, constants.AuthRecipeMethodMobileBasicAuth, res, err)
+	}()
 
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
@@ -200,29 +203,18 @@ func MobileSignupResolver(ctx context.Context, params *model.MobileSignUpInput)
 		return res, err
 	}
 	if !disablePhoneVerification && isSMSServiceEnabled {
-		duration, _ := time.ParseDuration("10m")
-		smsCode := utils.GenerateOTP()
-
-		smsBody := strings.Builder{}
-		smsBody.WriteString("Your verification code is: ")
-		smsBody.WriteString(smsCode)
-
 		// TODO: For those who enabled the webhook to call their sms vendor separately - sending the otp to their api
-		if err != nil {
-			log.Debug("error while upserting user: ", err.Error())
-			return nil, err
-		}
-		_, err = db.Provider.UpsertOTP(ctx, &models.OTP{
-			PhoneNumber: mobile,
-			Otp:         smsCode,
-			ExpiresAt:   time.Now().Add(duration).Unix(),
-		})
+		smsCode, err := utils.CreateOTP(ctx, "", mobile, true, utils.GetOTPExpiresAt(10*time.Minute))
 		if err != nil {
 			log.Debug("error while upserting OTP: ", err.Error())
 			return nil, err
 		}
+		smsBody := strings.Builder{}
+		smsBody.WriteString("Your verification code is: ")
+		smsBody.WriteString(smsCode)
 		go func() {
-			smsproviders.SendSMS(mobile, smsBody.String())
+			err := smsproviders.SendSMS(mobile, smsBody.String())
+			recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
 			utils.RegisterEvent(ctx, constants.UserCreatedWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
 		}()
 		return &model.AuthResponse{
@@ -302,9 +294,12 @@ func MobileSignupResolver(ctx context.Context, params *model.MobileSignUpInput)
 		// User is also logged in with signup
 		utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
 		db.Provider.AddSession(ctx, &models.Session{
-			UserID:    user.ID,
-			UserAgent: utils.GetUserAgent(gc.Request),
-			IP:        utils.GetIP(gc.Request),
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: constants.AuthRecipeMethodMobileBasicAuth,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
 		})
 	}()
 
@@ -0,0 +1,45 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// MyGrantsResolver is a resolver for my_grants query
+// It returns the clients the logged in user has granted access to
+func MyGrantsResolver(ctx context.Context) ([]*model.Grant, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return nil, err
+	}
+	log := log.WithFields(log.Fields{
+		"user_id": tokenData.UserID,
+	})
+	grants, err := db.Provider.ListGrantsByUserID(ctx, tokenData.UserID)
+	if err != nil {
+		log.Debug("Failed to list grants: ", err)
+		return nil, err
+	}
+	res := []*model.Grant{}
+	for _, grant := range grants {
+		apiGrant := grant.AsAPIGrant()
+		if client, err := db.Provider.GetClientByClientID(ctx, grant.ClientID); err == nil && client != nil && client.ClientName != "" {
+			apiGrant.ClientName = refs.NewStringRef(client.ClientName)
+		}
+		res = append(res, apiGrant)
+	}
+	return res, nil
+}
@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// MyOrganizationsResolver is a resolver for my_organizations query
+// It returns the organizations the logged in user is member of along with the roles in them
+func MyOrganizationsResolver(ctx context.Context) ([]*model.Membership, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return nil, err
+	}
+	log := log.WithFields(log.Fields{
+		"user_id": tokenData.UserID,
+	})
+	memberships, err := db.Provider.ListMembershipsByUserID(ctx, tokenData.UserID)
+	if err != nil {
+		log.Debug("Failed to list memberships: ", err)
+		return nil, err
+	}
+	res := []*model.Membership{}
+	for _, membership := range memberships {
+		organization, err := db.Provider.GetOrganizationByID(ctx, membership.OrganizationID)
+		if err != nil {
+			log.Debug("Failed to get organization: ", err)
+			continue
+		}
+		apiMembership := membership.AsAPIMembership()
+		apiMembership.Organization = organization.AsAPIOrganization()
+		res = append(res, apiMembership)
+	}
+	return res, nil
+}
@@ -0,0 +1,61 @@
+package resolvers
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// MySessionsResolver is a resolver for my_sessions query
+// It returns active sessions of the logged in user
+func MySessionsResolver(ctx context.Context) ([]*model.Session, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return nil, err
+	}
+	return listActiveSessions(ctx, tokenData.UserID, tokenData.Nonce)
+}
+
+// listActiveSessions returns the sessions of user that are still present in memory store.
+// Sessions that are expired or logged out are removed from database.
+func listActiveSessions(ctx context.Context, userID, currentNonce string) ([]*model.Session, error) {
+	log := log.WithFields(log.Fields{
+		"user_id": userID,
+	})
+	sessions, err := db.Provider.ListSessionsByUserID(ctx, userID)
+	if err != nil {
+		log.Debug("Failed to list sessions: ", err)
+		return nil, err
+	}
+	res := []*model.Session{}
+	for _, session := range sessions {
+		// sessions recorded before nonce was stored can't be mapped to memory store keys
+		if session.Nonce == "" {
+			continue
+		}
+		sessionToken, err := memorystore.Provider.GetUserSession(session.StoreKey(), constants.TokenTypeSessionToken+"_"+session.Nonce)
+		if err != nil || sessionToken == "" {
+			go db.Provider.DeleteSessionByID(ctx, session.ID)
+			continue
+		}
+		apiSession := session.AsAPISession()
+		device := utils.GetDeviceName(session.UserAgent)
+		apiSession.Device = &device
+		apiSession.IsCurrent = currentNonce != "" && session.Nonce == currentNonce
+		res = append(res, apiSession)
+	}
+	return res, nil
+}
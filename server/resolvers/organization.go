@@ -0,0 +1,33 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// OrganizationResolver resolver for getting organization by id
+func OrganizationResolver(ctx context.Context, params model.OrganizationRequest) (*model.Organization, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, fmt.Errorf("organization not found")
+	}
+	return organization.AsAPIOrganization(), nil
+}
@@ -0,0 +1,47 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// OrganizationMembersResolver resolver for getting the members of organization
+func OrganizationMembersResolver(ctx context.Context, params model.OrganizationRequest) ([]*model.Membership, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.ID)
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, fmt.Errorf("organization not found")
+	}
+	memberships, err := db.Provider.ListMembershipsByOrganizationID(ctx, organization.ID)
+	if err != nil {
+		log.Debug("Failed to list memberships: ", err)
+		return nil, err
+	}
+	res := []*model.Membership{}
+	for _, membership := range memberships {
+		apiMembership := membership.AsAPIMembership()
+		if user, err := db.Provider.GetUserByID(ctx, membership.UserID); err == nil && user != nil {
+			apiMembership.User = user.AsAPIUser()
+		}
+		res = append(res, apiMembership)
+	}
+	return res, nil
+}
@@ -0,0 +1,34 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// PermissionsResolver resolver for getting the list of permission definitions based on pagination
+func PermissionsResolver(ctx context.Context, params *model.PaginatedInput) (*model.Permissions, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	pagination := utils.GetPagination(params)
+	permissions, err := db.Provider.ListPermissions(ctx, pagination)
+	if err != nil {
+		log.Debug("Failed to get permissions: ", err)
+		return nil, err
+	}
+	return permissions, nil
+}
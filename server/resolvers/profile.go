@@ -6,6 +6,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
@@ -34,5 +35,20 @@ func ProfileResolver(ctx context.Context) (*model.User, error) {
 		return res, err
 	}
 
-	return user.AsAPIUser(), nil
+	return asAPIUserWithIdentities(ctx, user), nil
+}
+
+// asAPIUserWithIdentities returns graphql user object along with linked oauth identities
+func asAPIUserWithIdentities(ctx context.Context, user *models.User) *model.User {
+	res := user.AsAPIUser()
+	identities, err := db.Provider.ListIdentitiesByUserID(ctx, user.ID)
+	if err != nil {
+		log.Debug("Failed to list identities: ", err)
+		return res
+	}
+	res.Identities = []*model.Identity{}
+	for _, identity := range identities {
+		res.Identities = append(res.Identities, identity.AsAPIIdentity())
+	}
+	return res
 }
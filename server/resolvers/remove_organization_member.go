@@ -0,0 +1,46 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RemoveOrganizationMemberResolver resolver for remove organization member mutation
+// Organization claim is not added to the tokens issued to removed member afterwards
+func RemoveOrganizationMemberResolver(ctx context.Context, params model.RemoveOrganizationMemberRequest) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithFields(log.Fields{
+		"organization_id": params.OrganizationID,
+		"user_id":         params.UserID,
+	})
+	membership, err := db.Provider.GetMembershipByOrganizationIDAndUserID(ctx, params.OrganizationID, params.UserID)
+	if err != nil || membership == nil {
+		log.Debug("Failed to get membership: ", err)
+		return nil, fmt.Errorf("user is not member of organization")
+	}
+	err = db.Provider.DeleteMembership(ctx, membership)
+	if err != nil {
+		log.Debug("Failed to delete membership: ", err)
+		return nil, err
+	}
+
+	return &model.Response{
+		Message: "Member removed successfully",
+	}, nil
+}
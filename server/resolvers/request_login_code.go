@@ -0,0 +1,144 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	mailService "github.com/authorizerdev/authorizer/server/email"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/smsproviders"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// RequestLoginCodeResolver is a resolver for request login code mutation.
+// It sends a one time code to email or phone number, which is exchanged for tokens with login_with_code mutation
+func RequestLoginCodeResolver(ctx context.Context, params model.RequestLoginCodeInput) (res *model.Response, err error) {
+	defer func() {
+		// user is logged in once the code is verified
+		outcome := metrics.OutcomePending
+		if err != nil {
+			outcome = metrics.OutcomeFailure
+		}
+		metrics.RecordAuthEvent(metrics.EventLogin, constants.AuthRecipeMethodLoginCode, outcome)
+	}()
+
+	isLoginCodeEnabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyEnableLoginCode)
+	if err != nil || !isLoginCodeEnabled {
+		log.Debug("Login with code is disabled: ", err)
+		return res, fmt.Errorf(`login with code is disabled for this instance`)
+	}
+
+	email := strings.ToLower(strings.TrimSpace(refs.StringValue(params.Email)))
+	phoneNumber := strings.TrimSpace(refs.StringValue(params.PhoneNumber))
+	if email == "" && phoneNumber == "" {
+		log.Debug("Email or phone number is required")
+		return res, fmt.Errorf(`email or phone number is required`)
+	}
+	isEmailLogin := email != ""
+
+	log := log.WithFields(log.Fields{
+		"email":        email,
+		"phone_number": phoneNumber,
+	})
+
+	var user *models.User
+	if isEmailLogin {
+		if !validators.IsValidEmail(email) {
+			log.Debug("Invalid email")
+			return res, fmt.Errorf(`invalid email address`)
+		}
+		isEmailServiceEnabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyIsEmailServiceEnabled)
+		if err != nil || !isEmailServiceEnabled {
+			log.Debug("Email service not enabled: ", err)
+			return res, fmt.Errorf(`email service not enabled`)
+		}
+		user, err = db.Provider.GetUserByEmail(ctx, email)
+		if err != nil {
+			log.Debug("Failed to get user by email: ", err)
+		}
+	} else {
+		if len(phoneNumber) < 10 {
+			log.Debug("Invalid phone number")
+			return res, fmt.Errorf(`invalid phone number`)
+		}
+		isSMSServiceEnabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyIsSMSServiceEnabled)
+		if err != nil || !isSMSServiceEnabled {
+			log.Debug("SMS service not enabled: ", err)
+			return res, fmt.Errorf(`sms service not enabled`)
+		}
+		user, err = db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
+		if err != nil {
+			log.Debug("Failed to get user by phone number: ", err)
+		}
+	}
+
+	if user == nil {
+		// user is signed up once the code is verified
+		isSignupDisabled, err := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisableSignUp)
+		if err != nil {
+			log.Debug("Error getting signup disabled: ", err)
+		}
+		if isSignupDisabled {
+			log.Debug("Signup is disabled.")
+			return res, fmt.Errorf(`signup is disabled for this instance`)
+		}
+		user = &models.User{}
+		if isEmailLogin {
+			user.Email = refs.NewStringRef(email)
+		} else {
+			user.PhoneNumber = refs.NewStringRef(phoneNumber)
+		}
+	} else if user.RevokedTimestamp != nil {
+		log.Debug("User access is revoked at: ", user.RevokedTimestamp)
+		return res, fmt.Errorf(`user access has been revoked`)
+	}
+
+	code, err := utils.CreateOTP(ctx, email, phoneNumber, !isEmailLogin, utils.GetOTPExpiresAt(10*time.Minute))
+	if err != nil {
+		log.Debug("Failed to create login code: ", err)
+		return res, err
+	}
+
+	if isEmailLogin {
+		go func() {
+			// exec it as go routine so that we can reduce the api latency
+			err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
+				"user":         user.ToMap(),
+				"organization": utils.GetOrganization(),
+				"otp":          code,
+			})
+			recordOTPSend(constants.AuthRecipeMethodLoginCode, err)
+			if err != nil {
+				log.Debug("Failed to send login code email: ", err)
+			}
+		}()
+		return &model.Response{
+			Message: `Login code has been sent to your email. Please check your inbox!`,
+		}, nil
+	}
+
+	smsBody := strings.Builder{}
+	smsBody.WriteString("Your login code is: ")
+	smsBody.WriteString(code)
+	go func() {
+		err := smsproviders.SendSMS(phoneNumber, smsBody.String())
+		recordOTPSend(constants.AuthRecipeMethodLoginCode, err)
+		if err != nil {
+			log.Debug("Failed to send login code sms: ", err)
+		}
+	}()
+	return &model.Response{
+		Message: `Login code has been sent to your phone number.`,
+	}, nil
+}
@@ -98,19 +98,13 @@ func ResendOTPResolver(ctx context.Context, params model.ResendOTPRequest) (*mod
 		}, errors.New("failed to get otp for given email")
 	}
 	// If multi factor authentication is enabled and we need to generate OTP for mail / sms based MFA
-	generateOTP := func(expiresAt int64) (*models.OTP, error) {
-		otp := utils.GenerateOTP()
-		otpData, err := db.Provider.UpsertOTP(ctx, &models.OTP{
-			Email:       refs.StringValue(user.Email),
-			PhoneNumber: refs.StringValue(user.PhoneNumber),
-			Otp:         otp,
-			ExpiresAt:   expiresAt,
-		})
+	generateOTP := func(expiresAt int64) (string, error) {
+		otp, err := utils.CreateOTP(ctx, refs.StringValue(user.Email), refs.StringValue(user.PhoneNumber), email == "", expiresAt)
 		if err != nil {
 			log.Debug("Failed to add otp: ", err)
-			return nil, err
+			return "", err
 		}
-		return otpData, nil
+		return otp, nil
 	}
 	setOTPMFaSession := func(expiresAt int64) error {
 		mfaSession := uuid.NewString()
@@ -122,8 +116,8 @@ func ResendOTPResolver(ctx context.Context, params model.ResendOTPRequest) (*mod
 		cookie.SetMfaSession(gc, mfaSession)
 		return nil
 	}
-	expiresAt := time.Now().Add(1 * time.Minute).Unix()
-	otpData, err = generateOTP(expiresAt)
+	expiresAt := utils.GetOTPExpiresAt(time.Minute)
+	otp, err := generateOTP(expiresAt)
 	if err != nil {
 		log.Debug("Failed to generate otp: ", err)
 		return nil, err
@@ -135,11 +129,13 @@ func ResendOTPResolver(ctx context.Context, params model.ResendOTPRequest) (*mod
 	if email != "" {
 		go func() {
 			// exec it as go routine so that we can reduce the api latency
-			if err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
+			err := mailService.SendEmail([]string{email}, constants.VerificationTypeOTP, map[string]interface{}{
 				"user":         user.ToMap(),
 				"organization": utils.GetOrganization(),
-				"otp":          otpData.Otp,
-			}); err != nil {
+				"otp":          otp,
+			})
+			recordOTPSend(constants.AuthRecipeMethodBasicAuth, err)
+			if err != nil {
 				log.Debug("Failed to send otp email: ", err)
 			}
 			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodBasicAuth, user)
@@ -148,9 +144,11 @@ func ResendOTPResolver(ctx context.Context, params model.ResendOTPRequest) (*mod
 		go func() {
 			smsBody := strings.Builder{}
 			smsBody.WriteString("Your verification code is: ")
-			smsBody.WriteString(otpData.Otp)
+			smsBody.WriteString(otp)
 			utils.RegisterEvent(ctx, constants.UserLoginWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
-			if err := smsproviders.SendSMS(phoneNumber, smsBody.String()); err != nil {
+			err := smsproviders.SendSMS(phoneNumber, smsBody.String())
+			recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
+			if err != nil {
 				log.Debug("Failed to send sms: ", err)
 			}
 		}()
@@ -112,9 +112,9 @@ func ResetPasswordResolver(ctx context.Context, params model.ResetPasswordInput)
 			log.Debug("Failed to get otp request by phone number: ", err)
 			return res, fmt.Errorf(`invalid otp`)
 		}
-		if otpRequest.Otp != otp {
-			log.Debug("Failed to verify otp request: Incorrect value")
-			return res, fmt.Errorf(`invalid otp`)
+		if err := utils.VerifyOTP(ctx, otpRequest, otp); err != nil {
+			log.Debug("Failed to verify otp request: ", err)
+			return res, err
 		}
 	}
 	if params.Password != params.ConfirmPassword {
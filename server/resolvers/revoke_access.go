@@ -10,7 +10,9 @@ import (
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
@@ -48,8 +50,10 @@ func RevokeAccessResolver(ctx context.Context, params model.UpdateAccessInput) (
 		return res, err
 	}
 
+	hostname := parsers.GetHost(gc)
 	go func() {
 		memorystore.Provider.DeleteAllUserSessions(user.ID)
+		logout.SendBackchannelLogout(hostname, user.ID, "")
 		utils.RegisterEvent(ctx, constants.UserAccessRevokedWebhookEvent, "", user)
 	}()
 
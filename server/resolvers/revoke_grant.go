@@ -0,0 +1,59 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeGrantResolver is a resolver for revoke_grant mutation
+// It removes the consent given to a client, refresh tokens issued
+// to the client before revocation can no longer be used
+func RevokeGrantResolver(ctx context.Context, params model.RevokeGrantInput) (*model.Response, error) {
+	var res *model.Response
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return res, err
+	}
+	log := log.WithFields(log.Fields{
+		"user_id":  tokenData.UserID,
+		"grant_id": params.ID,
+	})
+	grants, err := db.Provider.ListGrantsByUserID(ctx, tokenData.UserID)
+	if err != nil {
+		log.Debug("Failed to list grants: ", err)
+		return res, err
+	}
+	var grant *models.Grant
+	for _, g := range grants {
+		if g.AsAPIGrant().ID == params.ID {
+			grant = g
+			break
+		}
+	}
+	if grant == nil {
+		log.Debug("Grant not found")
+		return res, fmt.Errorf("grant not found")
+	}
+	if err := db.Provider.DeleteGrant(ctx, grant); err != nil {
+		log.Debug("Failed to delete grant: ", err)
+		return res, err
+	}
+	res = &model.Response{
+		Message: `Grant revoked successfully`,
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeRoleResolver resolver for revoke role mutation
+// Role is removed from user along with its grant and sessions of user are ended,
+// so that role is not used with the tokens issued before
+func RevokeRoleResolver(ctx context.Context, params model.RevokeRoleInput) (*model.Response, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	role := strings.TrimSpace(params.Role)
+	log := log.WithFields(log.Fields{
+		"user_id": params.UserID,
+		"role":    role,
+	})
+	user, err := db.Provider.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get user: ", err)
+		return nil, fmt.Errorf("user not found")
+	}
+	roles := strings.Split(user.Roles, ",")
+	if !utils.StringSliceContains(roles, role) {
+		log.Debug("User does not have role")
+		return nil, fmt.Errorf("user does not have %s role", role)
+	}
+
+	user.Roles = strings.Join(utils.DeleteFromArray(roles, []string{role}), ",")
+	user, err = db.Provider.UpdateUser(ctx, user)
+	if err != nil {
+		log.Debug("Failed to update user: ", err)
+		return nil, err
+	}
+	if roleGrant, _ := db.Provider.GetRoleGrantByUserIDAndRole(ctx, user.ID, role); roleGrant != nil {
+		if err := db.Provider.DeleteRoleGrant(ctx, roleGrant); err != nil {
+			log.Debug("Failed to delete role grant: ", err)
+			return nil, err
+		}
+	}
+	_, err = db.Provider.AddRoleChange(ctx, &models.RoleChange{
+		UserID:    user.ID,
+		Role:      role,
+		Action:    constants.RoleChangeActionRevoked,
+		ChangedBy: constants.RoleChangedBySuperAdmin,
+	})
+	if err != nil {
+		log.Debug("Failed to add role change: ", err)
+		return nil, err
+	}
+
+	go memorystore.Provider.DeleteAllUserSessions(user.ID)
+	go logout.SendBackchannelLogout(parsers.GetHost(gc), user.ID, "")
+
+	return &model.Response{
+		Message: "Role revoked successfully",
+	}, nil
+}
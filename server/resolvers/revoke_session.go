@@ -0,0 +1,62 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeSessionResolver is a resolver for revoke_session mutation
+// It logs out a single session of the logged in user
+func RevokeSessionResolver(ctx context.Context, params model.RevokeSessionInput) (*model.Response, error) {
+	var res *model.Response
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return res, err
+	}
+	log := log.WithFields(log.Fields{
+		"user_id":    tokenData.UserID,
+		"session_id": params.ID,
+	})
+	session, err := db.Provider.GetSessionByID(ctx, params.ID)
+	if err != nil || session == nil || session.UserID != tokenData.UserID {
+		log.Debug("Failed to get session: ", err)
+		return res, fmt.Errorf("session not found")
+	}
+	if err := revokeSession(gc, session); err != nil {
+		log.Debug("Failed to revoke session: ", err)
+		return res, err
+	}
+	res = &model.Response{
+		Message: `Session revoked successfully`,
+	}
+	return res, nil
+}
+
+// revokeSession removes the session tokens from memory store and session from database
+func revokeSession(gc *gin.Context, session *models.Session) error {
+	if session.Nonce != "" {
+		if err := memorystore.Provider.DeleteUserSession(session.StoreKey(), session.Nonce); err != nil {
+			return err
+		}
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), session.UserID, session.Nonce)
+	}
+	return db.Provider.DeleteSessionByID(gc, session.ID)
+}
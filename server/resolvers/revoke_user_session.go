@@ -0,0 +1,44 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// RevokeUserSessionResolver is a resolver for _revoke_user_session mutation
+// This is admin only mutation
+func RevokeUserSessionResolver(ctx context.Context, params model.RevokeSessionInput) (*model.Response, error) {
+	var res *model.Response
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin.")
+		return res, fmt.Errorf("unauthorized")
+	}
+	log := log.WithFields(log.Fields{
+		"session_id": params.ID,
+	})
+	session, err := db.Provider.GetSessionByID(ctx, params.ID)
+	if err != nil || session == nil {
+		log.Debug("Failed to get session: ", err)
+		return res, fmt.Errorf("session not found")
+	}
+	if err := revokeSession(gc, session); err != nil {
+		log.Debug("Failed to revoke session: ", err)
+		return res, err
+	}
+	res = &model.Response{
+		Message: `Session revoked successfully`,
+	}
+	return res, nil
+}
@@ -72,7 +72,10 @@ func SessionResolver(ctx context.Context, params *model.SessionQueryInput) (*mod
 	}
 
 	nonce := uuid.New().String()
-	authToken, err := token.CreateAuthToken(gc, user, claimRoles, scope, claims.LoginMethod, nonce, "")
+	authToken, err := token.CreateAuthTokenWithOptions(gc, user, claimRoles, scope, claims.LoginMethod, nonce, "", token.AuthTokenOptions{
+		AuthTime:       claims.AuthTime,
+		OrganizationID: claims.OrganizationID,
+	})
 	if err != nil {
 		log.Debug("Failed to create auth token: ", err)
 		return res, err
@@ -84,6 +87,7 @@ func SessionResolver(ctx context.Context, params *model.SessionQueryInput) (*mod
 		sessionKey = claims.LoginMethod + ":" + userID
 	}
 	go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+	go utils.RolloverSession(ctx, claims.Nonce, authToken.FingerPrint, authToken.SessionTokenExpiresAt)
 
 	expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
 	if expiresIn <= 0 {
@@ -19,6 +19,7 @@ import (
 	emailService "github.com/authorizerdev/authorizer/server/email"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/smsproviders"
@@ -28,8 +29,14 @@ import (
 )
 
 // SignupResolver is a resolver for signup mutation
-func SignupResolver(ctx context.Context, params model.SignUpInput) (*model.AuthResponse, error) {
-	var res *model.AuthResponse
+func SignupResolver(ctx context.Context, params model.SignUpInput) (res *model.AuthResponse, err error) {
+	defer func() {
+		recipe := constants.AuthRecipeMethodBasicAuth
+		if refs.StringValue(params.Email) == "" && refs.StringValue(params.PhoneNumber) != "" {
+			recipe = constants.AuthRecipeMethodMobileBasicAuth
+		}
+		recordAuthResponse(metrics.EventSignup, recipe, res, err)
+	}()
 
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
@@ -288,17 +295,8 @@ func SignupResolver(ctx context.Context, params model.SignUpInput) (*model.AuthR
 			User:    userToReturn,
 		}, nil
 	} else if !disablePhoneVerification && isSMSServiceEnabled && isMobileSignup {
-		duration, _ := time.ParseDuration("10m")
-		smsCode := utils.GenerateOTP()
-		smsBody := strings.Builder{}
-		smsBody.WriteString("Your verification code is: ")
-		smsBody.WriteString(smsCode)
-		expiresAt := time.Now().Add(duration).Unix()
-		_, err = db.Provider.UpsertOTP(ctx, &models.OTP{
-			PhoneNumber: phoneNumber,
-			Otp:         smsCode,
-			ExpiresAt:   expiresAt,
-		})
+		expiresAt := utils.GetOTPExpiresAt(10 * time.Minute)
+		smsCode, err := utils.CreateOTP(ctx, "", phoneNumber, true, expiresAt)
 		if err != nil {
 			log.Debug("error while upserting OTP: ", err.Error())
 			return nil, err
@@ -310,8 +308,12 @@ func SignupResolver(ctx context.Context, params model.SignUpInput) (*model.AuthR
 			return nil, err
 		}
 		cookie.SetMfaSession(gc, mfaSession)
+		smsBody := strings.Builder{}
+		smsBody.WriteString("Your verification code is: ")
+		smsBody.WriteString(smsCode)
 		go func() {
-			smsproviders.SendSMS(phoneNumber, smsBody.String())
+			err := smsproviders.SendSMS(phoneNumber, smsBody.String())
+			recordOTPSend(constants.AuthRecipeMethodMobileBasicAuth, err)
 			utils.RegisterEvent(ctx, constants.UserCreatedWebhookEvent, constants.AuthRecipeMethodMobileBasicAuth, user)
 		}()
 		return &model.AuthResponse{
@@ -393,9 +395,12 @@ func SignupResolver(ctx context.Context, params model.SignUpInput) (*model.AuthR
 		}
 
 		db.Provider.AddSession(ctx, &models.Session{
-			UserID:    user.ID,
-			UserAgent: utils.GetUserAgent(gc.Request),
-			IP:        utils.GetIP(gc.Request),
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: constants.AuthRecipeMethodBasicAuth,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
 		})
 	}()
 
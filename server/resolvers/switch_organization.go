@@ -0,0 +1,103 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// SwitchOrganizationResolver is a resolver for switch organization mutation
+// It selects the organization for the current session and issues new tokens with
+// organization claim, session is rolled over without changing its authentication time
+func SwitchOrganizationResolver(ctx context.Context, params model.SwitchOrganizationInput) (*model.AuthResponse, error) {
+	var res *model.AuthResponse
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+
+	sessionToken, err := cookie.GetSession(gc)
+	if err != nil {
+		log.Debug("Failed to get session token: ", err)
+		return res, errors.New("unauthorized")
+	}
+	claims, err := token.ValidateBrowserSession(gc, sessionToken)
+	if err != nil {
+		log.Debug("Failed to validate session token: ", err)
+		return res, errors.New("unauthorized")
+	}
+	userID := claims.Subject
+	organizationID := refs.StringValue(params.OrganizationID)
+
+	log := log.WithFields(log.Fields{
+		"user_id":         userID,
+		"organization_id": organizationID,
+	})
+
+	user, err := db.Provider.GetUserByID(ctx, userID)
+	if err != nil {
+		return res, err
+	}
+	if organizationID != "" {
+		membership, err := db.Provider.GetMembershipByOrganizationIDAndUserID(ctx, organizationID, userID)
+		if err != nil || membership == nil {
+			log.Debug("User is not member of organization: ", err)
+			return res, fmt.Errorf(`user is not member of organization`)
+		}
+	}
+
+	nonce := uuid.New().String()
+	authToken, err := token.CreateAuthTokenWithOptions(gc, user, claims.Roles, claims.Scope, claims.LoginMethod, nonce, "", token.AuthTokenOptions{
+		AuthTime:       claims.AuthTime,
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		log.Debug("Failed to create auth token: ", err)
+		return res, err
+	}
+
+	sessionKey := userID
+	if claims.LoginMethod != "" {
+		sessionKey = claims.LoginMethod + ":" + userID
+	}
+	go memorystore.Provider.DeleteUserSession(sessionKey, claims.Nonce)
+	go utils.RolloverSession(ctx, claims.Nonce, authToken.FingerPrint, authToken.SessionTokenExpiresAt)
+
+	expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
+	if expiresIn <= 0 {
+		expiresIn = 1
+	}
+
+	res = &model.AuthResponse{
+		Message:     `Organization switched successfully`,
+		AccessToken: &authToken.AccessToken.Token,
+		ExpiresIn:   &expiresIn,
+		IDToken:     &authToken.IDToken.Token,
+		User:        user.AsAPIUser(),
+	}
+
+	cookie.SetSession(gc, authToken.FingerPrintHash)
+	memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+authToken.FingerPrint, authToken.FingerPrintHash, authToken.SessionTokenExpiresAt)
+	memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token, authToken.AccessToken.ExpiresAt)
+
+	if authToken.RefreshToken != nil {
+		res.RefreshToken = &authToken.RefreshToken.Token
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+authToken.FingerPrint, authToken.RefreshToken.Token, authToken.RefreshToken.ExpiresAt)
+	}
+	return res, nil
+}
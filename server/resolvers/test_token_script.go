@@ -0,0 +1,55 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// TestTokenScriptResolver is a resolver for _test_token_script query
+// It runs the custom script against the user without issuing any token.
+// This is admin only query
+func TestTokenScriptResolver(ctx context.Context, params model.TestTokenScriptInput) (*model.TestTokenScriptResponse, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin.")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	scriptType := refs.StringValue(params.Type, constants.TokenTypeAccessToken)
+	if scriptType != constants.TokenTypeAccessToken && scriptType != constants.TokenTypeIdentityToken && scriptType != token.CustomScriptTypeUserInfo {
+		log.Debug("Invalid script type: ", scriptType)
+		return nil, fmt.Errorf("invalid script type, expected one of %s, %s, %s", constants.TokenTypeAccessToken, constants.TokenTypeIdentityToken, token.CustomScriptTypeUserInfo)
+	}
+
+	user, err := db.Provider.GetUserByID(ctx, params.UserID)
+	if err != nil {
+		log.Debug("Failed to get user by ID: ", err)
+		return nil, err
+	}
+
+	// script errors are part of the response, so that admin can fix the script
+	claims, err := token.DryRunCustomScript(parsers.GetHost(gc), scriptType, params.Script, user)
+	if err != nil {
+		log.Debug("Failed to run custom script: ", err)
+		return &model.TestTokenScriptResponse{
+			Error: refs.NewStringRef(err.Error()),
+		}, nil
+	}
+	return &model.TestTokenScriptResponse{
+		Claims: claims,
+	}, nil
+}
@@ -0,0 +1,116 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UnlinkIdentityResolver is the resolver for the unlink_identity field.
+func UnlinkIdentityResolver(ctx context.Context, params model.UnlinkIdentityInput) (*model.Response, error) {
+	var res *model.Response
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return res, err
+	}
+	log := log.WithFields(log.Fields{
+		"user_id":     tokenData.UserID,
+		"identity_id": params.ID,
+	})
+	user, err := db.Provider.GetUserByID(ctx, tokenData.UserID)
+	if err != nil {
+		log.Debug("Failed to get user by id: ", err)
+		return res, err
+	}
+	identities, err := db.Provider.ListIdentitiesByUserID(ctx, user.ID)
+	if err != nil {
+		log.Debug("Failed to list identities: ", err)
+		return res, err
+	}
+	var identity *models.Identity
+	for _, i := range identities {
+		if i.AsAPIIdentity().ID == params.ID {
+			identity = i
+			break
+		}
+	}
+	if identity == nil {
+		log.Debug("Identity not found for user")
+		return res, fmt.Errorf("identity not found")
+	}
+
+	// user should still be able to login after unlinking the identity
+	hasOtherLoginMethod := len(identities) > 1
+	if !hasOtherLoginMethod {
+		signupMethods := strings.Split(user.SignupMethods, ",")
+		for _, method := range signupMethods {
+			if method == identity.Provider || utils.StringSliceContains(constants.OAuthRecipeMethods, method) {
+				continue
+			}
+			if method == constants.AuthRecipeMethodBasicAuth || method == constants.AuthRecipeMethodMobileBasicAuth {
+				hasOtherLoginMethod = user.Password != nil && refs.StringValue(user.Password) != ""
+			} else {
+				hasOtherLoginMethod = true
+			}
+			if hasOtherLoginMethod {
+				break
+			}
+		}
+	}
+	if !hasOtherLoginMethod {
+		log.Debug("Cannot unlink the only login method")
+		return res, fmt.Errorf("cannot unlink the only login method of the account")
+	}
+
+	if err := db.Provider.DeleteIdentity(ctx, identity); err != nil {
+		log.Debug("Failed to delete identity: ", err)
+		return res, err
+	}
+
+	// remove provider from signup methods when no other account of the provider is linked
+	isProviderLinked := false
+	for _, i := range identities {
+		if i.ID != identity.ID && i.Provider == identity.Provider {
+			isProviderLinked = true
+			break
+		}
+	}
+	if !isProviderLinked {
+		signupMethods := []string{}
+		for _, method := range strings.Split(user.SignupMethods, ",") {
+			if method != identity.Provider {
+				signupMethods = append(signupMethods, method)
+			}
+		}
+		user.SignupMethods = strings.Join(signupMethods, ",")
+		user, err = db.Provider.UpdateUser(ctx, user)
+		if err != nil {
+			log.Debug("Failed to update user: ", err)
+			return res, err
+		}
+		go memorystore.Provider.DeleteSessionForNamespace(identity.Provider + ":" + user.ID)
+	}
+	go utils.RegisterEvent(ctx, constants.UserIdentityUnlinkedWebhookEvent, identity.Provider, user)
+
+	res = &model.Response{
+		Message: `Identity unlinked successfully`,
+	}
+	return res, nil
+}
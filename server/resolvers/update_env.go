@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"reflect"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/crypto"
 	"github.com/authorizerdev/authorizer/server/db"
+	envstore "github.com/authorizerdev/authorizer/server/env"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/oauth"
@@ -28,6 +30,7 @@ func clearSessionIfRequired(currentData, updatedData map[string]interface{}) {
 	isCurrentBasicAuthEnabled := !currentData[constants.EnvKeyDisableBasicAuthentication].(bool)
 	isCurrentMobileBasicAuthEnabled := !currentData[constants.EnvKeyDisableMobileBasicAuthentication].(bool)
 	isCurrentMagicLinkLoginEnabled := !currentData[constants.EnvKeyDisableMagicLinkLogin].(bool)
+	isCurrentLoginCodeEnabled := currentData[constants.EnvKeyEnableLoginCode].(bool)
 	isCurrentAppleLoginEnabled := currentData[constants.EnvKeyAppleClientID] != nil && currentData[constants.EnvKeyAppleClientSecret] != nil && currentData[constants.EnvKeyAppleClientID].(string) != "" && currentData[constants.EnvKeyAppleClientSecret].(string) != ""
 	isCurrentFacebookLoginEnabled := currentData[constants.EnvKeyFacebookClientID] != nil && currentData[constants.EnvKeyFacebookClientSecret] != nil && currentData[constants.EnvKeyFacebookClientID].(string) != "" && currentData[constants.EnvKeyFacebookClientSecret].(string) != ""
 	isCurrentGoogleLoginEnabled := currentData[constants.EnvKeyGoogleClientID] != nil && currentData[constants.EnvKeyGoogleClientSecret] != nil && currentData[constants.EnvKeyGoogleClientID].(string) != "" && currentData[constants.EnvKeyGoogleClientSecret].(string) != ""
@@ -40,6 +43,7 @@ func clearSessionIfRequired(currentData, updatedData map[string]interface{}) {
 	isUpdatedBasicAuthEnabled := !updatedData[constants.EnvKeyDisableBasicAuthentication].(bool)
 	isUpdatedMobileBasicAuthEnabled := !updatedData[constants.EnvKeyDisableMobileBasicAuthentication].(bool)
 	isUpdatedMagicLinkLoginEnabled := !updatedData[constants.EnvKeyDisableMagicLinkLogin].(bool)
+	isUpdatedLoginCodeEnabled := updatedData[constants.EnvKeyEnableLoginCode].(bool)
 	isUpdatedAppleLoginEnabled := updatedData[constants.EnvKeyAppleClientID] != nil && updatedData[constants.EnvKeyAppleClientSecret] != nil && updatedData[constants.EnvKeyAppleClientID].(string) != "" && updatedData[constants.EnvKeyAppleClientSecret].(string) != ""
 	isUpdatedFacebookLoginEnabled := updatedData[constants.EnvKeyFacebookClientID] != nil && updatedData[constants.EnvKeyFacebookClientSecret] != nil && updatedData[constants.EnvKeyFacebookClientID].(string) != "" && updatedData[constants.EnvKeyFacebookClientSecret].(string) != ""
 	isUpdatedGoogleLoginEnabled := updatedData[constants.EnvKeyGoogleClientID] != nil && updatedData[constants.EnvKeyGoogleClientSecret] != nil && updatedData[constants.EnvKeyGoogleClientID].(string) != "" && updatedData[constants.EnvKeyGoogleClientSecret].(string) != ""
@@ -61,6 +65,10 @@ func clearSessionIfRequired(currentData, updatedData map[string]interface{}) {
 		memorystore.Provider.DeleteSessionForNamespace(constants.AuthRecipeMethodMagicLinkLogin)
 	}
 
+	if isCurrentLoginCodeEnabled && !isUpdatedLoginCodeEnabled {
+		memorystore.Provider.DeleteSessionForNamespace(constants.AuthRecipeMethodLoginCode)
+	}
+
 	if isCurrentAppleLoginEnabled && !isUpdatedAppleLoginEnabled {
 		memorystore.Provider.DeleteSessionForNamespace(constants.AuthRecipeMethodApple)
 	}
@@ -141,6 +149,30 @@ func updateRoles(ctx context.Context, deletedRoles []string) error {
 	return nil
 }
 
+// updateEnvVariables updates the given env variables in store and persists the env in db
+func updateEnvVariables(ctx context.Context, data map[string]interface{}) error {
+	for key, value := range data {
+		if err := memorystore.Provider.UpdateEnvVariable(key, value); err != nil {
+			return err
+		}
+	}
+	storeData, err := memorystore.Provider.GetEnvStore()
+	if err != nil {
+		return err
+	}
+	env, err := db.Provider.GetEnv(ctx)
+	if err != nil {
+		return err
+	}
+	encryptedConfig, err := crypto.EncryptEnvData(storeData)
+	if err != nil {
+		return err
+	}
+	env.EnvData = encryptedConfig
+	_, err = db.Provider.UpdateEnv(ctx, env)
+	return err
+}
+
 // UpdateEnvResolver is a resolver for update config mutation
 // This is admin only mutation
 func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model.Response, error) {
@@ -248,6 +280,88 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 
 	}
 
+	for _, expiryTime := range []*string{params.SessionExpiryTime, params.RefreshTokenExpiryTime, params.SessionIDLeTimeout} {
+		if expiryTime != nil && *expiryTime != "" {
+			if _, err := utils.ParseDurationInSeconds(*expiryTime); err != nil {
+				log.Debug("Invalid expiry time: ", err)
+				return res, fmt.Errorf("invalid expiry time %s: %s", *expiryTime, err.Error())
+			}
+		}
+	}
+
+	if params.SessionExpiryTimeByRole != nil && *params.SessionExpiryTimeByRole != "" {
+		for _, pair := range strings.Split(*params.SessionExpiryTimeByRole, ",") {
+			parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+			if len(parts) != 2 {
+				log.Debug("Invalid session expiry time by role: ", pair)
+				return res, fmt.Errorf("invalid session expiry time by role, expected role:duration pairs")
+			}
+			if _, err := utils.ParseDurationInSeconds(strings.TrimSpace(parts[1])); err != nil {
+				log.Debug("Invalid session expiry time for role: ", err)
+				return res, fmt.Errorf("invalid session expiry time for role %s: %s", parts[0], err.Error())
+			}
+		}
+	}
+
+	if params.OtpLength != nil && *params.OtpLength != "" {
+		if _, err := utils.ParseOTPLength(*params.OtpLength); err != nil {
+			log.Debug("Invalid otp length: ", err)
+			return res, err
+		}
+	}
+	if params.OtpCharset != nil && *params.OtpCharset != "" {
+		if err := utils.ValidateOTPCharset(*params.OtpCharset); err != nil {
+			log.Debug("Invalid otp charset: ", err)
+			return res, err
+		}
+	}
+	if params.OtpExpiryTime != nil && *params.OtpExpiryTime != "" {
+		if _, err := utils.ParseDurationInSeconds(*params.OtpExpiryTime); err != nil {
+			log.Debug("Invalid otp expiry time: ", err)
+			return res, fmt.Errorf("invalid otp expiry time %s: %s", *params.OtpExpiryTime, err.Error())
+		}
+	}
+	if params.OtpMaxAttempts != nil && *params.OtpMaxAttempts != "" {
+		if _, err := utils.ParseOTPMaxAttempts(*params.OtpMaxAttempts); err != nil {
+			log.Debug("Invalid otp max attempts: ", err)
+			return res, err
+		}
+	}
+
+	if params.TokenExchangePolicy != nil && *params.TokenExchangePolicy != "" {
+		for _, pair := range strings.Split(*params.TokenExchangePolicy, ",") {
+			parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+				log.Debug("Invalid token exchange policy: ", pair)
+				return res, fmt.Errorf("invalid token exchange policy, expected client_id:audience pairs")
+			}
+		}
+	}
+
+	if params.BackchannelLogoutURI != nil && *params.BackchannelLogoutURI != "" {
+		if u, err := url.ParseRequestURI(*params.BackchannelLogoutURI); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			log.Debug("Invalid back-channel logout uri: ", *params.BackchannelLogoutURI)
+			return res, fmt.Errorf("invalid back-channel logout uri")
+		}
+	}
+
+	if params.CustomScopes != nil {
+		if _, err := token.ParseCustomScopes(*params.CustomScopes); err != nil {
+			log.Debug("Invalid custom scopes: ", err)
+			return res, err
+		}
+	}
+
+	for _, script := range []*string{params.CustomAccessTokenScript, params.CustomIDTokenScript, params.CustomUserInfoScript} {
+		if script == nil || strings.TrimSpace(*script) == "" {
+			continue
+		}
+		if err := token.ValidateCustomScript(*script); err != nil {
+			log.Debug("Invalid custom script: ", err)
+			return res, err
+		}
+	}
+
 	var data map[string]interface{}
 	byteData, err := json.Marshal(params)
 	if err != nil {
@@ -364,6 +478,17 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 		}
 	}
 
+	// roles granting permissions are deleted with delete_role mutation
+	for _, role := range append(utils.FindDeletedValues(previousRoles, updatedRoles), utils.FindDeletedValues(previousProtectedRoles, updatedProtectedRoles)...) {
+		if role == "" || utils.StringSliceContains(updatedRoles, role) || utils.StringSliceContains(updatedProtectedRoles, role) {
+			continue
+		}
+		if roleData, err := db.Provider.GetRoleByName(ctx, role); err == nil && len(roleData.GetPermissions()) > 0 {
+			log.Debug("Role with permissions can not be removed from roles: ", role)
+			return res, fmt.Errorf("role %s has permissions, use delete_role to delete it", role)
+		}
+	}
+
 	deletedRoles := utils.FindDeletedValues(previousRoles, updatedRoles)
 	if len(deletedRoles) > 0 {
 		go updateRoles(ctx, deletedRoles)
@@ -427,6 +552,12 @@ func UpdateEnvResolver(ctx context.Context, params model.UpdateEnvInput) (*model
 		return res, err
 	}
 
+	err = envstore.SyncRoles(ctx)
+	if err != nil {
+		log.Debug("Failed to sync roles: ", err)
+		return res, err
+	}
+
 	go clearSessionIfRequired(currentData, updatedData)
 
 	res = &model.Response{
@@ -0,0 +1,68 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// UpdateOrganizationResolver resolver for update organization mutation
+// Roles removed from organization are not removed from the existing memberships
+func UpdateOrganizationResolver(ctx context.Context, params model.UpdateOrganizationRequest) (*model.Organization, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithField("organization_id", params.ID)
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.ID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, fmt.Errorf("organization not found")
+	}
+
+	if params.Name != nil {
+		name := strings.TrimSpace(*params.Name)
+		if name == "" {
+			log.Debug("Organization name is required")
+			return nil, fmt.Errorf("organization name is required")
+		}
+		if name != organization.Name {
+			if existingOrganization, _ := db.Provider.GetOrganizationByName(ctx, name); existingOrganization != nil {
+				log.Debug("Organization already exists: ", name)
+				return nil, fmt.Errorf("organization with %s name already exists", name)
+			}
+			organization.Name = name
+		}
+	}
+	if params.Roles != nil {
+		organization.Roles = joinOrganizationRoles(params.Roles)
+	}
+	if params.DefaultRoles != nil {
+		organization.DefaultRoles = joinOrganizationRoles(params.DefaultRoles)
+	}
+	if !validators.IsValidRoles(organization.GetDefaultRoles(), organization.GetRoles()) {
+		log.Debug("Invalid default roles: ", organization.DefaultRoles)
+		return nil, fmt.Errorf("default roles should be subset of roles")
+	}
+
+	organization, err = db.Provider.UpdateOrganization(ctx, organization)
+	if err != nil {
+		log.Debug("Failed to update organization: ", err)
+		return nil, err
+	}
+	return organization.AsAPIOrganization(), nil
+}
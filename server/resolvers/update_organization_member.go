@@ -0,0 +1,57 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/authorizerdev/authorizer/server/validators"
+)
+
+// UpdateOrganizationMemberResolver resolver for update organization member mutation
+// Updated roles are reflected in the organization claim when member refreshes the session
+func UpdateOrganizationMemberResolver(ctx context.Context, params model.UpdateOrganizationMemberRequest) (*model.Membership, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	log := log.WithFields(log.Fields{
+		"organization_id": params.OrganizationID,
+		"user_id":         params.UserID,
+	})
+	organization, err := db.Provider.GetOrganizationByID(ctx, params.OrganizationID)
+	if err != nil {
+		log.Debug("Failed to get organization: ", err)
+		return nil, fmt.Errorf("organization not found")
+	}
+	membership, err := db.Provider.GetMembershipByOrganizationIDAndUserID(ctx, organization.ID, params.UserID)
+	if err != nil || membership == nil {
+		log.Debug("Failed to get membership: ", err)
+		return nil, fmt.Errorf("user is not member of organization")
+	}
+	if !validators.IsValidRoles(params.Roles, organization.GetRoles()) {
+		log.Debug("Invalid roles: ", params.Roles)
+		return nil, fmt.Errorf("invalid roles")
+	}
+
+	membership.Roles = joinOrganizationRoles(params.Roles)
+	membership, err = db.Provider.UpdateMembership(ctx, membership)
+	if err != nil {
+		log.Debug("Failed to update membership: ", err)
+		return nil, err
+	}
+	res := membership.AsAPIMembership()
+	res.Organization = organization.AsAPIOrganization()
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UpdatePermissionResolver resolver for update permission mutation
+func UpdatePermissionResolver(ctx context.Context, params model.UpdatePermissionRequest) (*model.Permission, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	log := log.WithField("permission", name)
+	permission, err := db.Provider.GetPermissionByName(ctx, name)
+	if err != nil {
+		log.Debug("Failed to get permission: ", err)
+		return nil, fmt.Errorf("permission not found")
+	}
+
+	if params.Description != nil {
+		permission.Description = refs.StringValue(params.Description)
+	}
+	permission, err = db.Provider.UpdatePermission(ctx, permission)
+	if err != nil {
+		log.Debug("Failed to update permission: ", err)
+		return nil, err
+	}
+	return permission.AsAPIPermission(), nil
+}
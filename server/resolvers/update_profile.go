@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"golang.org/x/crypto/bcrypt"
 
 	log "github.com/sirupsen/logrus"
@@ -19,9 +20,11 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/email"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/smsproviders"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 	"github.com/authorizerdev/authorizer/server/validators"
@@ -80,13 +83,32 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 		user.Gender = params.Gender
 	}
 
+	previousPhoneNumber := refs.StringValue(user.PhoneNumber)
+	// phone number which is updated once the otp sent to it is verified
+	pendingPhoneNumber := ""
 	if params.PhoneNumber != nil && refs.StringValue(user.PhoneNumber) != refs.StringValue(params.PhoneNumber) {
+		phoneNumber := strings.TrimSpace(refs.StringValue(params.PhoneNumber))
 		// verify if phone number is unique
-		if _, err := db.Provider.GetUserByPhoneNumber(ctx, strings.TrimSpace(refs.StringValue(params.PhoneNumber))); err == nil {
+		if _, err := db.Provider.GetUserByPhoneNumber(ctx, phoneNumber); err == nil {
 			log.Debug("user with given phone number already exists")
 			return nil, errors.New("user with given phone number already exists")
 		}
-		user.PhoneNumber = params.PhoneNumber
+		disablePhoneVerification, _ := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyDisablePhoneVerification)
+		isSMSServiceEnabled, _ := memorystore.Provider.GetBoolStoreEnvVariable(constants.EnvKeyIsSMSServiceEnabled)
+		if !disablePhoneVerification && isSMSServiceEnabled {
+			if len(phoneNumber) < 10 {
+				log.Debug("Invalid phone number: ", phoneNumber)
+				return nil, errors.New("invalid phone number")
+			}
+			pendingPhoneNumber = phoneNumber
+		} else {
+			user.PhoneNumber = params.PhoneNumber
+			user.PhoneNumberVerifiedAt = nil
+			if disablePhoneVerification {
+				now := time.Now().Unix()
+				user.PhoneNumberVerifiedAt = &now
+			}
+		}
 	}
 
 	if params.Picture != nil && refs.StringValue(user.Picture) != refs.StringValue(params.Picture) {
@@ -211,6 +233,7 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 	}
 
 	hasEmailChanged := false
+	previousEmail := refs.StringValue(user.Email)
 
 	if params.Email != nil && refs.StringValue(user.Email) != refs.StringValue(params.Email) {
 		// check if valid email
@@ -234,6 +257,7 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 		}
 
 		go memorystore.Provider.DeleteAllUserSessions(user.ID)
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), user.ID, "")
 		go cookie.DeleteSession(gc)
 
 		user.Email = &newEmail
@@ -281,18 +305,136 @@ func UpdateProfileResolver(ctx context.Context, params model.UpdateProfileInput)
 
 		}
 	}
-	_, err = db.Provider.UpdateUser(ctx, user)
+	user, err = db.Provider.UpdateUser(ctx, user)
 	if err != nil {
 		log.Debug("Failed to update user: ", err)
 		return res, err
 	}
+	if previousEmail != "" && previousEmail != refs.StringValue(user.Email) {
+		if err := sendRevertChangeNotification(ctx, gc, user, constants.VerificationTypeRevertEmailChange, previousEmail); err != nil {
+			log.Debug("Failed to notify previous email: ", err)
+		}
+	}
+	if previousPhoneNumber != "" && previousPhoneNumber != refs.StringValue(user.PhoneNumber) {
+		if err := sendRevertChangeNotification(ctx, gc, user, constants.VerificationTypeRevertPhoneNumberChange, previousPhoneNumber); err != nil {
+			log.Debug("Failed to notify previous phone number: ", err)
+		}
+	}
+	if pendingPhoneNumber != "" {
+		expiresAt := utils.GetOTPExpiresAt(10 * time.Minute)
+		if err := savePendingPhoneNumberChange(ctx, gc, user.ID, pendingPhoneNumber, expiresAt); err != nil {
+			log.Debug("Failed to save pending phone number change: ", err)
+			return res, err
+		}
+		smsCode, err := utils.CreateOTP(ctx, "", pendingPhoneNumber, true, expiresAt)
+		if err != nil {
+			log.Debug("Failed to create otp for phone number change: ", err)
+			return res, err
+		}
+		smsBody := strings.Builder{}
+		smsBody.WriteString("Your verification code is: ")
+		smsBody.WriteString(smsCode)
+		go func() {
+			if err := smsproviders.SendSMS(pendingPhoneNumber, smsBody.String()); err != nil {
+				log.Debug("Failed to send otp for phone number change: ", err)
+			}
+		}()
+	}
 	message := `Profile details updated successfully.`
 	if hasEmailChanged {
 		message += `For the email change we have sent new verification email, please verify and continue`
 	}
+	if pendingPhoneNumber != "" {
+		message += `For the phone number change we have sent an otp to new phone number, please verify it to continue`
+	}
 	res = &model.Response{
 		Message: message,
 	}
 
 	return res, nil
 }
+
+// savePendingPhoneNumberChange saves the phone number which is pending verification for user,
+// replacing the previous pending change. It is saved with user id, so that only the phone number
+// set by user can be verified with verify_phone_number_change.
+func savePendingPhoneNumberChange(ctx context.Context, gc *gin.Context, userID, phoneNumber string, expiresAt int64) error {
+	if verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, userID, constants.VerificationTypeUpdatePhoneNumber); err == nil && verificationRequest != nil {
+		if err := db.Provider.DeleteVerificationRequest(ctx, verificationRequest); err != nil {
+			return err
+		}
+	}
+	_, nonceHash, err := utils.GenerateNonce()
+	if err != nil {
+		return err
+	}
+	verificationToken, err := token.CreatePhoneNumberChangeToken(userID, phoneNumber, parsers.GetHost(gc), nonceHash, expiresAt)
+	if err != nil {
+		return err
+	}
+	_, err = db.Provider.AddVerificationRequest(ctx, &models.VerificationRequest{
+		Token:      verificationToken,
+		Identifier: constants.VerificationTypeUpdatePhoneNumber,
+		ExpiresAt:  expiresAt,
+		Email:      userID,
+		Nonce:      nonceHash,
+	})
+	return err
+}
+
+// revertChangeExpiresIn is the duration for which email or phone number change can be reverted
+const revertChangeExpiresIn = 7 * 24 * time.Hour
+
+// sendRevertChangeNotification notifies the previous email or phone number of user about the change.
+// Notification has a link which restores the value user had before the recent changes and ends
+// the sessions of user, in case the change was not done by user. There is one pending revert per user,
+// so that changing the value again does not replace the link sent to the original email or phone number.
+// The same link is sent when value is changed again, so it might restore an older value than the notified one.
+func sendRevertChangeNotification(ctx context.Context, gc *gin.Context, user *models.User, verificationType, previousValue string) error {
+	hostname := parsers.GetHost(gc)
+	redirectURL := parsers.GetAppURL(gc)
+	verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, user.ID, verificationType)
+	if err != nil || verificationRequest == nil || verificationRequest.ExpiresAt < time.Now().Unix() {
+		_, nonceHash, err := utils.GenerateNonce()
+		if err != nil {
+			return err
+		}
+		expiresAt := time.Now().Add(revertChangeExpiresIn).Unix()
+		revertToken, err := token.CreateRevertChangeToken(user.ID, previousValue, verificationType, hostname, nonceHash, redirectURL, expiresAt)
+		if err != nil {
+			return err
+		}
+		verificationRequest, err = db.Provider.AddVerificationRequest(ctx, &models.VerificationRequest{
+			Token:       revertToken,
+			Identifier:  verificationType,
+			ExpiresAt:   expiresAt,
+			Email:       user.ID,
+			Nonce:       nonceHash,
+			RedirectURI: redirectURL,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	revertURL := utils.GetRevertChangeURL(verificationRequest.Token, hostname, redirectURL)
+	if verificationType == constants.VerificationTypeRevertEmailChange {
+		// exec it as go routine so that we can reduce the api latency
+		go email.SendEmail([]string{previousValue}, verificationType, map[string]interface{}{
+			"user":           user.ToMap(),
+			"organization":   utils.GetOrganization(),
+			"previous_email": previousValue,
+			"revert_url":     revertURL,
+		})
+		return nil
+	}
+
+	smsBody := strings.Builder{}
+	smsBody.WriteString("Phone number of your account was changed. If this wasn't you, restore the phone number your account had before the recent changes using: ")
+	smsBody.WriteString(revertURL)
+	go func() {
+		if err := smsproviders.SendSMS(previousValue, smsBody.String()); err != nil {
+			log.Debug("Failed to send phone number change sms: ", err)
+		}
+	}()
+	return nil
+}
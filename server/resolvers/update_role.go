@@ -0,0 +1,56 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UpdateRoleResolver resolver for update role mutation
+// Permissions of role are replaced with given permissions
+func UpdateRoleResolver(ctx context.Context, params model.UpdateRoleRequest) (*model.Role, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	name := strings.TrimSpace(params.Name)
+	log := log.WithField("role", name)
+	role, err := db.Provider.GetRoleByName(ctx, name)
+	if err != nil {
+		log.Debug("Failed to get role: ", err)
+		return nil, fmt.Errorf("role not found")
+	}
+
+	if params.Description != nil {
+		role.Description = refs.StringValue(params.Description)
+	}
+	if params.Permissions != nil {
+		permissions, err := joinPermissions(ctx, params.Permissions)
+		if err != nil {
+			log.Debug("Invalid permissions: ", err)
+			return nil, err
+		}
+		role.Permissions = permissions
+	}
+
+	role, err = db.Provider.UpdateRole(ctx, role)
+	if err != nil {
+		log.Debug("Failed to update role: ", err)
+		return nil, err
+	}
+	return role.AsAPIRole(), nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/email"
 	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/logout"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/refs"
@@ -179,6 +180,7 @@ func UpdateUserResolver(ctx context.Context, params model.UpdateUserInput) (*mod
 		}
 
 		go memorystore.Provider.DeleteAllUserSessions(user.ID)
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), user.ID, "")
 
 		hostname := parsers.GetHost(gc)
 		user.Email = &newEmail
@@ -231,6 +233,7 @@ func UpdateUserResolver(ctx context.Context, params model.UpdateUserInput) (*mod
 			return res, fmt.Errorf("user with this phone number already exists")
 		}
 		go memorystore.Provider.DeleteAllUserSessions(user.ID)
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), user.ID, "")
 		user.PhoneNumber = &phone
 		user.PhoneNumberVerifiedAt = nil
 	}
@@ -270,8 +273,10 @@ func UpdateUserResolver(ctx context.Context, params model.UpdateUserInput) (*mod
 		}
 
 		go memorystore.Provider.DeleteAllUserSessions(user.ID)
+		go logout.SendBackchannelLogout(parsers.GetHost(gc), user.ID, "")
 	}
 
+	previousRoles := strings.Split(user.Roles, ",")
 	if rolesToSave != "" {
 		user.Roles = rolesToSave
 	}
@@ -280,6 +285,13 @@ func UpdateUserResolver(ctx context.Context, params model.UpdateUserInput) (*mod
 		log.Debug("Failed to update user: ", err)
 		return res, err
 	}
+	if rolesToSave != "" {
+		err = utils.RecordRoleChanges(ctx, user.ID, previousRoles, strings.Split(rolesToSave, ","), constants.RoleChangedBySuperAdmin)
+		if err != nil {
+			log.Debug("Failed to record role changes: ", err)
+			return res, err
+		}
+	}
 
 	createdAt := user.CreatedAt
 	updatedAt := user.UpdatedAt
@@ -32,7 +32,7 @@ func UserResolver(ctx context.Context, params model.GetUserRequest) (*model.User
 			log.Debug("Failed to get users by ID: ", err)
 			return nil, err
 		}
-		return res.AsAPIUser(), nil
+		return asAPIUserWithIdentities(ctx, res), nil
 	}
 	// Try getting user by email
 	if params.Email != nil && strings.Trim(*params.Email, " ") != "" {
@@ -41,7 +41,7 @@ func UserResolver(ctx context.Context, params model.GetUserRequest) (*model.User
 			log.Debug("Failed to get users by email: ", err)
 			return nil, err
 		}
-		return res.AsAPIUser(), nil
+		return asAPIUserWithIdentities(ctx, res), nil
 	}
 	// Return error if no params are provided
 	return nil, fmt.Errorf("invalid params, user id or email is required")
@@ -0,0 +1,37 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UserRoleGrantsResolver resolver for user role grants query
+func UserRoleGrantsResolver(ctx context.Context, params model.UserRolesRequest) ([]*model.RoleGrant, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	roleGrants, err := db.Provider.ListRoleGrantsByUserID(ctx, params.UserID)
+	if err != nil {
+		log.WithField("user_id", params.UserID).Debug("Failed to list role grants: ", err)
+		return nil, err
+	}
+	res := make([]*model.RoleGrant, 0, len(roleGrants))
+	for _, roleGrant := range roleGrants {
+		res = append(res, roleGrant.AsAPIRoleGrant())
+	}
+	return res, nil
+}
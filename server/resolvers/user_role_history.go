@@ -0,0 +1,38 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UserRoleHistoryResolver resolver for user role history query
+// It returns the role changes of user, oldest first
+func UserRoleHistoryResolver(ctx context.Context, params model.UserRolesRequest) ([]*model.RoleChange, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin")
+		return nil, fmt.Errorf("unauthorized")
+	}
+
+	roleChanges, err := db.Provider.ListRoleChangesByUserID(ctx, params.UserID)
+	if err != nil {
+		log.WithField("user_id", params.UserID).Debug("Failed to list role changes: ", err)
+		return nil, err
+	}
+	res := make([]*model.RoleChange, 0, len(roleChanges))
+	for _, roleChange := range roleChanges {
+		res = append(res, roleChange.AsAPIRoleChange())
+	}
+	return res, nil
+}
@@ -0,0 +1,46 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// UserSessionsResolver is a resolver for _user_sessions query
+// This is admin only query
+func UserSessionsResolver(ctx context.Context, params model.GetUserRequest) ([]*model.Session, error) {
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return nil, err
+	}
+	if !token.IsSuperAdmin(gc) {
+		log.Debug("Not logged in as super admin.")
+		return nil, fmt.Errorf("unauthorized")
+	}
+	var user *models.User
+	if params.ID != nil && strings.Trim(*params.ID, " ") != "" {
+		user, err = db.Provider.GetUserByID(ctx, *params.ID)
+		if err != nil {
+			log.Debug("Failed to get user by ID: ", err)
+			return nil, err
+		}
+	} else if params.Email != nil && strings.Trim(*params.Email, " ") != "" {
+		user, err = db.Provider.GetUserByEmail(ctx, *params.Email)
+		if err != nil {
+			log.Debug("Failed to get user by email: ", err)
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("invalid params, user id or email is required")
+	}
+	return listActiveSessions(ctx, user.ID, "")
+}
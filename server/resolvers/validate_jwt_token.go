@@ -12,6 +12,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
@@ -77,6 +78,18 @@ func ValidateJwtTokenResolver(ctx context.Context, params model.ValidateJWTToken
 		}
 	}
 
+	// DPoP bound tokens are only valid along with proof of possession of the key
+	if tokenType == constants.TokenTypeAccessToken || tokenType == constants.TokenTypeRefreshToken {
+		accessToken := ""
+		if tokenType == constants.TokenTypeAccessToken {
+			accessToken = params.Token
+		}
+		if err := token.ValidateDPoPBoundToken(claims, refs.StringValue(params.DpopProof), refs.StringValue(params.HTTPMethod), refs.StringValue(params.HTTPURL), accessToken); err != nil {
+			log.Debug("Failed to validate DPoP proof: ", err)
+			return nil, err
+		}
+	}
+
 	claimKey := "roles"
 
 	if tokenType == constants.TokenTypeIdentityToken {
@@ -193,9 +193,12 @@ func VerifyEmailResolver(ctx context.Context, params model.VerifyEmailInput) (*m
 		}
 
 		db.Provider.AddSession(ctx, &models.Session{
-			UserID:    user.ID,
-			UserAgent: utils.GetUserAgent(gc.Request),
-			IP:        utils.GetIP(gc.Request),
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: loginMethod,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
 		})
 	}()
 	expiresIn := authToken.AccessToken.ExpiresAt - time.Now().Unix()
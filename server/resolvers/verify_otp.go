@@ -16,14 +16,21 @@ import (
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/graph/model"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/refs"
 	"github.com/authorizerdev/authorizer/server/token"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
 
 // VerifyOtpResolver resolver for verify otp mutation
-func VerifyOtpResolver(ctx context.Context, params model.VerifyOTPRequest) (*model.AuthResponse, error) {
-	var res *model.AuthResponse
+func VerifyOtpResolver(ctx context.Context, params model.VerifyOTPRequest) (res *model.AuthResponse, err error) {
+	defer func() {
+		recipe := constants.AuthRecipeMethodBasicAuth
+		if refs.StringValue(params.Email) == "" && refs.StringValue(params.PhoneNumber) != "" {
+			recipe = constants.AuthRecipeMethodMobileBasicAuth
+		}
+		metrics.RecordAuthEvent(metrics.EventMFAChallenge, recipe, metrics.OutcomeFromError(err))
+	}()
 	gc, err := utils.GinContextFromContext(ctx)
 	if err != nil {
 		log.Debug("Failed to get GinContext: ", err)
@@ -97,14 +104,9 @@ func VerifyOtpResolver(ctx context.Context, params model.VerifyOTPRequest) (*mod
 		if otp == nil && err != nil {
 			return res, fmt.Errorf(`OTP not found`)
 		}
-		if params.Otp != otp.Otp {
-			log.Debug("Failed to verify otp request: Incorrect value")
-			return res, fmt.Errorf(`invalid otp`)
-		}
-		expiresIn := otp.ExpiresAt - time.Now().Unix()
-		if expiresIn < 0 {
-			log.Debug("Failed to verify otp request: Timeout")
-			return res, fmt.Errorf("otp expired")
+		if err := utils.VerifyOTP(ctx, otp, params.Otp); err != nil {
+			log.Debug("Failed to verify otp request: ", err)
+			return res, err
 		}
 		db.Provider.DeleteOTP(gc, otp)
 	}
@@ -182,9 +184,12 @@ func VerifyOtpResolver(ctx context.Context, params model.VerifyOTPRequest) (*mod
 		}
 
 		db.Provider.AddSession(ctx, &models.Session{
-			UserID:    user.ID,
-			UserAgent: utils.GetUserAgent(gc.Request),
-			IP:        utils.GetIP(gc.Request),
+			UserID:      user.ID,
+			UserAgent:   utils.GetUserAgent(gc.Request),
+			IP:          utils.GetIP(gc.Request),
+			LoginMethod: loginMethod,
+			Nonce:       authToken.FingerPrint,
+			ExpiresAt:   authToken.SessionTokenExpiresAt,
 		})
 	}()
 
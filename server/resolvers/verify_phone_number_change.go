@@ -0,0 +1,109 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// VerifyPhoneNumberChangeResolver is resolver for verify phone number change mutation.
+// Phone number set with update_profile is saved once the otp sent to it is verified,
+// and previous phone number is notified about the change. Only the phone number pending
+// verification for user can be verified, otp of the same phone number sent by other flows is not accepted.
+func VerifyPhoneNumberChangeResolver(ctx context.Context, params model.VerifyPhoneNumberChangeInput) (*model.Response, error) {
+	var res *model.Response
+
+	gc, err := utils.GinContextFromContext(ctx)
+	if err != nil {
+		log.Debug("Failed to get GinContext: ", err)
+		return res, err
+	}
+	tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+	if err != nil {
+		log.Debug("Failed GetUserIDFromSessionOrAccessToken: ", err)
+		return res, err
+	}
+
+	phoneNumber := strings.TrimSpace(params.PhoneNumber)
+	log := log.WithFields(log.Fields{
+		"user_id":      tokenData.UserID,
+		"phone_number": phoneNumber,
+	})
+	user, err := db.Provider.GetUserByID(ctx, tokenData.UserID)
+	if err != nil {
+		log.Debug("Failed to get user by id: ", err)
+		return res, err
+	}
+
+	// phone number should be the one set by user with update_profile
+	verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, user.ID, constants.VerificationTypeUpdatePhoneNumber)
+	if err != nil || verificationRequest == nil {
+		log.Debug("Failed to get pending phone number change: ", err)
+		return res, fmt.Errorf(`phone number change not found`)
+	}
+	claims, err := token.ParseJWTToken(verificationRequest.Token)
+	if err != nil {
+		log.Debug("Failed to parse phone number change token: ", err)
+		return res, fmt.Errorf(`phone number change expired, please update phone number again`)
+	}
+	if ok, err := token.ValidateJWTClaims(claims, parsers.GetHost(gc), verificationRequest.Nonce, user.ID); !ok || err != nil {
+		log.Debug("Failed to validate phone number change token: ", err)
+		return res, fmt.Errorf(`phone number change not found`)
+	}
+	if pendingPhoneNumber, _ := claims["phone_number"].(string); pendingPhoneNumber != phoneNumber {
+		log.Debug("Phone number does not match pending phone number change")
+		return res, fmt.Errorf(`phone number does not match the phone number to be verified`)
+	}
+
+	otp, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+	if err != nil || otp == nil {
+		log.Debug("Failed to get otp: ", err)
+		return res, fmt.Errorf(`invalid otp`)
+	}
+	if err := utils.VerifyOTP(ctx, otp, params.Otp); err != nil {
+		log.Debug("Failed to verify otp: ", err)
+		return res, err
+	}
+	if err := db.Provider.DeleteOTP(ctx, otp); err != nil {
+		log.Debug("Failed to delete otp: ", err)
+	}
+	if err := db.Provider.DeleteVerificationRequest(ctx, verificationRequest); err != nil {
+		log.Debug("Failed to delete pending phone number change: ", err)
+	}
+
+	// phone number could have been used by other user after the otp was sent
+	if existingUser, err := db.Provider.GetUserByPhoneNumber(ctx, phoneNumber); err == nil && existingUser.ID != user.ID {
+		log.Debug("User with given phone number already exists")
+		return res, fmt.Errorf(`user with given phone number already exists`)
+	}
+
+	previousPhoneNumber := refs.StringValue(user.PhoneNumber)
+	now := time.Now().Unix()
+	user.PhoneNumber = refs.NewStringRef(phoneNumber)
+	user.PhoneNumberVerifiedAt = &now
+	user, err = db.Provider.UpdateUser(ctx, user)
+	if err != nil {
+		log.Debug("Failed to update user: ", err)
+		return res, err
+	}
+	if previousPhoneNumber != "" && previousPhoneNumber != phoneNumber {
+		if err := sendRevertChangeNotification(ctx, gc, user, constants.VerificationTypeRevertPhoneNumberChange, previousPhoneNumber); err != nil {
+			log.Debug("Failed to notify previous phone number: ", err)
+		}
+	}
+
+	return &model.Response{
+		Message: `Phone number updated successfully`,
+	}, nil
+}
@@ -3,9 +3,11 @@ package routes
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	"github.com/authorizerdev/authorizer/server/handlers"
 	"github.com/authorizerdev/authorizer/server/middlewares"
+	"github.com/authorizerdev/authorizer/server/tracing"
 )
 
 // InitRouter initializes gin router
@@ -13,27 +15,42 @@ func InitRouter(log *logrus.Logger) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 
+	// continue the trace from incoming traceparent header, it has to be before
+	// other middlewares so that request context used by them contains the span
+	router.Use(otelgin.Middleware(tracing.ServiceName))
 	router.Use(middlewares.Logger(log), gin.Recovery())
+	router.Use(middlewares.MetricsMiddleware())
 	router.Use(middlewares.GinContextToContextMiddleware())
 	router.Use(middlewares.CORSMiddleware())
 	router.Use(middlewares.ClientCheckMiddleware())
 
 	router.GET("/", handlers.RootHandler())
 	router.GET("/health", handlers.HealthHandler())
+	router.GET("/healthz", handlers.LivenessHandler())
+	router.GET("/readyz", handlers.ReadinessHandler())
+	router.GET("/metrics", handlers.MetricsHandler())
 	router.POST("/graphql", handlers.GraphqlHandler())
 	router.GET("/playground", handlers.PlaygroundHandler())
 	router.GET("/oauth_login/:oauth_provider", handlers.OAuthLoginHandler())
 	router.GET("/oauth_callback/:oauth_provider", handlers.OAuthCallbackHandler())
 	router.POST("/oauth_callback/:oauth_provider", handlers.OAuthCallbackHandler())
 	router.GET("/verify_email", handlers.VerifyEmailHandler())
+	router.GET("/revert_change", handlers.RevertChangeHandler())
 	// OPEN ID routes
 	router.GET("/.well-known/openid-configuration", handlers.OpenIDConfigurationHandler())
 	router.GET("/.well-known/jwks.json", handlers.JWKsHandler())
 	router.GET("/authorize", handlers.AuthorizeHandler())
+	router.POST("/authorize/consent", handlers.ConsentHandler())
 	router.GET("/userinfo", handlers.UserInfoHandler())
 	router.GET("/logout", handlers.LogoutHandler())
+	router.POST("/logout", handlers.LogoutHandler())
 	router.POST("/oauth/token", handlers.TokenHandler())
 	router.POST("/oauth/revoke", handlers.RevokeRefreshTokenHandler())
+	router.POST("/oauth/par", handlers.PushedAuthorizationRequestHandler())
+	router.POST("/oauth/register", handlers.ClientRegistrationHandler())
+	router.GET("/oauth/register/:client_id", handlers.ClientConfigurationHandler())
+	router.PUT("/oauth/register/:client_id", handlers.ClientConfigurationHandler())
+	router.DELETE("/oauth/register/:client_id", handlers.ClientConfigurationHandler())
 
 	router.LoadHTMLGlob("templates/*")
 	// login page app related routes.
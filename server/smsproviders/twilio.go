@@ -1,8 +1,12 @@
 package smsproviders
 
 import (
+	"context"
+
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
+	"github.com/authorizerdev/authorizer/server/tracing"
 	log "github.com/sirupsen/logrus"
 	twilio "github.com/twilio/twilio-go"
 	api "github.com/twilio/twilio-go/rest/api/v2010"
@@ -10,7 +14,14 @@ import (
 
 // SendSMS util to send sms
 // TODO: Should be restructured to interface when another provider is added
-func SendSMS(sendTo, messageBody string) error {
+func SendSMS(sendTo, messageBody string) (err error) {
+	_, span := tracing.StartSpan(context.Background(), "sms.send")
+	defer func() {
+		if err != nil {
+			metrics.RecordNotificationFailure(metrics.ChannelSMS)
+		}
+		tracing.EndSpan(span, err)
+	}()
 	twilioAPISecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyTwilioAPISecret)
 	if err != nil || twilioAPISecret == "" {
 		log.Debug("Failed to get api secret: ", err)
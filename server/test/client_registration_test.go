@@ -0,0 +1,285 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// clientRegistrationRequest calls client registration handlers with given bearer token and body
+func clientRegistrationRequest(s TestSetup, method, clientID, bearerToken string, body interface{}) (int, map[string]interface{}) {
+	path := "/oauth/register"
+	if clientID != "" {
+		path += "/" + clientID
+	}
+	var reqBody bytes.Buffer
+	if body != nil {
+		json.NewEncoder(&reqBody).Encode(body)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, "http://"+s.Server.Listener.Addr().String()+path, &reqBody)
+	c.Request.Header.Set("Content-Type", "application/json")
+	if bearerToken != "" {
+		c.Request.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+	if clientID == "" {
+		handlers.ClientRegistrationHandler()(c)
+	} else {
+		c.Params = gin.Params{{Key: "client_id", Value: clientID}}
+		handlers.ClientConfigurationHandler()(c)
+	}
+	res := map[string]interface{}{}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func clientRegistrationTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should register client and use it in authorization code flow`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		redirectURI := "https://integration.example.com/callback"
+		metadata := map[string]interface{}{
+			"client_name":    "Integration",
+			"redirect_uris":  []string{redirectURI},
+			"grant_types":    []string{"authorization_code", "refresh_token"},
+			"response_types": []string{"code"},
+		}
+
+		status, _ := clientRegistrationRequest(s, http.MethodPost, "", "initial-token", metadata)
+		assert.Equal(t, http.StatusNotFound, status)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "initial-token")
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "")
+
+		status, _ = clientRegistrationRequest(s, http.MethodPost, "", "invalid-token", metadata)
+		assert.Equal(t, http.StatusUnauthorized, status)
+
+		status, res := clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"redirect_uris": []string{"/relative#fragment"},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "invalid_redirect_uri", res["error"])
+		for _, redirectURI := range []string{"javascript:alert(document.cookie)", "data:text/html,<script>alert(1)</script>", "http://example.com/callback", "file:///etc/passwd"} {
+			status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+				"redirect_uris": []string{redirectURI},
+			})
+			assert.Equal(t, http.StatusBadRequest, status, redirectURI)
+			assert.Equal(t, "invalid_redirect_uri", res["error"], redirectURI)
+		}
+		status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"redirect_uris":              []string{"http://127.0.0.1:8080/callback", "http://localhost/callback", "com.example.app:/callback"},
+			"token_endpoint_auth_method": "none",
+		})
+		assert.Equal(t, http.StatusCreated, status)
+		if publicClientID, ok := res["client_id"].(string); ok {
+			clientRegistrationRequest(s, http.MethodDelete, publicClientID, res["registration_access_token"].(string), nil)
+		}
+
+		// back-channel logout uri should be https url of public host
+		for _, logoutURI := range []string{"http://rp.example.com/logout", "https://169.254.169.254/latest/meta-data", "https://10.0.0.1/logout", "https://[::ffff:192.168.1.1]/logout"} {
+			status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+				"redirect_uris":          []string{redirectURI},
+				"backchannel_logout_uri": logoutURI,
+			})
+			assert.Equal(t, http.StatusBadRequest, status, logoutURI)
+			assert.Equal(t, "invalid_client_metadata", res["error"], logoutURI)
+		}
+		// loopback back-channel logout uri is only allowed when not running in production
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyIsProd, true)
+		for _, logoutURI := range []string{"http://127.0.0.1:8080/logout", "https://localhost/logout"} {
+			status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+				"redirect_uris":          []string{redirectURI},
+				"backchannel_logout_uri": logoutURI,
+			})
+			assert.Equal(t, http.StatusBadRequest, status, logoutURI)
+			assert.Equal(t, "invalid_client_metadata", res["error"], logoutURI)
+		}
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyIsProd, false)
+
+		status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"redirect_uris": []string{redirectURI},
+			"jwks":          map[string]interface{}{"keys": []interface{}{map[string]interface{}{"kty": "oct", "k": "c2VjcmV0"}}},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "invalid_client_metadata", res["error"])
+
+		status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", metadata)
+		assert.Equal(t, http.StatusCreated, status)
+		clientID := res["client_id"].(string)
+		clientSecret := res["client_secret"].(string)
+		registrationAccessToken := res["registration_access_token"].(string)
+		assert.NotEmpty(t, clientID)
+		assert.NotEmpty(t, clientSecret)
+		assert.NotEmpty(t, registrationAccessToken)
+		assert.Equal(t, "client_secret_basic", res["token_endpoint_auth_method"])
+		assert.True(t, strings.HasSuffix(res["registration_client_uri"].(string), "/oauth/register/"+clientID))
+
+		// secrets are not stored as is
+		client, err := db.Provider.GetClientByClientID(ctx, clientID)
+		assert.NoError(t, err)
+		assert.NotEqual(t, clientSecret, client.ClientSecret)
+		assert.NotEqual(t, registrationAccessToken, client.RegistrationAccessToken)
+
+		status, _ = clientRegistrationRequest(s, http.MethodGet, clientID, "initial-token", nil)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		status, res = clientRegistrationRequest(s, http.MethodGet, clientID, registrationAccessToken, nil)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Integration", res["client_name"])
+		assert.Nil(t, res["client_secret"])
+
+		// login and authorize the registered client using session cookie
+		email := "client_registration." + s.TestInfo.Email
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(*verifyRes.AccessToken)
+		assert.NoError(t, err)
+		sessionToken, err := memorystore.Provider.GetUserSession(constants.AuthRecipeMethodBasicAuth+":"+verifyRes.User.ID, constants.TokenTypeSessionToken+"_"+claims["nonce"].(string))
+		assert.NoError(t, err)
+		// user has already consented to share the scopes with client
+		_, err = db.Provider.AddGrant(ctx, &models.Grant{
+			UserID:   verifyRes.User.ID,
+			ClientID: clientID,
+			Scope:    "openid profile email offline_access",
+		})
+		assert.NoError(t, err)
+
+		authorize := func(redirectURI string) *httptest.ResponseRecorder {
+			_, codeChallenge := utils.GenerateCodeChallenge()
+			query := url.Values{
+				"client_id":      {clientID},
+				"response_type":  {"code"},
+				"response_mode":  {"query"},
+				"state":          {"state"},
+				"code_challenge": {codeChallenge},
+				"scope":          {"openid profile email offline_access"},
+			}
+			if redirectURI != "" {
+				query.Set("redirect_uri", redirectURI)
+			}
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/authorize?"+query.Encode(), nil)
+			c.Request.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AppCookieName+"_session", sessionToken))
+			handlers.AuthorizeHandler()(c)
+			if cookies := w.Result().Cookies(); len(cookies) > 0 {
+				sessionToken, _ = url.PathUnescape(cookies[0].Value)
+			}
+			return w
+		}
+
+		w := authorize("https://evil.example.com/callback")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// single registered redirect uri is used when it is not sent
+		w = authorize("")
+		assert.Equal(t, http.StatusFound, w.Code)
+		location, err := url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.Equal(t, "integration.example.com", location.Host)
+		code := location.Query().Get("code")
+		assert.NotEmpty(t, code)
+
+		// code cannot be redeemed without client secret
+		status, res = tokenGrant(s, url.Values{
+			"grant_type": {"authorization_code"},
+			"client_id":  {clientID},
+			"code":       {code},
+		})
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_client", res["error"])
+
+		w = authorize(redirectURI)
+		location, err = url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		code = location.Query().Get("code")
+		// code issued to registered client cannot be used by instance client
+		envClientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+		envClientSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientSecret)
+		assert.NoError(t, err)
+		status, res = tokenGrant(s, url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {envClientID},
+			"client_secret": {envClientSecret},
+			"code":          {code},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "invalid_grant", res["error"])
+
+		w = authorize(redirectURI)
+		location, err = url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		code = location.Query().Get("code")
+		status, res = tokenGrant(s, url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"code":          {code},
+		})
+		assert.Equal(t, http.StatusOK, status)
+		idTokenClaims, err := token.ParseJWTToken(res["id_token"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, clientID, idTokenClaims["aud"])
+		accessTokenClaims, err := token.ParseJWTToken(res["access_token"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, clientID, accessTokenClaims["client_id"])
+
+		// refresh token can only be used by the client it was issued to
+		refreshToken := res["refresh_token"].(string)
+		status, res = refreshTokenGrant(s, envClientID, refreshToken)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "invalid_grant", res["error"])
+
+		// client without refresh_token grant type cannot refresh tokens
+		status, res = clientRegistrationRequest(s, http.MethodPut, clientID, registrationAccessToken, map[string]interface{}{
+			"client_name":   "Integration",
+			"redirect_uris": []string{redirectURI},
+		})
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []interface{}{"authorization_code"}, res["grant_types"])
+		status, res = tokenGrant(s, url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"refresh_token": {refreshToken},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "unauthorized_client", res["error"])
+
+		status, _ = clientRegistrationRequest(s, http.MethodDelete, clientID, registrationAccessToken, nil)
+		assert.Equal(t, http.StatusNoContent, status)
+		_, err = db.Provider.GetClientByClientID(ctx, clientID)
+		assert.Error(t, err)
+
+		cleanData(email)
+	})
+}
@@ -0,0 +1,174 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+var consentIDRegex = regexp.MustCompile(`name="consent_id" value="([^"]+)"`)
+
+func consentTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should ask consent for registered clients and revoke grants`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "initial-token")
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "")
+
+		redirectURI := "https://consent.example.com/callback"
+		status, res := clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"client_name":   "Consent App",
+			"redirect_uris": []string{redirectURI},
+			"grant_types":   []string{"authorization_code", "refresh_token"},
+		})
+		assert.Equal(t, http.StatusCreated, status)
+		clientID := res["client_id"].(string)
+		clientSecret := res["client_secret"].(string)
+		defer clientRegistrationRequest(s, http.MethodDelete, clientID, res["registration_access_token"].(string), nil)
+
+		email := "consent." + s.TestInfo.Email
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(*verifyRes.AccessToken)
+		assert.NoError(t, err)
+		sessionToken, err := memorystore.Provider.GetUserSession(constants.AuthRecipeMethodBasicAuth+":"+verifyRes.User.ID, constants.TokenTypeSessionToken+"_"+claims["nonce"].(string))
+		assert.NoError(t, err)
+
+		// sendWithSession calls the handler with session cookie and keeps the rolled over session
+		sendWithSession := func(r *http.Request, handler gin.HandlerFunc) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, engine := gin.CreateTestContext(w)
+			engine.LoadHTMLGlob("../../templates/*")
+			c.Request = r
+			c.Request.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AppCookieName+"_session", sessionToken))
+			handler(c)
+			c.Writer.WriteHeaderNow()
+			if cookies := w.Result().Cookies(); len(cookies) > 0 {
+				sessionToken, _ = url.PathUnescape(cookies[0].Value)
+			}
+			return w
+		}
+		authorize := func() *httptest.ResponseRecorder {
+			_, codeChallenge := utils.GenerateCodeChallenge()
+			query := url.Values{
+				"client_id":      {clientID},
+				"response_type":  {"code"},
+				"response_mode":  {"query"},
+				"state":          {"consent-state"},
+				"code_challenge": {codeChallenge},
+				"scope":          {"openid email offline_access"},
+			}
+			return sendWithSession(httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/authorize?"+query.Encode(), nil), handlers.AuthorizeHandler())
+		}
+		consent := func(consentID, action string) *httptest.ResponseRecorder {
+			form := url.Values{"consent_id": {consentID}, "action": {action}}
+			r := httptest.NewRequest(http.MethodPost, "http://"+s.Server.Listener.Addr().String()+"/authorize/consent", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return sendWithSession(r, handlers.ConsentHandler())
+		}
+		getConsentID := func(w *httptest.ResponseRecorder) string {
+			assert.Equal(t, http.StatusOK, w.Code)
+			body := w.Body.String()
+			assert.Contains(t, body, "Consent App")
+			assert.Contains(t, body, "offline_access")
+			matches := consentIDRegex.FindStringSubmatch(body)
+			if !assert.Len(t, matches, 2) {
+				t.FailNow()
+			}
+			return matches[1]
+		}
+		getCode := func(w *httptest.ResponseRecorder) string {
+			assert.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			assert.NoError(t, err)
+			assert.Equal(t, "consent.example.com", location.Host)
+			return location.Query().Get("code")
+		}
+
+		consentID := getConsentID(authorize())
+		w := consent(consentID, "deny")
+		assert.Equal(t, http.StatusFound, w.Code)
+		location, err := url.Parse(w.Header().Get("Location"))
+		assert.NoError(t, err)
+		assert.Equal(t, "consent.example.com", location.Host)
+		assert.Equal(t, "access_denied", location.Query().Get("error"))
+		assert.Equal(t, "consent-state", location.Query().Get("state"))
+		// consent request can only be answered once
+		w = consent(consentID, "allow")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		consentID = getConsentID(authorize())
+		assert.NotEmpty(t, getCode(consent(consentID, "allow")))
+
+		// consent is not asked again for granted scopes
+		code := getCode(authorize())
+		status, res = tokenGrant(s, url.Values{
+			"grant_type":    {"authorization_code"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"code":          {code},
+		})
+		assert.Equal(t, http.StatusOK, status)
+		refreshToken, _ := res["refresh_token"].(string)
+		assert.NotEmpty(t, refreshToken)
+		accessToken, _ := res["access_token"].(string)
+
+		s.GinContext.Request.Header.Set("Authorization", "Bearer "+accessToken)
+		ctx = context.WithValue(req.Context(), "GinContextKey", s.GinContext)
+		grants, err := resolvers.MyGrantsResolver(ctx)
+		assert.NoError(t, err)
+		if assert.Len(t, grants, 1) {
+			assert.Equal(t, clientID, grants[0].ClientID)
+			assert.Equal(t, "Consent App", refs.StringValue(grants[0].ClientName))
+			assert.Equal(t, []string{"openid", "email", "offline_access"}, grants[0].Scopes)
+		}
+
+		_, err = resolvers.RevokeGrantResolver(ctx, model.RevokeGrantInput{ID: "invalid"})
+		assert.Error(t, err)
+		_, err = resolvers.RevokeGrantResolver(ctx, model.RevokeGrantInput{ID: grants[0].ID})
+		assert.NoError(t, err)
+		grants, err = resolvers.MyGrantsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, grants, 0)
+
+		// refresh tokens of client can not be used after grant is revoked
+		status, res = tokenGrant(s, url.Values{
+			"grant_type":    {"refresh_token"},
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"refresh_token": {refreshToken},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "invalid_grant", res["error"])
+
+		s.GinContext.Request.Header.Del("Authorization")
+		cleanData(email)
+	})
+}
@@ -0,0 +1,128 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func customScopesTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	customScopes := `[{"name":"billing","description":"Read your billing plan","claims":["app_data.plan","phone_number"]},{"name":"audit","roles":["admin"],"claims":["app_data.team"]}]`
+
+	t.Run(`should validate custom scopes on update env`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		for _, value := range []string{
+			`{"name":"billing"}`,
+			`[{"name":"email"}]`,
+			`[{"name":"billing"},{"name":"billing"}]`,
+			`[{"name":"billing","claims":["password"]}]`,
+			`[{"name":"billing","claims":["app_data.sub"]}]`,
+		} {
+			_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+				CustomScopes: refs.NewStringRef(value),
+			})
+			assert.Error(t, err, value)
+		}
+		_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+			CustomScopes: refs.NewStringRef(customScopes),
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+			CustomScopes: refs.NewStringRef(""),
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run(`should filter token and userinfo claims by scopes`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomScopes, customScopes)
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomScopes, "")
+
+		_, ctx := createContext(s)
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		user := &models.User{
+			ID:          uuid.New().String(),
+			Email:       refs.NewStringRef("custom_scopes_" + s.TestInfo.Email),
+			GivenName:   refs.NewStringRef("Custom"),
+			PhoneNumber: refs.NewStringRef("+911234567890"),
+			AppData:     refs.NewStringRef(`{"plan":"pro","team":"security"}`),
+			Roles:       "user,admin",
+			UpdatedAt:   time.Now().Unix(),
+			CreatedAt:   time.Now().Unix(),
+		}
+
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid", "billing", "audit"}, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(authToken.IDToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, claims["sub"])
+		assert.Equal(t, "pro", claims["plan"])
+		assert.Equal(t, "+911234567890", claims["phone_number"])
+		// audit scope is only allowed for admin role
+		assert.Nil(t, claims["team"])
+		// standard claims need the standard scopes
+		assert.Nil(t, claims["email"])
+		assert.Nil(t, claims["given_name"])
+
+		accessClaims, err := token.ParseJWTToken(authToken.AccessToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{"openid", "billing"}, accessClaims["scope"])
+		assert.Equal(t, "pro", accessClaims["plan"])
+
+		authToken, err = token.CreateAuthToken(gc, user, []string{"admin"}, []string{"openid", "email", "profile", "audit"}, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		claims, err = token.ParseJWTToken(authToken.IDToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, "security", claims["team"])
+		assert.Equal(t, refs.StringValue(user.Email), claims["email"])
+		assert.Equal(t, "Custom", claims["given_name"])
+		assert.Nil(t, claims["plan"])
+
+		userInfo, err := token.GetUserInfoClaims(user, []string{"openid", "billing"})
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, userInfo["sub"])
+		assert.Equal(t, "pro", userInfo["plan"])
+		assert.Nil(t, userInfo["email"])
+	})
+
+	t.Run(`should advertise custom scopes in discovery document`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomScopes, customScopes)
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomScopes, "")
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/.well-known/openid-configuration", nil)
+		handlers.OpenIDConfigurationHandler()(c)
+		res := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Contains(t, res["scopes_supported"], "phone")
+		assert.Contains(t, res["scopes_supported"], "billing")
+		assert.Contains(t, res["scopes_supported"], "audit")
+		assert.Contains(t, res["claims_supported"], "plan")
+		assert.Contains(t, res["claims_supported"], "team")
+	})
+}
@@ -0,0 +1,94 @@
+package test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore/providers"
+	"github.com/authorizerdev/authorizer/server/memorystore/providers/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func databaseMemoryStoreTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should keep sessions and states in database`, func(t *testing.T) {
+		p, err := database.NewDatabaseProvider(db.Provider)
+		assert.NoError(t, err)
+		providers.ProviderTests(t, p)
+
+		// entries are shared by the providers of all instances
+		other, err := database.NewDatabaseProvider(db.Provider)
+		assert.NoError(t, err)
+		assert.NoError(t, p.SetState("database_store_state", "state_value"))
+		state, err := other.GetState("database_store_state")
+		assert.NoError(t, err)
+		assert.Equal(t, "state_value", state)
+		assert.NoError(t, other.RemoveState("database_store_state"))
+		state, err = p.GetState("database_store_state")
+		assert.Error(t, err)
+		assert.Empty(t, state)
+
+		// expired entry which is not cleaned up yet does not prevent setting the session
+		entryIDHash := sha256.Sum256([]byte("basic_auth:database_store_user:used_key"))
+		_, err = db.Provider.UpsertMemoryStoreEntry(context.Background(), &models.MemoryStoreEntry{
+			ID:        hex.EncodeToString(entryIDHash[:]),
+			Namespace: "basic_auth:database_store_user",
+			Recipe:    "basic_auth",
+			Subject:   "database_store_user",
+			Value:     "expired_value",
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		})
+		assert.NoError(t, err)
+		ok, err := p.SetUserSessionIfNotExists("basic_auth:database_store_user", "used_key", "used_value", time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = other.SetUserSessionIfNotExists("basic_auth:database_store_user", "used_key", "other_value", time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+
+		assert.NoError(t, p.SetUserSession("basic_auth:database_store_user", "session_token_key", "session_value", time.Now().Add(time.Minute).Unix()))
+		session, err := other.GetUserSession("basic_auth:database_store_user", "session_token_key")
+		assert.NoError(t, err)
+		assert.Equal(t, "session_value", session)
+		assert.NoError(t, other.DeleteAllUserSessions("database_store_user"))
+		_, err = p.GetUserSession("basic_auth:database_store_user", "session_token_key")
+		assert.Error(t, err)
+	})
+
+	t.Run(`should delete expired memory store entries`, func(t *testing.T) {
+		ctx := context.Background()
+		_, err := db.Provider.UpsertMemoryStoreEntry(ctx, &models.MemoryStoreEntry{
+			ID:        "expired_memory_store_entry",
+			Namespace: "basic_auth:expired_user",
+			Recipe:    "basic_auth",
+			Subject:   "expired_user",
+			Value:     "expired",
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+		})
+		assert.NoError(t, err)
+		_, err = db.Provider.UpsertMemoryStoreEntry(ctx, &models.MemoryStoreEntry{
+			ID:        "active_memory_store_entry",
+			Namespace: "basic_auth:active_user",
+			Recipe:    "basic_auth",
+			Subject:   "active_user",
+			Value:     "active",
+			ExpiresAt: time.Now().Add(time.Minute).Unix(),
+		})
+		assert.NoError(t, err)
+
+		assert.NoError(t, db.Provider.DeleteExpiredMemoryStoreEntries(ctx, time.Now().Unix()))
+		_, err = db.Provider.GetMemoryStoreEntryByID(ctx, "expired_memory_store_entry")
+		assert.Error(t, err)
+		entry, err := db.Provider.GetMemoryStoreEntryByID(ctx, "active_memory_store_entry")
+		assert.NoError(t, err)
+		if assert.NotNil(t, entry) {
+			assert.Equal(t, "active", entry.Value)
+		}
+		assert.NoError(t, db.Provider.DeleteMemoryStoreEntryByID(ctx, "active_memory_store_entry"))
+	})
+}
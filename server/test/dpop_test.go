@@ -0,0 +1,221 @@
+package test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// createDPoPProof creates DPoP proof signed with given key
+func createDPoPProof(t *testing.T, key *ecdsa.PrivateKey, htm, htu, accessToken string) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{EmbedJWK: true}).WithType("dpop+jwt"))
+	assert.NoError(t, err)
+	claims := map[string]interface{}{
+		"jti": uuid.New().String(),
+		"htm": htm,
+		"htu": htu,
+		"iat": time.Now().Unix(),
+	}
+	if accessToken != "" {
+		ath := sha256.Sum256([]byte(accessToken))
+		claims["ath"] = base64.RawURLEncoding.EncodeToString(ath[:])
+	}
+	payload, _ := json.Marshal(claims)
+	jws, err := signer.Sign(payload)
+	assert.NoError(t, err)
+	proof, err := jws.CompactSerialize()
+	assert.NoError(t, err)
+	return proof
+}
+
+// dpopJKT returns the thumbprint of public key
+func dpopJKT(t *testing.T, key *ecdsa.PrivateKey) string {
+	jwk := jose.JSONWebKey{Key: key.Public()}
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	assert.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+func dpopTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	_, ctx := createContext(s)
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	resourceURL := "https://api.example.com/resource"
+
+	user := &models.User{
+		ID:        uuid.New().String(),
+		Email:     refs.NewStringRef("dpop_test_" + s.TestInfo.Email),
+		Roles:     "user",
+		UpdatedAt: time.Now().Unix(),
+		CreatedAt: time.Now().Unix(),
+	}
+	gc, err := utils.GinContextFromContext(ctx)
+	assert.NoError(t, err)
+	sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+	scope := []string{"openid", "email", "profile", "offline_access"}
+	authToken, err := token.CreateAuthTokenWithOptions(gc, user, []string{"user"}, scope, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "", token.AuthTokenOptions{
+		DPoPJKT: dpopJKT(t, key),
+	})
+	assert.NoError(t, err)
+	memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeAccessToken+"_"+authToken.FingerPrint, authToken.AccessToken.Token, authToken.AccessToken.ExpiresAt)
+
+	t.Run(`should bind access token to dpop key`, func(t *testing.T) {
+		claims, err := token.ParseJWTToken(authToken.AccessToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, dpopJKT(t, key), token.GetDPoPJKT(claims))
+	})
+
+	t.Run(`should require dpop proof for bound access token`, func(t *testing.T) {
+		_, err := resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType: constants.TokenTypeAccessToken,
+			Token:     authToken.AccessToken.Token,
+		})
+		assert.Error(t, err)
+
+		// proof signed with other key
+		_, err = resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType:  constants.TokenTypeAccessToken,
+			Token:      authToken.AccessToken.Token,
+			DpopProof:  refs.NewStringRef(createDPoPProof(t, otherKey, http.MethodGet, resourceURL, authToken.AccessToken.Token)),
+			HTTPMethod: refs.NewStringRef(http.MethodGet),
+			HTTPURL:    refs.NewStringRef(resourceURL),
+		})
+		assert.Error(t, err)
+
+		// proof for other request
+		_, err = resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType:  constants.TokenTypeAccessToken,
+			Token:      authToken.AccessToken.Token,
+			DpopProof:  refs.NewStringRef(createDPoPProof(t, key, http.MethodPost, resourceURL, authToken.AccessToken.Token)),
+			HTTPMethod: refs.NewStringRef(http.MethodGet),
+			HTTPURL:    refs.NewStringRef(resourceURL),
+		})
+		assert.Error(t, err)
+
+		proof := createDPoPProof(t, key, http.MethodGet, resourceURL, authToken.AccessToken.Token)
+		res, err := resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType:  constants.TokenTypeAccessToken,
+			Token:      authToken.AccessToken.Token,
+			DpopProof:  refs.NewStringRef(proof),
+			HTTPMethod: refs.NewStringRef(http.MethodGet),
+			HTTPURL:    refs.NewStringRef(resourceURL + "?query=ignored"),
+		})
+		assert.NoError(t, err)
+		assert.True(t, res.IsValid)
+
+		// proof cannot be replayed
+		_, err = resolvers.ValidateJwtTokenResolver(ctx, model.ValidateJWTTokenInput{
+			TokenType:  constants.TokenTypeAccessToken,
+			Token:      authToken.AccessToken.Token,
+			DpopProof:  refs.NewStringRef(proof),
+			HTTPMethod: refs.NewStringRef(http.MethodGet),
+			HTTPURL:    refs.NewStringRef(resourceURL),
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run(`should require dpop scheme and proof for bound access token on authorizer apis`, func(t *testing.T) {
+		defer gc.Request.Header.Del("Authorization")
+		defer gc.Request.Header.Del(token.DPoPHeader)
+		requestURL := token.GetDPoPRequestURL(gc)
+
+		// bound access token cannot be used as bearer token
+		gc.Request.Header.Set("Authorization", "Bearer "+authToken.AccessToken.Token)
+		gc.Request.Header.Set(token.DPoPHeader, createDPoPProof(t, key, gc.Request.Method, requestURL, authToken.AccessToken.Token))
+		_, err := token.GetUserIDFromSessionOrAccessToken(gc)
+		assert.Error(t, err)
+		_, err = token.ValidateAccessToken(gc, authToken.AccessToken.Token)
+		assert.ErrorIs(t, err, token.ErrInvalidDPoPProof)
+
+		// proof is required with dpop scheme
+		gc.Request.Header.Set("Authorization", "DPoP "+authToken.AccessToken.Token)
+		gc.Request.Header.Del(token.DPoPHeader)
+		_, err = token.GetUserIDFromSessionOrAccessToken(gc)
+		assert.Error(t, err)
+		gc.Request.Header.Set(token.DPoPHeader, createDPoPProof(t, otherKey, gc.Request.Method, requestURL, authToken.AccessToken.Token))
+		_, err = token.GetUserIDFromSessionOrAccessToken(gc)
+		assert.Error(t, err)
+
+		gc.Request.Header.Set(token.DPoPHeader, createDPoPProof(t, key, gc.Request.Method, requestURL, authToken.AccessToken.Token))
+		tokenData, err := token.GetUserIDFromSessionOrAccessToken(gc)
+		assert.NoError(t, err)
+		if assert.NotNil(t, tokenData) {
+			assert.Equal(t, user.ID, tokenData.UserID)
+		}
+	})
+
+	t.Run(`should issue dpop bound tokens at token endpoint`, func(t *testing.T) {
+		email := "dpop_token." + s.TestInfo.Email
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+		tokenURL := "http://" + s.Server.Listener.Addr().String() + "/oauth/token"
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+			Scope:    scope,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, loginRes.RefreshToken)
+
+		// invalid proof is rejected
+		code, res := refreshTokenGrantWithDPoP(s, clientID, *loginRes.RefreshToken, createDPoPProof(t, key, http.MethodGet, tokenURL, ""))
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_dpop_proof", res["error"])
+
+		code, res = refreshTokenGrantWithDPoP(s, clientID, *loginRes.RefreshToken, createDPoPProof(t, key, http.MethodPost, tokenURL, ""))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, token.TokenTypeDPoP, res["token_type"])
+		refreshToken, ok := res["refresh_token"].(string)
+		assert.True(t, ok)
+		claims, err := token.ParseJWTToken(refreshToken)
+		assert.NoError(t, err)
+		assert.Equal(t, dpopJKT(t, key), token.GetDPoPJKT(claims))
+
+		// bound refresh token cannot be used without proof of same key
+		code, _ = refreshTokenGrant(s, clientID, refreshToken)
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, _ = refreshTokenGrantWithDPoP(s, clientID, refreshToken, createDPoPProof(t, otherKey, http.MethodPost, tokenURL, ""))
+		assert.Equal(t, http.StatusBadRequest, code)
+		code, res = refreshTokenGrantWithDPoP(s, clientID, refreshToken, createDPoPProof(t, key, http.MethodPost, tokenURL, ""))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, token.TokenTypeDPoP, res["token_type"])
+		cleanData(email)
+	})
+}
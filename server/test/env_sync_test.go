@@ -0,0 +1,71 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/env"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/stretchr/testify/assert"
+)
+
+func envSyncTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should reload env changed by other instance`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		// record the current version
+		_, err := env.SyncEnv(ctx, nil)
+		assert.NoError(t, err)
+		reloaded, err := env.SyncEnv(ctx, nil)
+		assert.NoError(t, err)
+		assert.False(t, reloaded)
+
+		orgName, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+		assert.NoError(t, err)
+		envRow, err := db.Provider.GetEnv(ctx)
+		assert.NoError(t, err)
+		originalEnvData := envRow.EnvData
+
+		// simulate update from other instance, which only writes to database
+		encryptedConfig, err := crypto.EncryptEnvData(map[string]interface{}{
+			constants.EnvKeyOrganizationName: "Synced Org",
+		})
+		assert.NoError(t, err)
+		envRow.EnvData = encryptedConfig
+		_, err = db.Provider.UpdateEnv(ctx, envRow)
+		assert.NoError(t, err)
+
+		changes := 0
+		reloaded, err = env.SyncEnv(ctx, func() error {
+			changes++
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.True(t, reloaded)
+		assert.Equal(t, 1, changes)
+		syncedOrgName, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOrganizationName)
+		assert.NoError(t, err)
+		assert.Equal(t, "Synced Org", syncedOrgName)
+		jwk, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJWK)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, jwk)
+
+		reloaded, err = env.SyncEnv(ctx, func() error {
+			changes++
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.False(t, reloaded)
+		assert.Equal(t, 1, changes)
+
+		// restore
+		envRow.EnvData = originalEnvData
+		_, err = db.Provider.UpdateEnv(ctx, envRow)
+		assert.NoError(t, err)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOrganizationName, orgName)
+		_, err = env.SyncEnv(ctx, nil)
+		assert.NoError(t, err)
+	})
+}
@@ -36,6 +36,9 @@ func forgotPasswordMobileTest(t *testing.T, s TestSetup) {
 		assert.True(t, *forgotPasswordRes.ShouldShowMobileOtpScreen)
 		otpReq, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
 		assert.Nil(t, err)
+		assert.NotEmpty(t, otpReq.Otp)
+		otpCode, err := renewOTP(ctx, "", phoneNumber)
+		assert.Nil(t, err)
 		mfaSession := uuid.NewString()
 		memorystore.Provider.SetMfaSession(res.User.ID, mfaSession, time.Now().Add(1*time.Minute).Unix())
 		cookie := fmt.Sprintf("%s=%s;", constants.MfaCookieName+"_session", mfaSession)
@@ -44,7 +47,7 @@ func forgotPasswordMobileTest(t *testing.T, s TestSetup) {
 		// Reset password
 		resetPasswordRes, err := resolvers.ResetPasswordResolver(ctx, model.ResetPasswordInput{
 			PhoneNumber:     refs.NewStringRef(phoneNumber),
-			Otp:             refs.NewStringRef(otpReq.Otp),
+			Otp:             refs.NewStringRef(otpCode),
 			Password:        s.TestInfo.Password + "test",
 			ConfirmPassword: s.TestInfo.Password + "test",
 		})
@@ -0,0 +1,67 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+func healthTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should report liveness and readiness`, func(t *testing.T) {
+		router := gin.New()
+		router.GET("/healthz", handlers.LivenessHandler())
+		router.GET("/readyz", handlers.ReadinessHandler())
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		type readiness struct {
+			Status     string `json:"status"`
+			Components map[string]struct {
+				Status    string  `json:"status"`
+				LatencyMs float64 `json:"latency_ms"`
+			} `json:"components"`
+		}
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var res readiness
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "ok", res.Status)
+		for _, name := range []string{"database", "memory_store", "jwt_keys"} {
+			assert.Equal(t, "ok", res.Components[name].Status, name)
+		}
+
+		// broken signing key should make the instance not ready
+		privateKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtPrivateKey)
+		assert.NoError(t, err)
+		jwtType, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtType)
+		assert.NoError(t, err)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtType, "RS256")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPrivateKey, "invalid")
+		defer func() {
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtType, jwtType)
+			memorystore.Provider.UpdateEnvVariable(constants.EnvKeyJwtPrivateKey, privateKey)
+		}()
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		res = readiness{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		assert.Equal(t, "unavailable", res.Status)
+		assert.Equal(t, "ok", res.Components["database"].Status)
+		assert.Equal(t, "error", res.Components["jwt_keys"].Status)
+	})
+}
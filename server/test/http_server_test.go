@@ -0,0 +1,121 @@
+package test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/httpserver"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// writeTestCert writes self signed certificate with given serial number and returns it
+func writeTestCert(t *testing.T, certFile, keyFile string, serial int64) *x509.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(serial),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		DNSNames:              []string{"localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	return cert
+}
+
+func httpServerTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should serve tls and reload renewed certificates`, func(t *testing.T) {
+		dir := t.TempDir()
+		certFile := filepath.Join(dir, "cert.pem")
+		keyFile := filepath.Join(dir, "key.pem")
+		writeTestCert(t, certFile, keyFile, 1)
+
+		_, err := httpserver.NewTLSConfig(certFile, "", "")
+		assert.Error(t, err)
+		config, err := httpserver.NewTLSConfig("", "", "")
+		assert.NoError(t, err)
+		assert.Nil(t, config)
+
+		// server cert is used as client CA and client cert for mTLS
+		config, err = httpserver.NewTLSConfig(certFile, keyFile, certFile)
+		assert.NoError(t, err)
+		listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+		assert.NoError(t, err)
+		server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})}
+		go server.Serve(listener)
+		defer server.Close()
+		url := "https://" + listener.Addr().String()
+
+		// handshake returns the certificate presented by server
+		serverSerial := func(clientCert bool) (int64, error) {
+			clientConfig := &tls.Config{InsecureSkipVerify: true}
+			if clientCert {
+				cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+				assert.NoError(t, err)
+				clientConfig.Certificates = []tls.Certificate{cert}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientConfig}}
+			res, err := client.Get(url)
+			if err != nil {
+				return 0, err
+			}
+			defer res.Body.Close()
+			return res.TLS.PeerCertificates[0].SerialNumber.Int64(), nil
+		}
+
+		_, err = serverSerial(false)
+		assert.Error(t, err)
+		serial, err := serverSerial(true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), serial)
+
+		writeTestCert(t, certFile, keyFile, 2)
+		modTime := time.Now().Add(time.Minute)
+		assert.NoError(t, os.Chtimes(certFile, modTime, modTime))
+		assert.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+		serial, err = serverSerial(true)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), serial)
+	})
+
+	t.Run(`should stop serving when context is done`, func(t *testing.T) {
+		server := &http.Server{Addr: "127.0.0.1:0"}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.NoError(t, httpserver.ListenAndServe(ctx, server))
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), time.Second)
+		defer cancelShutdown()
+		assert.NoError(t, server.Shutdown(shutdownCtx))
+		assert.NoError(t, utils.WaitForWebhookDeliveries(shutdownCtx))
+	})
+}
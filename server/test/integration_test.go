@@ -148,6 +148,32 @@ func TestResolvers(t *testing.T) {
 			resendOTPTest(t, s)
 			validateSessionTests(t, s)
 			deactivateAccountTests(t, s)
+			linkIdentityTests(t, s)
+			unlinkIdentityTests(t, s)
+			mySessionsTests(t, s)
+			userSessionsTests(t, s)
+			sessionExpiryTests(t, s)
+			refreshTokenReuseTests(t, s)
+			dpopTests(t, s)
+			testTokenScriptTests(t, s)
+			tokenExchangeTests(t, s)
+			rpLogoutTests(t, s)
+			clientRegistrationTests(t, s)
+			parTests(t, s)
+			consentTests(t, s)
+			customScopesTests(t, s)
+			databaseMemoryStoreTests(t, s)
+			envSyncTests(t, s)
+			metricsTests(t, s)
+			tracingTests(t, s)
+			healthTests(t, s)
+			httpServerTests(t, s)
+			otpTests(t, s)
+			loginCodeTests(t, s)
+			organizationTests(t, s)
+			permissionTests(t, s)
+			roleGrantTests(t, s)
+			revertChangeTests(t, s)
 
 			updateAllUsersTest(t, s)
 			webhookLogsTest(t, s)   // get logs after above resolver tests are done
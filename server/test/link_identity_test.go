@@ -0,0 +1,102 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/stretchr/testify/assert"
+)
+
+func linkIdentityTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should return authorization url for linking identity`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "link_identity." + s.TestInfo.Email
+
+		_, err := resolvers.LinkIdentityResolver(ctx, model.LinkIdentityInput{
+			Provider:    constants.AuthRecipeMethodGithub,
+			RedirectURI: "http://localhost:3000",
+		})
+		assert.NotNil(t, err, "unauthorized")
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		s.GinContext.Request.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		ctx = context.WithValue(req.Context(), "GinContextKey", s.GinContext)
+
+		_, err = resolvers.LinkIdentityResolver(ctx, model.LinkIdentityInput{
+			Provider:    "invalid_provider",
+			RedirectURI: "http://localhost:3000",
+		})
+		assert.Error(t, err)
+
+		res, err := resolvers.LinkIdentityResolver(ctx, model.LinkIdentityInput{
+			Provider:    constants.AuthRecipeMethodGithub,
+			RedirectURI: "http://localhost:3000",
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+		assert.Contains(t, res.AuthorizationURL, "/oauth_login/"+constants.AuthRecipeMethodGithub)
+		linkToken := res.AuthorizationURL[strings.Index(res.AuthorizationURL, "link_token=")+len("link_token="):]
+		userID, err := memorystore.Provider.GetState(linkToken)
+		assert.NoError(t, err)
+		assert.Equal(t, verifyRes.User.ID, userID)
+
+		// link token is only accepted with the browser session of same user
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/oauth_callback/github", nil)
+		_, err = token.ValidateLinkToken(c, linkToken)
+		assert.Error(t, err)
+		claims, err := token.ParseJWTToken(*verifyRes.AccessToken)
+		assert.NoError(t, err)
+		sessionToken, err := memorystore.Provider.GetUserSession(constants.AuthRecipeMethodBasicAuth+":"+verifyRes.User.ID, constants.TokenTypeSessionToken+"_"+claims["nonce"].(string))
+		assert.NoError(t, err)
+		c.Request.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AppCookieName+"_session", sessionToken))
+		userID, err = token.ValidateLinkToken(c, linkToken)
+		assert.NoError(t, err)
+		assert.Equal(t, verifyRes.User.ID, userID)
+
+		// provider account can be linked with only one user
+		identity, err := db.Provider.AddIdentity(ctx, &models.Identity{
+			UserID:         verifyRes.User.ID,
+			Provider:       constants.AuthRecipeMethodGithub,
+			ProviderUserID: "link-identity-github-user",
+		})
+		assert.NoError(t, err)
+		_, err = db.Provider.AddIdentity(ctx, &models.Identity{
+			UserID:         "other-user",
+			Provider:       constants.AuthRecipeMethodGithub,
+			ProviderUserID: "link-identity-github-user",
+		})
+		assert.Error(t, err)
+		assert.NoError(t, db.Provider.DeleteIdentity(ctx, identity))
+
+		s.GinContext.Request.Header.Set("Authorization", "")
+		cleanData(email)
+	})
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+)
+
+func loginCodeTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should login with code`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "login_code." + s.TestInfo.Email
+		phoneNumber := "3234567890"
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyEnableLoginCode, false)
+		_, err := resolvers.RequestLoginCodeResolver(ctx, model.RequestLoginCodeInput{
+			Email: refs.NewStringRef(email),
+		})
+		assert.Error(t, err, "login code is disabled")
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyEnableLoginCode, true)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyDisableSignUp, true)
+		_, err = resolvers.RequestLoginCodeResolver(ctx, model.RequestLoginCodeInput{
+			Email: refs.NewStringRef(email),
+		})
+		assert.Error(t, err, "signup is disabled")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyDisableSignUp, false)
+
+		res, err := resolvers.RequestLoginCodeResolver(ctx, model.RequestLoginCodeInput{
+			Email: refs.NewStringRef(email),
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, res.Message)
+		// user is created only after code is verified
+		_, err = db.Provider.GetUserByEmail(ctx, email)
+		assert.Error(t, err)
+
+		code, err := renewOTP(ctx, email, "")
+		assert.NoError(t, err)
+		_, err = resolvers.LoginWithCodeResolver(ctx, model.LoginWithCodeInput{
+			Email: refs.NewStringRef(email),
+			Code:  "wrong",
+		})
+		assert.Error(t, err)
+		authRes, err := resolvers.LoginWithCodeResolver(ctx, model.LoginWithCodeInput{
+			Email: refs.NewStringRef(email),
+			Code:  code,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, refs.StringValue(authRes.AccessToken))
+		assert.True(t, authRes.User.EmailVerified)
+
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.AuthRecipeMethodLoginCode, user.SignupMethods)
+		defaultRoles, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+		assert.NoError(t, err)
+		assert.Equal(t, defaultRoles, user.Roles)
+
+		// code can be used only once
+		_, err = resolvers.LoginWithCodeResolver(ctx, model.LoginWithCodeInput{
+			Email: refs.NewStringRef(email),
+			Code:  code,
+		})
+		assert.Error(t, err)
+
+		// existing user logs in with new code
+		_, err = resolvers.RequestLoginCodeResolver(ctx, model.RequestLoginCodeInput{
+			Email: refs.NewStringRef(email),
+		})
+		assert.NoError(t, err)
+		code, err = renewOTP(ctx, email, "")
+		assert.NoError(t, err)
+		authRes, err = resolvers.LoginWithCodeResolver(ctx, model.LoginWithCodeInput{
+			Email: refs.NewStringRef(email),
+			Code:  code,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, authRes.User.ID)
+		cleanData(email)
+
+		// login with code sent via sms
+		_, err = resolvers.RequestLoginCodeResolver(ctx, model.RequestLoginCodeInput{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+		})
+		assert.NoError(t, err)
+		code, err = renewOTP(ctx, "", phoneNumber)
+		assert.NoError(t, err)
+		authRes, err = resolvers.LoginWithCodeResolver(ctx, model.LoginWithCodeInput{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+			Code:        code,
+		})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, refs.StringValue(authRes.AccessToken))
+		assert.Equal(t, phoneNumber, refs.StringValue(authRes.User.PhoneNumber))
+		assert.True(t, refs.BoolValue(authRes.User.PhoneNumberVerified))
+		user, err = db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
+		assert.NoError(t, err)
+		db.Provider.DeleteUser(ctx, user)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyEnableLoginCode, false)
+	})
+}
@@ -0,0 +1,45 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/middlewares"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+)
+
+func metricsTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should expose metrics`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		_, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef("metrics_" + s.TestInfo.Email),
+			Password: s.TestInfo.Password,
+		})
+		assert.Error(t, err)
+
+		router := gin.New()
+		router.Use(middlewares.MetricsMiddleware())
+		router.GET("/health", handlers.HealthHandler())
+		router.GET("/metrics", handlers.MetricsHandler())
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		body := w.Body.String()
+		assert.Contains(t, body, `authorizer_http_request_duration_seconds_count{method="GET",route="/health",status="200"}`)
+		assert.Contains(t, body, `authorizer_auth_events_total{event="login",outcome="failure",recipe="basic_auth"}`)
+		assert.Contains(t, body, `authorizer_db_call_duration_seconds_count{method="GetUserByEmail",status="error"}`)
+	})
+}
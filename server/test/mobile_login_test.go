@@ -42,6 +42,8 @@ func mobileLoginTests(t *testing.T, s TestSetup) {
 		smsRequest, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, smsRequest.Otp)
+		otpCode, err := renewOTP(ctx, "", phoneNumber)
+		assert.NoError(t, err)
 		// Get user by phone number
 		user, err := db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
 		assert.NoError(t, err)
@@ -55,7 +57,7 @@ func mobileLoginTests(t *testing.T, s TestSetup) {
 		req.Header.Set("Cookie", cookie)
 		verifySMSRequest, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
 			PhoneNumber: &phoneNumber,
-			Otp:         smsRequest.Otp,
+			Otp:         otpCode,
 		})
 		assert.Nil(t, err)
 		assert.NotEqual(t, verifySMSRequest.Message, "", "message should not be empty")
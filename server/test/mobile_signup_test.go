@@ -81,6 +81,8 @@ func mobileSingupTest(t *testing.T, s TestSetup) {
 		otp, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
 		assert.Nil(t, err)
 		assert.NotEmpty(t, otp.Otp)
+		otpCode, err := renewOTP(ctx, "", phoneNumber)
+		assert.NoError(t, err)
 		// Get user by phone number
 		user, err := db.Provider.GetUserByPhoneNumber(ctx, phoneNumber)
 		assert.NoError(t, err)
@@ -94,7 +96,7 @@ func mobileSingupTest(t *testing.T, s TestSetup) {
 		req.Header.Set("Cookie", cookie)
 		otpRes, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
 			PhoneNumber: refs.NewStringRef(phoneNumber),
-			Otp:         otp.Otp,
+			Otp:         otpCode,
 		})
 		assert.Nil(t, err)
 		assert.NotEmpty(t, otpRes.Message)
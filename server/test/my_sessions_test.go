@@ -0,0 +1,81 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func mySessionsTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should list and revoke sessions of user`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "my_sessions." + s.TestInfo.Email
+
+		_, err := resolvers.MySessionsResolver(ctx)
+		assert.NotNil(t, err, "unauthorized")
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		// sessions are recorded asynchronously
+		time.Sleep(time.Second)
+
+		s.GinContext.Request.Header.Set("Authorization", "Bearer "+*loginRes.AccessToken)
+		ctx = context.WithValue(req.Context(), "GinContextKey", s.GinContext)
+		sessions, err := resolvers.MySessionsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		var otherSession *model.Session
+		currentSessions := 0
+		for _, session := range sessions {
+			assert.Equal(t, constants.AuthRecipeMethodBasicAuth, refs.StringValue(session.LoginMethod))
+			if session.IsCurrent {
+				currentSessions++
+			} else {
+				otherSession = session
+			}
+		}
+		assert.Equal(t, 1, currentSessions)
+		assert.NotNil(t, otherSession)
+
+		_, err = resolvers.RevokeSessionResolver(ctx, model.RevokeSessionInput{
+			ID: "invalid",
+		})
+		assert.Error(t, err)
+
+		res, err := resolvers.RevokeSessionResolver(ctx, model.RevokeSessionInput{
+			ID: otherSession.ID,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+
+		// current session should still be valid
+		sessions, err = resolvers.MySessionsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.True(t, sessions[0].IsCurrent)
+		cleanData(email)
+	})
+}
@@ -0,0 +1,212 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+)
+
+func organizationTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should manage organizations and switch organization of session`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "organization." + s.TestInfo.Email
+
+		_, err := resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name: "acme",
+		})
+		assert.Error(t, err, "unauthorized")
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		adminCookie := fmt.Sprintf("%s=%s", constants.AdminCookieName, h)
+		req.Header.Set("Cookie", adminCookie)
+
+		_, err = resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name:         "acme",
+			Roles:        []string{"owner", "member"},
+			DefaultRoles: []string{"admin"},
+		})
+		assert.Error(t, err, "default roles should be subset of roles")
+		organization, err := resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name:         "acme",
+			Roles:        []string{"owner", "member"},
+			DefaultRoles: []string{"member"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"owner", "member"}, organization.Roles)
+		assert.Equal(t, []string{"member"}, organization.DefaultRoles)
+		_, err = resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name: "acme",
+		})
+		assert.Error(t, err, "organization already exists")
+		otherOrganization, err := resolvers.AddOrganizationResolver(ctx, model.AddOrganizationRequest{
+			Name: "globex",
+		})
+		assert.NoError(t, err)
+		// roles default to global roles
+		defaultRoles, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyDefaultRoles)
+		assert.NoError(t, err)
+		assert.Equal(t, defaultRoles, strings.Join(otherOrganization.DefaultRoles, ","))
+
+		otherOrganization, err = resolvers.UpdateOrganizationResolver(ctx, model.UpdateOrganizationRequest{
+			ID:   otherOrganization.ID,
+			Name: refs.NewStringRef("globex corp"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "globex corp", otherOrganization.Name)
+		_, err = resolvers.UpdateOrganizationResolver(ctx, model.UpdateOrganizationRequest{
+			ID:   otherOrganization.ID,
+			Name: refs.NewStringRef("acme"),
+		})
+		assert.Error(t, err, "organization already exists")
+
+		organizations, err := resolvers.OrganizationsResolver(ctx, &model.PaginatedInput{})
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, len(organizations.Organizations), 2)
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		userID := verifyRes.User.ID
+
+		_, err = resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organization.ID,
+			Email:          refs.NewStringRef(email),
+			Roles:          []string{"admin"},
+		})
+		assert.Error(t, err, "invalid roles")
+		membership, err := resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organization.ID,
+			Email:          refs.NewStringRef(email),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, userID, membership.UserID)
+		assert.Equal(t, []string{"member"}, membership.Roles)
+		_, err = resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organization.ID,
+			UserID:         refs.NewStringRef(userID),
+		})
+		assert.Error(t, err, "user is already member")
+
+		members, err := resolvers.OrganizationMembersResolver(ctx, model.OrganizationRequest{
+			ID: organization.ID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, members, 1)
+		assert.Equal(t, email, refs.StringValue(members[0].User.Email))
+
+		// organization is selected for the session of member
+		req.Header.Set("Cookie", sessionCookie(t, *verifyRes.AccessToken))
+		memberships, err := resolvers.MyOrganizationsResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, memberships, 1)
+		assert.Equal(t, "acme", memberships[0].Organization.Name)
+
+		_, err = resolvers.SwitchOrganizationResolver(ctx, model.SwitchOrganizationInput{
+			OrganizationID: refs.NewStringRef(otherOrganization.ID),
+		})
+		assert.Error(t, err, "user is not member of organization")
+		switchRes, err := resolvers.SwitchOrganizationResolver(ctx, model.SwitchOrganizationInput{
+			OrganizationID: refs.NewStringRef(organization.ID),
+		})
+		assert.NoError(t, err)
+		organizationClaim := getOrganizationClaim(t, *switchRes.AccessToken)
+		assert.NotNil(t, organizationClaim)
+		assert.Equal(t, organization.ID, organizationClaim["id"])
+		assert.Equal(t, "acme", organizationClaim["name"])
+		assert.Equal(t, []interface{}{"member"}, organizationClaim["roles"])
+
+		// updated roles are reflected when session is refreshed
+		req.Header.Set("Cookie", adminCookie)
+		_, err = resolvers.UpdateOrganizationMemberResolver(ctx, model.UpdateOrganizationMemberRequest{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+			Roles:          []string{"owner"},
+		})
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", sessionCookie(t, *switchRes.AccessToken))
+		sessionRes, err := resolvers.SessionResolver(ctx, &model.SessionQueryInput{})
+		assert.NoError(t, err)
+		organizationClaim = getOrganizationClaim(t, *sessionRes.AccessToken)
+		assert.NotNil(t, organizationClaim)
+		assert.Equal(t, []interface{}{"owner"}, organizationClaim["roles"])
+
+		// organization claim is dropped once user is removed from organization
+		req.Header.Set("Cookie", adminCookie)
+		_, err = resolvers.RemoveOrganizationMemberResolver(ctx, model.RemoveOrganizationMemberRequest{
+			OrganizationID: organization.ID,
+			UserID:         userID,
+		})
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", sessionCookie(t, *sessionRes.AccessToken))
+		sessionRes, err = resolvers.SessionResolver(ctx, &model.SessionQueryInput{})
+		assert.NoError(t, err)
+		assert.Nil(t, getOrganizationClaim(t, *sessionRes.AccessToken))
+
+		// memberships are deleted with organization
+		req.Header.Set("Cookie", adminCookie)
+		_, err = resolvers.AddOrganizationMemberResolver(ctx, model.AddOrganizationMemberRequest{
+			OrganizationID: organization.ID,
+			UserID:         refs.NewStringRef(userID),
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.DeleteOrganizationResolver(ctx, model.OrganizationRequest{
+			ID: organization.ID,
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.OrganizationResolver(ctx, model.OrganizationRequest{
+			ID: organization.ID,
+		})
+		assert.Error(t, err, "organization not found")
+		remainingMemberships, err := db.Provider.ListMembershipsByUserID(ctx, userID)
+		assert.NoError(t, err)
+		assert.Len(t, remainingMemberships, 0)
+		_, err = resolvers.DeleteOrganizationResolver(ctx, model.OrganizationRequest{
+			ID: otherOrganization.ID,
+		})
+		assert.NoError(t, err)
+
+		cleanData(email)
+	})
+}
+
+// sessionCookie returns the session cookie of session to which access token was issued
+func sessionCookie(t *testing.T, accessToken string) string {
+	claims, err := token.ParseJWTToken(accessToken)
+	assert.NoError(t, err)
+	sessionKey := claims["login_method"].(string) + ":" + claims["sub"].(string)
+	sessionToken, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+claims["nonce"].(string))
+	assert.NoError(t, err)
+	return fmt.Sprintf("%s=%s", constants.AppCookieName+"_session", sessionToken)
+}
+
+// getOrganizationClaim returns the organization claim of access token
+func getOrganizationClaim(t *testing.T, accessToken string) map[string]interface{} {
+	claims, err := token.ParseJWTToken(accessToken)
+	assert.NoError(t, err)
+	organizationClaim, _ := claims["organization"].(map[string]interface{})
+	return organizationClaim
+}
@@ -0,0 +1,125 @@
+package test
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func otpTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should generate otp of configured length and charset`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPLength, "8")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPCharset, constants.OTPCharsetAlphanumeric)
+		otp, err := utils.GenerateOTP(false)
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^[A-Z1-9]{8}$`), otp)
+
+		// sms otp is always numeric
+		otp, err = utils.GenerateOTP(true)
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9]{8}$`), otp)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPCharset, constants.OTPCharsetNumeric)
+		otp, err = utils.GenerateOTP(false)
+		assert.NoError(t, err)
+		assert.Regexp(t, regexp.MustCompile(`^[0-9]{8}$`), otp)
+
+		_, err = utils.ParseOTPLength("2")
+		assert.Error(t, err)
+		assert.Error(t, utils.ValidateOTPCharset("hex"))
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPLength, "6")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPCharset, constants.OTPCharsetAlphanumeric)
+	})
+
+	t.Run(`should save hash of otp`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "otp_hash." + s.TestInfo.Email
+		otp, err := utils.CreateOTP(ctx, email, "", false, time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		otpData, err := db.Provider.GetOTPByEmail(ctx, email)
+		assert.NoError(t, err)
+		assert.NotEqual(t, otp, otpData.Otp)
+		hashedOTP, err := crypto.HashOTP(otp)
+		assert.NoError(t, err)
+		assert.Equal(t, hashedOTP, otpData.Otp)
+		assert.NoError(t, utils.VerifyOTP(ctx, otpData, otp))
+		// hash itself should not be accepted as otp
+		assert.ErrorIs(t, utils.VerifyOTP(ctx, otpData, otpData.Otp), utils.ErrInvalidOTP)
+		cleanData(email)
+	})
+
+	t.Run(`should not verify expired otp`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "otp_expired." + s.TestInfo.Email
+		otp, err := utils.CreateOTP(ctx, email, "", false, time.Now().Add(-time.Minute).Unix())
+		assert.NoError(t, err)
+		otpData, err := db.Provider.GetOTPByEmail(ctx, email)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, utils.VerifyOTP(ctx, otpData, otp), utils.ErrOTPExpired)
+		cleanData(email)
+	})
+
+	t.Run(`should invalidate otp after max attempts`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		phoneNumber := "2234567890"
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPMaxAttempts, "3")
+		otp, err := utils.CreateOTP(ctx, "", phoneNumber, true, time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			otpData, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+			assert.NoError(t, err)
+			assert.ErrorIs(t, utils.VerifyOTP(ctx, otpData, "wrong"), utils.ErrInvalidOTP)
+		}
+		otpData, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), otpData.Attempts)
+		assert.ErrorIs(t, utils.VerifyOTP(ctx, otpData, "wrong"), utils.ErrOTPAttemptsExceeded)
+
+		// correct otp is rejected as well once attempts are exhausted
+		otpData, err = db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+		assert.NoError(t, err)
+		assert.ErrorIs(t, utils.VerifyOTP(ctx, otpData, otp), utils.ErrOTPAttemptsExceeded)
+
+		// new otp resets the attempts
+		otp, err = utils.CreateOTP(ctx, "", phoneNumber, true, time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		otpData, err = db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), otpData.Attempts)
+		assert.NoError(t, utils.VerifyOTP(ctx, otpData, otp))
+		db.Provider.DeleteOTP(ctx, otpData)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPMaxAttempts, "5")
+	})
+
+	t.Run(`should count attempts made with stale otp data`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		phoneNumber := "2234567891"
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPMaxAttempts, "3")
+		otp, err := utils.CreateOTP(ctx, "", phoneNumber, true, time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		// concurrent requests read the otp before any of them is counted
+		otpData, err := db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+		assert.NoError(t, err)
+		for i := 0; i < 5; i++ {
+			staleOTPData := *otpData
+			assert.Error(t, utils.VerifyOTP(ctx, &staleOTPData, "wrong"))
+		}
+		staleOTPData := *otpData
+		assert.ErrorIs(t, utils.VerifyOTP(ctx, &staleOTPData, otp), utils.ErrOTPAttemptsExceeded)
+		otpData, err = db.Provider.GetOTPByPhoneNumber(ctx, phoneNumber)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(6), otpData.Attempts)
+		db.Provider.DeleteOTP(ctx, otpData)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyOTPMaxAttempts, "5")
+	})
+}
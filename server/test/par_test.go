@@ -0,0 +1,266 @@
+package test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/square/go-jose.v2"
+)
+
+// createRequestObject signs the authorization request params as request object,
+// new jti is added to it unless claims has one
+func createRequestObject(t *testing.T, key *ecdsa.PrivateKey, claims map[string]interface{}) string {
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key}, (&jose.SignerOptions{}).WithHeader("kid", "request-key"))
+	assert.NoError(t, err)
+	requestObjectClaims := map[string]interface{}{"jti": uuid.New().String()}
+	for k, v := range claims {
+		requestObjectClaims[k] = v
+	}
+	payload, err := json.Marshal(requestObjectClaims)
+	assert.NoError(t, err)
+	jws, err := signer.Sign(payload)
+	assert.NoError(t, err)
+	requestObject, err := jws.CompactSerialize()
+	assert.NoError(t, err)
+	return requestObject
+}
+
+// pushAuthorizationRequest calls pushed authorization request endpoint with given form
+func pushAuthorizationRequest(s TestSetup, form url.Values) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "http://"+s.Server.Listener.Addr().String()+"/oauth/par", strings.NewReader(form.Encode()))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handlers.PushedAuthorizationRequestHandler()(c)
+	res := map[string]interface{}{}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func parTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should authorize with pushed authorization requests and request objects`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "initial-token")
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "")
+
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		redirectURI := "https://par.example.com/callback"
+		status, res := clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"redirect_uris": []string{redirectURI},
+			"jwks": jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				{Key: key.Public(), KeyID: "request-key", Algorithm: string(jose.ES256), Use: "sig"},
+			}},
+			"require_pushed_authorization_requests": true,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, true, res["require_pushed_authorization_requests"])
+		clientID := res["client_id"].(string)
+		clientSecret := res["client_secret"].(string)
+		registrationAccessToken := res["registration_access_token"].(string)
+		defer clientRegistrationRequest(s, http.MethodDelete, clientID, registrationAccessToken, nil)
+
+		_, codeChallenge := utils.GenerateCodeChallenge()
+		authorizeParams := url.Values{
+			"client_id":      {clientID},
+			"response_type":  {"code"},
+			"response_mode":  {"query"},
+			"state":          {"par-state"},
+			"code_challenge": {codeChallenge},
+			"redirect_uri":   {redirectURI},
+		}
+		authorize := func(query url.Values) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/authorize?"+query.Encode(), nil)
+			handlers.AuthorizeHandler()(c)
+			return w
+		}
+		// login is required, so authorize redirects to login page with the state of the request
+		assertLoginRedirect := func(w *httptest.ResponseRecorder, state string) {
+			assert.Equal(t, http.StatusFound, w.Code)
+			location, err := url.Parse(w.Header().Get("Location"))
+			assert.NoError(t, err)
+			assert.Equal(t, "/app", location.Path)
+			assert.Equal(t, state, location.Query().Get("state"))
+			assert.Equal(t, redirectURI, location.Query().Get("redirect_uri"))
+		}
+
+		// front channel params are not accepted when client requires PAR
+		w := authorize(authorizeParams)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		form := url.Values{"client_secret": {clientSecret}}
+		for k, v := range authorizeParams {
+			form[k] = v
+		}
+		form.Set("client_secret", "invalid-secret")
+		status, res = pushAuthorizationRequest(s, form)
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "invalid_client", res["error"])
+
+		form.Set("client_secret", clientSecret)
+		form.Set("redirect_uri", "https://evil.example.com/callback")
+		status, _ = pushAuthorizationRequest(s, form)
+		assert.Equal(t, http.StatusBadRequest, status)
+
+		form.Set("redirect_uri", redirectURI)
+		status, res = pushAuthorizationRequest(s, form)
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, float64(60), res["expires_in"])
+		requestURI := res["request_uri"].(string)
+		assert.True(t, strings.HasPrefix(requestURI, "urn:ietf:params:oauth:request_uri:"))
+
+		// request_uri can only be used by the client it was issued to
+		envClientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+		w = authorize(url.Values{"client_id": {envClientID}, "request_uri": {requestURI}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		w = authorize(url.Values{"client_id": {clientID}, "request_uri": {"urn:ietf:params:oauth:request_uri:unknown"}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// front channel params other than client_id and request_uri are ignored
+		w = authorize(url.Values{"client_id": {clientID}, "request_uri": {requestURI}, "state": {"front-channel-state"}})
+		assertLoginRedirect(w, "par-state")
+
+		// request_uri can only be used once
+		w = authorize(url.Values{"client_id": {clientID}, "request_uri": {requestURI}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// only one of the concurrent requests with same request_uri can use it
+		status, res = pushAuthorizationRequest(s, form)
+		assert.Equal(t, http.StatusCreated, status)
+		requestURI = res["request_uri"].(string)
+		codes := make(chan int, 5)
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				codes <- authorize(url.Values{"client_id": {clientID}, "request_uri": {requestURI}}).Code
+			}()
+		}
+		wg.Wait()
+		close(codes)
+		usedCount := 0
+		for code := range codes {
+			if code == http.StatusFound {
+				usedCount++
+				continue
+			}
+			assert.Equal(t, http.StatusBadRequest, code)
+		}
+		assert.Equal(t, 1, usedCount)
+
+		// request_uri marked used by concurrent request cannot be used, even though the request is not deleted yet
+		status, res = pushAuthorizationRequest(s, form)
+		assert.Equal(t, http.StatusCreated, status)
+		requestURI = res["request_uri"].(string)
+		requestID := strings.TrimPrefix(requestURI, "urn:ietf:params:oauth:request_uri:")
+		err = memorystore.Provider.SetUserSession("pushed_authorization_request_used", requestID, requestID, time.Now().Add(time.Minute).Unix())
+		assert.NoError(t, err)
+		w = authorize(url.Values{"client_id": {clientID}, "request_uri": {requestURI}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		claims := map[string]interface{}{
+			"iss":            clientID,
+			"aud":            "http://" + s.Server.Listener.Addr().String(),
+			"exp":            time.Now().Add(time.Minute).Unix(),
+			"client_id":      clientID,
+			"response_type":  "code",
+			"response_mode":  "query",
+			"state":          "request-object-state",
+			"code_challenge": codeChallenge,
+			"redirect_uri":   redirectURI,
+		}
+		status, res = pushAuthorizationRequest(s, url.Values{
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"request":       {createRequestObject(t, otherKey, claims)},
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "invalid_request_object", res["error"])
+
+		status, res = pushAuthorizationRequest(s, url.Values{
+			"client_id":     {clientID},
+			"client_secret": {clientSecret},
+			"request":       {createRequestObject(t, key, claims)},
+		})
+		assert.Equal(t, http.StatusCreated, status)
+		w = authorize(url.Values{"client_id": {clientID}, "request_uri": {res["request_uri"].(string)}})
+		assertLoginRedirect(w, "request-object-state")
+
+		// request object can be sent to authorize directly once PAR is not required
+		status, _ = clientRegistrationRequest(s, http.MethodPut, clientID, registrationAccessToken, map[string]interface{}{
+			"redirect_uris": []string{redirectURI},
+			"jwks": jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+				{Key: key.Public(), KeyID: "request-key", Algorithm: string(jose.ES256), Use: "sig"},
+			}},
+		})
+		assert.Equal(t, http.StatusOK, status)
+		w = authorize(url.Values{"client_id": {clientID}, "request": {createRequestObject(t, key, claims)}})
+		assertLoginRedirect(w, "request-object-state")
+
+		// request object with same jti cannot be replayed until it expires
+		claims["jti"] = uuid.New().String()
+		requestObject := createRequestObject(t, key, claims)
+		w = authorize(url.Values{"client_id": {clientID}, "request": {requestObject}})
+		assertLoginRedirect(w, "request-object-state")
+		w = authorize(url.Values{"client_id": {clientID}, "request": {requestObject}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "jti already used")
+		// request object signed with symmetric alg is not accepted
+		hmacSigner, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.HS256, Key: []byte(clientSecret)}, nil)
+		assert.NoError(t, err)
+		claims["jti"] = uuid.New().String()
+		payload, err := json.Marshal(claims)
+		assert.NoError(t, err)
+		jws, err := hmacSigner.Sign(payload)
+		assert.NoError(t, err)
+		requestObject, err = jws.CompactSerialize()
+		assert.NoError(t, err)
+		w = authorize(url.Values{"client_id": {clientID}, "request": {requestObject}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "unsupported request object signing alg HS256")
+		claims["jti"] = ""
+		w = authorize(url.Values{"client_id": {clientID}, "request": {createRequestObject(t, key, claims)}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "jti is required")
+		delete(claims, "jti")
+
+		claims["exp"] = time.Now().Add(-time.Minute).Unix()
+		w = authorize(url.Values{"client_id": {clientID}, "request": {createRequestObject(t, key, claims)}})
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		// keys are not fetched from jwks_uri of internal address
+		claims["exp"] = time.Now().Add(time.Minute).Unix()
+		for _, jwksURI := range []string{"https://127.0.0.1:8443/jwks", "https://169.254.169.254/jwks", "https://10.0.0.1/jwks"} {
+			status, _ = clientRegistrationRequest(s, http.MethodPut, clientID, registrationAccessToken, map[string]interface{}{
+				"redirect_uris": []string{redirectURI},
+				"jwks_uri":      jwksURI,
+			})
+			assert.Equal(t, http.StatusOK, status)
+			w = authorize(url.Values{"client_id": {clientID}, "request": {createRequestObject(t, key, claims)}})
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), "is not allowed", jwksURI)
+		}
+	})
+}
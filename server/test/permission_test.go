@@ -0,0 +1,249 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/env"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func permissionTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should grant permissions with roles`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "permission." + s.TestInfo.Email
+
+		_, err := resolvers.AddPermissionResolver(ctx, model.AddPermissionRequest{
+			Name: "invoice:read",
+		})
+		assert.Error(t, err, "unauthorized")
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		adminCookie := fmt.Sprintf("%s=%s", constants.AdminCookieName, h)
+		req.Header.Set("Cookie", adminCookie)
+
+		_, err = resolvers.AddPermissionResolver(ctx, model.AddPermissionRequest{
+			Name:        "invoice:read",
+			Description: refs.NewStringRef("read invoices"),
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.AddPermissionResolver(ctx, model.AddPermissionRequest{
+			Name: "invoice:read",
+		})
+		assert.Error(t, err, "permission already exists")
+		_, err = resolvers.AddPermissionResolver(ctx, model.AddPermissionRequest{
+			Name: "invoice:write",
+		})
+		assert.NoError(t, err)
+		permissions, err := resolvers.PermissionsResolver(ctx, &model.PaginatedInput{})
+		assert.NoError(t, err)
+		assert.Len(t, permissions.Permissions, 2)
+
+		// configured roles have definitions
+		role, err := db.Provider.GetRoleByName(ctx, "user")
+		assert.NoError(t, err)
+		assert.Empty(t, role.GetPermissions())
+
+		_, err = resolvers.AddRoleResolver(ctx, model.AddRoleRequest{
+			Name:        "accountant",
+			Permissions: []string{"invoice:read", "invoice:delete"},
+		})
+		assert.Error(t, err, "permission not found")
+		accountant, err := resolvers.AddRoleResolver(ctx, model.AddRoleRequest{
+			Name:        "accountant",
+			Permissions: []string{"invoice:read"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"invoice:read"}, accountant.Permissions)
+		roles, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+		assert.NoError(t, err)
+		assert.Contains(t, strings.Split(roles, ","), "accountant")
+
+		// role granting permissions is not removed with roles configuration
+		_, err = resolvers.UpdateEnvResolver(ctx, model.UpdateEnvInput{
+			Roles: utils.DeleteFromArray(strings.Split(roles, ","), []string{"accountant"}),
+		})
+		assert.Error(t, err)
+		assert.NoError(t, memorystore.Provider.UpdateEnvVariable(constants.EnvKeyRoles, strings.Join(utils.DeleteFromArray(strings.Split(roles, ","), []string{"accountant"}), ",")))
+		assert.NoError(t, env.SyncRoles(ctx))
+		_, err = db.Provider.GetRoleByName(ctx, "accountant")
+		assert.NoError(t, err)
+		roles, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+		assert.NoError(t, err)
+		assert.Contains(t, strings.Split(roles, ","), "accountant")
+
+		_, err = resolvers.UpdateRoleResolver(ctx, model.UpdateRoleRequest{
+			Name:        "user",
+			Permissions: []string{"invoice:write"},
+		})
+		assert.NoError(t, err)
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+			Roles:           []string{"user", "accountant"},
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		userID := verifyRes.User.ID
+		claims, err := token.ParseJWTToken(*verifyRes.AccessToken)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []interface{}{"invoice:read", "invoice:write"}, claims["permissions"])
+
+		checkRes, err := resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			UserID:     refs.NewStringRef(userID),
+			Permission: "invoice:read",
+		})
+		assert.NoError(t, err)
+		assert.True(t, checkRes.Allowed)
+
+		// users can check their own permissions
+		req.Header.Set("Cookie", "")
+		req.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		checkRes, err = resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			Permission: "invoice:write",
+		})
+		assert.NoError(t, err)
+		assert.True(t, checkRes.Allowed)
+		checkRes, err = resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			Permission: "invoice:delete",
+		})
+		assert.NoError(t, err)
+		assert.False(t, checkRes.Allowed)
+		_, err = resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			UserID:     refs.NewStringRef("other-user"),
+			Permission: "invoice:read",
+		})
+		assert.Error(t, err, "unauthorized")
+		req.Header.Del("Authorization")
+
+		// deleted permission is removed from the roles granting it
+		req.Header.Set("Cookie", adminCookie)
+		_, err = resolvers.DeletePermissionResolver(ctx, model.PermissionRequest{
+			Name: "invoice:read",
+		})
+		assert.NoError(t, err)
+		role, err = db.Provider.GetRoleByName(ctx, "accountant")
+		assert.NoError(t, err)
+		assert.Empty(t, role.GetPermissions())
+		checkRes, err = resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			UserID:     refs.NewStringRef(userID),
+			Permission: "invoice:read",
+		})
+		assert.NoError(t, err)
+		assert.False(t, checkRes.Allowed)
+
+		_, err = resolvers.DeleteRoleResolver(ctx, model.RoleRequest{
+			Name: "accountant",
+		})
+		assert.NoError(t, err)
+		roles, err = memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyRoles)
+		assert.NoError(t, err)
+		assert.NotContains(t, strings.Split(roles, ","), "accountant")
+		_, err = db.Provider.GetRoleByName(ctx, "accountant")
+		assert.Error(t, err, "role not found")
+
+		_, err = resolvers.DeletePermissionResolver(ctx, model.PermissionRequest{
+			Name: "invoice:write",
+		})
+		assert.NoError(t, err)
+		cleanData(email)
+	})
+
+	t.Run(`should not grant permissions of expired role grants`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "permission_expired_grant." + s.TestInfo.Email
+		// background sweep is disabled, so expired grants stay on user
+		requiredEnv := memorystore.RequiredEnvStoreObj.GetRequiredEnv()
+		sweepDisabledEnv := requiredEnv
+		sweepDisabledEnv.RoleGrantSweepInterval = "0"
+		memorystore.RequiredEnvStoreObj.SetRequiredEnv(sweepDisabledEnv)
+		defer memorystore.RequiredEnvStoreObj.SetRequiredEnv(requiredEnv)
+		interval, err := utils.GetRoleGrantSweepInterval()
+		assert.NoError(t, err)
+		assert.Zero(t, interval)
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		_, err = resolvers.AddPermissionResolver(ctx, model.AddPermissionRequest{
+			Name: "report:read",
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.AddRoleResolver(ctx, model.AddRoleRequest{
+			Name:        "auditor",
+			Permissions: []string{"report:read"},
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+		_, err = resolvers.GrantRoleResolver(ctx, model.GrantRoleInput{
+			UserID:    user.ID,
+			Role:      "auditor",
+			ExpiresAt: refs.NewInt64Ref(time.Now().Unix() + 3600),
+		})
+		assert.NoError(t, err)
+		checkRes, err := resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			UserID:     refs.NewStringRef(user.ID),
+			Permission: "report:read",
+		})
+		assert.NoError(t, err)
+		assert.True(t, checkRes.Allowed)
+
+		grant, err := db.Provider.GetRoleGrantByUserIDAndRole(ctx, user.ID, "auditor")
+		assert.NoError(t, err)
+		grant.ExpiresAt = time.Now().Unix() - 1
+		_, err = db.Provider.UpdateRoleGrant(ctx, grant)
+		assert.NoError(t, err)
+		user, err = db.Provider.GetUserByID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.Contains(t, strings.Split(user.Roles, ","), "auditor")
+		checkRes, err = resolvers.CheckPermissionResolver(ctx, model.CheckPermissionInput{
+			UserID:     refs.NewStringRef(user.ID),
+			Permission: "report:read",
+		})
+		assert.NoError(t, err)
+		assert.False(t, checkRes.Allowed)
+
+		_, err = resolvers.DeleteRoleResolver(ctx, model.RoleRequest{
+			Name: "auditor",
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.DeletePermissionResolver(ctx, model.PermissionRequest{
+			Name: "report:read",
+		})
+		assert.NoError(t, err)
+		cleanData(email)
+	})
+}
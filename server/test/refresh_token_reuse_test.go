@@ -0,0 +1,191 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// refreshTokenGrant calls token handler with refresh token grant
+func refreshTokenGrant(s TestSetup, clientID, refreshToken string) (int, map[string]interface{}) {
+	return refreshTokenGrantWithDPoP(s, clientID, refreshToken, "")
+}
+
+// refreshTokenGrantWithDPoP calls token handler with refresh token grant and DPoP proof
+func refreshTokenGrantWithDPoP(s TestSetup, clientID, refreshToken, dpopProof string) (int, map[string]interface{}) {
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("client_id", clientID)
+	form.Set("refresh_token", refreshToken)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "http://"+s.Server.Listener.Addr().String()+"/oauth/token", strings.NewReader(form.Encode()))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if dpopProof != "" {
+		c.Request.Header.Set("DPoP", dpopProof)
+	}
+	handlers.TokenHandler()(c)
+	res := map[string]interface{}{}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func refreshTokenReuseTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should revoke refresh token family on reuse`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "refresh_token_reuse." + s.TestInfo.Email
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+			Scope:    []string{"openid", "email", "profile", "offline_access"},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, loginRes.RefreshToken)
+
+		// rotate refresh token
+		code, res := refreshTokenGrant(s, clientID, *loginRes.RefreshToken)
+		assert.Equal(t, http.StatusOK, code)
+		rotatedRefreshToken, ok := res["refresh_token"].(string)
+		assert.True(t, ok)
+		assert.NotEqual(t, *loginRes.RefreshToken, rotatedRefreshToken)
+
+		// presenting already rotated refresh token revokes the family
+		code, res = refreshTokenGrant(s, clientID, *loginRes.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Contains(t, res["error_description"], "reused")
+		code, _ = refreshTokenGrant(s, clientID, rotatedRefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+
+		// reuse is recorded
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+		refreshTokenReuses, err := db.Provider.ListRefreshTokenReusesByUserID(ctx, user.ID)
+		assert.NoError(t, err)
+		if assert.Len(t, refreshTokenReuses, 1) {
+			assert.NotEmpty(t, refreshTokenReuses[0].FamilyID)
+			assert.NotEmpty(t, refreshTokenReuses[0].RevokedNonce)
+			assert.Equal(t, constants.AuthRecipeMethodBasicAuth, refreshTokenReuses[0].LoginMethod)
+
+			// refresh token rotated concurrently with revocation is not handed out
+			sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+			err = token.RotateRefreshTokenFamily(sessionKey, refreshTokenReuses[0].FamilyID, "concurrent_nonce", time.Now().Add(time.Minute).Unix())
+			assert.ErrorIs(t, err, token.ErrRefreshTokenReused)
+		}
+		cleanData(email)
+	})
+
+	t.Run(`should not rotate refresh token twice with concurrent requests`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "refresh_token_concurrent." + s.TestInfo.Email
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+			Scope:    []string{"openid", "email", "profile", "offline_access"},
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, loginRes.RefreshToken)
+
+		type refreshResult struct {
+			code int
+			res  map[string]interface{}
+		}
+		results := make(chan refreshResult, 2)
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				code, res := refreshTokenGrant(s, clientID, *loginRes.RefreshToken)
+				results <- refreshResult{code, res}
+			}()
+		}
+		wg.Wait()
+		close(results)
+
+		rotatedRefreshTokens := []string{}
+		for result := range results {
+			if result.code == http.StatusOK {
+				rotatedRefreshTokens = append(rotatedRefreshTokens, result.res["refresh_token"].(string))
+				continue
+			}
+			assert.Equal(t, http.StatusUnauthorized, result.code)
+			assert.Contains(t, result.res["error_description"], "reused")
+		}
+		// only one request can rotate the token, and the family is revoked because of the other one
+		assert.LessOrEqual(t, len(rotatedRefreshTokens), 1)
+		for _, rotatedRefreshToken := range rotatedRefreshTokens {
+			code, _ := refreshTokenGrant(s, clientID, rotatedRefreshToken)
+			assert.Equal(t, http.StatusUnauthorized, code)
+		}
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+		refreshTokenReuses, err := db.Provider.ListRefreshTokenReusesByUserID(ctx, user.ID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, refreshTokenReuses)
+
+		// request presenting the token while concurrent request is rotating it is reuse,
+		// even though the token is not deleted yet
+		loginRes, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+			Scope:    []string{"openid", "email", "profile", "offline_access"},
+		})
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(*loginRes.RefreshToken)
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+		err = token.MarkRefreshTokenUsed(sessionKey, token.GetRefreshTokenFamilyID(claims), claims["nonce"].(string), claims["exp"].(int64))
+		assert.NoError(t, err)
+		code, res := refreshTokenGrant(s, clientID, *loginRes.RefreshToken)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		assert.Contains(t, res["error_description"], "reused")
+		cleanData(email)
+	})
+}
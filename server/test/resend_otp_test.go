@@ -73,6 +73,10 @@ func resendOTPTest(t *testing.T, s TestSetup) {
 		otp, err := db.Provider.GetOTPByEmail(ctx, email)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, otp.Otp)
+		otpCode, err := renewOTP(ctx, email, "")
+		assert.NoError(t, err)
+		otp, err = db.Provider.GetOTPByEmail(ctx, email)
+		assert.NoError(t, err)
 
 		// resend otp
 		resendOtpRes, err := resolvers.ResendOTPResolver(ctx, model.ResendOTPRequest{
@@ -84,12 +88,12 @@ func resendOTPTest(t *testing.T, s TestSetup) {
 		newOtp, err := db.Provider.GetOTPByEmail(ctx, email)
 		assert.NoError(t, err)
 		assert.NotEmpty(t, newOtp.Otp)
-		assert.NotEqual(t, otp.Otp, newOtp)
+		assert.NotEqual(t, otp.Otp, newOtp.Otp)
 
 		// Should return error for older otp
 		verifyOtpRes, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
 			Email: &email,
-			Otp:   otp.Otp,
+			Otp:   otpCode,
 		})
 		assert.Error(t, err)
 		assert.Nil(t, verifyOtpRes)
@@ -103,9 +107,11 @@ func resendOTPTest(t *testing.T, s TestSetup) {
 		cookie := fmt.Sprintf("%s=%s;", constants.MfaCookieName+"_session", mfaSession)
 		cookie = strings.TrimSuffix(cookie, ";")
 		req.Header.Set("Cookie", cookie)
+		newOtpCode, err := renewOTP(ctx, email, "")
+		assert.NoError(t, err)
 		verifyOtpRes, err = resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
 			Email: &email,
-			Otp:   newOtp.Otp,
+			Otp:   newOtpCode,
 		})
 		assert.NoError(t, err)
 		assert.NotEqual(t, verifyOtpRes.AccessToken, "", "access token should not be empty")
@@ -0,0 +1,148 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+)
+
+func revertChange(s TestSetup, revertToken string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/revert_change?token="+revertToken, nil)
+	handlers.RevertChangeHandler()(c)
+	return w
+}
+
+func revertChangeTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should verify phone number change and revert email and phone number change`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "revert_change." + s.TestInfo.Email
+		newEmail := "new_" + email
+		phoneNumber := "+919999000101"
+		newPhoneNumber := "+919999000102"
+		otherPhoneNumber := "+919999000103"
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyIsSMSServiceEnabled, true)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyDisablePhoneVerification, false)
+
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		userID := verifyRes.User.ID
+		req.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		ctx = context.WithValue(req.Context(), "GinContextKey", s.GinContext)
+
+		// phone number is updated once otp sent to it is verified
+		_, err = resolvers.UpdateProfileResolver(ctx, model.UpdateProfileInput{
+			PhoneNumber: refs.NewStringRef(phoneNumber),
+		})
+		assert.NoError(t, err)
+		user, err := db.Provider.GetUserByID(ctx, userID)
+		assert.NoError(t, err)
+		assert.Nil(t, user.PhoneNumber)
+		// otp of other phone number is not accepted
+		otherCode, err := renewOTP(ctx, "", otherPhoneNumber)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyPhoneNumberChangeResolver(ctx, model.VerifyPhoneNumberChangeInput{
+			PhoneNumber: otherPhoneNumber,
+			Otp:         otherCode,
+		})
+		assert.Error(t, err)
+		code, err := renewOTP(ctx, "", phoneNumber)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyPhoneNumberChangeResolver(ctx, model.VerifyPhoneNumberChangeInput{
+			PhoneNumber: phoneNumber,
+			Otp:         "000000",
+		})
+		assert.Error(t, err)
+		_, err = resolvers.VerifyPhoneNumberChangeResolver(ctx, model.VerifyPhoneNumberChangeInput{
+			PhoneNumber: phoneNumber,
+			Otp:         code,
+		})
+		assert.NoError(t, err)
+		user, err = db.Provider.GetUserByID(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, phoneNumber, refs.StringValue(user.PhoneNumber))
+		assert.NotNil(t, user.PhoneNumberVerifiedAt)
+		// pending phone number change is removed once verified
+		_, err = db.Provider.GetVerificationRequestByEmail(ctx, userID, constants.VerificationTypeUpdatePhoneNumber)
+		assert.Error(t, err)
+		// there was no previous phone number to notify
+		_, err = db.Provider.GetVerificationRequestByEmail(ctx, userID, constants.VerificationTypeRevertPhoneNumberChange)
+		assert.Error(t, err)
+
+		_, err = resolvers.UpdateProfileResolver(ctx, model.UpdateProfileInput{
+			PhoneNumber: refs.NewStringRef(newPhoneNumber),
+		})
+		assert.NoError(t, err)
+		code, err = renewOTP(ctx, "", newPhoneNumber)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyPhoneNumberChangeResolver(ctx, model.VerifyPhoneNumberChangeInput{
+			PhoneNumber: newPhoneNumber,
+			Otp:         code,
+		})
+		assert.NoError(t, err)
+		revertPhoneRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, userID, constants.VerificationTypeRevertPhoneNumberChange)
+		assert.NoError(t, err)
+
+		_, err = resolvers.UpdateProfileResolver(ctx, model.UpdateProfileInput{
+			Email: refs.NewStringRef(newEmail),
+		})
+		assert.NoError(t, err)
+		req.Header.Del("Authorization")
+		revertEmailRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, userID, constants.VerificationTypeRevertEmailChange)
+		assert.NoError(t, err)
+
+		w := revertChange(s, "invalid")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		w = revertChange(s, revertEmailRequest.Token)
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Contains(t, w.Header().Get("Location"), "message=")
+		w = revertChange(s, revertPhoneRequest.Token)
+		assert.Equal(t, http.StatusTemporaryRedirect, w.Code)
+		assert.Contains(t, w.Header().Get("Location"), "message=")
+		user, err = db.Provider.GetUserByID(ctx, userID)
+		assert.NoError(t, err)
+		assert.Equal(t, email, refs.StringValue(user.Email))
+		assert.NotNil(t, user.EmailVerifiedAt)
+		assert.Equal(t, phoneNumber, refs.StringValue(user.PhoneNumber))
+		// pending verification of changed email is removed
+		_, err = db.Provider.GetVerificationRequestByEmail(ctx, newEmail, constants.VerificationTypeUpdateEmail)
+		assert.Error(t, err)
+
+		// revert link can be used only once
+		w = revertChange(s, revertEmailRequest.Token)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		if otherOTP, err := db.Provider.GetOTPByPhoneNumber(ctx, otherPhoneNumber); err == nil {
+			db.Provider.DeleteOTP(ctx, otherOTP)
+		}
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyIsSMSServiceEnabled, false)
+		db.Provider.DeleteUser(ctx, user)
+		cleanData(email)
+		cleanData(newEmail)
+	})
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+func roleGrantTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should expire time bound role grants`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "role_grant." + s.TestInfo.Email
+
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		userID := verifyRes.User.ID
+
+		_, err = resolvers.GrantRoleResolver(ctx, model.GrantRoleInput{
+			UserID: userID,
+			Role:   "admin",
+		})
+		assert.Error(t, err, "unauthorized")
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		_, err = resolvers.GrantRoleResolver(ctx, model.GrantRoleInput{
+			UserID: userID,
+			Role:   "invalid_role",
+		})
+		assert.Error(t, err, "invalid role")
+		_, err = resolvers.GrantRoleResolver(ctx, model.GrantRoleInput{
+			UserID:    userID,
+			Role:      "admin",
+			ExpiresAt: refs.NewInt64Ref(time.Now().Unix() - 60),
+		})
+		assert.Error(t, err, "expires_at should be in future")
+
+		expiresAt := time.Now().Unix() + 3600
+		roleGrant, err := resolvers.GrantRoleResolver(ctx, model.GrantRoleInput{
+			UserID:    userID,
+			Role:      "admin",
+			ExpiresAt: refs.NewInt64Ref(expiresAt),
+			GrantedBy: refs.NewStringRef("jane@example.com"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "jane@example.com", roleGrant.GrantedBy)
+		assert.Equal(t, expiresAt, refs.Int64Value(roleGrant.ExpiresAt))
+		user, err := db.Provider.GetUserByID(ctx, userID)
+		assert.NoError(t, err)
+		assert.Contains(t, strings.Split(user.Roles, ","), "admin")
+		roleGrants, err := resolvers.UserRoleGrantsResolver(ctx, model.UserRolesRequest{
+			UserID: userID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, roleGrants, 1)
+
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+			Roles:    []string{"user", "admin"},
+		})
+		assert.NoError(t, err)
+		claims, err := token.ParseJWTToken(*loginRes.AccessToken)
+		assert.NoError(t, err)
+		assert.Contains(t, claims["roles"], "admin")
+
+		// expired grant is dropped from tokens before it is swept
+		grant, err := db.Provider.GetRoleGrantByUserIDAndRole(ctx, userID, "admin")
+		assert.NoError(t, err)
+		grant.ExpiresAt = time.Now().Unix() - 1
+		_, err = db.Provider.UpdateRoleGrant(ctx, grant)
+		assert.NoError(t, err)
+		loginRes, err = resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+			Roles:    []string{"user", "admin"},
+		})
+		assert.NoError(t, err)
+		claims, err = token.ParseJWTToken(*loginRes.AccessToken)
+		assert.NoError(t, err)
+		assert.NotContains(t, claims["roles"], "admin")
+		assert.NotContains(t, claims["allowed_roles"], "admin")
+
+		err = utils.SweepExpiredRoleGrants(ctx)
+		assert.NoError(t, err)
+		user, err = db.Provider.GetUserByID(ctx, userID)
+		assert.NoError(t, err)
+		assert.NotContains(t, strings.Split(user.Roles, ","), "admin")
+		roleGrants, err = resolvers.UserRoleGrantsResolver(ctx, model.UserRolesRequest{
+			UserID: userID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, roleGrants, 0)
+
+		// roles assigned with _update_user and _revoke_role are recorded too
+		_, err = resolvers.UpdateUserResolver(ctx, model.UpdateUserInput{
+			ID:    userID,
+			Roles: []*string{refs.NewStringRef("user"), refs.NewStringRef("admin")},
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.RevokeRoleResolver(ctx, model.RevokeRoleInput{
+			UserID: userID,
+			Role:   "admin",
+		})
+		assert.NoError(t, err)
+		_, err = resolvers.RevokeRoleResolver(ctx, model.RevokeRoleInput{
+			UserID: userID,
+			Role:   "admin",
+		})
+		assert.Error(t, err, "user does not have admin role")
+
+		history, err := resolvers.UserRoleHistoryResolver(ctx, model.UserRolesRequest{
+			UserID: userID,
+		})
+		assert.NoError(t, err)
+		actions := []string{}
+		for _, roleChange := range history {
+			assert.Equal(t, "admin", roleChange.Role)
+			actions = append(actions, roleChange.Action)
+		}
+		assert.ElementsMatch(t, []string{
+			constants.RoleChangeActionGranted,
+			constants.RoleChangeActionExpired,
+			constants.RoleChangeActionGranted,
+			constants.RoleChangeActionRevoked,
+		}, actions)
+		cleanData(email)
+	})
+}
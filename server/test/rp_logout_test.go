@@ -0,0 +1,246 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/logout"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func rpLogoutTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should advertise configured features in discovery document`, func(t *testing.T) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/.well-known/openid-configuration", nil)
+		handlers.OpenIDConfigurationHandler()(c)
+		res := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+		issuer := res["issuer"].(string)
+		assert.Equal(t, issuer+"/logout", res["end_session_endpoint"])
+		assert.Equal(t, issuer+"/oauth/revoke", res["revocation_endpoint"])
+		assert.NotEqual(t, issuer+"/app", res["registration_endpoint"])
+		assert.Contains(t, res["grant_types_supported"], "refresh_token")
+		assert.NotContains(t, res["grant_types_supported"], token.GrantTypeTokenExchange)
+		assert.Equal(t, false, res["backchannel_logout_supported"])
+	})
+
+	t.Run(`should logout with id token hint and notify back-channel logout uri`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "rp_logout." + s.TestInfo.Email
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		idTokenClaims, err := token.ParseJWTToken(*loginRes.IDToken)
+		assert.NoError(t, err)
+		sid, ok := idTokenClaims["sid"].(string)
+		assert.True(t, ok)
+
+		logoutTokens := make(chan string, 1)
+		client := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logoutTokens <- r.FormValue("logout_token")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer client.Close()
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyBackchannelLogoutURI, client.URL)
+
+		logoutRequest := func(query url.Values) *httptest.ResponseRecorder {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/logout?"+query.Encode(), nil)
+			handlers.LogoutHandler()(c)
+			return w
+		}
+
+		query := url.Values{}
+		query.Set("id_token_hint", "invalid")
+		w := logoutRequest(query)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		query.Set("id_token_hint", *loginRes.IDToken)
+		query.Set("post_logout_redirect_uri", "http://localhost:3000/logged-out")
+		query.Set("state", "xyz")
+		w = logoutRequest(query)
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "http://localhost:3000/logged-out?state=xyz", w.Header().Get("Location"))
+
+		sessionToken, _ := memorystore.Provider.GetUserSession(constants.AuthRecipeMethodBasicAuth+":"+loginRes.User.ID, constants.TokenTypeSessionToken+"_"+sid)
+		assert.Empty(t, sessionToken)
+
+		select {
+		case logoutToken := <-logoutTokens:
+			claims, err := token.ParseJWTToken(logoutToken)
+			assert.NoError(t, err)
+			assert.Equal(t, loginRes.User.ID, claims["sub"])
+			assert.Equal(t, sid, claims["sid"])
+			assert.Nil(t, claims["nonce"])
+			assert.Contains(t, claims["events"], token.BackchannelLogoutEvent)
+		case <-time.After(5 * time.Second):
+			t.Error("back-channel logout token was not sent")
+		}
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyBackchannelLogoutURI, "")
+		cleanData(email)
+	})
+
+	t.Run(`should notify back-channel logout uri of registered clients the user has granted`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "initial-token")
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeyClientRegistrationInitialAccessToken, "")
+		_, ctx := createContext(s)
+		email := "rp_logout_client." + s.TestInfo.Email
+
+		logoutTokens := make(chan string, 2)
+		rp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			logoutTokens <- r.FormValue("logout_token")
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer rp.Close()
+
+		status, res := clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"redirect_uris":          []string{"https://rp.example.com/callback"},
+			"backchannel_logout_uri": "javascript:alert(1)",
+		})
+		assert.Equal(t, http.StatusBadRequest, status)
+		status, res = clientRegistrationRequest(s, http.MethodPost, "", "initial-token", map[string]interface{}{
+			"redirect_uris":                       []string{"https://rp.example.com/callback"},
+			"backchannel_logout_uri":              rp.URL,
+			"backchannel_logout_session_required": true,
+		})
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, rp.URL, res["backchannel_logout_uri"])
+		assert.Equal(t, true, res["backchannel_logout_session_required"])
+		clientID := res["client_id"].(string)
+		defer clientRegistrationRequest(s, http.MethodDelete, clientID, res["registration_access_token"].(string), nil)
+
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+
+		// client without grant is not notified
+		assert.Empty(t, logout.GetBackchannelLogoutClients(ctx, user.ID))
+		_, err = db.Provider.AddGrant(ctx, &models.Grant{
+			UserID:   user.ID,
+			ClientID: clientID,
+			Scope:    "openid",
+		})
+		assert.NoError(t, err)
+		clients := logout.GetBackchannelLogoutClients(ctx, user.ID)
+		if assert.Len(t, clients, 1) {
+			assert.Equal(t, clientID, clients[0].ClientID)
+		}
+
+		logout.SendBackchannelLogout("http://"+s.Server.Listener.Addr().String(), user.ID, "session-id")
+		select {
+		case logoutToken := <-logoutTokens:
+			claims, err := token.ParseJWTToken(logoutToken)
+			assert.NoError(t, err)
+			assert.Equal(t, clientID, claims["aud"])
+			assert.Equal(t, user.ID, claims["sub"])
+			assert.Equal(t, "session-id", claims["sid"])
+		default:
+			t.Error("back-channel logout token was not sent to registered client")
+		}
+
+		// logout uri of registered client on loopback address is not called in production
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyIsProd, true)
+		logout.SendBackchannelLogout("http://"+s.Server.Listener.Addr().String(), user.ID, "session-id")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyIsProd, false)
+		select {
+		case <-logoutTokens:
+			t.Error("back-channel logout token was sent to loopback address")
+		default:
+		}
+		cleanData(email)
+	})
+
+	t.Run(`should only accept expired id token hint matching the session`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		email := "rp_logout_expired." + s.TestInfo.Email
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		loginRes, err := resolvers.LoginResolver(ctx, model.LoginInput{
+			Email:    refs.NewStringRef(email),
+			Password: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		idTokenClaims, err := token.ParseJWTToken(*loginRes.IDToken)
+		assert.NoError(t, err)
+		sid := idTokenClaims["sid"].(string)
+		idTokenClaims["exp"] = time.Now().Add(-time.Minute).Unix()
+		expiredIDToken, err := token.SignJWTToken(idTokenClaims)
+		assert.NoError(t, err)
+		sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + loginRes.User.ID
+		sessionCookie, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+sid)
+		assert.NoError(t, err)
+
+		logoutRequest := func(cookie string) *httptest.ResponseRecorder {
+			query := url.Values{}
+			query.Set("id_token_hint", expiredIDToken)
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "http://"+s.Server.Listener.Addr().String()+"/logout?"+query.Encode(), nil)
+			if cookie != "" {
+				c.Request.Header.Set("Cookie", constants.AppCookieName+"_session="+cookie)
+			}
+			handlers.LogoutHandler()(c)
+			return w
+		}
+
+		// expired hint alone cannot end the session
+		w := logoutRequest("")
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		existingSession, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+sid)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, existingSession)
+
+		w = logoutRequest(sessionCookie)
+		assert.Equal(t, http.StatusOK, w.Code)
+		existingSession, _ = memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+sid)
+		assert.Empty(t, existingSession)
+		cleanData(email)
+	})
+}
@@ -0,0 +1,82 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func sessionExpiryTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	_, ctx := createContext(s)
+	gc, err := utils.GinContextFromContext(ctx)
+	assert.NoError(t, err)
+	scope := []string{"openid", "email", "profile", "offline_access"}
+	user := &models.User{
+		ID:        uuid.New().String(),
+		Email:     refs.NewStringRef("session_expiry_" + s.TestInfo.Email),
+		Roles:     "user,admin",
+		UpdatedAt: time.Now().Unix(),
+		CreatedAt: time.Now().Unix(),
+	}
+	sessionKey := constants.AuthRecipeMethodBasicAuth + ":" + user.ID
+
+	t.Run(`should use configured session and refresh token lifetime`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionExpiryTime, "24h")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyRefreshTokenExpiryTime, "48h")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionExpiryTimeByRole, "admin:1h")
+
+		now := time.Now().Unix()
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, scope, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		assert.InDelta(t, now+24*60*60, authToken.SessionTokenExpiresAt, 2)
+		assert.InDelta(t, now+48*60*60, authToken.RefreshToken.ExpiresAt, 2)
+
+		// role specific lifetime applies to session and refresh token
+		authToken, err = token.CreateAuthToken(gc, user, []string{"user", "admin"}, scope, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		assert.InDelta(t, now+60*60, authToken.SessionTokenExpiresAt, 2)
+		assert.InDelta(t, now+60*60, authToken.RefreshToken.ExpiresAt, 2)
+
+		// lifetime is counted from the time of authentication for rolled over sessions
+		authTime := now - 30*60
+		authToken, err = token.CreateAuthTokenWithOptions(gc, user, []string{"admin"}, scope, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "", token.AuthTokenOptions{AuthTime: authTime})
+		assert.NoError(t, err)
+		assert.InDelta(t, authTime+60*60, authToken.SessionTokenExpiresAt, 2)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionExpiryTime, "8760h")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyRefreshTokenExpiryTime, "8760h")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionExpiryTimeByRole, "")
+	})
+
+	t.Run(`should expire idle session and extend it on use`, func(t *testing.T) {
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionIdleTimeout, "3s")
+		defer memorystore.Provider.UpdateEnvVariable(constants.EnvKeySessionIdleTimeout, "")
+
+		activeToken, err := token.CreateAuthToken(gc, user, []string{"user"}, scope, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, activeToken.SessionTokenExpiresAt, time.Now().Unix()+3)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+activeToken.FingerPrint, activeToken.FingerPrintHash, activeToken.SessionTokenExpiresAt)
+		idleToken, err := token.CreateAuthToken(gc, user, []string{"user"}, scope, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		memorystore.Provider.SetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+idleToken.FingerPrint, idleToken.FingerPrintHash, idleToken.SessionTokenExpiresAt)
+
+		time.Sleep(2 * time.Second)
+		_, err = token.ValidateBrowserSession(gc, activeToken.FingerPrintHash)
+		assert.NoError(t, err)
+		time.Sleep(1500 * time.Millisecond)
+		// active session was extended on use, idle session has expired
+		_, err = token.ValidateBrowserSession(gc, activeToken.FingerPrintHash)
+		assert.NoError(t, err)
+		_, err = token.ValidateBrowserSession(gc, idleToken.FingerPrintHash)
+		assert.Error(t, err)
+	})
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/authorizerdev/authorizer/server/handlers"
 	"github.com/authorizerdev/authorizer/server/memorystore"
 	"github.com/authorizerdev/authorizer/server/middlewares"
+	"github.com/authorizerdev/authorizer/server/utils"
 	"github.com/gin-gonic/gin"
 )
 
@@ -86,6 +87,12 @@ func cleanData(email string) {
 	}
 }
 
+// renewOTP replaces the otp sent to given email or phone number and returns the new one,
+// as only hash of otp is saved in db tests can't read the otp which was sent
+func renewOTP(ctx context.Context, email, phoneNumber string) (string, error) {
+	return utils.CreateOTP(ctx, email, phoneNumber, email == "", time.Now().Add(time.Minute).Unix())
+}
+
 func createContext(s TestSetup) (*http.Request, context.Context) {
 	req, _ := http.NewRequest(
 		"POST",
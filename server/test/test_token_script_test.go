@@ -0,0 +1,126 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/authorizerdev/authorizer/server/utils"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func testTokenScriptTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should run custom scripts for tokens without overriding protected claims`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "test_token_script." + s.TestInfo.Email
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		user, err := db.Provider.GetUserByEmail(ctx, email)
+		assert.NoError(t, err)
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomAccessTokenScript, `function(user, tokenPayload, context) { return { sub: "hijacked", plan: "pro", client: context.client_id }; }`)
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomIDTokenScript, `function(user, tokenPayload) { return { id_token_claim: user.email }; }`)
+		gc, err := utils.GinContextFromContext(ctx)
+		assert.NoError(t, err)
+		authToken, err := token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid"}, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+
+		claims, err := token.ParseJWTToken(authToken.AccessToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, claims["sub"])
+		assert.Equal(t, "pro", claims["plan"])
+		assert.Equal(t, clientID, claims["client"])
+		assert.Nil(t, claims["id_token_claim"])
+
+		claims, err = token.ParseJWTToken(authToken.IDToken.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, email, claims["id_token_claim"])
+		assert.Nil(t, claims["plan"])
+
+		// script that never returns is interrupted and token is issued without custom claims
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomAccessTokenScript, `function() { while (true) {} }`)
+		startedAt := time.Now()
+		authToken, err = token.CreateAuthToken(gc, user, []string{"user"}, []string{"openid"}, constants.AuthRecipeMethodBasicAuth, uuid.New().String(), "")
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(startedAt), 5*time.Second)
+		claims, err = token.ParseJWTToken(authToken.AccessToken.Token)
+		assert.NoError(t, err)
+		assert.Nil(t, claims["plan"])
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomAccessTokenScript, "")
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyCustomIDTokenScript, "")
+
+		// dry run is admin only
+		_, err = resolvers.TestTokenScriptResolver(ctx, model.TestTokenScriptInput{
+			UserID: user.ID,
+			Script: `function() { return { plan: "pro" }; }`,
+		})
+		assert.Error(t, err)
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		res, err := resolvers.TestTokenScriptResolver(ctx, model.TestTokenScriptInput{
+			UserID: user.ID,
+			Script: `function(user) { return { plan: "pro", email: user.email, iss: "hijacked" }; }`,
+			Type:   refs.NewStringRef(token.CustomScriptTypeUserInfo),
+		})
+		assert.NoError(t, err)
+		assert.Nil(t, res.Error)
+		assert.Equal(t, "pro", res.Claims["plan"])
+		assert.Equal(t, user.ID, res.Claims["sub"])
+		assert.Nil(t, res.Claims["iss"])
+
+		res, err = resolvers.TestTokenScriptResolver(ctx, model.TestTokenScriptInput{
+			UserID: user.ID,
+			Script: `function() { throw new Error("boom"); }`,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res.Error)
+		assert.Contains(t, *res.Error, "boom")
+
+		res, err = resolvers.TestTokenScriptResolver(ctx, model.TestTokenScriptInput{
+			UserID: user.ID,
+			Script: `function() { while (true) {} }`,
+			Type:   refs.NewStringRef(constants.TokenTypeIdentityToken),
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res.Error)
+		assert.Contains(t, *res.Error, "timed out")
+
+		res, err = resolvers.TestTokenScriptResolver(ctx, model.TestTokenScriptInput{
+			UserID: user.ID,
+			Script: `function() { var s = "x"; for (var i = 0; i < 40; i++) { s += s; } return { length: s.length }; }`,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res.Error)
+		assert.Contains(t, *res.Error, "memory limit")
+
+		_, err = resolvers.TestTokenScriptResolver(ctx, model.TestTokenScriptInput{
+			UserID: user.ID,
+			Script: `function() { return {}; }`,
+			Type:   refs.NewStringRef("invalid"),
+		})
+		assert.Error(t, err)
+		cleanData(email)
+	})
+}
@@ -0,0 +1,126 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/authorizerdev/authorizer/server/token"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// tokenGrant calls token handler with given form params
+func tokenGrant(s TestSetup, form url.Values) (int, map[string]interface{}) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "http://"+s.Server.Listener.Addr().String()+"/oauth/token", strings.NewReader(form.Encode()))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	handlers.TokenHandler()(c)
+	res := map[string]interface{}{}
+	json.Unmarshal(w.Body.Bytes(), &res)
+	return w.Code, res
+}
+
+func tokenExchangeTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should exchange access token for other audience`, func(t *testing.T) {
+		_, ctx := createContext(s)
+		clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+		assert.NoError(t, err)
+		clientSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientSecret)
+		assert.NoError(t, err)
+
+		login := func(email string) *model.AuthResponse {
+			_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+				Email:           refs.NewStringRef(email),
+				Password:        s.TestInfo.Password,
+				ConfirmPassword: s.TestInfo.Password,
+			})
+			assert.NoError(t, err)
+			verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+			assert.NoError(t, err)
+			_, err = resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+				Token: verificationRequest.Token,
+			})
+			assert.NoError(t, err)
+			res, err := resolvers.LoginResolver(ctx, model.LoginInput{
+				Email:    refs.NewStringRef(email),
+				Password: s.TestInfo.Password,
+			})
+			assert.NoError(t, err)
+			return res
+		}
+		email := "token_exchange." + s.TestInfo.Email
+		actorEmail := "token_exchange_actor." + s.TestInfo.Email
+		subject := login(email)
+		actor := login(actorEmail)
+
+		form := url.Values{}
+		form.Set("grant_type", token.GrantTypeTokenExchange)
+		form.Set("client_id", clientID)
+		form.Set("client_secret", clientSecret)
+		form.Set("subject_token", *subject.AccessToken)
+		form.Set("subject_token_type", token.TokenTypeURIAccessToken)
+		form.Set("audience", "orders-api")
+
+		// exchange is disabled without policy
+		code, res := tokenGrant(s, form)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_target", res["error"])
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyTokenExchangePolicy, clientID+":orders-api")
+		form.Set("client_secret", "invalid")
+		code, _ = tokenGrant(s, form)
+		assert.Equal(t, http.StatusUnauthorized, code)
+		form.Set("client_secret", clientSecret)
+
+		form.Set("audience", "billing-api")
+		code, res = tokenGrant(s, form)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_target", res["error"])
+		form.Set("audience", "orders-api")
+
+		form.Set("scope", "openid admin")
+		code, res = tokenGrant(s, form)
+		assert.Equal(t, http.StatusBadRequest, code)
+		assert.Equal(t, "invalid_scope", res["error"])
+
+		form.Set("scope", "openid")
+		code, res = tokenGrant(s, form)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, token.TokenTypeURIAccessToken, res["issued_token_type"])
+		assert.Equal(t, "openid", res["scope"])
+		claims, err := token.ParseJWTToken(res["access_token"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, "orders-api", claims["aud"])
+		assert.Equal(t, subject.User.ID, claims["sub"])
+		assert.Equal(t, clientID, claims["act"].(map[string]interface{})["sub"])
+
+		// exchanged token cannot be used with authorizer apis
+		_, err = token.ValidateAccessToken(nil, res["access_token"].(string))
+		assert.Error(t, err)
+
+		form.Set("actor_token", *actor.AccessToken)
+		form.Set("actor_token_type", token.TokenTypeURIAccessToken)
+		code, res = tokenGrant(s, form)
+		assert.Equal(t, http.StatusOK, code)
+		claims, err = token.ParseJWTToken(res["access_token"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, actor.User.ID, claims["act"].(map[string]interface{})["sub"])
+
+		memorystore.Provider.UpdateEnvVariable(constants.EnvKeyTokenExchangePolicy, "")
+		cleanData(email)
+		cleanData(actorEmail)
+	})
+}
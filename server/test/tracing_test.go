@@ -0,0 +1,76 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/handlers"
+	"github.com/authorizerdev/authorizer/server/middlewares"
+	"github.com/authorizerdev/authorizer/server/tracing"
+)
+
+func tracingTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should trace http, graphql and db calls`, func(t *testing.T) {
+		shutdown, err := tracing.InitTracer(context.Background(), tracing.ExporterNone)
+		assert.NoError(t, err)
+		defer shutdown(context.Background())
+		_, err = tracing.InitTracer(context.Background(), "invalid")
+		assert.Error(t, err)
+
+		recorder := tracetest.NewSpanRecorder()
+		previousProvider := otel.GetTracerProvider()
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+		otel.SetTextMapPropagator(propagation.TraceContext{})
+		defer otel.SetTracerProvider(previousProvider)
+
+		router := gin.New()
+		router.Use(otelgin.Middleware(tracing.ServiceName))
+		router.Use(middlewares.GinContextToContextMiddleware())
+		router.POST("/graphql", handlers.GraphqlHandler())
+
+		traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+		req := httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(`{"query":"{ meta { version } }"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		spanNames := map[string]bool{}
+		for _, span := range recorder.Ended() {
+			// all spans should be part of the incoming trace
+			assert.Equal(t, traceID, span.SpanContext().TraceID().String(), span.Name())
+			spanNames[span.Name()] = true
+		}
+		assert.True(t, spanNames["/graphql"])
+		assert.True(t, spanNames["graphql.Query.meta"])
+
+		ctx, parent := tracing.StartSpan(context.Background(), "parent")
+		_, err = db.Provider.GetUserByEmail(ctx, "tracing_"+s.TestInfo.Email)
+		assert.Error(t, err)
+		parent.End()
+		var dbSpan sdktrace.ReadOnlySpan
+		for _, span := range recorder.Ended() {
+			if span.Name() == "db.GetUserByEmail" {
+				dbSpan = span
+			}
+		}
+		if assert.NotNil(t, dbSpan) {
+			assert.Equal(t, parent.SpanContext().SpanID(), dbSpan.Parent().SpanID())
+			assert.NotEmpty(t, dbSpan.Events())
+		}
+	})
+}
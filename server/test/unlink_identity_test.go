@@ -0,0 +1,79 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func unlinkIdentityTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should unlink identity from user`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "unlink_identity." + s.TestInfo.Email
+
+		_, err := resolvers.UnlinkIdentityResolver(ctx, model.UnlinkIdentityInput{
+			ID: "test",
+		})
+		assert.NotNil(t, err, "unauthorized")
+
+		_, err = resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		s.GinContext.Request.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		ctx = context.WithValue(req.Context(), "GinContextKey", s.GinContext)
+
+		identity, err := db.Provider.AddIdentity(ctx, &models.Identity{
+			UserID:         verifyRes.User.ID,
+			Provider:       constants.AuthRecipeMethodGithub,
+			ProviderUserID: "unlink_identity_test",
+			Email:          email,
+		})
+		assert.NoError(t, err)
+		user, err := db.Provider.GetUserByID(ctx, verifyRes.User.ID)
+		assert.NoError(t, err)
+		user.SignupMethods = user.SignupMethods + "," + constants.AuthRecipeMethodGithub
+		_, err = db.Provider.UpdateUser(ctx, user)
+		assert.NoError(t, err)
+
+		profile, err := resolvers.ProfileResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, profile.Identities, 1)
+		assert.Equal(t, constants.AuthRecipeMethodGithub, profile.Identities[0].Provider)
+
+		_, err = resolvers.UnlinkIdentityResolver(ctx, model.UnlinkIdentityInput{
+			ID: "invalid",
+		})
+		assert.Error(t, err)
+
+		res, err := resolvers.UnlinkIdentityResolver(ctx, model.UnlinkIdentityInput{
+			ID: identity.AsAPIIdentity().ID,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+
+		profile, err = resolvers.ProfileResolver(ctx)
+		assert.NoError(t, err)
+		assert.Len(t, profile.Identities, 0)
+		assert.NotContains(t, profile.SignupMethods, constants.AuthRecipeMethodGithub)
+
+		s.GinContext.Request.Header.Set("Authorization", "")
+		cleanData(email)
+	})
+}
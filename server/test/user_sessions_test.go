@@ -0,0 +1,80 @@
+package test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/graph/model"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/resolvers"
+	"github.com/stretchr/testify/assert"
+)
+
+func userSessionsTests(t *testing.T, s TestSetup) {
+	t.Helper()
+	t.Run(`should list and revoke sessions of user with admin secret only`, func(t *testing.T) {
+		req, ctx := createContext(s)
+		email := "user_sessions." + s.TestInfo.Email
+
+		_, err := resolvers.SignupResolver(ctx, model.SignUpInput{
+			Email:           refs.NewStringRef(email),
+			Password:        s.TestInfo.Password,
+			ConfirmPassword: s.TestInfo.Password,
+		})
+		assert.NoError(t, err)
+		verificationRequest, err := db.Provider.GetVerificationRequestByEmail(ctx, email, constants.VerificationTypeBasicAuthSignup)
+		assert.NoError(t, err)
+		verifyRes, err := resolvers.VerifyEmailResolver(ctx, model.VerifyEmailInput{
+			Token: verificationRequest.Token,
+		})
+		assert.NoError(t, err)
+		// sessions are recorded asynchronously
+		time.Sleep(time.Second)
+
+		_, err = resolvers.UserSessionsResolver(ctx, model.GetUserRequest{
+			ID: &verifyRes.User.ID,
+		})
+		assert.NotNil(t, err, "unauthorized")
+
+		adminSecret, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAdminSecret)
+		assert.NoError(t, err)
+		h, err := crypto.EncryptPassword(adminSecret)
+		assert.NoError(t, err)
+		req.Header.Set("Cookie", fmt.Sprintf("%s=%s", constants.AdminCookieName, h))
+
+		_, err = resolvers.UserSessionsResolver(ctx, model.GetUserRequest{})
+		assert.NotNil(t, err, "invalid params, user id or email is required")
+
+		sessions, err := resolvers.UserSessionsResolver(ctx, model.GetUserRequest{
+			Email: refs.NewStringRef(email),
+		})
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 1)
+		assert.False(t, sessions[0].IsCurrent)
+
+		res, err := resolvers.RevokeUserSessionResolver(ctx, model.RevokeSessionInput{
+			ID: sessions[0].ID,
+		})
+		assert.NoError(t, err)
+		assert.NotNil(t, res)
+
+		sessions, err = resolvers.UserSessionsResolver(ctx, model.GetUserRequest{
+			ID: &verifyRes.User.ID,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 0)
+
+		// revoked session should no longer be usable
+		req.Header.Set("Cookie", "")
+		s.GinContext.Request.Header.Set("Authorization", "Bearer "+*verifyRes.AccessToken)
+		_, err = resolvers.ProfileResolver(ctx)
+		assert.Error(t, err)
+		s.GinContext.Request.Header.Set("Authorization", "")
+		cleanData(email)
+	})
+}
@@ -87,6 +87,8 @@ func verifyOTPTest(t *testing.T, s TestSetup) {
 			otp, err := db.Provider.GetOTPByEmail(ctx, email)
 			assert.NoError(t, err)
 			assert.NotEmpty(t, otp.Otp)
+			otpCode, err := renewOTP(ctx, email, "")
+			assert.NoError(t, err)
 
 			// Get user by email
 			user, err := db.Provider.GetUserByEmail(ctx, email)
@@ -103,7 +105,7 @@ func verifyOTPTest(t *testing.T, s TestSetup) {
 			// Verify OTP
 			verifyOtpRes, err := resolvers.VerifyOtpResolver(ctx, model.VerifyOTPRequest{
 				Email: &email,
-				Otp:   otp.Otp,
+				Otp:   otpCode,
 			})
 			assert.Nil(t, err)
 			assert.NotEqual(t, verifyOtpRes.AccessToken, "", "access token should not be empty")
@@ -1,6 +1,7 @@
 package token
 
 import (
+	"context"
 	"crypto/sha256"
 	"encoding/base64"
 	"encoding/json"
@@ -12,13 +13,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
-	"github.com/robertkrimen/otto"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/cookie"
 	"github.com/authorizerdev/authorizer/server/crypto"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/parsers"
 	"github.com/authorizerdev/authorizer/server/utils"
 )
@@ -49,16 +50,55 @@ type SessionData struct {
 	IssuedAt    int64    `json:"iat"`
 	ExpiresAt   int64    `json:"exp"`
 	LoginMethod string   `json:"login_method"`
+	// AuthTime is the time when user authenticated, session lifetime is counted from it
+	AuthTime int64 `json:"auth_time"`
+	// OrganizationID is the organization selected for the session
+	OrganizationID string `json:"organization_id,omitempty"`
+}
+
+// AuthTokenOptions are the optional parameters to create auth token
+type AuthTokenOptions struct {
+	// AuthTime is the time when user authenticated, session and refresh token
+	// expiry are counted from it. Current time is used if it is 0.
+	AuthTime int64
+	// RefreshTokenFamilyID is the family continued by refresh token,
+	// new family is started if it is empty
+	RefreshTokenFamilyID string
+	// DPoPJKT is the thumbprint of DPoP key to which access and refresh tokens are bound
+	DPoPJKT string
+	// ClientID is the dynamically registered client to which tokens are issued,
+	// it is empty for the client configured with CLIENT_ID env
+	ClientID string
+	// OrganizationID is the organization selected for the session,
+	// access token has the organization claim when user is its member
+	OrganizationID string
 }
 
 // CreateAuthToken creates a new auth token when userlogs in
 func CreateAuthToken(gc *gin.Context, user *models.User, roles, scope []string, loginMethod, nonce string, code string) (*Token, error) {
+	return CreateAuthTokenWithOptions(gc, user, roles, scope, loginMethod, nonce, code, AuthTokenOptions{})
+}
+
+// CreateAuthTokenWithOptions creates a new auth token, it is used for
+// existing sessions and sender constrained tokens
+func CreateAuthTokenWithOptions(gc *gin.Context, user *models.User, roles, scope []string, loginMethod, nonce string, code string, opts AuthTokenOptions) (res *Token, err error) {
+	defer func() {
+		metrics.RecordAuthEvent(metrics.EventTokenIssue, loginMethod, metrics.OutcomeFromError(err))
+	}()
+	authTime := opts.AuthTime
+	if authTime == 0 {
+		authTime = time.Now().Unix()
+	}
+	user, roles = DropExpiredRoles(user, roles)
+	// custom scopes can only be granted to the roles allowed for them
+	scope = FilterScopes(scope, roles)
 	hostname := parsers.GetHost(gc)
-	_, fingerPrintHash, sessionTokenExpiresAt, err := CreateSessionToken(user, nonce, roles, scope, loginMethod)
+	opts.AuthTime = authTime
+	_, fingerPrintHash, sessionTokenExpiresAt, err := CreateSessionToken(user, nonce, roles, scope, loginMethod, opts)
 	if err != nil {
 		return nil, err
 	}
-	accessToken, accessTokenExpiresAt, err := CreateAccessToken(user, roles, scope, hostname, nonce, loginMethod)
+	accessToken, accessTokenExpiresAt, err := CreateAccessToken(user, roles, scope, hostname, nonce, loginMethod, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -79,12 +119,12 @@ func CreateAuthToken(gc *gin.Context, user *models.User, roles, scope []string,
 		codeHashString = base64.RawURLEncoding.EncodeToString(codeHashDigest)
 	}
 
-	idToken, idTokenExpiresAt, err := CreateIDToken(user, roles, hostname, nonce, atHashString, codeHashString, loginMethod)
+	idToken, idTokenExpiresAt, err := CreateIDToken(user, roles, scope, hostname, nonce, atHashString, codeHashString, loginMethod, opts.ClientID)
 	if err != nil {
 		return nil, err
 	}
 
-	res := &Token{
+	res = &Token{
 		FingerPrint:           nonce,
 		FingerPrintHash:       fingerPrintHash,
 		SessionTokenExpiresAt: sessionTokenExpiresAt,
@@ -92,7 +132,7 @@ func CreateAuthToken(gc *gin.Context, user *models.User, roles, scope []string,
 		IDToken:               &JWTToken{Token: idToken, ExpiresAt: idTokenExpiresAt},
 	}
 	if utils.StringSliceContains(scope, "offline_access") {
-		refreshToken, refreshTokenExpiresAt, err := CreateRefreshToken(user, roles, scope, hostname, nonce, loginMethod)
+		refreshToken, refreshTokenExpiresAt, err := CreateRefreshToken(user, roles, scope, hostname, nonce, loginMethod, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -104,16 +144,24 @@ func CreateAuthToken(gc *gin.Context, user *models.User, roles, scope []string,
 }
 
 // CreateSessionToken creates a new session token
-func CreateSessionToken(user *models.User, nonce string, roles, scope []string, loginMethod string) (*SessionData, string, int64, error) {
-	expiresAt := time.Now().AddDate(1, 0, 0).Unix()
+// It returns the expiry of session in memory store, which can be before
+// the absolute expiry of session when idle timeout is configured
+func CreateSessionToken(user *models.User, nonce string, roles, scope []string, loginMethod string, opts AuthTokenOptions) (*SessionData, string, int64, error) {
+	authTime := opts.AuthTime
+	if authTime == 0 {
+		authTime = time.Now().Unix()
+	}
+	expiresAt := authTime + int64(getSessionExpiryTime(roles).Seconds())
 	fingerPrintMap := &SessionData{
-		Nonce:       nonce,
-		Roles:       roles,
-		Subject:     user.ID,
-		Scope:       scope,
-		LoginMethod: loginMethod,
-		IssuedAt:    time.Now().Unix(),
-		ExpiresAt:   expiresAt,
+		Nonce:          nonce,
+		Roles:          roles,
+		Subject:        user.ID,
+		Scope:          scope,
+		LoginMethod:    loginMethod,
+		IssuedAt:       time.Now().Unix(),
+		ExpiresAt:      expiresAt,
+		AuthTime:       authTime,
+		OrganizationID: opts.OrganizationID,
 	}
 	fingerPrintBytes, _ := json.Marshal(fingerPrintMap)
 	fingerPrintHash, err := crypto.EncryptAES(string(fingerPrintBytes))
@@ -121,14 +169,21 @@ func CreateSessionToken(user *models.User, nonce string, roles, scope []string,
 		return nil, "", 0, err
 	}
 
-	return fingerPrintMap, fingerPrintHash, expiresAt, nil
+	return fingerPrintMap, fingerPrintHash, getIdleExpiresAt(expiresAt), nil
 }
 
 // CreateRefreshToken util to create JWT token
-func CreateRefreshToken(user *models.User, roles, scopes []string, hostname, nonce, loginMethod string) (string, int64, error) {
-	// expires in 1 year
-	expiryBound := time.Hour * 8760
-	expiresAt := time.Now().Add(expiryBound).Unix()
+// When idle timeout is configured, refresh token expires if it is not used (rotated) within it
+func CreateRefreshToken(user *models.User, roles, scopes []string, hostname, nonce, loginMethod string, opts AuthTokenOptions) (string, int64, error) {
+	authTime := opts.AuthTime
+	if authTime == 0 {
+		authTime = time.Now().Unix()
+	}
+	familyID := opts.RefreshTokenFamilyID
+	if familyID == "" {
+		familyID = nonce
+	}
+	expiresAt := getIdleExpiresAt(authTime + int64(getRefreshTokenExpiryTime(roles).Seconds()))
 	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
 	if err != nil {
 		return "", 0, err
@@ -145,6 +200,17 @@ func CreateRefreshToken(user *models.User, roles, scopes []string, hostname, non
 		"nonce":         nonce,
 		"login_method":  loginMethod,
 		"allowed_roles": strings.Split(user.Roles, ","),
+		"auth_time":     authTime,
+		"family_id":     familyID,
+	}
+	if opts.DPoPJKT != "" {
+		customClaims["cnf"] = map[string]interface{}{"jkt": opts.DPoPJKT}
+	}
+	if opts.ClientID != "" {
+		customClaims["client_id"] = opts.ClientID
+	}
+	if opts.OrganizationID != "" {
+		customClaims["organization_id"] = opts.OrganizationID
 	}
 
 	token, err := SignJWTToken(customClaims)
@@ -156,12 +222,44 @@ func CreateRefreshToken(user *models.User, roles, scopes []string, hostname, non
 }
 
 // CreateAccessToken util to create JWT token, based on
-// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT
-func CreateAccessToken(user *models.User, roles, scopes []string, hostName, nonce, loginMethod string) (string, int64, error) {
-	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT.
+// Token is bound to DPoP key when opts.DPoPJKT is not empty.
+func CreateAccessToken(user *models.User, roles, scopes []string, hostName, nonce, loginMethod string, opts AuthTokenOptions) (string, int64, error) {
+	customClaims, expiresAt, err := getAccessTokenClaims(user, roles, scopes, hostName, nonce, loginMethod)
 	if err != nil {
 		return "", 0, err
 	}
+	applyCustomScript(constants.TokenTypeAccessToken, user, customClaims, loginMethod)
+	// set after custom script, so that confirmation and client claims cannot be overridden
+	if opts.DPoPJKT != "" {
+		customClaims["cnf"] = map[string]interface{}{"jkt": opts.DPoPJKT}
+	}
+	if opts.ClientID != "" {
+		customClaims["client_id"] = opts.ClientID
+	}
+	if opts.OrganizationID != "" {
+		organizationClaim, err := getOrganizationClaim(user, opts.OrganizationID)
+		if err != nil {
+			// membership could have been removed after organization was selected
+			log.Debug("Failed to get organization claim: ", err)
+		} else {
+			customClaims["organization"] = organizationClaim
+		}
+	}
+	token, err := SignJWTToken(customClaims)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// getAccessTokenClaims returns the claims of access token before custom script is applied
+func getAccessTokenClaims(user *models.User, roles, scopes []string, hostName, nonce, loginMethod string) (jwt.MapClaims, int64, error) {
+	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+	if err != nil {
+		return nil, 0, err
+	}
 	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
 	if err != nil {
 		expiryBound = time.Minute * 30
@@ -169,7 +267,7 @@ func CreateAccessToken(user *models.User, roles, scopes []string, hostName, nonc
 	expiresAt := time.Now().Add(expiryBound).Unix()
 	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
 	if err != nil {
-		return "", 0, err
+		return nil, 0, err
 	}
 	customClaims := jwt.MapClaims{
 		"iss":           hostName,
@@ -183,48 +281,19 @@ func CreateAccessToken(user *models.User, roles, scopes []string, hostName, nonc
 		"roles":         roles,
 		"login_method":  loginMethod,
 		"allowed_roles": strings.Split(user.Roles, ","),
+		"permissions":   GetPermissions(context.Background(), roles),
 	}
-	// check for the extra access token script
-	accessTokenScript, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyCustomAccessTokenScript)
+	// only the claims mapped by custom scopes are added to access token
+	scopeClaims, err := getScopedUserClaims(user, scopes, false)
 	if err != nil {
-		log.Debug("Failed to get custom access token script: ", err)
-		accessTokenScript = ""
-	}
-	if accessTokenScript != "" {
-		resUser := user.AsAPIUser()
-		userBytes, _ := json.Marshal(&resUser)
-		var userMap map[string]interface{}
-		json.Unmarshal(userBytes, &userMap)
-		vm := otto.New()
-		claimBytes, _ := json.Marshal(customClaims)
-		vm.Run(fmt.Sprintf(`
-			var user = %s;
-			var tokenPayload = %s;
-			var customFunction = %s;
-			var functionRes = JSON.stringify(customFunction(user, tokenPayload));
-		`, string(userBytes), string(claimBytes), accessTokenScript))
-
-		val, err := vm.Get("functionRes")
-		if err != nil {
-			log.Debug("error getting custom access token script: ", err)
-		} else {
-			extraPayload := make(map[string]interface{})
-			err = json.Unmarshal([]byte(fmt.Sprintf("%s", val)), &extraPayload)
-			if err != nil {
-				log.Debug("error converting accessTokenScript response to map: ", err)
-			} else {
-				for k, v := range extraPayload {
-					customClaims[k] = v
-				}
-			}
-		}
+		return nil, 0, err
 	}
-	token, err := SignJWTToken(customClaims)
-	if err != nil {
-		return "", 0, err
+	for k, v := range scopeClaims {
+		if _, ok := customClaims[k]; !ok {
+			customClaims[k] = v
+		}
 	}
-
-	return token, expiresAt, nil
+	return customClaims, expiresAt, nil
 }
 
 // GetAccessToken returns the access token from the request (either from header or cookie)
@@ -240,16 +309,43 @@ func GetAccessToken(gc *gin.Context) (string, error) {
 		return "", fmt.Errorf(`unauthorized`)
 	}
 
-	if strings.ToLower(authSplit[0]) != "bearer" {
+	// DPoP bound tokens are sent with DPoP scheme
+	scheme := strings.ToLower(authSplit[0])
+	if scheme != "bearer" && scheme != "dpop" {
 		return "", fmt.Errorf(`not a bearer token`)
 	}
 
-	token := strings.TrimPrefix(auth, "Bearer ")
-	return token, nil
+	return authSplit[1], nil
 }
 
 // Function to validate access token for authorizer apis (profile, update_profile)
+// DPoP bound access token is only valid when sent with DPoP scheme along with proof of the request.
 func ValidateAccessToken(gc *gin.Context, accessToken string) (map[string]interface{}, error) {
+	res, err := validateAccessToken(gc, accessToken)
+	if err != nil {
+		return res, err
+	}
+	if err := validateDPoPBoundRequest(gc, res, accessToken); err != nil {
+		return res, err
+	}
+	extendSessionByNonce(getSessionKeyFromClaims(res), res["nonce"].(string))
+	return res, nil
+}
+
+// ValidateAccessTokenWithoutProof validates access token that is not sent as authorization of the request,
+// eg: subject token of token exchange. DPoP binding is not enforced, caller should match the key of
+// DPoP bound token with proof presented for the request.
+func ValidateAccessTokenWithoutProof(gc *gin.Context, accessToken string) (map[string]interface{}, error) {
+	res, err := validateAccessToken(gc, accessToken)
+	if err != nil {
+		return res, err
+	}
+	extendSessionByNonce(getSessionKeyFromClaims(res), res["nonce"].(string))
+	return res, nil
+}
+
+// validateAccessToken validates access token against the session store and its claims
+func validateAccessToken(gc *gin.Context, accessToken string) (map[string]interface{}, error) {
 	res := make(map[string]interface{})
 
 	if accessToken == "" {
@@ -261,8 +357,9 @@ func ValidateAccessToken(gc *gin.Context, accessToken string) (map[string]interf
 		return res, err
 	}
 
-	userID := res["sub"].(string)
-	nonce := res["nonce"].(string)
+	// exchanged tokens do not have nonce and are never saved in session store
+	userID, _ := res["sub"].(string)
+	nonce, _ := res["nonce"].(string)
 	loginMethod := res["login_method"]
 	sessionKey := userID
 	if loginMethod != nil && loginMethod != "" {
@@ -290,6 +387,15 @@ func ValidateAccessToken(gc *gin.Context, accessToken string) (map[string]interf
 	return res, nil
 }
 
+// getSessionKeyFromClaims returns the memory store key of session to which token belongs
+func getSessionKeyFromClaims(claims map[string]interface{}) string {
+	userID, _ := claims["sub"].(string)
+	if loginMethod, _ := claims["login_method"].(string); loginMethod != "" {
+		return loginMethod + ":" + userID
+	}
+	return userID
+}
+
 // Function to validate refreshToken
 func ValidateRefreshToken(gc *gin.Context, refreshToken string) (map[string]interface{}, error) {
 	res := make(map[string]interface{})
@@ -312,6 +418,9 @@ func ValidateRefreshToken(gc *gin.Context, refreshToken string) (map[string]inte
 	}
 	token, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeRefreshToken+"_"+nonce)
 	if nonce == "" || err != nil {
+		if nonce != "" && isRefreshTokenUsed(sessionKey, nonce) {
+			return res, ErrRefreshTokenReused
+		}
 		return res, fmt.Errorf(`unauthorized`)
 	}
 
@@ -365,27 +474,46 @@ func ValidateBrowserSession(gc *gin.Context, encryptedSession string) (*SessionD
 		return nil, fmt.Errorf(`unauthorized: token expired`)
 	}
 
+	extendSession(sessionStoreKey, res.Nonce, res.ExpiresAt)
 	return &res, nil
 }
 
 // CreateIDToken util to create JWT token, based on
-// user information, roles config and CUSTOM_ACCESS_TOKEN_SCRIPT
+// user information, roles config and CUSTOM_ID_TOKEN_SCRIPT
 // For response_type (code) / authorization_code grant nonce should be empty
-// for implicit flow it should be present to verify with actual state
-func CreateIDToken(user *models.User, roles []string, hostname, nonce, atHash, cHash, loginMethod string) (string, int64, error) {
-	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+// for implicit flow it should be present to verify with actual state.
+// Audience is the registered client when clientID is not empty.
+func CreateIDToken(user *models.User, roles, scopes []string, hostname, nonce, atHash, cHash, loginMethod, clientID string) (string, int64, error) {
+	customClaims, expiresAt, err := getIDTokenClaims(user, roles, scopes, hostname, nonce, atHash, cHash, loginMethod, clientID)
 	if err != nil {
 		return "", 0, err
 	}
+	applyCustomScript(constants.TokenTypeIdentityToken, user, customClaims, loginMethod)
+
+	token, err := SignJWTToken(customClaims)
+	if err != nil {
+		return "", 0, err
+	}
+
+	return token, expiresAt, nil
+}
+
+// getIDTokenClaims returns the claims of id token before custom script is applied
+func getIDTokenClaims(user *models.User, roles, scopes []string, hostname, nonce, atHash, cHash, loginMethod, registeredClientID string) (jwt.MapClaims, int64, error) {
+	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+	if err != nil {
+		return nil, 0, err
+	}
 	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
 	if err != nil {
 		expiryBound = time.Minute * 30
 	}
 	expiresAt := time.Now().Add(expiryBound).Unix()
-	resUser := user.AsAPIUser()
-	userBytes, _ := json.Marshal(&resUser)
-	var userMap map[string]interface{}
-	json.Unmarshal(userBytes, &userMap)
+	// user claims are filtered by the granted scopes
+	userClaims, err := getScopedUserClaims(user, scopes, true)
+	if err != nil {
+		return nil, 0, err
+	}
 	claimKey, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJwtRoleClaim)
 	if err != nil {
 		claimKey = "roles"
@@ -393,7 +521,7 @@ func CreateIDToken(user *models.User, roles []string, hostname, nonce, atHash, c
 
 	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
 	if err != nil {
-		return "", 0, err
+		return nil, 0, err
 	}
 
 	customClaims := jwt.MapClaims{
@@ -407,6 +535,14 @@ func CreateIDToken(user *models.User, roles []string, hostname, nonce, atHash, c
 		"login_method":  loginMethod,
 		claimKey:        roles,
 	}
+	if registeredClientID != "" {
+		customClaims["aud"] = registeredClientID
+		customClaims["azp"] = registeredClientID
+	}
+	// session id is used to identify the session in logout requests
+	if nonce != "" {
+		customClaims["sid"] = nonce
+	}
 	// split nonce to see if its authorization code grant method
 	if cHash != "" {
 		customClaims["at_hash"] = atHash
@@ -415,49 +551,12 @@ func CreateIDToken(user *models.User, roles []string, hostname, nonce, atHash, c
 		customClaims["nonce"] = nonce
 		customClaims["at_hash"] = atHash
 	}
-	for k, v := range userMap {
-		if k != "roles" {
+	for k, v := range userClaims {
+		if _, ok := customClaims[k]; !ok {
 			customClaims[k] = v
 		}
 	}
-	// check for the extra access token script
-	accessTokenScript, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyCustomAccessTokenScript)
-	if err != nil {
-		log.Debug("Failed to get custom access token script: ", err)
-		accessTokenScript = ""
-	}
-	if accessTokenScript != "" {
-		vm := otto.New()
-		claimBytes, _ := json.Marshal(customClaims)
-		vm.Run(fmt.Sprintf(`
-			var user = %s;
-			var tokenPayload = %s;
-			var customFunction = %s;
-			var functionRes = JSON.stringify(customFunction(user, tokenPayload));
-		`, string(userBytes), string(claimBytes), accessTokenScript))
-
-		val, err := vm.Get("functionRes")
-		if err != nil {
-			log.Debug("error getting custom access token script: ", err)
-		} else {
-			extraPayload := make(map[string]interface{})
-			err = json.Unmarshal([]byte(fmt.Sprintf("%s", val)), &extraPayload)
-			if err != nil {
-				log.Debug("error converting accessTokenScript response to map: ", err)
-			} else {
-				for k, v := range extraPayload {
-					customClaims[k] = v
-				}
-			}
-		}
-	}
-
-	token, err := SignJWTToken(customClaims)
-	if err != nil {
-		return "", 0, err
-	}
-
-	return token, expiresAt, nil
+	return customClaims, expiresAt, nil
 }
 
 // GetIDToken returns the id token from the request header
@@ -473,12 +572,13 @@ func GetIDToken(gc *gin.Context) (string, error) {
 		return "", fmt.Errorf(`unauthorized`)
 	}
 
-	if strings.ToLower(authSplit[0]) != "bearer" {
+	// DPoP bound tokens are sent with DPoP scheme
+	scheme := strings.ToLower(authSplit[0])
+	if scheme != "bearer" && scheme != "dpop" {
 		return "", fmt.Errorf(`not a bearer token`)
 	}
 
-	token := strings.TrimPrefix(auth, "Bearer ")
-	return token, nil
+	return authSplit[1], nil
 }
 
 // SessionOrAccessTokenData is a struct to hold session or access token data
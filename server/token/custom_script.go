@@ -0,0 +1,266 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	runtimemetrics "runtime/metrics"
+	"strings"
+	"time"
+
+	"github.com/robertkrimen/otto"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
+)
+
+// Custom scripts are configured by admin and receive user, payload and context
+// of the request: function(user, tokenPayload, context) { return {...} }.
+// They run in a fresh vm which is interrupted once the timeout or allocation limit is reached.
+const (
+	// CustomScriptTypeUserInfo is the type of script run for /userinfo response
+	CustomScriptTypeUserInfo = "userinfo"
+
+	customScriptTimeout       = 500 * time.Millisecond
+	maxCustomScriptSize       = 64 * 1024
+	maxCustomScriptResultSize = 64 * 1024
+	// maxCustomScriptAllocBytes is the memory script can allocate while it runs
+	maxCustomScriptAllocBytes = 128 * 1024 * 1024
+	// customScriptAllocCheckInterval is the interval at which allocations of script are checked
+	customScriptAllocCheckInterval = 5 * time.Millisecond
+)
+
+var (
+	// errCustomScriptTimeout is raised in vm when script runs longer than customScriptTimeout
+	errCustomScriptTimeout = errors.New("custom script timed out")
+	// errCustomScriptAllocLimit is raised in vm when script allocates more than maxCustomScriptAllocBytes
+	errCustomScriptAllocLimit = fmt.Errorf("custom script exceeds memory limit of %d bytes", maxCustomScriptAllocBytes)
+)
+
+// protectedClaims cannot be set or overridden by custom scripts
+var protectedClaims = []string{"iss", "sub", "aud", "exp", "iat", "nbf", "jti", "token_type", "nonce", "cnf", "at_hash", "c_hash", "sid", "client_id", "azp"}
+
+// runCustomScript executes the script and returns the claims returned by it,
+// protected claims are dropped from the result
+func runCustomScript(script string, user *models.User, payload, scriptContext map[string]interface{}) (res map[string]interface{}, err error) {
+	if len(script) > maxCustomScriptSize {
+		return nil, fmt.Errorf("custom script exceeds %d bytes", maxCustomScriptSize)
+	}
+	userBytes, err := json.Marshal(user.AsAPIUser())
+	if err != nil {
+		return nil, err
+	}
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	contextBytes, err := json.Marshal(scriptContext)
+	if err != nil {
+		return nil, err
+	}
+
+	vm := otto.New()
+	vm.Interrupt = make(chan func(), 1)
+	defer func() {
+		if caught := recover(); caught != nil {
+			if caught == errCustomScriptTimeout || caught == errCustomScriptAllocLimit {
+				res, err = nil, caught.(error)
+				return
+			}
+			panic(caught)
+		}
+	}()
+	done := make(chan struct{})
+	defer close(done)
+	go watchCustomScript(vm, done)
+
+	// data is passed as json strings, so that it is never evaluated as code
+	vm.Set("userJSON", string(userBytes))
+	vm.Set("tokenPayloadJSON", string(payloadBytes))
+	vm.Set("contextJSON", string(contextBytes))
+	if _, err := vm.Run("var customFunction = " + script + ";"); err != nil {
+		return nil, fmt.Errorf("invalid custom script: %s", err.Error())
+	}
+	val, err := vm.Run(`JSON.stringify(customFunction(JSON.parse(userJSON), JSON.parse(tokenPayloadJSON), JSON.parse(contextJSON)))`)
+	if err != nil {
+		return nil, fmt.Errorf("custom script failed: %s", err.Error())
+	}
+	res = make(map[string]interface{})
+	if val.IsUndefined() || val.IsNull() {
+		return res, nil
+	}
+	resString := val.String()
+	if len(resString) > maxCustomScriptResultSize {
+		return nil, fmt.Errorf("custom script result exceeds %d bytes", maxCustomScriptResultSize)
+	}
+	if err := json.Unmarshal([]byte(resString), &res); err != nil {
+		return nil, fmt.Errorf("custom script should return an object: %s", err.Error())
+	}
+	for _, claim := range protectedClaims {
+		delete(res, claim)
+	}
+	return res, nil
+}
+
+// watchCustomScript interrupts the vm when script runs longer than customScriptTimeout
+// or allocates more than maxCustomScriptAllocBytes, until done is closed.
+// Go runtime does not count allocations per goroutine, so allocations of the
+// whole process are counted, which can only stop the script earlier.
+func watchCustomScript(vm *otto.Otto, done <-chan struct{}) {
+	startAllocBytes := heapAllocBytes()
+	timeout := time.NewTimer(customScriptTimeout)
+	defer timeout.Stop()
+	ticker := time.NewTicker(customScriptAllocCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-timeout.C:
+			vm.Interrupt <- func() {
+				panic(errCustomScriptTimeout)
+			}
+			return
+		case <-ticker.C:
+			if heapAllocBytes()-startAllocBytes > maxCustomScriptAllocBytes {
+				vm.Interrupt <- func() {
+					panic(errCustomScriptAllocLimit)
+				}
+				return
+			}
+		}
+	}
+}
+
+// heapAllocBytes returns the bytes allocated on heap since the process started
+func heapAllocBytes() uint64 {
+	sample := []runtimemetrics.Sample{{Name: "/gc/heap/allocs:bytes"}}
+	runtimemetrics.Read(sample)
+	if sample[0].Value.Kind() != runtimemetrics.KindUint64 {
+		return 0
+	}
+	return sample[0].Value.Uint64()
+}
+
+// ValidateCustomScript checks that the script can be compiled, it does not run the script
+func ValidateCustomScript(script string) error {
+	if len(script) > maxCustomScriptSize {
+		return fmt.Errorf("custom script exceeds %d bytes", maxCustomScriptSize)
+	}
+	if _, err := otto.New().Compile("", "var customFunction = "+script+";"); err != nil {
+		return fmt.Errorf("invalid custom script: %s", err.Error())
+	}
+	return nil
+}
+
+// getCustomScript returns the script configured for given type,
+// id token falls back to access token script for backward compatibility
+func getCustomScript(scriptType string) string {
+	envKey := constants.EnvKeyCustomAccessTokenScript
+	switch scriptType {
+	case constants.TokenTypeIdentityToken:
+		envKey = constants.EnvKeyCustomIDTokenScript
+	case CustomScriptTypeUserInfo:
+		envKey = constants.EnvKeyCustomUserInfoScript
+	}
+	script, err := memorystore.Provider.GetStringStoreEnvVariable(envKey)
+	if err != nil {
+		log.Debug("Failed to get custom script: ", err)
+		script = ""
+	}
+	if strings.TrimSpace(script) == "" && scriptType == constants.TokenTypeIdentityToken {
+		return getCustomScript(constants.TokenTypeAccessToken)
+	}
+	return strings.TrimSpace(script)
+}
+
+// getCustomScriptContext returns the context passed to custom scripts
+func getCustomScriptContext(scriptType, loginMethod string) map[string]interface{} {
+	clientID, _ := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	return map[string]interface{}{
+		"client_id":    clientID,
+		"type":         scriptType,
+		"login_method": loginMethod,
+	}
+}
+
+// applyCustomScript runs the configured script of given type and adds the
+// returned claims to payload. Payload is left as is if the script fails.
+func applyCustomScript(scriptType string, user *models.User, payload map[string]interface{}, loginMethod string) {
+	script := getCustomScript(scriptType)
+	if script == "" {
+		return
+	}
+	extraClaims, err := runCustomScript(script, user, payload, getCustomScriptContext(scriptType, loginMethod))
+	if err != nil {
+		metrics.RecordCustomScriptFailure(scriptType)
+		log.WithFields(log.Fields{
+			"user_id": user.ID,
+			"type":    scriptType,
+		}).Warn("Failed to run custom script: ", err)
+		return
+	}
+	for k, v := range extraClaims {
+		payload[k] = v
+	}
+}
+
+// ApplyUserInfoScript adds the claims returned by CUSTOM_USER_INFO_SCRIPT to userinfo response
+func ApplyUserInfoScript(user *models.User, userInfo map[string]interface{}) {
+	applyCustomScript(CustomScriptTypeUserInfo, user, userInfo, "")
+}
+
+// DryRunCustomScript runs the given script for the user without issuing any token,
+// it returns the payload that would be issued with the script
+func DryRunCustomScript(hostname, scriptType, script string, user *models.User) (map[string]interface{}, error) {
+	roles := strings.Split(user.Roles, ",")
+	scope := []string{"openid", "email", "profile"}
+	var payload map[string]interface{}
+	switch scriptType {
+	case constants.TokenTypeAccessToken:
+		claims, _, err := getAccessTokenClaims(user, roles, scope, hostname, "", "")
+		if err != nil {
+			return nil, err
+		}
+		payload = claims
+	case constants.TokenTypeIdentityToken:
+		claims, _, err := getIDTokenClaims(user, roles, scope, hostname, "", "", "", "", "")
+		if err != nil {
+			return nil, err
+		}
+		payload = claims
+	case CustomScriptTypeUserInfo:
+		claims, err := GetUserInfoClaims(user, scope)
+		if err != nil {
+			return nil, err
+		}
+		payload = claims
+	default:
+		return nil, fmt.Errorf("invalid script type %s", scriptType)
+	}
+
+	extraClaims, err := runCustomScript(script, user, payload, getCustomScriptContext(scriptType, ""))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range extraClaims {
+		payload[k] = v
+	}
+	return payload, nil
+}
+
+// GetUserInfoClaims returns the claims of user sent in /userinfo response,
+// claims are filtered by the scopes of access token
+func GetUserInfoClaims(user *models.User, scopes []string) (map[string]interface{}, error) {
+	res, err := getScopedUserClaims(user, scopes, true)
+	if err != nil {
+		return nil, err
+	}
+	// add sub field to user as per openid standards
+	// https://github.com/authorizerdev/authorizer/issues/327
+	res["sub"] = user.ID
+	return res, nil
+}
@@ -0,0 +1,194 @@
+package token
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/square/go-jose.v2"
+
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+)
+
+const (
+	// DPoPHeader is the request header containing DPoP proof
+	DPoPHeader = "DPoP"
+	// TokenTypeDPoP is the token_type of DPoP bound tokens
+	TokenTypeDPoP = "DPoP"
+	// dpopProofType is the typ header of DPoP proof
+	dpopProofType = "dpop+jwt"
+	// dpopProofMaxAge is the time for which DPoP proof is accepted after it is issued
+	dpopProofMaxAge = 60 * time.Second
+	// dpopJTIStoreKey is the memory store key to save used proof ids
+	dpopJTIStoreKey = "dpop_jti"
+	// dpopValidatedContextKey is the gin context key of access token whose proof is validated in the request,
+	// as proof can only be used once but access token can be validated more than once per request
+	dpopValidatedContextKey = "dpop_validated_access_token"
+)
+
+// ErrInvalidDPoPProof is returned when DPoP bound access token is used without valid proof
+var ErrInvalidDPoPProof = errors.New("unauthorized: invalid dpop proof")
+
+// DPoPSigningAlgValuesSupported returns the algorithms supported for signing DPoP proof
+func DPoPSigningAlgValuesSupported() []string {
+	return []string{
+		string(jose.RS256), string(jose.RS384), string(jose.RS512),
+		string(jose.PS256), string(jose.PS384), string(jose.PS512),
+		string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	}
+}
+
+// dpopProofClaims are the claims of DPoP proof JWT
+type dpopProofClaims struct {
+	JTI string `json:"jti"`
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	IAT int64  `json:"iat"`
+	ATH string `json:"ath"`
+}
+
+// GetDPoPRequestURL returns the url of request to validate htu claim of DPoP proof
+func GetDPoPRequestURL(gc *gin.Context) string {
+	return parsers.GetHost(gc) + gc.Request.URL.Path
+}
+
+// normalizeDPoPURL removes query and fragment from url as they are not part of htu
+func normalizeDPoPURL(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + strings.TrimSuffix(u.Path, "/")
+}
+
+// ValidateDPoPProof validates the DPoP proof for given http method and url.
+// When accessToken is not empty, proof should contain its hash in ath claim.
+// It returns the JWK SHA-256 thumbprint (jkt) of the key used for signing the proof.
+func ValidateDPoPProof(proof, htm, htu, accessToken string) (string, error) {
+	jws, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", fmt.Errorf("invalid dpop proof: %s", err.Error())
+	}
+	if len(jws.Signatures) != 1 {
+		return "", fmt.Errorf("invalid dpop proof: expected single signature")
+	}
+	header := jws.Signatures[0].Protected
+	if typ, ok := header.ExtraHeaders[jose.HeaderType].(string); !ok || typ != dpopProofType {
+		return "", fmt.Errorf("invalid dpop proof: typ should be %s", dpopProofType)
+	}
+	isSupportedAlg := false
+	for _, alg := range DPoPSigningAlgValuesSupported() {
+		if alg == header.Algorithm {
+			isSupportedAlg = true
+			break
+		}
+	}
+	if !isSupportedAlg {
+		return "", fmt.Errorf("invalid dpop proof: unsupported alg %s", header.Algorithm)
+	}
+	jwk := header.JSONWebKey
+	if jwk == nil || !jwk.Valid() || !jwk.IsPublic() {
+		return "", fmt.Errorf("invalid dpop proof: public jwk is required")
+	}
+	payload, err := jws.Verify(jwk)
+	if err != nil {
+		return "", fmt.Errorf("invalid dpop proof: %s", err.Error())
+	}
+	var claims dpopProofClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("invalid dpop proof: %s", err.Error())
+	}
+	if claims.JTI == "" || claims.HTM == "" || claims.HTU == "" {
+		return "", fmt.Errorf("invalid dpop proof: jti, htm and htu are required")
+	}
+	if !strings.EqualFold(claims.HTM, htm) {
+		return "", fmt.Errorf("invalid dpop proof: htm mismatch")
+	}
+	if normalizeDPoPURL(claims.HTU) != normalizeDPoPURL(htu) {
+		return "", fmt.Errorf("invalid dpop proof: htu mismatch")
+	}
+	now := time.Now()
+	issuedAt := time.Unix(claims.IAT, 0)
+	// allow small clock skew for proofs issued in future
+	if issuedAt.Before(now.Add(-dpopProofMaxAge)) || issuedAt.After(now.Add(5*time.Second)) {
+		return "", fmt.Errorf("invalid dpop proof: iat is not recent")
+	}
+	if accessToken != "" {
+		ath := sha256.Sum256([]byte(accessToken))
+		if claims.ATH != base64.RawURLEncoding.EncodeToString(ath[:]) {
+			return "", fmt.Errorf("invalid dpop proof: ath mismatch")
+		}
+	}
+	// proof can only be used once
+	if val, err := memorystore.Provider.GetUserSession(dpopJTIStoreKey, claims.JTI); err == nil && val != "" {
+		return "", fmt.Errorf("invalid dpop proof: jti already used")
+	}
+	if err := memorystore.Provider.SetUserSession(dpopJTIStoreKey, claims.JTI, claims.JTI, issuedAt.Add(dpopProofMaxAge).Unix()); err != nil {
+		return "", err
+	}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
+
+// GetDPoPJKT returns the thumbprint of DPoP key to which token is bound,
+// empty string is returned for bearer tokens
+func GetDPoPJKT(claims map[string]interface{}) string {
+	cnf, ok := claims["cnf"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	jkt, _ := cnf["jkt"].(string)
+	return jkt
+}
+
+// ValidateDPoPBoundToken enforces DPoP proof for tokens bound to DPoP key.
+// It is no-op for bearer tokens.
+func ValidateDPoPBoundToken(claims map[string]interface{}, proof, htm, htu, accessToken string) error {
+	jkt := GetDPoPJKT(claims)
+	if jkt == "" {
+		return nil
+	}
+	if proof == "" {
+		return fmt.Errorf("unauthorized: dpop proof is required")
+	}
+	proofJKT, err := ValidateDPoPProof(proof, htm, htu, accessToken)
+	if err != nil {
+		return err
+	}
+	if proofJKT != jkt {
+		return fmt.Errorf("unauthorized: dpop key mismatch")
+	}
+	return nil
+}
+
+// validateDPoPBoundRequest enforces that DPoP bound access token is sent with DPoP
+// authorization scheme along with proof for the request. It is no-op for bearer tokens.
+func validateDPoPBoundRequest(gc *gin.Context, claims map[string]interface{}, accessToken string) error {
+	if GetDPoPJKT(claims) == "" {
+		return nil
+	}
+	if validated, ok := gc.Get(dpopValidatedContextKey); ok && validated == accessToken {
+		return nil
+	}
+	authSplit := strings.Split(gc.GetHeader("Authorization"), " ")
+	if len(authSplit) != 2 || !strings.EqualFold(authSplit[0], TokenTypeDPoP) || authSplit[1] != accessToken {
+		return fmt.Errorf("%w: dpop authorization scheme is required", ErrInvalidDPoPProof)
+	}
+	if err := ValidateDPoPBoundToken(claims, gc.GetHeader(DPoPHeader), gc.Request.Method, GetDPoPRequestURL(gc), accessToken); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDPoPProof, err.Error())
+	}
+	gc.Set(dpopValidatedContextKey, accessToken)
+	return nil
+}
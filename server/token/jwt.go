@@ -1,7 +1,9 @@
 package token
 
 import (
+	"encoding/json"
 	"errors"
+	"time"
 
 	"github.com/golang-jwt/jwt"
 
@@ -120,6 +122,29 @@ func ParseJWTToken(token string) (jwt.MapClaims, error) {
 	return claims, nil
 }
 
+// CheckJWTKeys signs and parses a short lived token to check that
+// configured signing keys can be loaded and that they match each other
+func CheckJWTKeys() error {
+	jwk, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyJWK)
+	if err != nil {
+		return err
+	}
+	var data map[string]string
+	if err := json.Unmarshal([]byte(jwk), &data); err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	signedToken, err := SignJWTToken(jwt.MapClaims{
+		"iat": now,
+		"exp": now + 60,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = ParseJWTToken(signedToken)
+	return err
+}
+
 // ValidateJWTClaims common util to validate claims
 func ValidateJWTClaims(claims jwt.MapClaims, hostname, nonce, subject string) (bool, error) {
 	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
@@ -0,0 +1,35 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/cookie"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// ValidateLinkToken returns the user for whom the link token of link_identity mutation was issued.
+// Link token travels in the urls of oauth flow, so it is only accepted along with
+// the browser session of same user, else anyone could link their account to the user
+// who completes the oauth flow using it.
+func ValidateLinkToken(gc *gin.Context, linkToken string) (string, error) {
+	userID, err := memorystore.Provider.GetState(linkToken)
+	if err != nil || userID == "" {
+		log.Debug("Invalid link token: ", err)
+		return "", fmt.Errorf("invalid link token")
+	}
+	sessionToken, err := cookie.GetSession(gc)
+	if err != nil {
+		log.Debug("Failed to get session for link token: ", err)
+		return "", fmt.Errorf("unauthorized")
+	}
+	sessionData, err := ValidateBrowserSession(gc, sessionToken)
+	if err != nil || sessionData.Subject != userID {
+		log.Debug("Link token is not issued for the user of session: ", err)
+		return "", fmt.Errorf("unauthorized")
+	}
+	go memorystore.Provider.RemoveState(linkToken)
+	return userID, nil
+}
@@ -0,0 +1,51 @@
+package token
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// BackchannelLogoutEvent is the event claim of OIDC back-channel logout token
+const BackchannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// CreateLogoutToken creates OIDC back-channel logout token for the client.
+// sid identifies the ended session, when it is empty all sessions of user are ended.
+func CreateLogoutToken(hostname, clientID, userID, sid string) (string, error) {
+	customClaims := jwt.MapClaims{
+		"iss": hostname,
+		"aud": clientID,
+		"sub": userID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(2 * time.Minute).Unix(),
+		"jti": uuid.New().String(),
+		"events": map[string]interface{}{
+			BackchannelLogoutEvent: map[string]interface{}{},
+		},
+	}
+	if sid != "" {
+		customClaims["sid"] = sid
+	}
+	return SignJWTToken(customClaims)
+}
+
+// ParseIDTokenHint parses the id token sent as hint, for example in logout request.
+// Expired tokens are accepted as hint, but the signature should be valid.
+// expired is true when the hint has expired, caller should not trust it alone to identify the session.
+func ParseIDTokenHint(idTokenHint string) (claims jwt.MapClaims, expired bool, err error) {
+	claims, err = ParseJWTToken(idTokenHint)
+	if err == nil {
+		return claims, false, nil
+	}
+	validationErr, ok := err.(*jwt.ValidationError)
+	if !ok || validationErr.Errors != jwt.ValidationErrorExpired {
+		return nil, false, err
+	}
+	if claims["token_type"] != nil && claims["token_type"] != constants.TokenTypeIdentityToken {
+		return nil, false, err
+	}
+	return claims, true, nil
+}
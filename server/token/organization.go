@@ -0,0 +1,33 @@
+package token
+
+import (
+	"context"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+)
+
+// getOrganizationClaim returns the organization claim of access token,
+// it has the roles user has in the organization
+func getOrganizationClaim(user *models.User, organizationID string) (map[string]interface{}, error) {
+	ctx := context.Background()
+	membership, err := db.Provider.GetMembershipByOrganizationIDAndUserID(ctx, organizationID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	organization, err := db.Provider.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"id":    organizationID,
+		"name":  organization.Name,
+		"roles": membership.GetRoles(),
+	}, nil
+}
+
+// GetOrganizationID returns the organization selected for the session from refresh token claims
+func GetOrganizationID(claims map[string]interface{}) string {
+	organizationID, _ := claims["organization_id"].(string)
+	return organizationID
+}
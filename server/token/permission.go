@@ -0,0 +1,25 @@
+package token
+
+import (
+	"context"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// GetPermissions returns the permissions granted by given roles,
+// roles without definition do not grant any permission
+func GetPermissions(ctx context.Context, roles []string) []string {
+	permissions := []string{}
+	for _, name := range roles {
+		role, err := db.Provider.GetRoleByName(ctx, name)
+		if err != nil {
+			log.Debug("Failed to get role: ", err)
+			continue
+		}
+		permissions = append(permissions, role.GetPermissions()...)
+	}
+	return utils.RemoveDuplicateString(permissions)
+}
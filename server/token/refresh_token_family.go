@@ -0,0 +1,96 @@
+package token
+
+import (
+	"errors"
+	"time"
+
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// Refresh tokens are rotated on every use. All the refresh tokens issued
+// by rotating the same initial token belong to one family, so that the
+// whole family can be revoked when an already rotated token is presented.
+const (
+	// memory store key prefix of refresh tokens that are already rotated, value is family id
+	usedRefreshTokenPrefix = "used_refresh_token_"
+	// memory store key prefix of refresh token family, value is nonce of latest refresh token
+	refreshTokenFamilyPrefix = "refresh_token_family_"
+	// memory store key prefix of refresh token family revoked because of reuse
+	revokedRefreshTokenFamilyPrefix = "revoked_refresh_token_family_"
+)
+
+// ErrRefreshTokenReused is returned when refresh token that was already rotated is presented
+var ErrRefreshTokenReused = errors.New("unauthorized: refresh token reused")
+
+// GetRefreshTokenFamilyID returns the family id from refresh token claims,
+// refresh tokens issued before families existed are treated as family of their own
+func GetRefreshTokenFamilyID(claims map[string]interface{}) string {
+	if familyID, ok := claims["family_id"].(string); ok && familyID != "" {
+		return familyID
+	}
+	nonce, _ := claims["nonce"].(string)
+	return nonce
+}
+
+// MarkRefreshTokenUsed marks the refresh token with given nonce as used.
+// It should be called before new refresh token is issued, so that the token
+// presented again while it is being rotated is detected as reused.
+// Token is marked only if it is not marked already, hence only one of the concurrent
+// requests with the same token can rotate it, others get ErrRefreshTokenReused.
+func MarkRefreshTokenUsed(sessionKey, familyID, usedNonce string, usedExpiresAt int64) error {
+	ok, err := memorystore.Provider.SetUserSessionIfNotExists(sessionKey, usedRefreshTokenPrefix+usedNonce, familyID, usedExpiresAt)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// RotateRefreshTokenFamily saves the nonce of new refresh token as latest of the family.
+// It returns ErrRefreshTokenReused if family was revoked while the token was rotated,
+// in which case new refresh token must not be handed out.
+func RotateRefreshTokenFamily(sessionKey, familyID, newNonce string, newExpiresAt int64) error {
+	if err := memorystore.Provider.SetUserSession(sessionKey, refreshTokenFamilyPrefix+familyID, newNonce, newExpiresAt); err != nil {
+		return err
+	}
+	if isRefreshTokenFamilyRevoked(sessionKey, familyID) {
+		return ErrRefreshTokenReused
+	}
+	return nil
+}
+
+// isRefreshTokenUsed returns true if refresh token with given nonce was already rotated
+func isRefreshTokenUsed(sessionKey, nonce string) bool {
+	familyID, err := memorystore.Provider.GetUserSession(sessionKey, usedRefreshTokenPrefix+nonce)
+	return err == nil && familyID != ""
+}
+
+// isRefreshTokenFamilyRevoked returns true if refresh token family was revoked because of reuse
+func isRefreshTokenFamilyRevoked(sessionKey, familyID string) bool {
+	revoked, err := memorystore.Provider.GetUserSession(sessionKey, revokedRefreshTokenFamilyPrefix+familyID)
+	return err == nil && revoked != ""
+}
+
+// RevokeRefreshTokenFamily marks the family as revoked and deletes the latest refresh token
+// of the family along with its browser session and access token. It returns the nonce of revoked session.
+// Family stays revoked till the refresh tokens started at authTime can expire.
+func RevokeRefreshTokenFamily(sessionKey, familyID string, authTime int64) (string, error) {
+	revokedUntil := authTime + int64(getRefreshTokenExpiryTime(nil).Seconds())
+	if now := time.Now().Unix(); revokedUntil <= now {
+		revokedUntil = now + 1
+	}
+	if err := memorystore.Provider.SetUserSession(sessionKey, revokedRefreshTokenFamilyPrefix+familyID, familyID, revokedUntil); err != nil {
+		return "", err
+	}
+	nonce, err := memorystore.Provider.GetUserSession(sessionKey, refreshTokenFamilyPrefix+familyID)
+	if err != nil || nonce == "" {
+		// family was never rotated, hence initial token is the latest one
+		nonce = familyID
+	}
+	if err := memorystore.Provider.DeleteUserSession(sessionKey, nonce); err != nil {
+		return "", err
+	}
+	return nonce, nil
+}
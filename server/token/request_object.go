@@ -0,0 +1,13 @@
+package token
+
+import "gopkg.in/square/go-jose.v2"
+
+// RequestObjectSigningAlgValuesSupported returns the algorithms supported for signing request object,
+// only asymmetric algorithms are supported as request object is verified with the public keys of client
+func RequestObjectSigningAlgValuesSupported() []string {
+	return []string{
+		string(jose.RS256), string(jose.RS384), string(jose.RS512),
+		string(jose.PS256), string(jose.PS384), string(jose.PS512),
+		string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	}
+}
@@ -0,0 +1,37 @@
+package token
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// DropExpiredRoles removes the roles with expired grants from given roles and user roles,
+// as background sweep might not have removed them from user yet, or it might be disabled.
+// It returns a copy of user, so that the caller's user object is not changed.
+func DropExpiredRoles(user *models.User, roles []string) (*models.User, []string) {
+	roleGrants, err := db.Provider.ListRoleGrantsByUserID(context.Background(), user.ID)
+	if err != nil {
+		log.Debug("Failed to list role grants: ", err)
+		return user, roles
+	}
+	now := time.Now().Unix()
+	expiredRoles := []string{}
+	for _, roleGrant := range roleGrants {
+		if roleGrant.IsExpired(now) {
+			expiredRoles = append(expiredRoles, roleGrant.Role)
+		}
+	}
+	if len(expiredRoles) == 0 {
+		return user, roles
+	}
+	filteredUser := *user
+	filteredUser.Roles = strings.Join(utils.DeleteFromArray(strings.Split(user.Roles, ","), expiredRoles), ",")
+	return &filteredUser, utils.DeleteFromArray(roles, expiredRoles)
+}
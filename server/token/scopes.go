@@ -0,0 +1,185 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// appDataClaimPrefix is used in custom scope claims to map app_data keys, eg: app_data.plan
+const appDataClaimPrefix = "app_data."
+
+// CustomScope is the scope defined by admin with CUSTOM_SCOPES env
+type CustomScope struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Roles that can be granted the scope, it is allowed for all roles when empty
+	Roles []string `json:"roles"`
+	// Claims are the user fields or app_data keys (app_data.<key>) added for the scope
+	Claims []string `json:"claims"`
+}
+
+// StandardScopes are the scopes supported without any configuration
+var StandardScopes = []string{"openid", "profile", "email", "phone", "offline_access"}
+
+// standardScopeClaims are the user claims returned for standard scopes.
+// profile also includes authorizer specific user fields.
+var standardScopeClaims = map[string][]string{
+	"profile": {"id", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "picture", "gender", "birthdate", "signup_methods", "created_at", "updated_at", "revoked_timestamp", "is_multi_factor_auth_enabled", "app_data"},
+	"email":   {"email", "email_verified"},
+	"phone":   {"phone_number", "phone_number_verified"},
+}
+
+// reservedScopeClaims cannot be added by custom scopes as they are set by authorizer
+var reservedScopeClaims = append([]string{"scope", "roles", "allowed_roles", "login_method"}, protectedClaims...)
+
+// userClaims are the user fields which can be mapped by custom scopes
+var userClaims = []string{"id", "email", "email_verified", "signup_methods", "given_name", "family_name", "middle_name", "nickname", "preferred_username", "gender", "birthdate", "phone_number", "phone_number_verified", "picture", "created_at", "updated_at", "revoked_timestamp", "is_multi_factor_auth_enabled", "app_data"}
+
+// ParseCustomScopes parses and validates the CUSTOM_SCOPES json
+func ParseCustomScopes(value string) ([]CustomScope, error) {
+	var scopes []CustomScope
+	if strings.TrimSpace(value) == "" {
+		return scopes, nil
+	}
+	if err := json.Unmarshal([]byte(value), &scopes); err != nil {
+		return nil, fmt.Errorf("invalid custom scopes, expected json array of scopes")
+	}
+	names := []string{}
+	for _, scope := range scopes {
+		if scope.Name == "" || strings.ContainsAny(scope.Name, " \"\\") {
+			return nil, fmt.Errorf("invalid custom scope name %q", scope.Name)
+		}
+		if utils.StringSliceContains(StandardScopes, scope.Name) || utils.StringSliceContains(names, scope.Name) {
+			return nil, fmt.Errorf("custom scope %s is already defined", scope.Name)
+		}
+		names = append(names, scope.Name)
+		for _, claim := range scope.Claims {
+			if strings.HasPrefix(claim, appDataClaimPrefix) {
+				claim = strings.TrimPrefix(claim, appDataClaimPrefix)
+				if claim == "" || utils.StringSliceContains(reservedScopeClaims, claim) {
+					return nil, fmt.Errorf("invalid claim app_data.%s for custom scope %s", claim, scope.Name)
+				}
+				continue
+			}
+			if !utils.StringSliceContains(userClaims, claim) {
+				return nil, fmt.Errorf("invalid claim %s for custom scope %s", claim, scope.Name)
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// GetCustomScopes returns the scopes configured with CUSTOM_SCOPES env
+func GetCustomScopes() []CustomScope {
+	value, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyCustomScopes)
+	if err != nil {
+		return nil
+	}
+	// value is validated before it is saved
+	scopes, _ := ParseCustomScopes(value)
+	return scopes
+}
+
+// GetSupportedScopes returns the standard and custom scope names
+func GetSupportedScopes() []string {
+	scopes := append([]string{}, StandardScopes...)
+	for _, scope := range GetCustomScopes() {
+		scopes = append(scopes, scope.Name)
+	}
+	return scopes
+}
+
+// GetCustomScopeClaims returns the claim names added by custom scopes
+func GetCustomScopeClaims() []string {
+	claims := []string{}
+	for _, scope := range GetCustomScopes() {
+		for _, claim := range scope.Claims {
+			claim = strings.TrimPrefix(claim, appDataClaimPrefix)
+			if !utils.StringSliceContains(claims, claim) {
+				claims = append(claims, claim)
+			}
+		}
+	}
+	return claims
+}
+
+// FilterScopes removes the custom scopes which are not allowed for the roles
+func FilterScopes(scopes, roles []string) []string {
+	customScopes := GetCustomScopes()
+	res := []string{}
+	for _, scope := range scopes {
+		allowed := true
+		for _, customScope := range customScopes {
+			if customScope.Name != scope || len(customScope.Roles) == 0 {
+				continue
+			}
+			allowed = false
+			for _, role := range roles {
+				if utils.StringSliceContains(customScope.Roles, role) {
+					allowed = true
+					break
+				}
+			}
+		}
+		if allowed {
+			res = append(res, scope)
+		}
+	}
+	return res
+}
+
+// GetScopeDescriptions returns the description of custom scopes by name
+func GetScopeDescriptions() map[string]string {
+	res := map[string]string{}
+	for _, scope := range GetCustomScopes() {
+		res[scope.Name] = scope.Description
+	}
+	return res
+}
+
+// getScopedUserClaims returns the user claims allowed by the scopes.
+// Standard claims are included only for id token and userinfo.
+func getScopedUserClaims(user *models.User, scopes []string, includeStandardClaims bool) (map[string]interface{}, error) {
+	userBytes, err := json.Marshal(user.AsAPIUser())
+	if err != nil {
+		return nil, err
+	}
+	userMap := map[string]interface{}{}
+	if err := json.Unmarshal(userBytes, &userMap); err != nil {
+		return nil, err
+	}
+	appData, _ := userMap["app_data"].(map[string]interface{})
+
+	res := map[string]interface{}{}
+	if includeStandardClaims {
+		for _, scope := range scopes {
+			for _, claim := range standardScopeClaims[scope] {
+				if v, ok := userMap[claim]; ok {
+					res[claim] = v
+				}
+			}
+		}
+	}
+	for _, customScope := range GetCustomScopes() {
+		if !utils.StringSliceContains(scopes, customScope.Name) {
+			continue
+		}
+		for _, claim := range customScope.Claims {
+			if strings.HasPrefix(claim, appDataClaimPrefix) {
+				key := strings.TrimPrefix(claim, appDataClaimPrefix)
+				if v, ok := appData[key]; ok {
+					res[key] = v
+				}
+			} else if v, ok := userMap[claim]; ok {
+				res[claim] = v
+			}
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,132 @@
+package token
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+// default lifetime of session and refresh token
+const defaultSessionExpiryTime = time.Hour * 8760
+
+// getDurationEnv returns the duration env variable or given default value
+// if env is not set or is invalid
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	val, err := memorystore.Provider.GetStringStoreEnvVariable(key)
+	if err != nil || val == "" {
+		return defaultValue
+	}
+	duration, err := utils.ParseDurationInSeconds(val)
+	if err != nil {
+		log.Debugf("Invalid duration for %s: %v", key, err)
+		return defaultValue
+	}
+	return duration
+}
+
+// getRoleSessionExpiryTime returns the shortest session lifetime configured
+// for the given roles using SESSION_EXPIRY_TIME_BY_ROLE, 0 if none matches
+func getRoleSessionExpiryTime(roles []string) time.Duration {
+	val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeySessionExpiryTimeByRole)
+	if err != nil || val == "" {
+		return 0
+	}
+	var res time.Duration
+	for _, pair := range strings.Split(val, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || !utils.StringSliceContains(roles, strings.TrimSpace(parts[0])) {
+			continue
+		}
+		duration, err := utils.ParseDurationInSeconds(strings.TrimSpace(parts[1]))
+		if err != nil {
+			log.Debugf("Invalid session expiry time for role %s: %v", parts[0], err)
+			continue
+		}
+		if res == 0 || duration < res {
+			res = duration
+		}
+	}
+	return res
+}
+
+// getSessionExpiryTime returns the absolute lifetime of session for given roles
+func getSessionExpiryTime(roles []string) time.Duration {
+	expiryTime := getDurationEnv(constants.EnvKeySessionExpiryTime, defaultSessionExpiryTime)
+	if roleExpiryTime := getRoleSessionExpiryTime(roles); roleExpiryTime > 0 && roleExpiryTime < expiryTime {
+		expiryTime = roleExpiryTime
+	}
+	return expiryTime
+}
+
+// getRefreshTokenExpiryTime returns the absolute lifetime of refresh token for given roles.
+// Role specific session lifetime also applies to refresh token,
+// else it could be used to keep the session alive.
+func getRefreshTokenExpiryTime(roles []string) time.Duration {
+	expiryTime := getDurationEnv(constants.EnvKeyRefreshTokenExpiryTime, defaultSessionExpiryTime)
+	if roleExpiryTime := getRoleSessionExpiryTime(roles); roleExpiryTime > 0 && roleExpiryTime < expiryTime {
+		expiryTime = roleExpiryTime
+	}
+	return expiryTime
+}
+
+// getSessionIdleTimeout returns the idle timeout of session, 0 if it is disabled
+func getSessionIdleTimeout() time.Duration {
+	return getDurationEnv(constants.EnvKeySessionIdleTimeout, 0)
+}
+
+// getIdleExpiresAt returns the time till which session stays valid without any activity.
+// It is never after the absolute expiry of session.
+func getIdleExpiresAt(expiresAt int64) int64 {
+	now := time.Now().Unix()
+	if idleTimeout := getSessionIdleTimeout(); idleTimeout > 0 {
+		if idleExpiresAt := now + int64(idleTimeout.Seconds()); idleExpiresAt < expiresAt {
+			expiresAt = idleExpiresAt
+		}
+	}
+	// keep already expired sessions for a second instead of passing past time to memory store
+	if expiresAt <= now {
+		expiresAt = now + 1
+	}
+	return expiresAt
+}
+
+// extendSession extends the session token in memory store on activity when idle timeout is enabled
+func extendSession(sessionKey, nonce string, expiresAt int64) {
+	if getSessionIdleTimeout() == 0 {
+		return
+	}
+	if err := memorystore.Provider.ExtendUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+nonce, getIdleExpiresAt(expiresAt)); err != nil {
+		log.Debug("Failed to extend user session: ", err)
+	}
+}
+
+// extendSessionByNonce extends the session token in memory store on activity
+// with access token, absolute expiry is read from the saved session token
+func extendSessionByNonce(sessionKey, nonce string) {
+	if getSessionIdleTimeout() == 0 {
+		return
+	}
+	sessionToken, err := memorystore.Provider.GetUserSession(sessionKey, constants.TokenTypeSessionToken+"_"+nonce)
+	if err != nil || sessionToken == "" {
+		log.Debug("Failed to get user session: ", err)
+		return
+	}
+	decryptedFingerPrint, err := crypto.DecryptAES(sessionToken)
+	if err != nil {
+		log.Debug("Failed to decrypt session token: ", err)
+		return
+	}
+	var sessionData SessionData
+	if err := json.Unmarshal([]byte(decryptedFingerPrint), &sessionData); err != nil {
+		log.Debug("Failed to unmarshal session token: ", err)
+		return
+	}
+	extendSession(sessionKey, nonce, sessionData.ExpiresAt)
+}
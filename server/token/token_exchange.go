@@ -0,0 +1,118 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/parsers"
+	"github.com/authorizerdev/authorizer/server/utils"
+)
+
+const (
+	// GrantTypeTokenExchange is the grant type of token exchange (RFC 8693)
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// TokenTypeURIAccessToken is the token type identifier of access token
+	TokenTypeURIAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// ExchangedToken is the token issued by token exchange grant
+type ExchangedToken struct {
+	Token     string
+	ExpiresAt int64
+	Scope     []string
+}
+
+// IsTokenExchangeAllowed returns true if the client may exchange tokens for the audience,
+// as per TOKEN_EXCHANGE_POLICY (comma separated client_id:audience pairs)
+func IsTokenExchangeAllowed(clientID, audience string) bool {
+	policy, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyTokenExchangePolicy)
+	if err != nil || policy == "" {
+		return false
+	}
+	for _, pair := range strings.Split(policy, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) != clientID {
+			continue
+		}
+		if allowedAudience := strings.TrimSpace(parts[1]); allowedAudience == "*" || allowedAudience == audience {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateExchangedToken creates an access token for the audience on behalf of the subject of
+// subjectClaims. Scope of the token can only be narrowed down. The acting party is recorded
+// in act claim, which is actor token subject if present else the client.
+func CreateExchangedToken(gc *gin.Context, subjectClaims, actorClaims map[string]interface{}, clientID, audience string, scope []string, dpopJKT string) (*ExchangedToken, error) {
+	subjectScope := []string{}
+	for _, s := range utils.ConvertInterfaceToSlice(subjectClaims["scope"]) {
+		if val, ok := s.(string); ok {
+			subjectScope = append(subjectScope, val)
+		}
+	}
+	if len(scope) == 0 {
+		scope = subjectScope
+	}
+	for _, s := range scope {
+		if !utils.StringSliceContains(subjectScope, s) {
+			return nil, fmt.Errorf("invalid scope: %s is not granted to subject token", s)
+		}
+	}
+
+	expireTime, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyAccessTokenExpiryTime)
+	if err != nil {
+		return nil, err
+	}
+	expiryBound, err := utils.ParseDurationInSeconds(expireTime)
+	if err != nil {
+		expiryBound = time.Minute * 30
+	}
+	expiresAt := time.Now().Add(expiryBound).Unix()
+	// exchanged token cannot outlive the subject token
+	if subjectExpiresAt, ok := subjectClaims["exp"].(int64); ok && subjectExpiresAt < expiresAt {
+		expiresAt = subjectExpiresAt
+	}
+
+	act := map[string]interface{}{
+		"sub": clientID,
+	}
+	if actorClaims != nil {
+		act["sub"] = actorClaims["sub"]
+		act["client_id"] = clientID
+	}
+
+	customClaims := jwt.MapClaims{
+		"iss":          parsers.GetHost(gc),
+		"aud":          audience,
+		"sub":          subjectClaims["sub"],
+		"exp":          expiresAt,
+		"iat":          time.Now().Unix(),
+		"jti":          uuid.New().String(),
+		"token_type":   constants.TokenTypeAccessToken,
+		"scope":        scope,
+		"roles":        subjectClaims["roles"],
+		"login_method": subjectClaims["login_method"],
+		"client_id":    clientID,
+		"act":          act,
+	}
+	if dpopJKT != "" {
+		customClaims["cnf"] = map[string]interface{}{"jkt": dpopJKT}
+	}
+	token, err := SignJWTToken(customClaims)
+	if err != nil {
+		return nil, err
+	}
+	return &ExchangedToken{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		Scope:     scope,
+	}, nil
+}
@@ -27,3 +27,46 @@ func CreateVerificationToken(email, tokenType, hostname, nonceHash, redirectURL
 
 	return SignJWTToken(claims)
 }
+
+// CreateRevertChangeToken creates the token to revert the change of email or phone number.
+// User is the subject of token, as user is no longer found with previous value after the change.
+func CreateRevertChangeToken(userID, previousValue, tokenType, hostname, nonceHash, redirectURL string, expiresAt int64) (string, error) {
+	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"iss":            hostname,
+		"aud":            clientID,
+		"sub":            userID,
+		"previous_value": previousValue,
+		"exp":            expiresAt,
+		"iat":            time.Now().Unix(),
+		"token_type":     tokenType,
+		"nonce":          nonceHash,
+		"redirect_uri":   redirectURL,
+	}
+
+	return SignJWTToken(claims)
+}
+
+// CreatePhoneNumberChangeToken creates the token holding the phone number which is pending verification.
+// User is the subject of token, as phone number is not saved for user until it is verified.
+func CreatePhoneNumberChangeToken(userID, phoneNumber, hostname, nonceHash string, expiresAt int64) (string, error) {
+	clientID, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyClientID)
+	if err != nil {
+		return "", err
+	}
+	claims := jwt.MapClaims{
+		"iss":          hostname,
+		"aud":          clientID,
+		"sub":          userID,
+		"phone_number": phoneNumber,
+		"exp":          expiresAt,
+		"iat":          time.Now().Unix(),
+		"token_type":   constants.VerificationTypeUpdatePhoneNumber,
+		"nonce":        nonceHash,
+	}
+
+	return SignJWTToken(claims)
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+)
+
+// ServiceName is the name with which traces are reported, it can be overridden with OTEL_SERVICE_NAME
+const ServiceName = "authorizer"
+
+// Supported values of OTEL_TRACES_EXPORTER
+const (
+	ExporterNone    = "none"
+	ExporterOTLP    = "otlp"
+	ExporterConsole = "console"
+)
+
+const instrumentationName = "github.com/authorizerdev/authorizer/server"
+
+// InitTracer sets the global tracer provider for given exporter and
+// w3c trace context propagator, so that incoming traceparent header is continued.
+// It returns the function to flush and stop the tracer provider.
+// OTLP exporter is configured with the standard OTEL_EXPORTER_OTLP_* envs.
+func InitTracer(ctx context.Context, exporterName string) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch exporterName {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracehttp.New(ctx)
+	case ExporterConsole:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("invalid traces exporter: %s", exporterName)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(ServiceName), semconv.ServiceVersion(constants.VERSION)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a span with given name as child of the span in context.
+// For gin context the span of http request is used as parent, but the returned
+// context is still derived from gin context so that its cancellation is not changed.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	parent := ctx
+	if gc, ok := ctx.(*gin.Context); ok && gc.Request != nil {
+		parent = gc.Request.Context()
+	}
+	_, span := otel.Tracer(instrumentationName).Start(parent, name, trace.WithAttributes(attrs...))
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+// EndSpan records the error if any and ends the span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// HTTPClient returns http client which creates spans for outgoing requests
+// and propagates the trace context to the called service
+func HTTPClient() *http.Client {
+	return &http.Client{
+		Transport: otelhttp.NewTransport(http.DefaultTransport),
+	}
+}
+
+// OAuth2Context returns context which is used by oauth2 and oidc libraries
+// to make traced http calls, eg. while exchanging the code
+func OAuth2Context(ctx context.Context) context.Context {
+	return context.WithValue(ctx, oauth2.HTTPClient, HTTPClient())
+}
@@ -96,6 +96,11 @@ func GetEmailVerificationURL(token, hostname, redirectURI string) string {
 	return hostname + "/verify_email?token=" + token + "&redirect_uri=" + redirectURI
 }
 
+// GetRevertChangeURL to get url which reverts the change of email or phone number
+func GetRevertChangeURL(token, hostname, redirectURI string) string {
+	return hostname + "/revert_change?token=" + token + "&redirect_uri=" + redirectURI
+}
+
 // FindDeletedValues find deleted values between original and updated one
 func FindDeletedValues(original, updated []string) []string {
 	deletedValues := make([]string, 0)
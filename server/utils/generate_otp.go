@@ -1,25 +1,64 @@
 package utils
 
 import (
-	"math/rand"
-	"time"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/memorystore"
 )
 
-// GenerateOTP to generate random 6 digit otp
-func GenerateOTP() string {
-	code := ""
-	codeLength := 6
-	charSet := "ABCDEFGHJKLMNPQRSTUVWXYZ123456789"
-	charSetLength := int32(len(charSet))
-	for i := 0; i < codeLength; i++ {
-		index := randomNumber(0, charSetLength)
-		code += string(charSet[index])
+const (
+	// alphanumericOTPCharset excludes characters which look alike, eg. 0 and O
+	alphanumericOTPCharset = "ABCDEFGHJKLMNPQRSTUVWXYZ123456789"
+	numericOTPCharset      = "0123456789"
+
+	minOTPLength = 4
+	maxOTPLength = 12
+)
+
+// ParseOTPLength validates and returns the value of OTP_LENGTH
+func ParseOTPLength(value string) (int, error) {
+	length, err := strconv.Atoi(value)
+	if err != nil || length < minOTPLength || length > maxOTPLength {
+		return 0, fmt.Errorf("otp length should be between %d and %d", minOTPLength, maxOTPLength)
 	}
+	return length, nil
+}
 
-	return code
+// ValidateOTPCharset validates the value of OTP_CHARSET
+func ValidateOTPCharset(value string) error {
+	if value != constants.OTPCharsetAlphanumeric && value != constants.OTPCharsetNumeric {
+		return fmt.Errorf("otp charset should be %s or %s", constants.OTPCharsetAlphanumeric, constants.OTPCharsetNumeric)
+	}
+	return nil
 }
 
-func randomNumber(min, max int32) int32 {
-	rand.Seed(time.Now().UnixNano())
-	return min + int32(rand.Intn(int(max-min)))
+// GenerateOTP to generate random otp of configured length using crypto/rand.
+// Otps sent via sms are numeric so that they are easy to type and can be autofilled on phones.
+func GenerateOTP(isSMS bool) (string, error) {
+	codeLength := constants.DefaultOTPLength
+	if val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOTPLength); err == nil && val != "" {
+		codeLength, err = ParseOTPLength(val)
+		if err != nil {
+			return "", err
+		}
+	}
+	charSet := alphanumericOTPCharset
+	if charset, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOTPCharset); isSMS || (err == nil && charset == constants.OTPCharsetNumeric) {
+		charSet = numericOTPCharset
+	}
+
+	max := big.NewInt(int64(len(charSet)))
+	code := make([]byte, codeLength)
+	for i := range code {
+		index, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		code[i] = charSet[index.Int64()]
+	}
+	return string(code), nil
 }
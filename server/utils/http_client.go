@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// IsPublicIP returns false for loopback, link-local, private and unspecified addresses
+func IsPublicIP(ip net.IP) bool {
+	return ip != nil && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsInterfaceLocalMulticast()
+}
+
+// NewPublicHTTPClient returns http client which can only connect to public addresses.
+// It is used for urls registered by clients, eg: jwks_uri, so that they cannot be used to reach internal network.
+// Address is checked when connecting, hence it also applies to redirects and hosts resolving to internal addresses.
+func NewPublicHTTPClient(timeout time.Duration) *http.Client {
+	return newRestrictedHTTPClient(timeout, false)
+}
+
+// NewPublicOrLoopbackHTTPClient returns http client which can only connect to public and loopback addresses.
+// It is used for urls registered by clients when not running in production, where they can be on local machine.
+func NewPublicOrLoopbackHTTPClient(timeout time.Duration) *http.Client {
+	return newRestrictedHTTPClient(timeout, true)
+}
+
+// newRestrictedHTTPClient returns http client which refuses to connect to non public addresses
+func newRestrictedHTTPClient(timeout time.Duration, allowLoopback bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if allowLoopback && ip != nil && ip.IsLoopback() {
+				return nil
+			}
+			if !IsPublicIP(ip) {
+				return fmt.Errorf("connecting to %s is not allowed", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
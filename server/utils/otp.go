@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/crypto"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+var (
+	// ErrInvalidOTP is returned when otp does not match
+	ErrInvalidOTP = errors.New("invalid otp")
+	// ErrOTPExpired is returned when otp is expired
+	ErrOTPExpired = errors.New("otp expired")
+	// ErrOTPAttemptsExceeded is returned when otp is invalidated after too many failed attempts
+	ErrOTPAttemptsExceeded = errors.New("too many invalid attempts, please request a new otp")
+)
+
+// ParseOTPMaxAttempts validates and returns the value of OTP_MAX_ATTEMPTS
+func ParseOTPMaxAttempts(value string) (int64, error) {
+	attempts, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || attempts < 1 {
+		return 0, errors.New("otp max attempts should be a positive number")
+	}
+	return attempts, nil
+}
+
+// GetOTPExpiresAt returns the expiry time of new otp.
+// OTP_EXPIRY_TIME is used if it is set, else given default duration of the flow.
+func GetOTPExpiresAt(defaultDuration time.Duration) int64 {
+	duration := defaultDuration
+	if val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOTPExpiryTime); err == nil && val != "" {
+		if d, err := ParseDurationInSeconds(val); err == nil {
+			duration = d
+		} else {
+			log.Debug("Invalid otp expiry time: ", err)
+		}
+	}
+	return time.Now().Add(duration).Unix()
+}
+
+// getOTPMaxAttempts returns the number of verifications allowed for an otp
+func getOTPMaxAttempts() int64 {
+	val, err := memorystore.Provider.GetStringStoreEnvVariable(constants.EnvKeyOTPMaxAttempts)
+	if err != nil || val == "" {
+		return constants.DefaultOTPMaxAttempts
+	}
+	attempts, err := ParseOTPMaxAttempts(val)
+	if err != nil {
+		log.Debug("Invalid otp max attempts: ", err)
+		return constants.DefaultOTPMaxAttempts
+	}
+	return attempts
+}
+
+// CreateOTP generates otp for given email or phone number and saves its hash,
+// replacing the previous otp if any. It returns the otp which has to be sent to user.
+func CreateOTP(ctx context.Context, email, phoneNumber string, isSMS bool, expiresAt int64) (string, error) {
+	otp, err := GenerateOTP(isSMS)
+	if err != nil {
+		return "", err
+	}
+	hashedOTP, err := crypto.HashOTP(otp)
+	if err != nil {
+		return "", err
+	}
+	_, err = db.Provider.UpsertOTP(ctx, &models.OTP{
+		Email:       email,
+		PhoneNumber: phoneNumber,
+		Otp:         hashedOTP,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+	return otp, nil
+}
+
+// VerifyOTP checks the otp against saved otp data. Every verification is counted
+// before comparing the otp, so that concurrent requests can't exceed OTP_MAX_ATTEMPTS.
+// Once attempts are exhausted user has to request a new otp. Caller should delete
+// the otp once it is verified.
+func VerifyOTP(ctx context.Context, otpData *models.OTP, otp string) error {
+	maxAttempts := getOTPMaxAttempts()
+	if otpData.Attempts >= maxAttempts {
+		return ErrOTPAttemptsExceeded
+	}
+	if otpData.ExpiresAt < time.Now().Unix() {
+		return ErrOTPExpired
+	}
+	attempts, err := db.Provider.IncrementOTPAttempts(ctx, otpData)
+	if err != nil {
+		log.Debug("Failed to update otp attempts: ", err)
+		return err
+	}
+	otpData.Attempts = attempts
+	if attempts > maxAttempts {
+		return ErrOTPAttemptsExceeded
+	}
+	hashedOTP, err := crypto.HashOTP(otp)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashedOTP), []byte(otpData.Otp)) == 1 {
+		return nil
+	}
+	if attempts >= maxAttempts {
+		return ErrOTPAttemptsExceeded
+	}
+	return ErrInvalidOTP
+}
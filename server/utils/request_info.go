@@ -1,6 +1,9 @@
 package utils
 
-import "net/http"
+import (
+	"net/http"
+	"strings"
+)
 
 // GetIP helps in getting the IP address from the request
 func GetIP(r *http.Request) string {
@@ -19,3 +22,49 @@ func GetIP(r *http.Request) string {
 func GetUserAgent(r *http.Request) string {
 	return r.UserAgent()
 }
+
+// GetDeviceName returns human readable device name from the user agent
+// eg: Chrome on Mac OS X
+func GetDeviceName(userAgent string) string {
+	if userAgent == "" {
+		return ""
+	}
+	browser := "Unknown browser"
+	// order matters as most user agents contain multiple browser tokens
+	browsers := [][]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"Firefox/", "Firefox"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"curl/", "curl"},
+		{"PostmanRuntime/", "Postman"},
+		{"Go-http-client/", "Go HTTP client"},
+	}
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b[0]) {
+			browser = b[1]
+			break
+		}
+	}
+	os := ""
+	systems := [][]string{
+		{"Android", "Android"},
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Mac OS X", "Mac OS X"},
+		{"Windows", "Windows"},
+		{"CrOS", "Chrome OS"},
+		{"Linux", "Linux"},
+	}
+	for _, s := range systems {
+		if strings.Contains(userAgent, s[0]) {
+			os = s[1]
+			break
+		}
+	}
+	if os == "" {
+		return browser
+	}
+	return browser + " on " + os
+}
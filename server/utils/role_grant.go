@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/constants"
+	"github.com/authorizerdev/authorizer/server/db"
+	"github.com/authorizerdev/authorizer/server/db/models"
+	"github.com/authorizerdev/authorizer/server/memorystore"
+)
+
+// defaultRoleGrantSweepInterval is used when ROLE_GRANT_SWEEP_INTERVAL is not set
+const defaultRoleGrantSweepInterval = time.Minute
+
+// GetRoleGrantSweepInterval returns the interval at which expired role grants are removed.
+// Zero duration means sweeping is disabled, expired roles are still dropped from the issued tokens
+// and permission checks.
+func GetRoleGrantSweepInterval() (time.Duration, error) {
+	interval := memorystore.RequiredEnvStoreObj.GetRequiredEnv().RoleGrantSweepInterval
+	if interval == "" {
+		return defaultRoleGrantSweepInterval, nil
+	}
+	duration, err := time.ParseDuration(interval)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s: %s", constants.EnvKeyRoleGrantSweepInterval, interval)
+	}
+	return duration, nil
+}
+
+// SweepExpiredRoleGrants removes the expired roles from users,
+// records them in role history and registers user.role_expired event
+func SweepExpiredRoleGrants(ctx context.Context) error {
+	roleGrants, err := db.Provider.ListExpiredRoleGrants(ctx, time.Now().Unix())
+	if err != nil {
+		log.Debug("Error while listing expired role grants: ", err)
+		return err
+	}
+	for _, roleGrant := range roleGrants {
+		log := log.WithFields(log.Fields{
+			"user_id": roleGrant.UserID,
+			"role":    roleGrant.Role,
+		})
+		user, err := db.Provider.GetUserByID(ctx, roleGrant.UserID)
+		if err != nil {
+			log.Debug("Error while getting user of expired role grant: ", err)
+			// user might have been deleted
+			if err := db.Provider.DeleteRoleGrant(ctx, roleGrant); err != nil {
+				log.Debug("Error while deleting expired role grant: ", err)
+			}
+			continue
+		}
+
+		roles := strings.Split(user.Roles, ",")
+		if StringSliceContains(roles, roleGrant.Role) {
+			user.Roles = strings.Join(DeleteFromArray(roles, []string{roleGrant.Role}), ",")
+			user, err = db.Provider.UpdateUser(ctx, user)
+			if err != nil {
+				log.Debug("Error while removing expired role from user: ", err)
+				continue
+			}
+			_, err = db.Provider.AddRoleChange(ctx, &models.RoleChange{
+				UserID:    roleGrant.UserID,
+				Role:      roleGrant.Role,
+				Action:    constants.RoleChangeActionExpired,
+				ChangedBy: constants.RoleChangedBySystem,
+				ExpiresAt: roleGrant.ExpiresAt,
+			})
+			if err != nil {
+				log.Debug("Error while adding role change: ", err)
+			}
+			go RegisterEventWithData(ctx, constants.UserRoleExpiredWebhookEvent, "", user, map[string]interface{}{
+				"role": roleGrant.Role,
+			})
+		}
+
+		if err := db.Provider.DeleteRoleGrant(ctx, roleGrant); err != nil {
+			log.Debug("Error while deleting expired role grant: ", err)
+		}
+	}
+	return nil
+}
+
+// StartRoleGrantSweep removes the expired role grants at given interval until context is cancelled
+func StartRoleGrantSweep(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := SweepExpiredRoleGrants(ctx); err != nil {
+				log.Debug("Error while sweeping expired role grants: ", err)
+			}
+		}
+	}
+}
+
+// RecordRoleChanges adds role history entries for the roles added to or removed from user.
+// Grants of the removed roles are deleted, so that they do not expire a role assigned again later.
+func RecordRoleChanges(ctx context.Context, userID string, previousRoles, updatedRoles []string, changedBy string) error {
+	for _, role := range updatedRoles {
+		if role == "" || StringSliceContains(previousRoles, role) {
+			continue
+		}
+		_, err := db.Provider.AddRoleChange(ctx, &models.RoleChange{
+			UserID:    userID,
+			Role:      role,
+			Action:    constants.RoleChangeActionGranted,
+			ChangedBy: changedBy,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, role := range previousRoles {
+		if role == "" || StringSliceContains(updatedRoles, role) {
+			continue
+		}
+		_, err := db.Provider.AddRoleChange(ctx, &models.RoleChange{
+			UserID:    userID,
+			Role:      role,
+			Action:    constants.RoleChangeActionRevoked,
+			ChangedBy: changedBy,
+		})
+		if err != nil {
+			return err
+		}
+		if roleGrant, _ := db.Provider.GetRoleGrantByUserIDAndRole(ctx, userID, role); roleGrant != nil {
+			if err := db.Provider.DeleteRoleGrant(ctx, roleGrant); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
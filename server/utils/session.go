@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/authorizerdev/authorizer/server/db"
+)
+
+// RolloverSession updates the session row saved in database with the nonce of
+// the new session tokens, so that it keeps pointing to the memory store keys
+func RolloverSession(ctx context.Context, oldNonce, newNonce string, expiresAt int64) {
+	if oldNonce == "" || newNonce == "" {
+		return
+	}
+	session, err := db.Provider.GetSessionByNonce(ctx, oldNonce)
+	if err != nil || session == nil {
+		log.Debug("Failed to get session by nonce: ", err)
+		return
+	}
+	session.Nonce = newNonce
+	session.ExpiresAt = expiresAt
+	session.LastActiveAt = time.Now().Unix()
+	if _, err := db.Provider.UpdateSession(ctx, session); err != nil {
+		log.Debug("Failed to update session: ", err)
+	}
+}
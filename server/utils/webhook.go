@@ -6,22 +6,78 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/authorizerdev/authorizer/server/constants"
 	"github.com/authorizerdev/authorizer/server/db"
 	"github.com/authorizerdev/authorizer/server/db/models"
 	"github.com/authorizerdev/authorizer/server/memorystore"
+	"github.com/authorizerdev/authorizer/server/metrics"
 	"github.com/authorizerdev/authorizer/server/refs"
+	"github.com/authorizerdev/authorizer/server/tracing"
 	log "github.com/sirupsen/logrus"
 )
 
+// pendingWebhooks tracks the events which are being delivered in background,
+// so that they can be flushed before shutting down the server
+var pendingWebhooks = struct {
+	mutex sync.Mutex
+	count int
+	// done is closed when count drops to zero
+	done chan struct{}
+}{}
+
+func startWebhookDelivery() {
+	pendingWebhooks.mutex.Lock()
+	defer pendingWebhooks.mutex.Unlock()
+	if pendingWebhooks.count == 0 {
+		pendingWebhooks.done = make(chan struct{})
+	}
+	pendingWebhooks.count++
+}
+
+func endWebhookDelivery() {
+	pendingWebhooks.mutex.Lock()
+	defer pendingWebhooks.mutex.Unlock()
+	pendingWebhooks.count--
+	if pendingWebhooks.count == 0 {
+		close(pendingWebhooks.done)
+	}
+}
+
+// WaitForWebhookDeliveries waits till the pending webhook deliveries are done or context is done
+func WaitForWebhookDeliveries(ctx context.Context) error {
+	pendingWebhooks.mutex.Lock()
+	if pendingWebhooks.count == 0 {
+		pendingWebhooks.mutex.Unlock()
+		return nil
+	}
+	done := pendingWebhooks.done
+	pendingWebhooks.mutex.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 // RegisterEvent util to register event
 // TODO change user to user ref
 func RegisterEvent(ctx context.Context, eventName string, authRecipe string, user *models.User) error {
+	return RegisterEventWithData(ctx, eventName, authRecipe, user, nil)
+}
+
+// RegisterEventWithData util to register event with additional fields in request body
+func RegisterEventWithData(ctx context.Context, eventName string, authRecipe string, user *models.User, data map[string]interface{}) error {
+	startWebhookDelivery()
+	defer endWebhookDelivery()
+
 	webhooks, err := db.Provider.GetWebhookByEventName(ctx, eventName)
 	if err != nil {
-		log.Debug("error getting webhook: %v", err)
+		log.Debug("error getting webhook: ", err)
 		return err
 	}
 	for _, webhook := range webhooks {
@@ -47,9 +103,12 @@ func RegisterEvent(ctx context.Context, eventName string, authRecipe string, use
 			"user":              userMap,
 		}
 
-		if eventName == constants.UserLoginWebhookEvent || eventName == constants.UserSignUpWebhookEvent {
+		if eventName == constants.UserLoginWebhookEvent || eventName == constants.UserSignUpWebhookEvent || eventName == constants.UserIdentityLinkedWebhookEvent || eventName == constants.UserIdentityUnlinkedWebhookEvent || eventName == constants.UserRefreshTokenReusedWebhookEvent {
 			reqBody["auth_recipe"] = authRecipe
 		}
+		for key, val := range data {
+			reqBody[key] = val
+		}
 
 		requestBody, err := json.Marshal(reqBody)
 		if err != nil {
@@ -76,7 +135,8 @@ func RegisterEvent(ctx context.Context, eventName string, authRecipe string, use
 		}
 
 		requestBytesBuffer := bytes.NewBuffer(requestBody)
-		req, err := http.NewRequest("POST", refs.StringValue(webhook.Endpoint), requestBytesBuffer)
+		// events are registered in background, so request should not be cancelled with the context of api call
+		req, err := http.NewRequestWithContext(context.WithoutCancel(ctx), "POST", refs.StringValue(webhook.Endpoint), requestBytesBuffer)
 		if err != nil {
 			log.Debug("error creating webhook post request: ", err)
 			continue
@@ -87,8 +147,15 @@ func RegisterEvent(ctx context.Context, eventName string, authRecipe string, use
 			req.Header.Set(key, val.(string))
 		}
 
-		client := &http.Client{Timeout: time.Second * 30}
+		client := tracing.HTTPClient()
+		client.Timeout = time.Second * 30
+		start := time.Now()
 		resp, err := client.Do(req)
+		outcome := metrics.OutcomeSuccess
+		if err != nil || resp.StatusCode >= http.StatusBadRequest {
+			outcome = metrics.OutcomeFailure
+		}
+		metrics.RecordWebhookDelivery(eventName, outcome, time.Since(start))
 		if err != nil {
 			log.Debug("error making request: ", err)
 			continue